@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/your-org/boilerplate-go/internal/config"
+	"github.com/your-org/boilerplate-go/internal/database"
+	"github.com/your-org/boilerplate-go/internal/encryption"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+)
+
+// reencrypt rewrites every instance and webhook secret with the active encryption key.
+// Run it after adding a new key and switching active_key_id; old keys can be
+// removed from configuration once it finishes.
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	cipher, err := encryption.NewCipher(cfg.Encryption)
+	if err != nil {
+		log.Fatalf("failed to create cipher: %v", err)
+	}
+	if !cipher.Enabled() {
+		log.Fatal("no encryption key configured, nothing to do")
+	}
+
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	if err := database.MigrateAll(db); err != nil {
+		log.Fatalf("failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	instances, err := infrastructure.NewGormInstanceRepository(db, cipher).ReEncrypt(ctx)
+	if err != nil {
+		log.Fatalf("re-encryption stopped after %d instances: %v", instances, err)
+	}
+
+	subscriptions, err := infrastructure.NewGormWebhookSubscriptionRepository(db, cipher).ReEncrypt(ctx)
+	if err != nil {
+		log.Fatalf("re-encryption stopped after %d webhook subscriptions: %v", subscriptions, err)
+	}
+
+	log.Printf("re-encrypted %d instances and %d webhook subscriptions with key %q", instances, subscriptions, cipher.ActiveKeyID())
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/audit/domain"
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// Page size limits of the audit log query
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// actorSystem identifies actions without an authenticated caller, such as
+// automation rules and background workers
+const actorSystem = "system"
+
+// AuditService records audited actions and queries the audit log
+type AuditService struct {
+	entries domain.Repository
+	logger  zerolog.Logger
+}
+
+// NewAuditService creates a new AuditService
+func NewAuditService(entries domain.Repository, logger zerolog.Logger) *AuditService {
+	return &AuditService{
+		entries: entries,
+		logger:  logger.With().Str("service", "audit").Logger(),
+	}
+}
+
+// Record stores the action with the actor, tenant and request of the context.
+// Failures are logged and never fail the audited action
+func (s *AuditService) Record(ctx context.Context, record domain.Record) {
+	entry := &domain.Entry{
+		ID:           uuid.New(),
+		ActorType:    actorSystem,
+		Action:       record.Action,
+		ResourceType: record.ResourceType,
+		ResourceID:   record.ResourceID,
+		Summary:      record.Summary,
+		Changes:      record.Changes,
+		CreatedAt:    time.Now(),
+	}
+
+	if principal := authDomain.FromContext(ctx); principal != nil {
+		entry.ActorType = string(principal.Type)
+		entry.ActorID = principal.ID
+		entry.ActorName = principal.Name
+	}
+	if tenantID, ok := tenantDomain.FromContext(ctx); ok {
+		entry.TenantID = &tenantID
+	}
+	request := domain.RequestFromContext(ctx)
+	entry.RequestID = request.RequestID
+	entry.IP = request.IP
+
+	// The entry outlives a cancelled request
+	if err := s.entries.Save(context.WithoutCancel(ctx), entry); err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("action", entry.Action).
+			Str("resource_id", entry.ResourceID).
+			Msg("Failed to record audit entry")
+	}
+}
+
+// ListEntries queries the audit log of the tenant, newest first. Administrators
+// also see the entries outside tenants, such as user changes
+func (s *AuditService) ListEntries(ctx context.Context, filter domain.Filter, limit int, cursor string) (*domain.Page, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperrors.NewValidationError("from must be before to")
+	}
+
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+
+	principal := authDomain.FromContext(ctx)
+	filter.IncludeGlobal = principal != nil && principal.HasScope(authDomain.ScopeAdmin)
+
+	limit = NormalizePageLimit(limit)
+	entries, err := s.entries.List(ctx, filter, limit+1, before)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.Page{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		page.NextCursor = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// Anonymize replaces the value in the entries of the tenant with domain.Erased
+func (s *AuditService) Anonymize(ctx context.Context, tenantID uuid.UUID, value string) (int64, error) {
+	if value == "" {
+		return 0, apperrors.NewValidationError("value is required")
+	}
+
+	anonymized, err := s.entries.Anonymize(ctx, tenantID, value, domain.Erased)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info().
+		Str("tenant_id", tenantID.String()).
+		Int64("entries", anonymized).
+		Msg("Audit entries anonymized")
+	return anonymized, nil
+}
+
+// NormalizePageLimit applies the default and the maximum page size
+func NormalizePageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return limit
+}
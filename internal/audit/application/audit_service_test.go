@@ -0,0 +1,183 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/audit/application"
+	"github.com/your-org/boilerplate-go/internal/audit/domain"
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+type memoryEntries struct {
+	items []*domain.Entry
+}
+
+func (m *memoryEntries) Save(ctx context.Context, entry *domain.Entry) error {
+	m.items = append(m.items, entry)
+	return nil
+}
+
+// List mirrors the tenant scope and filters of the repository, newest first
+func (m *memoryEntries) List(ctx context.Context, filter domain.Filter, limit int, before *domain.Cursor) ([]*domain.Entry, error) {
+	tenantID, scoped := tenantDomain.FromContext(ctx)
+
+	var entries []*domain.Entry
+	for i := len(m.items) - 1; i >= 0 && len(entries) < limit; i-- {
+		entry := m.items[i]
+		if scoped && (entry.TenantID == nil && !filter.IncludeGlobal || entry.TenantID != nil && *entry.TenantID != tenantID) {
+			continue
+		}
+		if filter.ActorID != "" && entry.ActorID != filter.ActorID {
+			continue
+		}
+		if filter.ResourceType != "" && entry.ResourceType != filter.ResourceType {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Anonymize mirrors the whole-word replacement of the repository
+func (m *memoryEntries) Anonymize(ctx context.Context, tenantID uuid.UUID, value, replacement string) (int64, error) {
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(value) + `\b`)
+
+	var anonymized int64
+	for _, entry := range m.items {
+		if entry.TenantID == nil || *entry.TenantID != tenantID {
+			continue
+		}
+		changed := pattern.MatchString(entry.Summary)
+		entry.Summary = pattern.ReplaceAllString(entry.Summary, replacement)
+		for key, change := range entry.Changes {
+			if to, ok := change.To.(string); ok && pattern.MatchString(to) {
+				change.To = pattern.ReplaceAllString(to, replacement)
+				entry.Changes[key] = change
+				changed = true
+			}
+		}
+		if changed {
+			anonymized++
+		}
+	}
+	return anonymized, nil
+}
+
+func TestAuditService_RecordFillsActorTenantAndRequest(t *testing.T) {
+	entries := &memoryEntries{}
+	service := application.NewAuditService(entries, zerolog.Nop())
+
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	ctx = authDomain.NewContext(ctx, &authDomain.Principal{Type: authDomain.PrincipalUser, ID: "7", Name: "support@example.com"})
+	ctx = domain.NewRequestContext(ctx, domain.RequestInfo{RequestID: "req-1", IP: "10.0.0.1"})
+
+	service.Record(ctx, domain.Record{
+		Action:       domain.ActionInstanceDelete,
+		ResourceType: domain.ResourceInstance,
+		ResourceID:   "instance-1",
+	})
+
+	require.Len(t, entries.items, 1)
+	entry := entries.items[0]
+	assert.Equal(t, "user", entry.ActorType)
+	assert.Equal(t, "7", entry.ActorID)
+	assert.Equal(t, tenantID, *entry.TenantID)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, "10.0.0.1", entry.IP)
+
+	// Actions without a caller are attributed to the system
+	service.Record(context.Background(), domain.Record{Action: domain.ActionMessageSend, ResourceType: domain.ResourceMessage})
+	assert.Equal(t, "system", entries.items[1].ActorType)
+	assert.Nil(t, entries.items[1].TenantID)
+}
+
+func TestAuditService_ListEntries(t *testing.T) {
+	entries := &memoryEntries{}
+	service := application.NewAuditService(entries, zerolog.Nop())
+
+	tenantID := uuid.New()
+	tenantCtx := tenantDomain.NewContext(context.Background(), tenantID)
+	for i := 0; i < 3; i++ {
+		service.Record(tenantCtx, domain.Record{Action: domain.ActionInstanceCreate, ResourceType: domain.ResourceInstance})
+	}
+	service.Record(context.Background(), domain.Record{Action: domain.ActionUserDelete, ResourceType: domain.ResourceUser})
+
+	keyCtx := authDomain.NewContext(tenantCtx, &authDomain.Principal{Type: authDomain.PrincipalAPIKey, Scopes: []string{authDomain.ScopeAuditRead}})
+	page, err := service.ListEntries(keyCtx, domain.Filter{}, 2, "")
+	require.NoError(t, err)
+	assert.Len(t, page.Entries, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// User changes lie outside tenants and are visible to administrators only
+	adminCtx := authDomain.NewContext(tenantCtx, &authDomain.Principal{Type: authDomain.PrincipalMasterKey, Scopes: []string{authDomain.ScopeAdmin}})
+	page, err = service.ListEntries(adminCtx, domain.Filter{ResourceType: domain.ResourceUser}, 0, "")
+	require.NoError(t, err)
+	assert.Len(t, page.Entries, 1)
+
+	page, err = service.ListEntries(keyCtx, domain.Filter{ResourceType: domain.ResourceUser}, 0, "")
+	require.NoError(t, err)
+	assert.Empty(t, page.Entries)
+
+	from := time.Now()
+	_, err = service.ListEntries(keyCtx, domain.Filter{From: &from, To: &from}, 0, "")
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	_, err = service.ListEntries(keyCtx, domain.Filter{}, 0, "not-a-cursor")
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+}
+
+func TestAuditService_Anonymize(t *testing.T) {
+	entries := &memoryEntries{}
+	service := application.NewAuditService(entries, zerolog.Nop())
+
+	tenantID := uuid.New()
+	send := func(ctx context.Context, phone string) {
+		service.Record(ctx, domain.Record{
+			Action:       domain.ActionMessageSend,
+			ResourceType: domain.ResourceMessage,
+			Summary:      "text message to " + phone,
+			Changes:      map[string]domain.Change{"phone": {To: phone}},
+		})
+	}
+	send(tenantDomain.NewContext(context.Background(), tenantID), "5511999990000")
+	send(tenantDomain.NewContext(context.Background(), tenantID), "55119999900001")
+	send(tenantDomain.NewContext(context.Background(), uuid.New()), "5511999990000")
+
+	anonymized, err := service.Anonymize(context.Background(), tenantID, "5511999990000")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), anonymized)
+	assert.Equal(t, "text message to [erased]", entries.items[0].Summary)
+	assert.Equal(t, domain.Erased, entries.items[0].Changes["phone"].To)
+
+	// Longer numbers and other tenants are left untouched
+	assert.Equal(t, "text message to 55119999900001", entries.items[1].Summary)
+	assert.Equal(t, "text message to 5511999990000", entries.items[2].Summary)
+
+	_, err = service.Anonymize(context.Background(), tenantID, "")
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+}
+
+func TestDiff(t *testing.T) {
+	changes := domain.Diff(
+		map[string]any{"name": "Sales", "provider": "zapi", "config.timeout": 10},
+		map[string]any{"name": "Support", "provider": "zapi", "config.retries": 3},
+	)
+
+	assert.Equal(t, map[string]domain.Change{
+		"name":           {From: "Sales", To: "Support"},
+		"config.timeout": {From: 10},
+		"config.retries": {To: 3},
+	}, changes)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// RequestInfo identifies the HTTP request behind an audited action
+type RequestInfo struct {
+	RequestID string
+	IP        string
+}
+
+type requestKey struct{}
+
+// NewRequestContext returns a copy of ctx carrying the request information
+func NewRequestContext(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestKey{}, info)
+}
+
+// RequestFromContext returns the request information of the context, empty
+// for actions outside requests such as background workers
+func RequestFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestKey{}).(RequestInfo)
+	return info
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited actions
+const (
+	ActionInstanceCreate       = "instance.create"
+	ActionInstanceUpdate       = "instance.update"
+	ActionInstanceDelete       = "instance.delete"
+	ActionProfileNameUpdate    = "profile.name.update"
+	ActionProfilePictureUpdate = "profile.picture.update"
+	ActionMessageSend          = "message.send"
+	ActionMessageExport        = "message.export"
+	ActionContactErase         = "contact.erase"
+	ActionUserCreate           = "user.create"
+	ActionUserUpdate           = "user.update"
+	ActionUserDelete           = "user.delete"
+)
+
+// Audited resource types
+const (
+	ResourceInstance = "instance"
+	ResourceMessage  = "message"
+	ResourceExport   = "export"
+	ResourceErasure  = "erasure"
+	ResourceUser     = "user"
+)
+
+// Redacted replaces secret values in the changes of an entry
+const Redacted = "[redacted]"
+
+// Erased replaces personal values removed from existing entries
+const Erased = "[erased]"
+
+// Change holds the previous and the new value of a field
+type Change struct {
+	From any `json:"from,omitempty"`
+	To   any `json:"to,omitempty"`
+}
+
+// Entry records who did what to which resource, and from where
+type Entry struct {
+	ID           uuid.UUID         `json:"id"`
+	TenantID     *uuid.UUID        `json:"tenant_id,omitempty"` // nil for resources outside tenants, such as users
+	ActorType    string            `json:"actor_type"`          // api_key, master_key, user, anonymous or system
+	ActorID      string            `json:"actor_id,omitempty"`
+	ActorName    string            `json:"actor_name,omitempty"`
+	Action       string            `json:"action"`
+	ResourceType string            `json:"resource_type"`
+	ResourceID   string            `json:"resource_id"`
+	Summary      string            `json:"summary,omitempty"`
+	Changes      map[string]Change `json:"changes,omitempty"`
+	RequestID    string            `json:"request_id,omitempty"`
+	IP           string            `json:"ip,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// Record describes an audited action. The service completes it with the
+// actor, tenant and request of the context
+type Record struct {
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Summary      string
+	Changes      map[string]Change
+}
+
+// Recorder records audited actions. Recording never fails the action itself
+type Recorder interface {
+	Record(ctx context.Context, record Record)
+}
+
+// Anonymizer removes a personal value, such as a phone number, from the
+// summaries and changes of the entries of a tenant when its owner asks for
+// the data to be erased. It returns how many entries were changed
+type Anonymizer interface {
+	Anonymize(ctx context.Context, tenantID uuid.UUID, value string) (int64, error)
+}
+
+// Diff returns the fields whose values differ between before and after
+func Diff(before, after map[string]any) map[string]Change {
+	changes := make(map[string]Change)
+	for key, value := range after {
+		if previous, ok := before[key]; !ok || !reflect.DeepEqual(previous, value) {
+			changes[key] = Change{From: before[key], To: value}
+		}
+	}
+	for key, previous := range before {
+		if _, ok := after[key]; !ok {
+			changes[key] = Change{From: previous}
+		}
+	}
+	return changes
+}
+
+// Filter represents the filters of the audit log query. Empty fields do not filter
+type Filter struct {
+	ActorType    string
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         *time.Time // inclusive
+	To           *time.Time // exclusive
+	// IncludeGlobal also returns the entries outside tenants, for administrators
+	IncludeGlobal bool
+}
+
+// Cursor identifies the position of an entry in the listing, ordered by
+// (created_at, id) descending; the id breaks ties between entries of the same second
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+}
+
+// Encode returns the opaque representation of the cursor
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.Time.Unix(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor returned by Encode. An empty value results in nil
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	unix, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &Cursor{Time: time.Unix(seconds, 0), ID: parsedID}, nil
+}
+
+// Page represents a page of the audit log
+type Page struct {
+	Entries    []*Entry
+	NextCursor string
+}
+
+// Repository defines the interface for audit log persistence. Entries are
+// only updated to erase personal data
+type Repository interface {
+	Save(ctx context.Context, entry *Entry) error
+	// List lists the entries of the context tenant matching the filter, newest
+	// first, starting after the cursor
+	List(ctx context.Context, filter Filter, limit int, before *Cursor) ([]*Entry, error)
+	// Anonymize replaces the value, as a whole word, in the summary and the
+	// changes of the entries of the tenant. It returns how many entries changed
+	Anonymize(ctx context.Context, tenantID uuid.UUID, value, replacement string) (int64, error)
+}
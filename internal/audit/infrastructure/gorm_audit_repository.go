@@ -0,0 +1,174 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/audit/domain"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// GormAuditEntry is the GORM model of domain.Entry
+type GormAuditEntry struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID     *uuid.UUID `gorm:"type:uuid;index:idx_audit_logs_tenant_created,priority:1"`
+	ActorType    string     `gorm:"type:varchar(20);not null"`
+	ActorID      string     `gorm:"type:varchar(64);index"`
+	ActorName    string     `gorm:"type:varchar(255)"`
+	Action       string     `gorm:"type:varchar(50);not null;index"`
+	ResourceType string     `gorm:"type:varchar(50);not null;index:idx_audit_logs_resource,priority:1"`
+	ResourceID   string     `gorm:"type:varchar(64);not null;index:idx_audit_logs_resource,priority:2"`
+	Summary      string     `gorm:"type:text"`
+	Changes      string     `gorm:"type:jsonb"`
+	RequestID    string     `gorm:"type:varchar(64)"`
+	IP           string     `gorm:"type:varchar(45)"`
+	CreatedAt    int64      `gorm:"not null;index:idx_audit_logs_tenant_created,priority:2"`
+}
+
+// TableName returns the table name
+func (GormAuditEntry) TableName() string {
+	return "audit_logs"
+}
+
+// toDomain converts GormAuditEntry to domain.Entry
+func (g *GormAuditEntry) toDomain() *domain.Entry {
+	var changes map[string]domain.Change
+	if g.Changes != "" {
+		_ = json.Unmarshal([]byte(g.Changes), &changes)
+	}
+
+	return &domain.Entry{
+		ID:           g.ID,
+		TenantID:     g.TenantID,
+		ActorType:    g.ActorType,
+		ActorID:      g.ActorID,
+		ActorName:    g.ActorName,
+		Action:       g.Action,
+		ResourceType: g.ResourceType,
+		ResourceID:   g.ResourceID,
+		Summary:      g.Summary,
+		Changes:      changes,
+		RequestID:    g.RequestID,
+		IP:           g.IP,
+		CreatedAt:    time.Unix(g.CreatedAt, 0),
+	}
+}
+
+// GormAuditRepository implements Repository using GORM
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditRepository creates a new GormAuditRepository
+func NewGormAuditRepository(db *gorm.DB) *GormAuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+// Save stores a new audit entry
+func (r *GormAuditRepository) Save(ctx context.Context, entry *domain.Entry) error {
+	changes := "null"
+	if len(entry.Changes) > 0 {
+		encoded, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit changes: %w", err)
+		}
+		changes = string(encoded)
+	}
+
+	gormEntry := GormAuditEntry{
+		ID:           entry.ID,
+		TenantID:     entry.TenantID,
+		ActorType:    entry.ActorType,
+		ActorID:      entry.ActorID,
+		ActorName:    entry.ActorName,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		Summary:      entry.Summary,
+		Changes:      changes,
+		RequestID:    entry.RequestID,
+		IP:           entry.IP,
+		CreatedAt:    entry.CreatedAt.Unix(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormEntry).Error; err != nil {
+		return fmt.Errorf("failed to save audit entry: %w", err)
+	}
+	return nil
+}
+
+// List lists the entries of the context tenant matching the filter, newest first
+func (r *GormAuditRepository) List(ctx context.Context, filter domain.Filter, limit int, before *domain.Cursor) ([]*domain.Entry, error) {
+	query := r.db.WithContext(ctx).Model(&GormAuditEntry{})
+
+	if tenantID, ok := tenantDomain.FromContext(ctx); ok {
+		if filter.IncludeGlobal {
+			query = query.Where("(tenant_id = ? OR tenant_id IS NULL)", tenantID)
+		} else {
+			query = query.Where("tenant_id = ?", tenantID)
+		}
+	}
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", filter.From.Unix())
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", filter.To.Unix())
+	}
+	if before != nil {
+		createdAt := before.Time.Unix()
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	var gormEntries []GormAuditEntry
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&gormEntries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries := make([]*domain.Entry, len(gormEntries))
+	for i := range gormEntries {
+		entries[i] = gormEntries[i].toDomain()
+	}
+
+	return entries, nil
+}
+
+// Anonymize replaces the value, as a whole word, in the summary and the
+// changes of the entries of the tenant
+func (r *GormAuditRepository) Anonymize(ctx context.Context, tenantID uuid.UUID, value, replacement string) (int64, error) {
+	// \m and \M anchor the match at word boundaries, so a phone number does not
+	// match inside a longer one
+	pattern := `\m` + regexp.QuoteMeta(value) + `\M`
+
+	result := r.db.WithContext(ctx).
+		Model(&GormAuditEntry{}).
+		Where("tenant_id = ? AND (summary ~ ? OR changes::text ~ ?)", tenantID, pattern, pattern).
+		Updates(map[string]any{
+			"summary": gorm.Expr("regexp_replace(summary, ?, ?, 'g')", pattern, replacement),
+			"changes": gorm.Expr("regexp_replace(changes::text, ?, ?, 'g')::jsonb", pattern, replacement),
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to anonymize audit entries: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/your-org/boilerplate-go/internal/audit/application"
+	"github.com/your-org/boilerplate-go/internal/audit/domain"
+	"github.com/your-org/boilerplate-go/internal/audit/infrastructure"
+	"github.com/your-org/boilerplate-go/internal/audit/presentation"
+)
+
+// Module provides the audit log repository, service and controller. The
+// service is also provided as the domain.Recorder of the audited modules and
+// as the domain.Anonymizer used by data erasure
+var Module = fx.Module("audit",
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormAuditRepository,
+			fx.As(new(domain.Repository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			application.NewAuditService,
+			fx.As(fx.Self()),
+			fx.As(new(domain.Recorder)),
+			fx.As(new(domain.Anonymizer)),
+		),
+	),
+	fx.Provide(presentation.NewAuditController),
+)
@@ -0,0 +1,81 @@
+package presentation
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/audit/application"
+	"github.com/your-org/boilerplate-go/internal/audit/domain"
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/middleware"
+	"github.com/your-org/boilerplate-go/internal/response"
+)
+
+// AuditController handles HTTP requests for the audit log
+type AuditController struct {
+	service *application.AuditService
+	logger  zerolog.Logger
+}
+
+// NewAuditController creates a new AuditController
+func NewAuditController(service *application.AuditService, logger zerolog.Logger) *AuditController {
+	return &AuditController{
+		service: service,
+		logger:  logger.With().Str("controller", "audit").Logger(),
+	}
+}
+
+// ListEntries handles GET /audit-logs
+func (c *AuditController) ListEntries(ctx *gin.Context) {
+	filter := domain.Filter{
+		ActorType:    ctx.Query("actor_type"),
+		ActorID:      ctx.Query("actor_id"),
+		Action:       ctx.Query("action"),
+		ResourceType: ctx.Query("resource_type"),
+		ResourceID:   ctx.Query("resource_id"),
+	}
+
+	var err error
+	if filter.From, err = queryTime(ctx, "from"); err != nil {
+		response.BadRequest(ctx, "Invalid from date", err.Error())
+		return
+	}
+	if filter.To, err = queryTime(ctx, "to"); err != nil {
+		response.BadRequest(ctx, "Invalid to date", err.Error())
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	limit = application.NormalizePageLimit(limit)
+
+	page, err := c.service.ListEntries(ctx.Request.Context(), filter, limit, ctx.Query("cursor"))
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to list audit entries")
+		response.FromError(ctx, err, "Failed to list audit entries")
+		return
+	}
+
+	response.CursorPaginated(ctx, page.Entries, limit, nil, page.NextCursor)
+}
+
+// RegisterRoutes registers the audit log routes, scoped to the tenant of the request
+func (c *AuditController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/audit-logs", middleware.RequireScope(authDomain.ScopeAuditRead), c.ListEntries)
+}
+
+// queryTime reads an RFC 3339 date from the query; a missing value results in nil
+func queryTime(ctx *gin.Context, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
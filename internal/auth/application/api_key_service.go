@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/encryption"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// apiKeyPrefix starts every generated key, making leaked keys easy to spot
+const apiKeyPrefix = "wak_"
+
+// lastUsedResolution limits the last-used updates to one write per key and
+// interval instead of one per request
+const lastUsedResolution = time.Minute
+
+// APIKeySettings configures the authentication of API requests
+type APIKeySettings struct {
+	MasterKey string // grants the admin scope on every tenant; empty disables it
+}
+
+// APIKeyService manages API keys and authenticates the keys presented by callers
+type APIKeyService struct {
+	keys     domain.APIKeyRepository
+	settings APIKeySettings
+	logger   zerolog.Logger
+}
+
+// NewAPIKeyService creates a new APIKeyService
+func NewAPIKeyService(keys domain.APIKeyRepository, settings APIKeySettings, logger zerolog.Logger) *APIKeyService {
+	return &APIKeyService{
+		keys:     keys,
+		settings: settings,
+		logger:   logger.With().Str("service", "api_key").Logger(),
+	}
+}
+
+// CreateAPIKey creates a key in the tenant of the request. Callers can only
+// grant the scopes they hold themselves
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, request domain.CreateAPIKeyRequest) (*domain.APIKeyResponse, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, apperrors.NewValidationError("name is required")
+	}
+
+	principal := domain.FromContext(ctx)
+	for _, scope := range request.Scopes {
+		if !domain.IsAPIKeyScope(scope) {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("unknown scope: %s", scope))
+		}
+		if principal != nil && !principal.HasScope(scope) {
+			return nil, apperrors.NewForbiddenError("INSUFFICIENT_SCOPE",
+				fmt.Sprintf("cannot grant scope %s without holding it", scope))
+		}
+	}
+
+	now := time.Now()
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		return nil, apperrors.NewValidationError("expires_at must be in the future")
+	}
+
+	prefix, secret, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	rawKey := prefix + "_" + secret
+
+	tenantID, ok := tenantDomain.FromContext(ctx)
+	if !ok {
+		tenantID = tenantDomain.DefaultTenantID
+	}
+
+	key := &domain.APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   encryption.Hash(rawKey),
+		Scopes:    request.Scopes,
+		ExpiresAt: request.ExpiresAt,
+		CreatedAt: now,
+	}
+	if err := s.keys.Save(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("api_key_id", key.ID.String()).
+		Str("tenant_id", tenantID.String()).
+		Strs("scopes", key.Scopes).
+		Msg("API key created")
+
+	return &domain.APIKeyResponse{APIKey: key, Key: rawKey}, nil
+}
+
+// GetAPIKey retrieves an API key by ID
+func (s *APIKeyService) GetAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	key, err := s.keys.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("api key")
+	}
+	return key, nil
+}
+
+// ListAPIKeys lists the API keys of the tenant, including revoked ones
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	return s.keys.List(ctx)
+}
+
+// RevokeAPIKey revokes an API key. The key is kept for reference
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := s.keys.Revoke(ctx, id, time.Now()); err != nil {
+		return apperrors.NewNotFoundError("api key")
+	}
+
+	s.logger.Info().Str("api_key_id", id.String()).Msg("API key revoked")
+	return nil
+}
+
+// Authenticate returns the principal of the master key or of an active API key
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*domain.Principal, error) {
+	if s.settings.MasterKey != "" && subtle.ConstantTimeCompare([]byte(rawKey), []byte(s.settings.MasterKey)) == 1 {
+		return &domain.Principal{Type: domain.PrincipalMasterKey, Name: "master", Scopes: []string{domain.ScopeAdmin}}, nil
+	}
+
+	invalid := apperrors.NewUnauthorizedError("INVALID_API_KEY", "invalid or expired API key")
+
+	separator := strings.LastIndex(rawKey, "_")
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) || separator <= len(apiKeyPrefix) {
+		return nil, invalid
+	}
+
+	key, err := s.keys.GetByPrefix(ctx, rawKey[:separator])
+	if err != nil {
+		return nil, invalid
+	}
+
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(encryption.Hash(rawKey)), []byte(key.KeyHash)) != 1 || !key.Active(now) {
+		return nil, invalid
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.keys.TouchLastUsed(ctx, key.ID, now); err != nil {
+			s.logger.Warn().Err(err).Str("api_key_id", key.ID.String()).Msg("Failed to record API key use")
+		}
+	}
+
+	tenantID := key.TenantID
+	return &domain.Principal{
+		Type:     domain.PrincipalAPIKey,
+		ID:       key.ID.String(),
+		Name:     key.Name,
+		TenantID: &tenantID,
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+// generateAPIKey generates the public prefix and the secret part of a key
+func generateAPIKey() (string, string, error) {
+	random := make([]byte, 38)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + hex.EncodeToString(random[:6]), hex.EncodeToString(random[6:]), nil
+}
@@ -0,0 +1,146 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/auth/application"
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+type memoryAPIKeys struct {
+	domain.APIKeyRepository
+	items   []*domain.APIKey
+	touched int
+}
+
+func (m *memoryAPIKeys) Save(ctx context.Context, key *domain.APIKey) error {
+	m.items = append(m.items, key)
+	return nil
+}
+
+func (m *memoryAPIKeys) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	for _, item := range m.items {
+		if item.Prefix == prefix {
+			return item, nil
+		}
+	}
+	return nil, errors.New("api key not found")
+}
+
+func (m *memoryAPIKeys) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	for _, item := range m.items {
+		if item.ID == id && item.RevokedAt == nil {
+			item.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return errors.New("api key not found")
+}
+
+func (m *memoryAPIKeys) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	for _, item := range m.items {
+		if item.ID == id {
+			item.LastUsedAt = &usedAt
+			m.touched++
+		}
+	}
+	return nil
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	keys := &memoryAPIKeys{}
+	service := application.NewAPIKeyService(keys, application.APIKeySettings{}, zerolog.Nop())
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	created, err := service.CreateAPIKey(ctx, domain.CreateAPIKeyRequest{
+		Name:   "CRM",
+		Scopes: []string{domain.ScopeMessagesSend, domain.ScopeInstancesManage},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, created.Key, created.Prefix+"_")
+	assert.NotContains(t, created.KeyHash, created.Key)
+	assert.Equal(t, tenantID, created.TenantID)
+
+	principal, err := service.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrincipalAPIKey, principal.Type)
+	assert.Equal(t, tenantID, *principal.TenantID)
+	assert.True(t, principal.HasScope(domain.ScopeMessagesSend))
+	assert.True(t, principal.HasScope(domain.ScopeInstancesRead))
+	assert.False(t, principal.HasScope(domain.ScopeWebhooksManage))
+
+	// Repeated uses within a minute record the last use only once
+	_, err = service.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, 1, keys.touched)
+
+	_, err = service.Authenticate(context.Background(), created.Prefix+"_"+strings.Repeat("0", 64))
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+
+	require.NoError(t, service.RevokeAPIKey(ctx, created.ID))
+	_, err = service.Authenticate(context.Background(), created.Key)
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+}
+
+func TestAPIKeyService_ExpiredKey(t *testing.T) {
+	keys := &memoryAPIKeys{}
+	service := application.NewAPIKeyService(keys, application.APIKeySettings{}, zerolog.Nop())
+	expiresAt := time.Now().Add(time.Hour)
+
+	created, err := service.CreateAPIKey(context.Background(), domain.CreateAPIKeyRequest{
+		Name:      "temporary",
+		Scopes:    []string{domain.ScopeMessagesRead},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tenantDomain.DefaultTenantID, created.TenantID)
+
+	past := time.Now().Add(-time.Minute)
+	keys.items[0].ExpiresAt = &past
+
+	_, err = service.Authenticate(context.Background(), created.Key)
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+}
+
+func TestAPIKeyService_MasterKey(t *testing.T) {
+	service := application.NewAPIKeyService(&memoryAPIKeys{}, application.APIKeySettings{MasterKey: "master-secret"}, zerolog.Nop())
+
+	principal, err := service.Authenticate(context.Background(), "master-secret")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrincipalMasterKey, principal.Type)
+	assert.Nil(t, principal.TenantID)
+	assert.True(t, principal.HasScope(domain.ScopeAPIKeysManage))
+
+	_, err = service.Authenticate(context.Background(), "master")
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+}
+
+func TestAPIKeyService_CreateValidatesScopes(t *testing.T) {
+	service := application.NewAPIKeyService(&memoryAPIKeys{}, application.APIKeySettings{}, zerolog.Nop())
+
+	_, err := service.CreateAPIKey(context.Background(), domain.CreateAPIKeyRequest{Name: "all", Scopes: []string{domain.ScopeAdmin}})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	// A key cannot grant scopes it does not hold
+	ctx := domain.NewContext(context.Background(), &domain.Principal{
+		Type:   domain.PrincipalAPIKey,
+		Scopes: []string{domain.ScopeAPIKeysManage, domain.ScopeMessagesRead},
+	})
+	_, err = service.CreateAPIKey(ctx, domain.CreateAPIKeyRequest{Name: "sender", Scopes: []string{domain.ScopeMessagesSend}})
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+
+	_, err = service.CreateAPIKey(ctx, domain.CreateAPIKeyRequest{Name: "reader", Scopes: []string{domain.ScopeMessagesRead}})
+	assert.NoError(t, err)
+}
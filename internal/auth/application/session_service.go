@@ -0,0 +1,261 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/encryption"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	userDomain "github.com/your-org/boilerplate-go/internal/user/domain"
+	"github.com/your-org/boilerplate-go/pkg/jwt"
+)
+
+// refreshTokenPrefix starts every refresh token
+const refreshTokenPrefix = "wrt_"
+
+// roleScopes lists the scopes granted to the users of each role
+var roleScopes = map[string][]string{
+	userDomain.RoleAdmin: {domain.ScopeAdmin},
+	userDomain.RoleOperator: {
+		domain.ScopeInstancesManage,
+		domain.ScopeMessagesSend,
+		domain.ScopeMessagesRead,
+		domain.ScopeOptOutsManage,
+		domain.ScopeWebhooksManage,
+		domain.ScopeEventsRead,
+	},
+	userDomain.RoleViewer: {
+		domain.ScopeInstancesRead,
+		domain.ScopeMessagesRead,
+		domain.ScopeEventsRead,
+	},
+}
+
+// SessionSettings configures the tokens issued on login
+type SessionSettings struct {
+	Secret          []byte        // signs the access tokens; empty disables login
+	AccessTokenTTL  time.Duration // lifetime of the access tokens
+	RefreshTokenTTL time.Duration // lifetime of a session without refresh
+}
+
+// accessClaims are the claims of an access token
+type accessClaims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"sid"`
+	Role      string `json:"role"`
+}
+
+// SessionService logs users in and authenticates their access tokens
+type SessionService struct {
+	sessions domain.SessionRepository
+	users    userDomain.UserRepository
+	settings SessionSettings
+	logger   zerolog.Logger
+}
+
+// NewSessionService creates a new SessionService
+func NewSessionService(sessions domain.SessionRepository, users userDomain.UserRepository, settings SessionSettings, logger zerolog.Logger) *SessionService {
+	return &SessionService{
+		sessions: sessions,
+		users:    users,
+		settings: settings,
+		logger:   logger.With().Str("service", "session").Logger(),
+	}
+}
+
+// Enabled reports whether users can log in
+func (s *SessionService) Enabled() bool {
+	return len(s.settings.Secret) > 0
+}
+
+// Login checks the credentials of the user and opens a session
+func (s *SessionService) Login(ctx context.Context, request domain.LoginRequest) (*domain.TokenPair, error) {
+	if !s.Enabled() {
+		return nil, apperrors.NewUnavailableError("LOGIN_DISABLED", "login is not configured")
+	}
+
+	user, err := s.users.GetByEmail(ctx, strings.TrimSpace(request.Email))
+	if err != nil {
+		// A user without password still runs bcrypt, so the response time
+		// does not reveal which emails have accounts
+		user = &userDomain.User{}
+	}
+	if !user.CheckPassword(request.Password) {
+		return nil, apperrors.NewUnauthorizedError("INVALID_CREDENTIALS", "invalid email or password")
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		RefreshHash: encryption.Hash(refreshToken),
+		ExpiresAt:   now.Add(s.settings.RefreshTokenTTL),
+		CreatedAt:   now,
+	}
+	if err := s.sessions.Save(ctx, session); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Uint("user_id", user.ID).
+		Str("session_id", session.ID.String()).
+		Msg("User logged in")
+
+	return s.issue(user, session.ID, refreshToken, now)
+}
+
+// Refresh exchanges a refresh token for a new token pair. The refresh token
+// is rotated, so each one can be used only once
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	if !s.Enabled() {
+		return nil, apperrors.NewUnavailableError("LOGIN_DISABLED", "login is not configured")
+	}
+
+	invalid := apperrors.NewUnauthorizedError("INVALID_REFRESH_TOKEN", "invalid or expired refresh token")
+
+	oldHash := encryption.Hash(refreshToken)
+	session, err := s.sessions.GetByRefreshHash(ctx, oldHash)
+	now := time.Now()
+	if err != nil || !session.Active(now) {
+		return nil, invalid
+	}
+
+	user, err := s.users.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	newToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.Rotate(ctx, session.ID, oldHash, encryption.Hash(newToken), now.Add(s.settings.RefreshTokenTTL)); err != nil {
+		return nil, invalid
+	}
+
+	return s.issue(user, session.ID, newToken, now)
+}
+
+// Logout revokes the session of the principal, invalidating its access and
+// refresh tokens
+func (s *SessionService) Logout(ctx context.Context) error {
+	principal := domain.FromContext(ctx)
+	if principal == nil || principal.Type != domain.PrincipalUser {
+		return apperrors.NewValidationError("only user sessions can be logged out")
+	}
+
+	id, err := uuid.Parse(principal.SessionID)
+	if err != nil {
+		return apperrors.NewValidationError("invalid session")
+	}
+	if err := s.sessions.Revoke(ctx, id, time.Now()); err != nil {
+		return apperrors.NewNotFoundError("session")
+	}
+
+	s.logger.Info().Str("user_id", principal.ID).Str("session_id", principal.SessionID).Msg("User logged out")
+	return nil
+}
+
+// RevokeUserSessions revokes every session of the user and returns how many
+// were revoked
+func (s *SessionService) RevokeUserSessions(ctx context.Context, userID uint) (int64, error) {
+	revoked, err := s.sessions.RevokeByUser(ctx, userID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info().Uint("user_id", userID).Int64("revoked", revoked).Msg("User sessions revoked")
+	return revoked, nil
+}
+
+// Authenticate returns the principal of a valid access token. The session and
+// the user are loaded on every request, so logouts, deletions and role
+// changes apply immediately
+func (s *SessionService) Authenticate(ctx context.Context, accessToken string) (*domain.Principal, error) {
+	invalid := apperrors.NewUnauthorizedError("INVALID_ACCESS_TOKEN", "invalid or expired access token")
+	if !s.Enabled() {
+		return nil, invalid
+	}
+
+	var claims accessClaims
+	if err := jwt.Parse(accessToken, s.settings.Secret, &claims); err != nil {
+		if errors.Is(err, jwt.ErrExpiredToken) {
+			return nil, apperrors.NewUnauthorizedError("ACCESS_TOKEN_EXPIRED", "access token has expired")
+		}
+		return nil, invalid
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, invalid
+	}
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil || !session.Active(time.Now()) || strconv.FormatUint(uint64(session.UserID), 10) != claims.Subject {
+		return nil, invalid
+	}
+
+	user, err := s.users.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	// Users work in their own tenant; the admin scope lets admins select others
+	tenantID := user.TenantID
+	return &domain.Principal{
+		Type:      domain.PrincipalUser,
+		ID:        claims.Subject,
+		Name:      user.Email,
+		Role:      user.Role,
+		SessionID: claims.SessionID,
+		TenantID:  &tenantID,
+		Scopes:    roleScopes[user.Role],
+	}, nil
+}
+
+// issue signs an access token for the session of the user
+func (s *SessionService) issue(user *userDomain.User, sessionID uuid.UUID, refreshToken string, now time.Time) (*domain.TokenPair, error) {
+	accessToken, err := jwt.Sign(accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			ID:        uuid.NewString(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(s.settings.AccessTokenTTL).Unix(),
+		},
+		SessionID: sessionID.String(),
+		Role:      user.Role,
+	}, s.settings.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.settings.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// generateRefreshToken generates an opaque refresh token
+func generateRefreshToken() (string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return refreshTokenPrefix + hex.EncodeToString(random), nil
+}
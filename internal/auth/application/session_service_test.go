@@ -0,0 +1,211 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/auth/application"
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	userDomain "github.com/your-org/boilerplate-go/internal/user/domain"
+)
+
+type memorySessions struct {
+	items []*domain.Session
+}
+
+func (m *memorySessions) Save(ctx context.Context, session *domain.Session) error {
+	m.items = append(m.items, session)
+	return nil
+}
+
+func (m *memorySessions) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	for _, item := range m.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, errors.New("session not found")
+}
+
+func (m *memorySessions) GetByRefreshHash(ctx context.Context, refreshHash string) (*domain.Session, error) {
+	for _, item := range m.items {
+		if item.RefreshHash == refreshHash {
+			return item, nil
+		}
+	}
+	return nil, errors.New("session not found")
+}
+
+func (m *memorySessions) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) error {
+	for _, item := range m.items {
+		if item.ID == id && item.RefreshHash == oldHash && item.RevokedAt == nil {
+			item.RefreshHash = newHash
+			item.ExpiresAt = expiresAt
+			return nil
+		}
+	}
+	return errors.New("session not found")
+}
+
+func (m *memorySessions) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	for _, item := range m.items {
+		if item.ID == id && item.RevokedAt == nil {
+			item.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return errors.New("session not found")
+}
+
+func (m *memorySessions) RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) (int64, error) {
+	var revoked int64
+	for _, item := range m.items {
+		if item.UserID == userID && item.RevokedAt == nil {
+			item.RevokedAt = &revokedAt
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+type memoryUsers struct {
+	userDomain.UserRepository
+	items map[uint]*userDomain.User
+}
+
+func (m *memoryUsers) GetByID(ctx context.Context, id uint) (*userDomain.User, error) {
+	if user, ok := m.items[id]; ok {
+		return user, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+func (m *memoryUsers) GetByEmail(ctx context.Context, email string) (*userDomain.User, error) {
+	for _, user := range m.items {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+func newSessionService(t *testing.T, role string) (*application.SessionService, *memoryUsers) {
+	user := &userDomain.User{ID: 7, Name: "Support", Email: "support@example.com", Role: role, TenantID: uuid.New()}
+	require.NoError(t, user.SetPassword("correct horse"))
+	users := &memoryUsers{items: map[uint]*userDomain.User{user.ID: user}}
+
+	service := application.NewSessionService(&memorySessions{}, users, application.SessionSettings{
+		Secret:          []byte("test-secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}, zerolog.Nop())
+	return service, users
+}
+
+func TestSessionService_LoginAndAuthenticate(t *testing.T) {
+	service, users := newSessionService(t, userDomain.RoleOperator)
+
+	_, err := service.Login(context.Background(), domain.LoginRequest{Email: "support@example.com", Password: "wrong password"})
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+	_, err = service.Login(context.Background(), domain.LoginRequest{Email: "unknown@example.com", Password: "correct horse"})
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+
+	tokens, err := service.Login(context.Background(), domain.LoginRequest{Email: "support@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, int64(60), tokens.ExpiresIn)
+
+	principal, err := service.Authenticate(context.Background(), tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrincipalUser, principal.Type)
+	assert.Equal(t, "7", principal.ID)
+	// Operators are bound to the tenant of the user
+	require.NotNil(t, principal.TenantID)
+	assert.Equal(t, users.items[7].TenantID, *principal.TenantID)
+	assert.False(t, principal.CanSelectTenant())
+	assert.True(t, principal.HasScope(domain.ScopeMessagesSend))
+	assert.False(t, principal.HasScope(domain.ScopeAPIKeysManage))
+
+	// Role changes apply to the tokens already issued
+	users.items[7].Role = userDomain.RoleViewer
+	principal, err = service.Authenticate(context.Background(), tokens.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, principal.HasScope(domain.ScopeInstancesRead))
+	assert.False(t, principal.HasScope(domain.ScopeMessagesSend))
+
+	// Deleted users are rejected
+	delete(users.items, 7)
+	_, err = service.Authenticate(context.Background(), tokens.AccessToken)
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+}
+
+func TestSessionService_RefreshRotatesToken(t *testing.T) {
+	service, _ := newSessionService(t, userDomain.RoleViewer)
+
+	tokens, err := service.Login(context.Background(), domain.LoginRequest{Email: "support@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	refreshed, err := service.Refresh(context.Background(), tokens.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+	// A refresh token is valid only once
+	_, err = service.Refresh(context.Background(), tokens.RefreshToken)
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+
+	_, err = service.Authenticate(context.Background(), refreshed.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestSessionService_LogoutRevokesTokens(t *testing.T) {
+	service, _ := newSessionService(t, userDomain.RoleAdmin)
+
+	tokens, err := service.Login(context.Background(), domain.LoginRequest{Email: "support@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	principal, err := service.Authenticate(context.Background(), tokens.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, principal.HasScope(domain.ScopeAdmin))
+	assert.True(t, principal.CanSelectTenant())
+
+	require.NoError(t, service.Logout(domain.NewContext(context.Background(), principal)))
+
+	_, err = service.Authenticate(context.Background(), tokens.AccessToken)
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+	_, err = service.Refresh(context.Background(), tokens.RefreshToken)
+	assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+}
+
+func TestSessionService_RevokeUserSessions(t *testing.T) {
+	service, _ := newSessionService(t, userDomain.RoleOperator)
+	request := domain.LoginRequest{Email: "support@example.com", Password: "correct horse"}
+
+	first, err := service.Login(context.Background(), request)
+	require.NoError(t, err)
+	second, err := service.Login(context.Background(), request)
+	require.NoError(t, err)
+
+	revoked, err := service.RevokeUserSessions(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+
+	for _, tokens := range []*domain.TokenPair{first, second} {
+		_, err = service.Authenticate(context.Background(), tokens.AccessToken)
+		assert.True(t, errors.Is(err, apperrors.ErrUnauthorized))
+	}
+}
+
+func TestSessionService_LoginDisabledWithoutSecret(t *testing.T) {
+	service := application.NewSessionService(&memorySessions{}, &memoryUsers{}, application.SessionSettings{}, zerolog.Nop())
+
+	_, err := service.Login(context.Background(), domain.LoginRequest{Email: "support@example.com", Password: "correct horse"})
+	assert.True(t, errors.Is(err, apperrors.ErrUnavailable))
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Scopes granted to API keys. A manage scope also grants the read scope of
+// the same resource
+const (
+	ScopeInstancesRead   = "instances:read"
+	ScopeInstancesManage = "instances:manage"
+	ScopeMessagesRead    = "messages:read"
+	ScopeMessagesSend    = "messages:send"
+	ScopeOptOutsManage   = "opt-outs:manage"
+	ScopeWebhooksManage  = "webhooks:manage"
+	ScopeEventsRead      = "events:read"
+	ScopeAPIKeysManage   = "api-keys:manage"
+	ScopeAuditRead       = "audit:read"
+	ScopeErasuresManage  = "erasures:manage"
+
+	// ScopeAdmin grants every scope on every tenant. It is held by the master
+	// key and admin users and cannot be given to API keys
+	ScopeAdmin = "admin"
+)
+
+// APIKeyScopes lists the scopes that can be given to API keys
+var APIKeyScopes = []string{
+	ScopeInstancesRead,
+	ScopeInstancesManage,
+	ScopeMessagesRead,
+	ScopeMessagesSend,
+	ScopeOptOutsManage,
+	ScopeWebhooksManage,
+	ScopeEventsRead,
+	ScopeAPIKeysManage,
+	ScopeAuditRead,
+	ScopeErasuresManage,
+}
+
+// IsAPIKeyScope reports whether the scope can be given to an API key
+func IsAPIKeyScope(scope string) bool {
+	for _, candidate := range APIKeyScopes {
+		if candidate == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// PrincipalType identifies how the caller authenticated
+type PrincipalType string
+
+const (
+	PrincipalAPIKey    PrincipalType = "api_key"
+	PrincipalMasterKey PrincipalType = "master_key"
+	// PrincipalUser is a user logged in with a JWT access token
+	PrincipalUser PrincipalType = "user"
+	// PrincipalAnonymous is used for every request when authentication is disabled
+	PrincipalAnonymous PrincipalType = "anonymous"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Type      PrincipalType `json:"type"`
+	ID        string        `json:"id,omitempty"` // API key or user ID
+	Name      string        `json:"name,omitempty"`
+	Role      string        `json:"role,omitempty"`       // users only
+	SessionID string        `json:"session_id,omitempty"` // users only
+	TenantID  *uuid.UUID    `json:"tenant_id,omitempty"`  // own tenant; nil for the master key
+	Scopes    []string      `json:"scopes"`
+}
+
+// CanSelectTenant reports whether the principal may work in tenants other
+// than its own. Only admins can
+func (p *Principal) CanSelectTenant() bool {
+	return p.HasScope(ScopeAdmin)
+}
+
+// HasScope reports whether the principal was granted the scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == ScopeAdmin || granted == scope {
+			return true
+		}
+		resource, action, ok := strings.Cut(scope, ":")
+		if ok && action == "read" && granted == resource+":manage" {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal of the context, or nil for contexts that
+// did not go through authentication such as background workers
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session represents the login of a user. Access tokens reference the session,
+// so revoking it invalidates them at once; the refresh token is rotated on
+// every refresh and only its SHA-256 hash is stored
+type Session struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uint       `json:"user_id"`
+	RefreshHash string     `json:"-"`
+	ExpiresAt   time.Time  `json:"expires_at"` // expiry of the refresh token
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Active reports whether the session can still be used at the given time
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// LoginRequest represents the credentials of a user
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshRequest represents a request to exchange a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenPair is returned on login and refresh
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // lifetime of the access token in seconds
+}
+
+// SessionRepository defines the interface for session persistence
+type SessionRepository interface {
+	Save(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	GetByRefreshHash(ctx context.Context, refreshHash string) (*Session, error)
+	// Rotate replaces the refresh token of an active session
+	Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	// RevokeByUser revokes every active session of the user
+	RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) (int64, error)
+}
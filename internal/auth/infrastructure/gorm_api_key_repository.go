@@ -0,0 +1,183 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// GormAPIKey is the GORM model of domain.APIKey
+type GormAPIKey struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name       string    `gorm:"type:varchar(255);not null"`
+	Prefix     string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	KeyHash    string    `gorm:"type:varchar(64);not null"`
+	Scopes     string    `gorm:"type:text;not null"`
+	ExpiresAt  *int64
+	LastUsedAt *int64
+	RevokedAt  *int64
+	CreatedAt  int64 `gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name
+func (GormAPIKey) TableName() string {
+	return "api_keys"
+}
+
+// toDomain converts GormAPIKey to domain.APIKey
+func (g *GormAPIKey) toDomain() *domain.APIKey {
+	var scopes []string
+	if g.Scopes != "" {
+		scopes = strings.Split(g.Scopes, ",")
+	}
+
+	return &domain.APIKey{
+		ID:         g.ID,
+		TenantID:   g.TenantID,
+		Name:       g.Name,
+		Prefix:     g.Prefix,
+		KeyHash:    g.KeyHash,
+		Scopes:     scopes,
+		ExpiresAt:  optionalTime(g.ExpiresAt),
+		LastUsedAt: optionalTime(g.LastUsedAt),
+		RevokedAt:  optionalTime(g.RevokedAt),
+		CreatedAt:  time.Unix(g.CreatedAt, 0),
+	}
+}
+
+// GormAPIKeyRepository implements APIKeyRepository using GORM
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAPIKeyRepository creates a new GormAPIKeyRepository
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+// Save stores a new API key
+func (r *GormAPIKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	gormKey := GormAPIKey{
+		ID:        key.ID,
+		TenantID:  key.TenantID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		KeyHash:   key.KeyHash,
+		Scopes:    strings.Join(key.Scopes, ","),
+		ExpiresAt: optionalUnix(key.ExpiresAt),
+		CreatedAt: key.CreatedAt.Unix(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormKey).Error; err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an API key of the context tenant by ID
+func (r *GormAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id))
+}
+
+// GetByPrefix retrieves an API key of any tenant by prefix
+func (r *GormAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Where("prefix = ?", prefix))
+}
+
+// List lists the API keys of the context tenant, newest first
+func (r *GormAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	var gormKeys []GormAPIKey
+
+	err := r.db.WithContext(ctx).
+		Scopes(tenantScope(ctx)).
+		Order("created_at DESC").
+		Find(&gormKeys).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys := make([]*domain.APIKey, len(gormKeys))
+	for i := range gormKeys {
+		keys[i] = gormKeys[i].toDomain()
+	}
+
+	return keys, nil
+}
+
+// Revoke marks an active API key of the context tenant as revoked
+func (r *GormAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&GormAPIKey{}).
+		Scopes(tenantScope(ctx)).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt.Unix())
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("api key not found")
+	}
+	return nil
+}
+
+// TouchLastUsed records when the API key was last used
+func (r *GormAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&GormAPIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt.Unix()).Error
+	if err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+	return nil
+}
+
+// first retrieves the API key matching the query
+func (r *GormAPIKeyRepository) first(query *gorm.DB) (*domain.APIKey, error) {
+	var gormKey GormAPIKey
+
+	if err := query.First(&gormKey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("api key not found")
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return gormKey.toDomain(), nil
+}
+
+// tenantScope restricts the query to the tenant of the context, if any
+func tenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenantID, ok := tenantDomain.FromContext(ctx); ok {
+			return db.Where("tenant_id = ?", tenantID)
+		}
+		return db
+	}
+}
+
+// optionalUnix converts an optional time to unix seconds
+func optionalUnix(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
+
+// optionalTime converts optional unix seconds to time
+func optionalTime(unix *int64) *time.Time {
+	if unix == nil {
+		return nil
+	}
+	t := time.Unix(*unix, 0)
+	return &t
+}
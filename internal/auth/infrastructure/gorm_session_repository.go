@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+)
+
+// GormSession is the GORM model of domain.Session
+type GormSession struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uint      `gorm:"not null;index"`
+	RefreshHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt   int64     `gorm:"not null"`
+	RevokedAt   *int64
+	CreatedAt   int64 `gorm:"autoCreateTime"`
+	UpdatedAt   int64 `gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name
+func (GormSession) TableName() string {
+	return "auth_sessions"
+}
+
+// toDomain converts GormSession to domain.Session
+func (g *GormSession) toDomain() *domain.Session {
+	return &domain.Session{
+		ID:          g.ID,
+		UserID:      g.UserID,
+		RefreshHash: g.RefreshHash,
+		ExpiresAt:   time.Unix(g.ExpiresAt, 0),
+		RevokedAt:   optionalTime(g.RevokedAt),
+		CreatedAt:   time.Unix(g.CreatedAt, 0),
+	}
+}
+
+// GormSessionRepository implements SessionRepository using GORM
+type GormSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSessionRepository creates a new GormSessionRepository
+func NewGormSessionRepository(db *gorm.DB) *GormSessionRepository {
+	return &GormSessionRepository{db: db}
+}
+
+// Save stores a new session
+func (r *GormSessionRepository) Save(ctx context.Context, session *domain.Session) error {
+	gormSession := GormSession{
+		ID:          session.ID,
+		UserID:      session.UserID,
+		RefreshHash: session.RefreshHash,
+		ExpiresAt:   session.ExpiresAt.Unix(),
+		CreatedAt:   session.CreatedAt.Unix(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormSession).Error; err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *GormSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// GetByRefreshHash retrieves the session holding the refresh token
+func (r *GormSessionRepository) GetByRefreshHash(ctx context.Context, refreshHash string) (*domain.Session, error) {
+	return r.first(r.db.WithContext(ctx).Where("refresh_hash = ?", refreshHash))
+}
+
+// Rotate replaces the refresh token of an active session. The old hash guards
+// against two concurrent refreshes with the same token
+func (r *GormSessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&GormSession{}).
+		Where("id = ? AND refresh_hash = ? AND revoked_at IS NULL", id, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_hash": newHash,
+			"expires_at":   expiresAt.Unix(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to rotate session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("session not found")
+	}
+	return nil
+}
+
+// Revoke marks an active session as revoked
+func (r *GormSessionRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&GormSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt.Unix())
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("session not found")
+	}
+	return nil
+}
+
+// RevokeByUser revokes every active session of the user
+func (r *GormSessionRepository) RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&GormSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt.Unix())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// first retrieves the session matching the query
+func (r *GormSessionRepository) first(query *gorm.DB) (*domain.Session, error) {
+	var gormSession GormSession
+
+	if err := query.First(&gormSession).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("session not found")
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return gormSession.toDomain(), nil
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+
+	"github.com/your-org/boilerplate-go/internal/auth/application"
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/auth/infrastructure"
+	"github.com/your-org/boilerplate-go/internal/auth/presentation"
+	"github.com/your-org/boilerplate-go/internal/config"
+	userDomain "github.com/your-org/boilerplate-go/internal/user/domain"
+)
+
+// Module provides the API key and session repositories, services and controllers
+var Module = fx.Module("auth",
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormAPIKeyRepository,
+			fx.As(new(domain.APIKeyRepository)),
+		),
+		fx.Annotate(
+			infrastructure.NewGormSessionRepository,
+			fx.As(new(domain.SessionRepository)),
+		),
+	),
+	fx.Provide(newAPIKeyService),
+	fx.Provide(newSessionService),
+	fx.Provide(presentation.NewAPIKeyController),
+	fx.Provide(presentation.NewSessionController),
+)
+
+// newAPIKeyService creates the API key service with the configured master key
+func newAPIKeyService(cfg *config.Config, keys domain.APIKeyRepository, logger zerolog.Logger) *application.APIKeyService {
+	if cfg.Auth.Enabled && cfg.Auth.MasterKey == "" {
+		logger.Warn().Msg("Authentication has no master key configured; only stored API keys are accepted")
+	}
+	if !cfg.Auth.Enabled {
+		logger.Warn().Msg("Authentication is disabled; every request is allowed")
+	}
+
+	return application.NewAPIKeyService(keys, application.APIKeySettings{
+		MasterKey: cfg.Auth.MasterKey,
+	}, logger)
+}
+
+// newSessionService creates the session service with the configured token settings
+func newSessionService(cfg *config.Config, sessions domain.SessionRepository, users userDomain.UserRepository, logger zerolog.Logger) *application.SessionService {
+	if cfg.Auth.Enabled && cfg.Auth.JWTSecret == "" {
+		logger.Warn().Msg("No JWT secret configured; user login is disabled")
+	}
+
+	return application.NewSessionService(sessions, users, application.SessionSettings{
+		Secret:          []byte(cfg.Auth.JWTSecret),
+		AccessTokenTTL:  cfg.Auth.AccessTokenTTL,
+		RefreshTokenTTL: cfg.Auth.RefreshTokenTTL,
+	}, logger)
+}
@@ -0,0 +1,101 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/auth/application"
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/middleware"
+	"github.com/your-org/boilerplate-go/internal/response"
+)
+
+// APIKeyController handles HTTP requests for API keys
+type APIKeyController struct {
+	service *application.APIKeyService
+	logger  zerolog.Logger
+}
+
+// NewAPIKeyController creates a new APIKeyController
+func NewAPIKeyController(service *application.APIKeyService, logger zerolog.Logger) *APIKeyController {
+	return &APIKeyController{
+		service: service,
+		logger:  logger.With().Str("controller", "api_key").Logger(),
+	}
+}
+
+// CreateAPIKey handles POST /api-keys
+func (c *APIKeyController) CreateAPIKey(ctx *gin.Context) {
+	var request domain.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	key, err := c.service.CreateAPIKey(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create API key")
+		response.FromError(ctx, err, "Failed to create API key")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: key})
+}
+
+// ListAPIKeys handles GET /api-keys
+func (c *APIKeyController) ListAPIKeys(ctx *gin.Context) {
+	keys, err := c.service.ListAPIKeys(ctx.Request.Context())
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list API keys")
+		return
+	}
+
+	response.Success(ctx, gin.H{"api_keys": keys})
+}
+
+// GetAPIKey handles GET /api-keys/:id
+func (c *APIKeyController) GetAPIKey(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid API key ID", err.Error())
+		return
+	}
+
+	key, err := c.service.GetAPIKey(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "API key not found")
+		return
+	}
+
+	response.Success(ctx, key)
+}
+
+// RevokeAPIKey handles DELETE /api-keys/:id
+func (c *APIKeyController) RevokeAPIKey(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid API key ID", err.Error())
+		return
+	}
+
+	if err := c.service.RevokeAPIKey(ctx.Request.Context(), id); err != nil {
+		response.FromError(ctx, err, "Failed to revoke API key")
+		return
+	}
+
+	response.Success(ctx, nil, "API key revoked successfully")
+}
+
+// RegisterRoutes registers the API key routes, scoped to the tenant of the request
+func (c *APIKeyController) RegisterRoutes(router *gin.RouterGroup) {
+	keys := router.Group("/api-keys", middleware.RequireScope(domain.ScopeAPIKeysManage))
+	{
+		keys.POST("", c.CreateAPIKey)
+		keys.GET("", c.ListAPIKeys)
+		keys.GET("/:id", c.GetAPIKey)
+		keys.DELETE("/:id", c.RevokeAPIKey)
+	}
+}
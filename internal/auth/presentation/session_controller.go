@@ -0,0 +1,116 @@
+package presentation
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/auth/application"
+	"github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/response"
+)
+
+// SessionController handles HTTP requests for user logins
+type SessionController struct {
+	service *application.SessionService
+	logger  zerolog.Logger
+}
+
+// NewSessionController creates a new SessionController
+func NewSessionController(service *application.SessionService, logger zerolog.Logger) *SessionController {
+	return &SessionController{
+		service: service,
+		logger:  logger.With().Str("controller", "session").Logger(),
+	}
+}
+
+// Login handles POST /auth/login
+func (c *SessionController) Login(ctx *gin.Context) {
+	var request domain.LoginRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	tokens, err := c.service.Login(ctx.Request.Context(), request)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to log in")
+		return
+	}
+
+	response.Success(ctx, tokens)
+}
+
+// Refresh handles POST /auth/refresh
+func (c *SessionController) Refresh(ctx *gin.Context) {
+	var request domain.RefreshRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	tokens, err := c.service.Refresh(ctx.Request.Context(), request.RefreshToken)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to refresh session")
+		return
+	}
+
+	response.Success(ctx, tokens)
+}
+
+// Logout handles POST /auth/logout
+func (c *SessionController) Logout(ctx *gin.Context) {
+	if err := c.service.Logout(ctx.Request.Context()); err != nil {
+		response.FromError(ctx, err, "Failed to log out")
+		return
+	}
+
+	response.Success(ctx, nil, "Logged out successfully")
+}
+
+// Me handles GET /auth/me
+func (c *SessionController) Me(ctx *gin.Context) {
+	response.Success(ctx, domain.FromContext(ctx.Request.Context()))
+}
+
+// RevokeUserSessions handles DELETE /users/:id/sessions
+func (c *SessionController) RevokeUserSessions(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid user ID", err.Error())
+		return
+	}
+
+	revoked, err := c.service.RevokeUserSessions(ctx.Request.Context(), uint(id))
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to revoke user sessions")
+		response.FromError(ctx, err, "Failed to revoke user sessions")
+		return
+	}
+
+	response.Success(ctx, gin.H{"revoked": revoked}, "User sessions revoked successfully")
+}
+
+// RegisterPublicRoutes registers the routes reachable without credentials
+func (c *SessionController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	auth := router.Group("/auth")
+	{
+		auth.POST("/login", c.Login)
+		auth.POST("/refresh", c.Refresh)
+	}
+}
+
+// RegisterRoutes registers the routes of the authenticated caller
+func (c *SessionController) RegisterRoutes(router *gin.RouterGroup) {
+	auth := router.Group("/auth")
+	{
+		auth.POST("/logout", c.Logout)
+		auth.GET("/me", c.Me)
+	}
+}
+
+// RegisterAdminRoutes registers the session management routes of administrators
+func (c *SessionController) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.DELETE("/users/:id/sessions", c.RevokeUserSessions)
+}
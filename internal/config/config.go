@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -18,12 +19,18 @@ type Config struct {
 	Application ApplicationConfig `mapstructure:"application"`
 	Apm         Apm               `mapstructure:"apm"`
 	WhatsApp    WhatsAppConfig    `mapstructure:"whatsapp"`
+	Health      HealthConfig      `mapstructure:"health"`
+	Encryption  EncryptionConfig  `mapstructure:"encryption"`
+	Auth        AuthConfig        `mapstructure:"auth"`
 }
 
 type ServerConfig struct {
 	Host string `mapstructure:"host"`
 	Port int    `mapstructure:"port"`
 	Mode string `mapstructure:"mode"` // debug, release, test
+	// TrustedProxies lists the proxy IPs or CIDRs allowed to set the client IP
+	// through X-Forwarded-For; empty uses the address of the connection
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type DatabaseConfig struct {
@@ -78,7 +85,98 @@ type Apm struct {
 }
 
 type WhatsAppConfig struct {
-	ZApi ZApiConfig `mapstructure:"zapi"`
+	ZApi           ZApiConfig           `mapstructure:"zapi"`
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	StatusMonitor  StatusMonitorConfig  `mapstructure:"status_monitor"`
+	Webhooks       WebhooksConfig       `mapstructure:"webhooks"`
+	Stream         StreamConfig         `mapstructure:"stream"`
+	OptOut         OptOutConfig         `mapstructure:"opt_out"`
+	Retention      RetentionConfig      `mapstructure:"retention"`
+	Exports        ExportsConfig        `mapstructure:"exports"`
+	Media          MediaConfig          `mapstructure:"media"`
+	Erasure        ErasureConfig        `mapstructure:"erasure"`
+}
+
+// ErasureConfig configures the receipts of contact data erasures
+type ErasureConfig struct {
+	// HashKey keys the phone and chain hashes of the receipts; without it
+	// erasure is disabled. Changing it invalidates the receipts already issued
+	HashKey string `mapstructure:"hash_key"`
+}
+
+// ExportsConfig configures the message history exports
+type ExportsConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`       // runs the worker generating export files
+	Dir          string        `mapstructure:"dir"`           // directory of the generated files, shared between replicas
+	PollInterval time.Duration `mapstructure:"poll_interval"` // time between two scans for pending exports
+	TTL          time.Duration `mapstructure:"ttl"`           // time a generated file stays available for download
+	StreamLimit  int64         `mapstructure:"stream_limit"`  // messages exported directly in the response; larger exports need a job
+}
+
+// MediaConfig configures the storage of uploaded media and how it is handed to providers
+type MediaConfig struct {
+	Dir        string        `mapstructure:"dir"`         // directory of the stored files, shared between replicas
+	PublicURL  string        `mapstructure:"public_url"`  // public base URL of the API; providers download media from signed URLs under it
+	SigningKey string        `mapstructure:"signing_key"` // signs the media URLs; without it or public_url media is sent as base64
+	URLTTL     time.Duration `mapstructure:"url_ttl"`     // validity of a signed media URL
+	// Maximum size in bytes of each media type, following the WhatsApp limits
+	MaxImageSize    int64 `mapstructure:"max_image_size"`
+	MaxVideoSize    int64 `mapstructure:"max_video_size"`
+	MaxAudioSize    int64 `mapstructure:"max_audio_size"`
+	MaxDocumentSize int64 `mapstructure:"max_document_size"`
+	// Download of the media of inbound messages to the storage above
+	Download MediaDownloadConfig `mapstructure:"download"`
+}
+
+// MediaDownloadConfig configures the download of inbound media, whose provider URLs expire
+type MediaDownloadConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`         // downloads inbound media and runs the download worker
+	PollInterval   time.Duration `mapstructure:"poll_interval"`   // time between two scans for due downloads
+	Timeout        time.Duration `mapstructure:"timeout"`         // timeout of each download
+	BatchSize      int           `mapstructure:"batch_size"`      // downloads claimed per scan
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // attempts before a download is marked failed
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // wait before the first retry, doubled on each attempt
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // upper bound of the wait between retries
+	// AllowPrivateNetworks allows downloads from loopback and private addresses
+	// (e.g. a local provider mock); keep it off in production
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+// RetentionConfig configures the background job applying message retention policies
+type RetentionConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`     // runs the purge job
+	Interval   time.Duration `mapstructure:"interval"`    // time between two runs over all policies
+	BatchSize  int           `mapstructure:"batch_size"`  // messages redacted or deleted per transaction
+	BatchPause time.Duration `mapstructure:"batch_pause"` // wait between batches, keeping the load on the database low
+}
+
+// OptOutConfig configures the detection of opt-out requests in inbound messages
+type OptOutConfig struct {
+	Keywords []string `mapstructure:"keywords"` // whole-message keywords, case-insensitive; none disables detection
+	Scope    string   `mapstructure:"scope"`    // instance blocks the receiving instance only; global blocks every instance
+}
+
+// StreamConfig configures the real-time event stream served over SSE and WebSocket
+type StreamConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`     // serves the stream endpoints
+	Heartbeat  time.Duration `mapstructure:"heartbeat"`   // interval between keep-alive messages
+	BufferSize int           `mapstructure:"buffer_size"` // events buffered per client
+	ReplaySize int           `mapstructure:"replay_size"` // recent events kept for clients resuming with Last-Event-ID
+	Overflow   string        `mapstructure:"overflow"`    // slow client policy: disconnect, drop_oldest or drop_newest
+}
+
+// WebhooksConfig configures the delivery of events to client webhooks
+type WebhooksConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`         // runs the delivery worker
+	PollInterval   time.Duration `mapstructure:"poll_interval"`   // time between two scans for due deliveries
+	Timeout        time.Duration `mapstructure:"timeout"`         // timeout of each HTTP request
+	BatchSize      int           `mapstructure:"batch_size"`      // deliveries claimed per scan
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // attempts before a delivery is marked failed
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // wait before the first retry, doubled on each attempt
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // upper bound of the wait between retries
+	// AllowPrivateNetworks allows deliveries to loopback and private addresses
+	// (e.g. a local receiver); keep it off in production
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
 }
 
 type ZApiConfig struct {
@@ -86,6 +184,49 @@ type ZApiConfig struct {
 	ClientToken string `mapstructure:"client_token"`
 }
 
+// CircuitBreakerConfig configures the breakers guarding provider HTTP calls
+type CircuitBreakerConfig struct {
+	FailureThreshold    int           `mapstructure:"failure_threshold"`      // consecutive failures that open the breaker
+	OpenTimeout         time.Duration `mapstructure:"open_timeout"`           // time spent open before probing again
+	HalfOpenMaxRequests int           `mapstructure:"half_open_max_requests"` // probe calls allowed while half-open
+}
+
+// StatusMonitorConfig configures the background refresh of instance status
+type StatusMonitorConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"` // time between two rounds over all instances
+	Timeout  time.Duration `mapstructure:"timeout"`  // timeout of each provider status call
+}
+
+// HealthConfig configures the readiness checks. Checks listed in Critical make
+// /health/ready fail; the remaining enabled checks are only reported.
+type HealthConfig struct {
+	Timeout  time.Duration `mapstructure:"timeout"`  // timeout of each check
+	Checks   []string      `mapstructure:"checks"`   // enabled checks: database, migrations, providers
+	Critical []string      `mapstructure:"critical"` // subset of checks that gate readiness
+}
+
+// EncryptionConfig configures envelope encryption of secrets stored in the database.
+// Keys are base64-encoded 32-byte values; keep old keys listed after a rotation
+// until every value has been re-encrypted with the active key.
+type EncryptionConfig struct {
+	Key         string            `mapstructure:"key"`           // single key, registered under active_key_id or "default"
+	ActiveKeyID string            `mapstructure:"active_key_id"` // key used to encrypt new values
+	Keys        map[string]string `mapstructure:"keys"`          // key id -> base64 key
+}
+
+// AuthConfig configures the authentication of API requests. Applications
+// present an API key in the X-Api-Key header and users the access token issued
+// on login in the Authorization header; the master key grants every scope on
+// every tenant and is meant to create the first API keys.
+type AuthConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`           // disabled allows every request
+	MasterKey       string        `mapstructure:"master_key"`        // empty disables the master key
+	JWTSecret       string        `mapstructure:"jwt_secret"`        // signs access tokens; empty disables login
+	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`  // lifetime of access tokens
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"` // lifetime of idle sessions
+}
+
 // Load reads configuration from file and environment variables
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -124,6 +265,7 @@ func setDefaults() {
 	viper.SetDefault("server.host", "0.0.0.0")
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("server.mode", "debug")
+	viper.SetDefault("server.trusted_proxies", []string{})
 
 	// Database defaults
 	viper.SetDefault("database.driver", "sqlite")
@@ -164,6 +306,67 @@ func setDefaults() {
 	// WhatsApp defaults
 	viper.SetDefault("whatsapp.zapi.base_url", "https://api.z-api.io/instances")
 	viper.SetDefault("whatsapp.zapi.client_token", "123")
+	viper.SetDefault("whatsapp.circuit_breaker.failure_threshold", 5)
+	viper.SetDefault("whatsapp.circuit_breaker.open_timeout", "30s")
+	viper.SetDefault("whatsapp.circuit_breaker.half_open_max_requests", 1)
+	viper.SetDefault("whatsapp.status_monitor.enabled", true)
+	viper.SetDefault("whatsapp.status_monitor.interval", "1m")
+	viper.SetDefault("whatsapp.status_monitor.timeout", "10s")
+	viper.SetDefault("whatsapp.webhooks.enabled", true)
+	viper.SetDefault("whatsapp.webhooks.poll_interval", "2s")
+	viper.SetDefault("whatsapp.webhooks.timeout", "10s")
+	viper.SetDefault("whatsapp.webhooks.batch_size", 50)
+	viper.SetDefault("whatsapp.webhooks.max_attempts", 8)
+	viper.SetDefault("whatsapp.webhooks.initial_backoff", "10s")
+	viper.SetDefault("whatsapp.webhooks.max_backoff", "1h")
+	viper.SetDefault("whatsapp.webhooks.allow_private_networks", false)
+	viper.SetDefault("whatsapp.stream.enabled", true)
+	viper.SetDefault("whatsapp.stream.heartbeat", "15s")
+	viper.SetDefault("whatsapp.stream.buffer_size", 256)
+	viper.SetDefault("whatsapp.stream.replay_size", 1000)
+	viper.SetDefault("whatsapp.stream.overflow", "disconnect")
+	viper.SetDefault("whatsapp.opt_out.keywords", []string{"STOP", "SAIR", "CANCELAR"})
+	viper.SetDefault("whatsapp.opt_out.scope", "instance")
+	viper.SetDefault("whatsapp.retention.enabled", true)
+	viper.SetDefault("whatsapp.retention.interval", "1h")
+	viper.SetDefault("whatsapp.retention.batch_size", 1000)
+	viper.SetDefault("whatsapp.retention.batch_pause", "100ms")
+	viper.SetDefault("whatsapp.exports.enabled", true)
+	viper.SetDefault("whatsapp.exports.dir", "./data/exports")
+	viper.SetDefault("whatsapp.exports.poll_interval", "5s")
+	viper.SetDefault("whatsapp.exports.ttl", "24h")
+	viper.SetDefault("whatsapp.exports.stream_limit", 10000)
+	viper.SetDefault("whatsapp.media.dir", "./data/media")
+	viper.SetDefault("whatsapp.media.url_ttl", "15m")
+	viper.SetDefault("whatsapp.media.max_image_size", 5<<20)
+	viper.SetDefault("whatsapp.media.max_video_size", 16<<20)
+	viper.SetDefault("whatsapp.media.max_audio_size", 16<<20)
+	viper.SetDefault("whatsapp.media.max_document_size", 100<<20)
+	viper.SetDefault("whatsapp.media.download.enabled", true)
+	viper.SetDefault("whatsapp.media.download.poll_interval", "5s")
+	viper.SetDefault("whatsapp.media.download.timeout", "60s")
+	viper.SetDefault("whatsapp.media.download.batch_size", 10)
+	viper.SetDefault("whatsapp.media.download.max_attempts", 6)
+	viper.SetDefault("whatsapp.media.download.initial_backoff", "30s")
+	viper.SetDefault("whatsapp.media.download.max_backoff", "30m")
+	viper.SetDefault("whatsapp.media.download.allow_private_networks", false)
+	viper.SetDefault("whatsapp.erasure.hash_key", "")
+
+	// Health defaults
+	viper.SetDefault("health.timeout", "5s")
+	viper.SetDefault("health.checks", []string{"database", "migrations", "providers"})
+	viper.SetDefault("health.critical", []string{"database", "migrations"})
+
+	// Encryption defaults (no key means secrets are stored in plain text)
+	viper.SetDefault("encryption.key", "")
+	viper.SetDefault("encryption.active_key_id", "")
+
+	// Auth defaults
+	viper.SetDefault("auth.enabled", true)
+	viper.SetDefault("auth.master_key", "")
+	viper.SetDefault("auth.jwt_secret", "")
+	viper.SetDefault("auth.access_token_ttl", "15m")
+	viper.SetDefault("auth.refresh_token_ttl", "720h")
 }
 
 // handleDokkuDatabaseURL parses DATABASE_URL from Dokku PostgreSQL plugin
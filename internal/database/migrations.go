@@ -1,22 +1,273 @@
 package database
 
 import (
+	"fmt"
+
+	auditInfra "github.com/your-org/boilerplate-go/internal/audit/infrastructure"
+	authInfra "github.com/your-org/boilerplate-go/internal/auth/infrastructure"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	tenantInfra "github.com/your-org/boilerplate-go/internal/tenant/infrastructure"
 	"github.com/your-org/boilerplate-go/internal/user/domain"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// MigrateWithUsers runs migrations including user table
+// MigrateWithUsers runs migrations including user table. Users created before
+// tenants existed belong to the default tenant
 func MigrateWithUsers(db *gorm.DB) error {
+	if err := addTenantColumn(db, "users"); err != nil {
+		return err
+	}
 	return db.AutoMigrate(&domain.User{})
 }
 
-// MigrateWhatsApp runs migrations for WhatsApp tables
-func MigrateWhatsApp(db *gorm.DB) error {
-	return db.AutoMigrate(
+// MigrateAuth runs migrations for the API keys and sessions tables
+func MigrateAuth(db *gorm.DB) error {
+	return db.AutoMigrate(&authInfra.GormAPIKey{}, &authInfra.GormSession{})
+}
+
+// MigrateAudit runs migrations for the audit log table
+func MigrateAudit(db *gorm.DB) error {
+	return db.AutoMigrate(&auditInfra.GormAuditEntry{})
+}
+
+// MigrateTenants creates the tenants table and the default tenant that owns
+// the resources created without an explicit tenant
+func MigrateTenants(db *gorm.DB) error {
+	if err := db.AutoMigrate(&tenantInfra.GormTenant{}); err != nil {
+		return err
+	}
+
+	defaultTenant := tenantInfra.GormTenant{
+		ID:   tenantDomain.DefaultTenantID,
+		Name: "Default",
+		Slug: tenantDomain.DefaultTenantSlug,
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaultTenant).Error; err != nil {
+		return fmt.Errorf("failed to create default tenant: %w", err)
+	}
+	return nil
+}
+
+// tenantOwnedTables lists the WhatsApp tables with a tenant_id column
+var tenantOwnedTables = []string{
+	"whatsapp_instances",
+	"whatsapp_messages",
+	"whatsapp_conversations",
+	"whatsapp_instance_groups",
+	"whatsapp_webhook_subscriptions",
+	"whatsapp_automation_rules",
+	"whatsapp_opt_outs",
+}
+
+// addTenantColumns assigns the rows stored before tenants existed to the
+// default tenant. AutoMigrate cannot add a NOT NULL column to a filled table.
+func addTenantColumns(db *gorm.DB) error {
+	for _, table := range tenantOwnedTables {
+		if err := addTenantColumn(db, table); err != nil {
+			return err
+		}
+	}
+
+	// The opt-out unique index now includes the tenant
+	migrator := db.Migrator()
+	if migrator.HasIndex("whatsapp_opt_outs", "idx_whatsapp_opt_outs_instance_phone") {
+		if err := migrator.DropIndex("whatsapp_opt_outs", "idx_whatsapp_opt_outs_instance_phone"); err != nil {
+			return fmt.Errorf("failed to drop opt-out index: %w", err)
+		}
+	}
+	return nil
+}
+
+// addTenantColumn adds the tenant_id column to a filled table, assigning its
+// rows to the default tenant
+func addTenantColumn(db *gorm.DB, table string) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(table) || migrator.HasColumn(table, "tenant_id") {
+		return nil
+	}
+
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN tenant_id uuid NOT NULL DEFAULT '%s'", table, tenantDomain.DefaultTenantID),
+		fmt.Sprintf("ALTER TABLE %s ALTER COLUMN tenant_id DROP DEFAULT", table),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to add tenant to %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// whatsAppModels lists the GORM models backing the WhatsApp tables
+func whatsAppModels() []interface{} {
+	return []interface{}{
 		&infrastructure.GormInstance{},
 		&infrastructure.GormMessage{},
-	)
+		&infrastructure.GormConversation{},
+		&infrastructure.GormInstanceGroup{},
+		&infrastructure.GormInstanceGroupMember{},
+		&infrastructure.GormWebhookSubscription{},
+		&infrastructure.GormWebhookDelivery{},
+		&infrastructure.GormAutomationRule{},
+		&infrastructure.GormRuleExecution{},
+		&infrastructure.GormOptOut{},
+		&infrastructure.GormRetentionPolicy{},
+		&infrastructure.GormExportJob{},
+		&infrastructure.GormErasureReceipt{},
+		&infrastructure.GormMedia{},
+		&infrastructure.GormMediaDownload{},
+	}
+}
+
+// MigrateWhatsApp runs migrations for WhatsApp tables
+func MigrateWhatsApp(db *gorm.DB) error {
+	hadConversations := db.Migrator().HasTable(&infrastructure.GormConversation{})
+	hadMessageLifecycle := !db.Migrator().HasTable(&infrastructure.GormMessage{}) ||
+		db.Migrator().HasColumn(&infrastructure.GormMessage{}, "sent_at")
+
+	if err := addTenantColumns(db); err != nil {
+		return err
+	}
+
+	if err := prepareMessageProviderIndex(db); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(whatsAppModels()...); err != nil {
+		return err
+	}
+
+	if err := createMessageSearchIndex(db); err != nil {
+		return err
+	}
+
+	if !hadMessageLifecycle {
+		if err := backfillMessageLifecycle(db); err != nil {
+			return err
+		}
+	}
+
+	if !hadConversations {
+		return backfillConversations(db)
+	}
+	return nil
+}
+
+// backfillMessageLifecycle fills the provider and the sent/failed timestamps
+// of messages stored before they were recorded. Delivery and read times are
+// unknown for those messages and stay empty.
+func backfillMessageLifecycle(db *gorm.DB) error {
+	statements := []string{
+		`UPDATE whatsapp_messages SET provider = whatsapp_instances.provider
+			FROM whatsapp_instances
+			WHERE whatsapp_messages.provider = '' AND whatsapp_messages.instance_id = whatsapp_instances.id::text`,
+		`UPDATE whatsapp_messages SET sent_at = updated_at
+			WHERE sent_at IS NULL AND direction = 'outbound' AND status IN ('sent', 'delivered', 'read')`,
+		`UPDATE whatsapp_messages SET failed_at = updated_at
+			WHERE failed_at IS NULL AND status = 'failed'`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to backfill message lifecycle: %w", err)
+		}
+	}
+	return nil
+}
+
+// prepareMessageProviderIndex clears the provider ID of inbound messages stored
+// twice before it was unique per instance, keeping the oldest copy, so the
+// unique index can be created. The single-column index it replaces is dropped.
+func prepareMessageProviderIndex(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable("whatsapp_messages") || migrator.HasIndex("whatsapp_messages", "idx_whatsapp_messages_instance_provider") {
+		return nil
+	}
+
+	err := db.Exec(`UPDATE whatsapp_messages SET provider_id = NULL
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY instance_id, provider_id ORDER BY created_at, id) AS copy
+				FROM whatsapp_messages
+				WHERE provider_id IS NOT NULL
+			) copies
+			WHERE copy > 1
+		)`).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear duplicate provider IDs: %w", err)
+	}
+
+	if migrator.HasIndex("whatsapp_messages", "idx_whatsapp_messages_provider_id") {
+		if err := migrator.DropIndex("whatsapp_messages", "idx_whatsapp_messages_provider_id"); err != nil {
+			return fmt.Errorf("failed to drop provider ID index: %w", err)
+		}
+	}
+	return nil
+}
+
+// createMessageSearchIndex creates the full-text index used by message search.
+// Other databases fall back to LIKE and need no index.
+func createMessageSearchIndex(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_whatsapp_messages_content_fts
+		ON whatsapp_messages USING GIN (to_tsvector('simple', content))`).Error
+	if err != nil {
+		return fmt.Errorf("failed to create message search index: %w", err)
+	}
+	return nil
+}
+
+// backfillConversations builds the conversations of messages stored before the
+// conversations table existed, keeping the latest message of each chat
+func backfillConversations(db *gorm.DB) error {
+	err := db.Exec(`
+		INSERT INTO whatsapp_conversations (id, tenant_id, instance_id, phone, last_message_id, last_message_preview,
+			last_message_type, last_direction, last_message_at, unread_count, created_at, updated_at)
+		SELECT DISTINCT ON (instance_id, phone)
+			gen_random_uuid(), tenant_id, instance_id, phone, id, LEFT(content, 100),
+			type, direction, created_at, 0, created_at, created_at
+		FROM whatsapp_messages
+		ORDER BY instance_id, phone, created_at DESC, id DESC
+		ON CONFLICT (instance_id, phone) DO NOTHING
+	`).Error
+	if err != nil {
+		return fmt.Errorf("failed to backfill conversations: %w", err)
+	}
+	return nil
+}
+
+// PendingMigrations lists the tables and columns of the migrated models that
+// are missing from the database, e.g. after a deploy whose migrations failed
+func PendingMigrations(db *gorm.DB) ([]string, error) {
+	models := append([]interface{}{&domain.User{}, &tenantInfra.GormTenant{}, &authInfra.GormAPIKey{}, &authInfra.GormSession{}, &auditInfra.GormAuditEntry{}}, whatsAppModels()...)
+	migrator := db.Migrator()
+
+	var pending []string
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model schema: %w", err)
+		}
+
+		table := stmt.Schema.Table
+		if !migrator.HasTable(model) {
+			pending = append(pending, table)
+			continue
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, table+"."+field.DBName)
+			}
+		}
+	}
+
+	return pending, nil
 }
 
 // MigrateAll runs all migrations
@@ -25,6 +276,18 @@ func MigrateAll(db *gorm.DB) error {
 		return err
 	}
 
+	if err := MigrateTenants(db); err != nil {
+		return err
+	}
+
+	if err := MigrateAuth(db); err != nil {
+		return err
+	}
+
+	if err := MigrateAudit(db); err != nil {
+		return err
+	}
+
 	if err := MigrateWhatsApp(db); err != nil {
 		return err
 	}
@@ -43,11 +306,14 @@ func Seed(db *gorm.DB) error {
 		return err
 	}
 
-	// Seed admin user if no users exist
+	// Seed admin user if no users exist. It has no password and cannot log in
+	// until one is set through PUT /users/:id
 	if count == 0 {
 		adminUser := &domain.User{
-			Name:  "Admin User",
-			Email: "admin@example.com",
+			Name:     "Admin User",
+			Email:    "admin@example.com",
+			Role:     domain.RoleAdmin,
+			TenantID: tenantDomain.DefaultTenantID,
 		}
 
 		if err := db.Create(adminUser).Error; err != nil {
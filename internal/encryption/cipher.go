@@ -0,0 +1,226 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/boilerplate-go/internal/config"
+)
+
+const (
+	// envelopePrefix identifies values produced by Cipher.Encrypt
+	envelopePrefix = "enc:v1:"
+	// keySize is the size in bytes of both key-encryption keys and data keys (AES-256)
+	keySize = 32
+	// defaultKeyID is used when a single key is configured without an explicit ID
+	defaultKeyID = "default"
+)
+
+// ErrUnknownKey is returned when a value was encrypted with a key that is not configured
+var ErrUnknownKey = errors.New("encryption key not configured")
+
+// Cipher performs envelope encryption: every value is encrypted with a fresh
+// random data key, and that data key is wrapped with the active key-encryption
+// key from configuration. Values carry the ID of the key that wrapped them, so
+// old keys can stay configured for decryption while values are re-encrypted.
+type Cipher struct {
+	keys        map[string][]byte
+	activeKeyID string
+}
+
+// NewCipher creates a Cipher from configuration. When no key is configured the
+// cipher is disabled and values are stored as given.
+func NewCipher(cfg config.EncryptionConfig) (*Cipher, error) {
+	keys := make(map[string][]byte, len(cfg.Keys)+1)
+	for id, encoded := range cfg.Keys {
+		if strings.TrimSpace(encoded) == "" {
+			continue
+		}
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+
+	activeKeyID := cfg.ActiveKeyID
+	if cfg.Key != "" {
+		if activeKeyID == "" {
+			activeKeyID = defaultKeyID
+		}
+		key, err := decodeKey(cfg.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+		keys[activeKeyID] = key
+	}
+
+	if len(keys) > 0 {
+		if activeKeyID == "" {
+			return nil, fmt.Errorf("encryption.active_key_id is required when several keys are configured")
+		}
+		if _, ok := keys[activeKeyID]; !ok {
+			return nil, fmt.Errorf("active encryption key %q is not configured", activeKeyID)
+		}
+	}
+
+	return &Cipher{keys: keys, activeKeyID: activeKeyID}, nil
+}
+
+// Enabled reports whether an encryption key is configured
+func (c *Cipher) Enabled() bool {
+	return len(c.keys) > 0
+}
+
+// ActiveKeyID returns the ID of the key used to encrypt new values
+func (c *Cipher) ActiveKeyID() string {
+	return c.activeKeyID
+}
+
+// IsEncrypted reports whether the value is an envelope produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// Encrypt encrypts the plaintext with a new data key wrapped by the active key.
+// Empty strings and already encrypted values are returned unchanged.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if !c.Enabled() || plaintext == "" || IsEncrypted(plaintext) {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrappedKey, err := seal(c.keys[c.activeKeyID], dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+
+	return envelopePrefix + c.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a value produced by Encrypt. Values without the envelope
+// prefix are legacy plaintext and are returned unchanged.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, envelopePrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+
+	kek, ok := c.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, parts[0])
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed data key: %w", err)
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed ciphertext: %w", err)
+	}
+
+	dataKey, err := open(kek, wrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether the value should be re-encrypted: it is legacy
+// plaintext or was wrapped by a key other than the active one
+func (c *Cipher) NeedsRotation(value string) bool {
+	if !c.Enabled() || value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	return !strings.HasPrefix(value, envelopePrefix+c.activeKeyID+":")
+}
+
+// Hash returns a deterministic SHA-256 digest of the value, used to look up
+// encrypted values by equality without decrypting every row
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// seal encrypts data with AES-GCM, prefixing the random nonce
+func seal(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+// open decrypts data produced by seal
+func open(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+// newGCM creates an AES-GCM AEAD for the key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// decodeKey decodes a base64 key and checks its size
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
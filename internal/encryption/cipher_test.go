@@ -0,0 +1,97 @@
+package encryption_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/config"
+	"github.com/your-org/boilerplate-go/internal/encryption"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(rune(b)), 32)))
+}
+
+func TestCipher_EncryptDecrypt(t *testing.T) {
+	cipher, err := encryption.NewCipher(config.EncryptionConfig{Key: testKey('a')})
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt("657054F1246E3A6A2049CD9E")
+	require.NoError(t, err)
+	assert.True(t, encryption.IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "657054F1246E3A6A2049CD9E")
+
+	decrypted, err := cipher.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "657054F1246E3A6A2049CD9E", decrypted)
+
+	again, err := cipher.Encrypt("657054F1246E3A6A2049CD9E")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "each value must use a fresh data key")
+}
+
+func TestCipher_LegacyPlaintext(t *testing.T) {
+	cipher, err := encryption.NewCipher(config.EncryptionConfig{Key: testKey('a')})
+	require.NoError(t, err)
+
+	decrypted, err := cipher.Decrypt("plain-token")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", decrypted)
+	assert.True(t, cipher.NeedsRotation("plain-token"))
+}
+
+func TestCipher_Disabled(t *testing.T) {
+	cipher, err := encryption.NewCipher(config.EncryptionConfig{})
+	require.NoError(t, err)
+	assert.False(t, cipher.Enabled())
+
+	value, err := cipher.Encrypt("token")
+	require.NoError(t, err)
+	assert.Equal(t, "token", value)
+	assert.False(t, cipher.NeedsRotation("token"))
+}
+
+func TestCipher_KeyRotation(t *testing.T) {
+	oldCipher, err := encryption.NewCipher(config.EncryptionConfig{
+		ActiveKeyID: "k1",
+		Keys:        map[string]string{"k1": testKey('a')},
+	})
+	require.NoError(t, err)
+
+	encrypted, err := oldCipher.Encrypt("secret")
+	require.NoError(t, err)
+
+	rotated, err := encryption.NewCipher(config.EncryptionConfig{
+		ActiveKeyID: "k2",
+		Keys:        map[string]string{"k1": testKey('a'), "k2": testKey('b')},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, rotated.NeedsRotation(encrypted))
+
+	decrypted, err := rotated.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	reencrypted, err := rotated.Encrypt(decrypted)
+	require.NoError(t, err)
+	assert.False(t, rotated.NeedsRotation(reencrypted))
+
+	_, err = oldCipher.Decrypt(reencrypted)
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
+
+func TestNewCipher_InvalidKey(t *testing.T) {
+	_, err := encryption.NewCipher(config.EncryptionConfig{Key: base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.Error(t, err)
+
+	_, err = encryption.NewCipher(config.EncryptionConfig{
+		ActiveKeyID: "missing",
+		Keys:        map[string]string{"k1": testKey('a')},
+	})
+	assert.Error(t, err)
+}
@@ -12,6 +12,8 @@ var (
 	ErrBadRequest   = errors.New("bad request")
 	ErrInternal     = errors.New("internal server error")
 	ErrConflict     = errors.New("resource conflict")
+	ErrUnavailable  = errors.New("service unavailable")
+	ErrForbidden    = errors.New("forbidden")
 )
 
 // AppError represents an application error with additional context
@@ -70,3 +72,39 @@ func NewConflictError(message string) *AppError {
 		Err:     ErrConflict,
 	}
 }
+
+// NewUnavailableError creates an error for a dependency that cannot serve the request right now
+func NewUnavailableError(code, message string) *AppError {
+	return &AppError{
+		Code:    code,
+		Message: message,
+		Err:     ErrUnavailable,
+	}
+}
+
+// NewUnauthorizedError creates an error for a caller whose credentials are missing or invalid
+func NewUnauthorizedError(code, message string) *AppError {
+	return &AppError{
+		Code:    code,
+		Message: message,
+		Err:     ErrUnauthorized,
+	}
+}
+
+// NewForbiddenError creates an error for a valid request that policy does not allow
+func NewForbiddenError(code, message string) *AppError {
+	return &AppError{
+		Code:    code,
+		Message: message,
+		Err:     ErrForbidden,
+	}
+}
+
+// NewFeatureNotSupportedError creates an error for a request the underlying provider cannot fulfil
+func NewFeatureNotSupportedError(message string) *AppError {
+	return &AppError{
+		Code:    "FEATURE_NOT_SUPPORTED",
+		Message: message,
+		Err:     ErrBadRequest,
+	}
+}
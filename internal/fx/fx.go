@@ -2,19 +2,31 @@ package fx
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"go.uber.org/fx"
 
 	"github.com/rs/zerolog"
+	"github.com/your-org/boilerplate-go/internal/audit"
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	"github.com/your-org/boilerplate-go/internal/auth"
 	"github.com/your-org/boilerplate-go/internal/config"
 	"github.com/your-org/boilerplate-go/internal/database"
+	"github.com/your-org/boilerplate-go/internal/encryption"
+	"github.com/your-org/boilerplate-go/internal/health"
 	"github.com/your-org/boilerplate-go/internal/logger"
 	"github.com/your-org/boilerplate-go/internal/server"
 	"github.com/your-org/boilerplate-go/internal/telemetry"
+	"github.com/your-org/boilerplate-go/internal/tenant"
 	"github.com/your-org/boilerplate-go/internal/user/application"
+	userDomain "github.com/your-org/boilerplate-go/internal/user/domain"
 	"github.com/your-org/boilerplate-go/internal/user/infrastructure"
 	"github.com/your-org/boilerplate-go/internal/user/presentation"
 	"github.com/your-org/boilerplate-go/internal/whatsapp"
+	whatsappApplication "github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	whatsappDomain "github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
 	"gorm.io/gorm"
 )
 
@@ -24,8 +36,14 @@ var AppModule = fx.Module("app",
 	LoggerModule,
 	TelemetryModule,
 	DatabaseModule,
+	EncryptionModule,
+	EventsModule,
+	audit.Module,
 	UserModule,
+	tenant.Module,
+	auth.Module,
 	whatsapp.Module,
+	HealthModule,
 	ServerModule,
 )
 
@@ -52,13 +70,29 @@ var DatabaseModule = fx.Module("database",
 	fx.Invoke(SetupTracing),
 )
 
+// EncryptionModule fornece a criptografia de segredos em repouso
+var EncryptionModule = fx.Module("encryption",
+	fx.Provide(NewCipher),
+)
+
+// EventsModule fornece o barramento de eventos da aplicação
+var EventsModule = fx.Module("events",
+	fx.Provide(NewEventBus),
+)
+
 // UserModule fornece componentes do domínio User
 var UserModule = fx.Module("user",
 	fx.Provide(infrastructure.NewGormUserRepository),
+	fx.Provide(NewUserRepository),
 	fx.Provide(NewUserService),
 	fx.Provide(NewUserController),
 )
 
+// HealthModule fornece as verificações de prontidão
+var HealthModule = fx.Module("health",
+	fx.Provide(NewHealthChecker),
+)
+
 // ServerModule fornece o servidor HTTP
 var ServerModule = fx.Module("server",
 	fx.Provide(server.New),
@@ -75,9 +109,92 @@ func NewZerologLogger(log *logger.Logger) zerolog.Logger {
 	return log.Logger
 }
 
+// NewCipher adapter para a criptografia de segredos
+func NewCipher(cfg *config.Config, log zerolog.Logger) (*encryption.Cipher, error) {
+	cipher, err := encryption.NewCipher(cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cipher.Enabled() {
+		log.Warn().Msg("No encryption key configured, instance tokens will be stored in plain text")
+	}
+
+	return cipher, nil
+}
+
+// NewEventBus cria o barramento de eventos, fechado no encerramento da aplicação
+func NewEventBus(lc fx.Lifecycle) *events.ChannelEventBus {
+	bus := events.NewChannelEventBus(events.DefaultConfig())
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return bus.Close()
+		},
+	})
+
+	return bus
+}
+
+// NewHealthChecker monta as verificações de prontidão habilitadas na configuração
+func NewHealthChecker(cfg *config.Config, db *gorm.DB, whatsappService *whatsappApplication.WhatsAppService) (*health.Checker, error) {
+	available := map[string]health.CheckFunc{
+		"database": func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+		"migrations": func(ctx context.Context) error {
+			pending, err := database.PendingMigrations(db.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("pending migrations: %s", strings.Join(pending, ", "))
+			}
+			return nil
+		},
+		"providers": func(ctx context.Context) error {
+			var unhealthy []string
+			for _, result := range whatsappService.GetAllProvidersHealth(ctx) {
+				if result.Status == whatsappDomain.ProviderUnhealthy {
+					unhealthy = append(unhealthy, result.Provider)
+				}
+			}
+			if len(unhealthy) > 0 {
+				return fmt.Errorf("unhealthy providers: %s", strings.Join(unhealthy, ", "))
+			}
+			return nil
+		},
+	}
+
+	critical := make(map[string]bool, len(cfg.Health.Critical))
+	for _, name := range cfg.Health.Critical {
+		critical[name] = true
+	}
+
+	checks := make([]health.Check, 0, len(cfg.Health.Checks))
+	for _, name := range cfg.Health.Checks {
+		run, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown health check: %s", name)
+		}
+		checks = append(checks, health.Check{Name: name, Critical: critical[name], Run: run})
+	}
+
+	return health.NewChecker(cfg.Health.Timeout, checks...), nil
+}
+
+// NewUserRepository expõe o repositório de usuários pela interface do domínio
+func NewUserRepository(userRepo *infrastructure.GormUserRepository) userDomain.UserRepository {
+	return userRepo
+}
+
 // NewUserService adapter para o service de usuário
-func NewUserService(userRepo *infrastructure.GormUserRepository, log *logger.Logger) *application.UserService {
-	return application.NewUserService(userRepo, log)
+func NewUserService(userRepo *infrastructure.GormUserRepository, recorder auditDomain.Recorder, log *logger.Logger) *application.UserService {
+	return application.NewUserService(userRepo, recorder, log)
 }
 
 // NewTelemetryCleanup adapter para telemetria
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status of a single check or of the whole report
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDown     Status = "down"
+	StatusDegraded Status = "degraded" // only informational checks failed
+)
+
+// CheckFunc returns nil when the dependency is healthy
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named readiness check. Critical checks make the service not
+// ready when they fail; informational ones are only reported.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      CheckFunc
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report aggregates the results of all checks
+type Report struct {
+	Status    Status    `json:"status"`
+	Checks    []Result  `json:"checks"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Ready reports whether every critical check passed
+func (r Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Checker runs the registered readiness checks
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewChecker creates a checker that runs each check with the given timeout
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Run executes all checks concurrently and returns their results in registration order
+func (c *Checker) Run(ctx context.Context) Report {
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = c.runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	status := StatusUp
+	for _, result := range results {
+		if result.Status == StatusUp {
+			continue
+		}
+		if result.Critical {
+			status = StatusDown
+			break
+		}
+		status = StatusDegraded
+	}
+
+	return Report{Status: status, Checks: results, CheckedAt: time.Now()}
+}
+
+func (c *Checker) runCheck(ctx context.Context, check Check) Result {
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := runWithContext(checkCtx, check.Run)
+
+	result := Result{
+		Name:       check.Name,
+		Status:     StatusUp,
+		Critical:   check.Critical,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// runWithContext stops waiting for checks that ignore their context once it expires
+func runWithContext(ctx context.Context, run CheckFunc) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
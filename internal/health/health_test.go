@@ -0,0 +1,68 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/your-org/boilerplate-go/internal/health"
+)
+
+func up(ctx context.Context) error { return nil }
+
+func down(ctx context.Context) error { return errors.New("unreachable") }
+
+func TestCheckerAllUp(t *testing.T) {
+	checker := health.NewChecker(time.Second,
+		health.Check{Name: "database", Critical: true, Run: up},
+		health.Check{Name: "providers", Run: up},
+	)
+
+	report := checker.Run(context.Background())
+
+	assert.Equal(t, health.StatusUp, report.Status)
+	assert.True(t, report.Ready())
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, "database", report.Checks[0].Name)
+}
+
+func TestCheckerInformationalFailureDegrades(t *testing.T) {
+	checker := health.NewChecker(time.Second,
+		health.Check{Name: "database", Critical: true, Run: up},
+		health.Check{Name: "providers", Run: down},
+	)
+
+	report := checker.Run(context.Background())
+
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.True(t, report.Ready())
+	assert.Equal(t, "unreachable", report.Checks[1].Error)
+}
+
+func TestCheckerCriticalFailureIsNotReady(t *testing.T) {
+	checker := health.NewChecker(time.Second,
+		health.Check{Name: "database", Critical: true, Run: down},
+		health.Check{Name: "providers", Run: down},
+	)
+
+	report := checker.Run(context.Background())
+
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.False(t, report.Ready())
+}
+
+func TestCheckerTimesOutSlowChecks(t *testing.T) {
+	slow := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	checker := health.NewChecker(10*time.Millisecond, health.Check{Name: "slow", Critical: true, Run: slow})
+
+	report := checker.Run(context.Background())
+
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+)
+
+// RequestInfo stores the request ID and the client IP in the request context,
+// where the audit log picks them up. It must run after Logger, which assigns
+// the request ID when the client did not send one
+func RequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = c.Writer.Header().Get("X-Request-ID")
+		}
+
+		c.Request = c.Request.WithContext(auditDomain.NewRequestContext(c.Request.Context(), auditDomain.RequestInfo{
+			RequestID: requestID,
+			IP:        c.ClientIP(),
+		}))
+		c.Next()
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/response"
+)
+
+// APIKeyHeader carries the API key of the caller
+const APIKeyHeader = "X-Api-Key"
+
+// Authenticator resolves a credential to the principal it authenticates
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*authDomain.Principal, error)
+}
+
+// Authenticate authenticates the request with the access token of a
+// "Authorization: Bearer" header or with the key of the X-Api-Key header.
+// Browser clients such as EventSource and WebSocket, which cannot set headers,
+// may use the access_token or api_key query parameters instead, but only on the
+// routes listed in queryCredentialRoutes: query strings end up in proxy logs and
+// browser history. A nil tokens authenticator rejects access tokens
+func Authenticate(apiKeys, tokens Authenticator, queryCredentialRoutes ...string) gin.HandlerFunc {
+	queryRoutes := make(map[string]bool, len(queryCredentialRoutes))
+	for _, route := range queryCredentialRoutes {
+		queryRoutes[route] = true
+	}
+
+	return func(c *gin.Context) {
+		query := queryRoutes[c.FullPath()]
+
+		authenticator, credential := apiKeys, c.GetHeader(APIKeyHeader)
+		if token, ok := bearerToken(c, query); ok {
+			authenticator, credential = tokens, token
+		} else if credential == "" && query {
+			credential = c.Query("api_key")
+		}
+		if credential == "" || authenticator == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{
+				Error: "Unauthorized",
+				Code:  "UNAUTHORIZED",
+			})
+			return
+		}
+
+		principal, err := authenticator.Authenticate(c.Request.Context(), credential)
+		var appErr *apperrors.AppError
+		if errors.Is(err, apperrors.ErrUnauthorized) && errors.As(err, &appErr) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{
+				Error:   "Unauthorized",
+				Code:    appErr.Code,
+				Message: appErr.Message,
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.ErrorResponse{
+				Error:   "Failed to authenticate",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.Request = c.Request.WithContext(authDomain.NewContext(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// bearerToken returns the access token of the Authorization header or, when
+// query credentials are allowed, of the access_token query parameter
+func bearerToken(c *gin.Context, query bool) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if scheme, token, ok := strings.Cut(header, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token), true
+	}
+	if !query {
+		return "", false
+	}
+	if token := c.Query("access_token"); token != "" {
+		return token, true
+	}
+	return "", false
+}
+
+// Anonymous grants every scope to every request. It replaces Authenticate when
+// authentication is disabled
+func Anonymous() gin.HandlerFunc {
+	principal := &authDomain.Principal{
+		Type:   authDomain.PrincipalAnonymous,
+		Scopes: []string{authDomain.ScopeAdmin},
+	}
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(authDomain.NewContext(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireScope rejects requests whose principal was not granted the scope
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := authDomain.FromContext(c.Request.Context())
+		if principal == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ErrorResponse{
+				Error: "Unauthorized",
+				Code:  "UNAUTHORIZED",
+			})
+			return
+		}
+		if !principal.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.ErrorResponse{
+				Error:   "Forbidden",
+				Code:    "INSUFFICIENT_SCOPE",
+				Message: "missing scope " + scope,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,7 +16,7 @@ func Logger(logger zerolog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
+		raw := redactQuery(c.Request.URL.RawQuery)
 
 		// Generate request ID if not present
 		requestID := c.GetHeader("X-Request-ID")
@@ -65,6 +67,31 @@ func Logger(logger zerolog.Logger) gin.HandlerFunc {
 	}
 }
 
+// redactedQueryParams are the query parameters carrying credentials: the
+// stream credentials and the token of the provider callbacks
+var redactedQueryParams = map[string]bool{
+	"api_key":      true,
+	"access_token": true,
+	"token":        true,
+}
+
+// redactQuery replaces the values of credential parameters in a raw query,
+// keeping the other parameters as sent
+func redactQuery(raw string) string {
+	if raw == "" {
+		return raw
+	}
+
+	params := strings.Split(raw, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && redactedQueryParams[strings.ToLower(name)] {
+			params[i] = key + "=REDACTED"
+		}
+	}
+	return strings.Join(params, "&")
+}
+
 // Recovery middleware recovers from panics with enhanced logging
 func Recovery(logger zerolog.Logger) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
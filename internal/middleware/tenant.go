@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/response"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// TenantHeader selects the tenant of a request by ID or slug
+const TenantHeader = "X-Tenant-ID"
+
+// TenantResolver resolves a tenant reference to the tenant ID. An empty
+// reference resolves to the default tenant
+type TenantResolver interface {
+	ResolveTenant(ctx context.Context, ref string) (uuid.UUID, error)
+}
+
+// Tenant scopes the request to the tenant selected by the X-Tenant-ID header
+// or, for browser clients that cannot set headers, the tenant_id query
+// parameter. Without a selection the caller's own tenant is used. Only admins
+// may select other tenants: API keys, operators and viewers always use their
+// own. Unknown tenants and tenants of other callers are rejected with 404.
+func Tenant(resolver TenantResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ref := c.GetHeader(TenantHeader)
+		if ref == "" {
+			ref = c.Query("tenant_id")
+		}
+
+		principal := authDomain.FromContext(c.Request.Context())
+		own := principal != nil && principal.TenantID != nil
+		bound := principal == nil || !principal.CanSelectTenant()
+
+		var tenantID uuid.UUID
+		var err error
+		if own && ref == "" {
+			tenantID = *principal.TenantID
+		} else {
+			tenantID, err = resolver.ResolveTenant(c.Request.Context(), ref)
+		}
+		if errors.Is(err, apperrors.ErrNotFound) || (err == nil && bound && (!own || tenantID != *principal.TenantID)) {
+			c.AbortWithStatusJSON(http.StatusNotFound, response.ErrorResponse{
+				Error: "Tenant not found",
+				Code:  "TENANT_NOT_FOUND",
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.ErrorResponse{
+				Error:   "Failed to resolve tenant",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenantDomain.NewContext(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
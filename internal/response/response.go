@@ -1,14 +1,18 @@
 package response
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
 )
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
@@ -33,6 +37,22 @@ type Pagination struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// CursorPaginatedResponse represents a page of a keyset-paginated listing
+type CursorPaginatedResponse struct {
+	Data       interface{}      `json:"data"`
+	Pagination CursorPagination `json:"pagination"`
+	Message    string           `json:"message,omitempty"`
+}
+
+// CursorPagination represents cursor pagination info. NextCursor is empty on the last page
+// and Total is only present when the caller asked for it.
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
 // JSON sends a JSON response
 func JSON(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, data)
@@ -60,6 +80,46 @@ func Error(c *gin.Context, statusCode int, err string, message ...string) {
 	c.JSON(statusCode, response)
 }
 
+// ErrorWithCode sends an error response carrying a machine-readable error code
+func ErrorWithCode(c *gin.Context, statusCode int, code, err string, message ...string) {
+	response := ErrorResponse{
+		Error: err,
+		Code:  code,
+	}
+	if len(message) > 0 {
+		response.Message = message[0]
+	}
+	c.JSON(statusCode, response)
+}
+
+// FromError sends the response matching an application error, using 500 for
+// unclassified errors. The AppError code, when present, goes along so that
+// clients can handle it
+func FromError(c *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, apperrors.ErrBadRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, apperrors.ErrUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		ErrorWithCode(c, status, appErr.Code, message, err.Error())
+		return
+	}
+	Error(c, status, message, err.Error())
+}
+
 // BadRequest sends a bad request error response
 func BadRequest(c *gin.Context, err string, message ...string) {
 	Error(c, http.StatusBadRequest, err, message...)
@@ -94,3 +154,21 @@ func Paginated(c *gin.Context, data interface{}, page, limit int, total int64, m
 
 	c.JSON(http.StatusOK, response)
 }
+
+// CursorPaginated sends a keyset-paginated response
+func CursorPaginated(c *gin.Context, data interface{}, limit int, total *int64, nextCursor string, message ...string) {
+	response := CursorPaginatedResponse{
+		Data: data,
+		Pagination: CursorPagination{
+			Limit:      limit,
+			Total:      total,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	}
+	if len(message) > 0 {
+		response.Message = message[0]
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -10,11 +10,19 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	auditPresentation "github.com/your-org/boilerplate-go/internal/audit/presentation"
+	authApplication "github.com/your-org/boilerplate-go/internal/auth/application"
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	authPresentation "github.com/your-org/boilerplate-go/internal/auth/presentation"
 	"github.com/your-org/boilerplate-go/internal/config"
+	"github.com/your-org/boilerplate-go/internal/health"
 	"github.com/your-org/boilerplate-go/internal/logger"
 	"github.com/your-org/boilerplate-go/internal/middleware"
+	tenantApplication "github.com/your-org/boilerplate-go/internal/tenant/application"
+	tenantPresentation "github.com/your-org/boilerplate-go/internal/tenant/presentation"
 	"github.com/your-org/boilerplate-go/internal/user/presentation"
 	whatsappPresentation "github.com/your-org/boilerplate-go/internal/whatsapp/presentation"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
 	"gorm.io/gorm"
 )
 
@@ -24,7 +32,17 @@ type Server struct {
 	logger             *logger.Logger
 	router             *gin.Engine
 	userController     *presentation.UserController
+	tenantController   *tenantPresentation.TenantController
+	tenantService      *tenantApplication.TenantService
+	apiKeyController   *authPresentation.APIKeyController
+	auditController    *auditPresentation.AuditController
+	apiKeyService      *authApplication.APIKeyService
+	sessionController  *authPresentation.SessionController
+	sessionService     *authApplication.SessionService
 	whatsappController *whatsappPresentation.WhatsAppController
+	breakers           *circuitbreaker.Registry
+	checker            *health.Checker
+	startedAt          time.Time
 }
 
 // New creates a new server instance
@@ -33,7 +51,16 @@ func New(
 	db *gorm.DB,
 	appLogger *logger.Logger,
 	userController *presentation.UserController,
+	tenantController *tenantPresentation.TenantController,
+	tenantService *tenantApplication.TenantService,
+	apiKeyController *authPresentation.APIKeyController,
+	auditController *auditPresentation.AuditController,
+	apiKeyService *authApplication.APIKeyService,
+	sessionController *authPresentation.SessionController,
+	sessionService *authApplication.SessionService,
 	whatsappController *whatsappPresentation.WhatsAppController,
+	breakers *circuitbreaker.Registry,
+	checker *health.Checker,
 ) *Server {
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
@@ -47,12 +74,27 @@ func New(
 		logger:             appLogger,
 		router:             router,
 		userController:     userController,
+		tenantController:   tenantController,
+		tenantService:      tenantService,
+		apiKeyController:   apiKeyController,
+		auditController:    auditController,
+		apiKeyService:      apiKeyService,
+		sessionController:  sessionController,
+		sessionService:     sessionService,
 		whatsappController: whatsappController,
+		breakers:           breakers,
+		checker:            checker,
+		startedAt:          time.Now(),
 	}
 }
 
 // Start starts the HTTP server
 func (s *Server) Start() error {
+	// Only the configured proxies may set the client IP recorded in the audit log
+	if err := s.router.SetTrustedProxies(s.config.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	// Setup middleware
 	s.setupMiddleware()
 
@@ -101,6 +143,7 @@ func (s *Server) Start() error {
 // setupMiddleware configures middleware
 func (s *Server) setupMiddleware() {
 	s.router.Use(middleware.Logger(s.logger.Logger))
+	s.router.Use(middleware.RequestInfo())
 	s.router.Use(middleware.Recovery(s.logger.Logger))
 	s.router.Use(middleware.CORS())
 
@@ -114,6 +157,8 @@ func (s *Server) setupMiddleware() {
 func (s *Server) setupRoutes() {
 	// Health check endpoint
 	s.router.GET("/health", s.healthCheck)
+	s.router.GET("/health/live", s.liveness)
+	s.router.GET("/health/ready", s.readiness)
 
 	// API routes
 	v1 := s.router.Group("/api/v1")
@@ -121,22 +166,94 @@ func (s *Server) setupRoutes() {
 		// Welcome endpoint
 		v1.GET("/", s.welcome)
 
-		// User routes - using injected controller
-		s.userController.RegisterRoutes(v1)
+		// Provider callbacks identify the instance, and so the tenant, by URL
+		s.whatsappController.RegisterCallbackRoutes(v1)
+
+		// Login and refresh exchange credentials for tokens
+		s.sessionController.RegisterPublicRoutes(v1)
+
+		authenticated := v1.Group("", s.authenticate())
+		s.sessionController.RegisterRoutes(authenticated)
 
-		// WhatsApp routes - using injected controller
-		s.whatsappController.RegisterRoutes(v1)
+		// User and tenant routes span every tenant and need the admin scope
+		admin := authenticated.Group("", middleware.RequireScope(authDomain.ScopeAdmin))
+		s.userController.RegisterRoutes(admin)
+		s.sessionController.RegisterAdminRoutes(admin)
+		s.tenantController.RegisterRoutes(admin)
+		admin.GET("/circuit-breakers", s.circuitBreakers)
+
+		// API key, audit log and WhatsApp routes - scoped to the tenant of the caller
+		scoped := authenticated.Group("", middleware.Tenant(s.tenantService))
+		s.apiKeyController.RegisterRoutes(scoped)
+		s.auditController.RegisterRoutes(scoped)
+		s.whatsappController.RegisterRoutes(scoped)
 	}
 }
 
-// healthCheck handles health check requests
+// streamRoutes are the event stream routes, whose browser clients cannot send
+// headers and authenticate with query parameters instead
+var streamRoutes = []string{
+	"/api/v1/whatsapp/events/stream",
+	"/api/v1/whatsapp/events/ws",
+}
+
+// authenticate returns the middleware identifying the caller of the API routes
+func (s *Server) authenticate() gin.HandlerFunc {
+	if !s.config.Auth.Enabled {
+		return middleware.Anonymous()
+	}
+	return middleware.Authenticate(s.apiKeyService, s.sessionService, streamRoutes...)
+}
+
+// healthCheck handles health check requests. Open circuit breakers mark the
+// server as degraded without failing the check, since it can still serve requests.
+// The check is public, so it reports only the breaker states: their names carry
+// instance IDs and their errors provider responses.
 func (s *Server) healthCheck(c *gin.Context) {
+	status := "ok"
+	breakers := s.breakers.Snapshots()
+	states := make([]circuitbreaker.State, len(breakers))
+	for i, breaker := range breakers {
+		states[i] = breaker.State
+		if breaker.State != circuitbreaker.StateClosed {
+			status = "degraded"
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"message": "Server is running",
+		"status":           status,
+		"message":          "Server is running",
+		"circuit_breakers": states,
 	})
 }
 
+// circuitBreakers lists every circuit breaker with its name and last error
+func (s *Server) circuitBreakers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"circuit_breakers": s.breakers.Snapshots(),
+	})
+}
+
+// liveness reports that the process is up and serving requests. It does not
+// touch any dependency, so a database outage never restarts the container.
+func (s *Server) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":         health.StatusUp,
+		"uptime_seconds": int64(time.Since(s.startedAt).Seconds()),
+	})
+}
+
+// readiness runs the configured checks and returns 503 when a critical one fails
+func (s *Server) readiness(c *gin.Context) {
+	report := s.checker.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
 // welcome handles welcome requests
 func (s *Server) welcome(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
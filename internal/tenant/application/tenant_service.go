@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// slugPattern restricts slugs to lowercase letters, digits and inner hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// TenantService manages the tenants that own the WhatsApp resources
+type TenantService struct {
+	repo   domain.TenantRepository
+	logger zerolog.Logger
+}
+
+// NewTenantService creates a new TenantService
+func NewTenantService(repo domain.TenantRepository, logger zerolog.Logger) *TenantService {
+	return &TenantService{
+		repo:   repo,
+		logger: logger.With().Str("service", "tenant").Logger(),
+	}
+}
+
+// CreateTenant creates a tenant with a unique slug
+func (s *TenantService) CreateTenant(ctx context.Context, request domain.CreateTenantRequest) (*domain.Tenant, error) {
+	name := strings.TrimSpace(request.Name)
+	slug := strings.ToLower(strings.TrimSpace(request.Slug))
+	if name == "" {
+		return nil, apperrors.NewValidationError("name is required")
+	}
+	if !slugPattern.MatchString(slug) {
+		return nil, apperrors.NewValidationError("slug must have up to 63 lowercase letters, digits or inner hyphens")
+	}
+	if _, err := uuid.Parse(slug); err == nil {
+		return nil, apperrors.NewValidationError("slug must not be a UUID")
+	}
+
+	now := time.Now()
+	tenant := &domain.Tenant{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      slug,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	created, err := s.repo.Save(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, apperrors.NewConflictError("tenant slug is already taken")
+	}
+
+	s.logger.Info().Str("tenant_id", tenant.ID.String()).Str("slug", slug).Msg("Tenant created")
+	return tenant, nil
+}
+
+// GetTenant retrieves a tenant by ID or slug
+func (s *TenantService) GetTenant(ctx context.Context, ref string) (*domain.Tenant, error) {
+	var tenant *domain.Tenant
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		tenant, err = s.repo.GetByID(ctx, id)
+	} else {
+		tenant, err = s.repo.GetBySlug(ctx, strings.ToLower(ref))
+	}
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("tenant")
+	}
+	return tenant, nil
+}
+
+// ListTenants lists all tenants
+func (s *TenantService) ListTenants(ctx context.Context) ([]*domain.Tenant, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// ResolveTenant returns the ID of the tenant referenced by ID or slug. An
+// empty reference selects the default tenant
+func (s *TenantService) ResolveTenant(ctx context.Context, ref string) (uuid.UUID, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return domain.DefaultTenantID, nil
+	}
+
+	tenant, err := s.GetTenant(ctx, ref)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return tenant.ID, nil
+}
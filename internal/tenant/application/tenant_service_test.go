@@ -0,0 +1,114 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/tenant/application"
+	"github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// MockTenantRepository is a mock implementation of TenantRepository
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) Save(ctx context.Context, tenant *domain.Tenant) (bool, error) {
+	args := m.Called(ctx, tenant)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tenant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) GetBySlug(ctx context.Context, slug string) (*domain.Tenant, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) GetAll(ctx context.Context) ([]*domain.Tenant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Tenant), args.Error(1)
+}
+
+func TestTenantService_CreateTenant(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful tenant creation", func(t *testing.T) {
+		mockRepo := new(MockTenantRepository)
+		service := application.NewTenantService(mockRepo, zerolog.Nop())
+		mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(tenant *domain.Tenant) bool {
+			return tenant.Name == "Acme" && tenant.Slug == "acme-br"
+		})).Return(true, nil)
+
+		tenant, err := service.CreateTenant(ctx, domain.CreateTenantRequest{Name: " Acme ", Slug: "Acme-BR"})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, tenant.ID)
+		assert.Equal(t, "acme-br", tenant.Slug)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid slugs", func(t *testing.T) {
+		service := application.NewTenantService(new(MockTenantRepository), zerolog.Nop())
+
+		for _, slug := range []string{"", "-acme", "acme_br", uuid.NewString()} {
+			_, err := service.CreateTenant(ctx, domain.CreateTenantRequest{Name: "Acme", Slug: slug})
+			assert.True(t, errors.Is(err, apperrors.ErrBadRequest), slug)
+		}
+	})
+
+	t.Run("slug already taken", func(t *testing.T) {
+		mockRepo := new(MockTenantRepository)
+		service := application.NewTenantService(mockRepo, zerolog.Nop())
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(false, nil)
+
+		_, err := service.CreateTenant(ctx, domain.CreateTenantRequest{Name: "Acme", Slug: "acme"})
+
+		assert.True(t, errors.Is(err, apperrors.ErrConflict))
+	})
+}
+
+func TestTenantService_ResolveTenant(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTenantRepository)
+	service := application.NewTenantService(mockRepo, zerolog.Nop())
+	acme := &domain.Tenant{ID: uuid.New(), Name: "Acme", Slug: "acme"}
+	mockRepo.On("GetBySlug", mock.Anything, "acme").Return(acme, nil)
+	mockRepo.On("GetByID", mock.Anything, acme.ID).Return(acme, nil)
+	mockRepo.On("GetBySlug", mock.Anything, "unknown").Return(nil, errors.New("tenant not found"))
+
+	id, err := service.ResolveTenant(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultTenantID, id)
+
+	id, err = service.ResolveTenant(ctx, "ACME")
+	require.NoError(t, err)
+	assert.Equal(t, acme.ID, id)
+
+	id, err = service.ResolveTenant(ctx, acme.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, acme.ID, id)
+
+	_, err = service.ResolveTenant(ctx, "unknown")
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+}
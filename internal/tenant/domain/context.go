@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx scoped to the tenant. Repositories restrict
+// their queries to the tenant of the context
+func NewContext(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext returns the tenant of the context. Contexts without a tenant
+// belong to the system itself, such as background workers and provider
+// callbacks, and are not restricted to any tenant
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenantID, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return tenantID, ok
+}
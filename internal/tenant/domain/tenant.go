@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID identifies the tenant that owns the data created before
+// tenants existed and the requests that do not select a tenant
+var DefaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// DefaultTenantSlug is the slug of the default tenant
+const DefaultTenantSlug = "default"
+
+// Tenant represents an organization that owns instances, messages and webhooks
+type Tenant struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"` // stable identifier accepted in place of the ID
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateTenantRequest represents a request to create a tenant
+type CreateTenantRequest struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug" binding:"required"`
+}
+
+// TenantRepository defines the interface for tenant persistence
+type TenantRepository interface {
+	// Save stores the tenant. It returns false when the slug is already taken
+	Save(ctx context.Context, tenant *Tenant) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*Tenant, error)
+	GetAll(ctx context.Context) ([]*Tenant, error)
+}
@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// GormTenant is the GORM model of domain.Tenant
+type GormTenant struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	Slug      string    `gorm:"type:varchar(63);not null;uniqueIndex"`
+	CreatedAt int64     `gorm:"autoCreateTime"`
+	UpdatedAt int64     `gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name
+func (GormTenant) TableName() string {
+	return "tenants"
+}
+
+// toDomain converts GormTenant to domain.Tenant
+func (g *GormTenant) toDomain() *domain.Tenant {
+	return &domain.Tenant{
+		ID:        g.ID,
+		Name:      g.Name,
+		Slug:      g.Slug,
+		CreatedAt: time.Unix(g.CreatedAt, 0),
+		UpdatedAt: time.Unix(g.UpdatedAt, 0),
+	}
+}
+
+// GormTenantRepository implements TenantRepository using GORM
+type GormTenantRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTenantRepository creates a new GormTenantRepository
+func NewGormTenantRepository(db *gorm.DB) *GormTenantRepository {
+	return &GormTenantRepository{db: db}
+}
+
+// Save stores the tenant, ignoring it when the slug is already taken
+func (r *GormTenantRepository) Save(ctx context.Context, tenant *domain.Tenant) (bool, error) {
+	gormTenant := GormTenant{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		Slug:      tenant.Slug,
+		CreatedAt: tenant.CreatedAt.Unix(),
+		UpdatedAt: tenant.UpdatedAt.Unix(),
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&gormTenant)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save tenant: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID retrieves a tenant by ID
+func (r *GormTenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tenant, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+// GetBySlug retrieves a tenant by slug
+func (r *GormTenantRepository) GetBySlug(ctx context.Context, slug string) (*domain.Tenant, error) {
+	return r.first(ctx, "slug = ?", slug)
+}
+
+// GetAll retrieves all tenants ordered by name
+func (r *GormTenantRepository) GetAll(ctx context.Context) ([]*domain.Tenant, error) {
+	var gormTenants []GormTenant
+
+	if err := r.db.WithContext(ctx).Order("name").Find(&gormTenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tenants: %w", err)
+	}
+
+	tenants := make([]*domain.Tenant, len(gormTenants))
+	for i := range gormTenants {
+		tenants[i] = gormTenants[i].toDomain()
+	}
+
+	return tenants, nil
+}
+
+// first retrieves the tenant matching the condition
+func (r *GormTenantRepository) first(ctx context.Context, condition string, value interface{}) (*domain.Tenant, error) {
+	var gormTenant GormTenant
+
+	if err := r.db.WithContext(ctx).Where(condition, value).First(&gormTenant).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("tenant not found")
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	return gormTenant.toDomain(), nil
+}
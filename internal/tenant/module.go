@@ -0,0 +1,22 @@
+package tenant
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/your-org/boilerplate-go/internal/tenant/application"
+	"github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/tenant/infrastructure"
+	"github.com/your-org/boilerplate-go/internal/tenant/presentation"
+)
+
+// Module provides the tenant repository, service and controller
+var Module = fx.Module("tenant",
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormTenantRepository,
+			fx.As(new(domain.TenantRepository)),
+		),
+	),
+	fx.Provide(application.NewTenantService),
+	fx.Provide(presentation.NewTenantController),
+)
@@ -0,0 +1,76 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/tenant/application"
+	"github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// TenantController handles HTTP requests for tenants
+type TenantController struct {
+	service *application.TenantService
+	logger  zerolog.Logger
+}
+
+// NewTenantController creates a new TenantController
+func NewTenantController(service *application.TenantService, logger zerolog.Logger) *TenantController {
+	return &TenantController{
+		service: service,
+		logger:  logger.With().Str("controller", "tenant").Logger(),
+	}
+}
+
+// CreateTenant handles POST /tenants
+func (c *TenantController) CreateTenant(ctx *gin.Context) {
+	var request domain.CreateTenantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	tenant, err := c.service.CreateTenant(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create tenant")
+		response.FromError(ctx, err, "Failed to create tenant")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: tenant})
+}
+
+// ListTenants handles GET /tenants
+func (c *TenantController) ListTenants(ctx *gin.Context) {
+	tenants, err := c.service.ListTenants(ctx.Request.Context())
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list tenants")
+		return
+	}
+
+	response.Success(ctx, gin.H{"tenants": tenants})
+}
+
+// GetTenant handles GET /tenants/:id, accepting the ID or the slug
+func (c *TenantController) GetTenant(ctx *gin.Context) {
+	tenant, err := c.service.GetTenant(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.FromError(ctx, err, "Tenant not found")
+		return
+	}
+
+	response.Success(ctx, tenant)
+}
+
+// RegisterRoutes registers the tenant routes
+func (c *TenantController) RegisterRoutes(router *gin.RouterGroup) {
+	tenants := router.Group("/tenants")
+	{
+		tenants.POST("", c.CreateTenant)
+		tenants.GET("", c.ListTenants)
+		tenants.GET("/:id", c.GetTenant)
+	}
+}
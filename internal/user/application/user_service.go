@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
 	"github.com/your-org/boilerplate-go/internal/logger"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
 	"github.com/your-org/boilerplate-go/internal/user/domain"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -15,19 +19,23 @@ import (
 // UserService handles user business logic
 type UserService struct {
 	userRepo domain.UserRepository
+	audit    auditDomain.Recorder
 	logger   *logger.Logger
 }
 
 // NewUserService creates a new UserService
-func NewUserService(userRepo domain.UserRepository, logger *logger.Logger) *UserService {
+func NewUserService(userRepo domain.UserRepository, audit auditDomain.Recorder, logger *logger.Logger) *UserService {
 	return &UserService{
 		userRepo: userRepo,
+		audit:    audit,
 		logger:   logger,
 	}
 }
 
-// CreateUser creates a new user
-func (s *UserService) CreateUser(ctx context.Context, name, email string) (*domain.User, error) {
+// CreateUser creates a new user. Without a password the user cannot log in;
+// without a role the user is a viewer; without a tenant the user belongs to
+// the default tenant
+func (s *UserService) CreateUser(ctx context.Context, name, email, password, role string, tenantID uuid.UUID) (*domain.User, error) {
 	ctx, span := otel.Tracer("user-service").Start(ctx, "UserService.CreateUser")
 	defer span.End()
 
@@ -70,9 +78,27 @@ func (s *UserService) CreateUser(ctx context.Context, name, email string) (*doma
 		return nil, fmt.Errorf("user with email %s already exists", email)
 	}
 
+	if role == "" {
+		role = domain.RoleViewer
+	}
+	if !domain.IsValidRole(role) {
+		return nil, fmt.Errorf("invalid role %s", role)
+	}
+
+	if tenantID == uuid.Nil {
+		tenantID = tenantDomain.DefaultTenantID
+	}
+
 	user := &domain.User{
-		Name:  name,
-		Email: email,
+		Name:     name,
+		Email:    email,
+		Role:     role,
+		TenantID: tenantID,
+	}
+	if password != "" {
+		if err := user.SetPassword(password); err != nil {
+			return nil, err
+		}
 	}
 
 	createdUser, err := s.userRepo.Create(ctx, user)
@@ -84,6 +110,18 @@ func (s *UserService) CreateUser(ctx context.Context, name, email string) (*doma
 		return nil, err
 	}
 
+	changes := auditDomain.Diff(nil, userAuditState(createdUser))
+	if password != "" {
+		changes["password"] = auditDomain.Change{To: auditDomain.Redacted}
+	}
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionUserCreate,
+		ResourceType: auditDomain.ResourceUser,
+		ResourceID:   strconv.FormatUint(uint64(createdUser.ID), 10),
+		Summary:      createdUser.Email,
+		Changes:      changes,
+	})
+
 	duration := time.Since(start)
 	s.logger.LogInfo(ctx, "User created successfully", map[string]interface{}{
 		"user_id":  createdUser.ID,
@@ -160,8 +198,8 @@ func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*domain
 	return user, nil
 }
 
-// UpdateUser updates an existing user
-func (s *UserService) UpdateUser(ctx context.Context, id uint, name, email string) (*domain.User, error) {
+// UpdateUser updates an existing user. Empty values are left unchanged
+func (s *UserService) UpdateUser(ctx context.Context, id uint, name, email, password, role string, tenantID uuid.UUID) (*domain.User, error) {
 	ctx, span := otel.Tracer("user-service").Start(ctx, "UserService.UpdateUser")
 	defer span.End()
 
@@ -187,6 +225,7 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, name, email strin
 
 	oldName := user.Name
 	oldEmail := user.Email
+	before := userAuditState(user)
 
 	if name != "" {
 		user.Name = name
@@ -194,6 +233,20 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, name, email strin
 	if email != "" {
 		user.Email = email
 	}
+	if role != "" {
+		if !domain.IsValidRole(role) {
+			return nil, fmt.Errorf("invalid role %s", role)
+		}
+		user.Role = role
+	}
+	if tenantID != uuid.Nil {
+		user.TenantID = tenantID
+	}
+	if password != "" {
+		if err := user.SetPassword(password); err != nil {
+			return nil, err
+		}
+	}
 
 	err = s.userRepo.Update(ctx, user)
 	if err != nil {
@@ -207,6 +260,18 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, name, email strin
 		return nil, err
 	}
 
+	changes := auditDomain.Diff(before, userAuditState(user))
+	if password != "" {
+		changes["password"] = auditDomain.Change{To: auditDomain.Redacted}
+	}
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionUserUpdate,
+		ResourceType: auditDomain.ResourceUser,
+		ResourceID:   strconv.FormatUint(uint64(user.ID), 10),
+		Summary:      user.Email,
+		Changes:      changes,
+	})
+
 	s.logger.LogInfo(ctx, "User updated successfully", map[string]interface{}{
 		"user_id":   user.ID,
 		"old_name":  oldName,
@@ -244,6 +309,12 @@ func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
 		return err
 	}
 
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionUserDelete,
+		ResourceType: auditDomain.ResourceUser,
+		ResourceID:   strconv.FormatUint(uint64(id), 10),
+	})
+
 	s.logger.LogInfo(ctx, "User deleted successfully", map[string]interface{}{
 		"user_id": id,
 	})
@@ -290,3 +361,13 @@ func (s *UserService) ListUsers(ctx context.Context, limit, offset int) ([]*doma
 
 	return users, nil
 }
+
+// userAuditState returns the audited fields of a user
+func userAuditState(user *domain.User) map[string]any {
+	return map[string]any{
+		"name":      user.Name,
+		"email":     user.Email,
+		"role":      user.Role,
+		"tenant_id": user.TenantID.String(),
+	}
+}
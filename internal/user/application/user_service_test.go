@@ -5,10 +5,13 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
 	"github.com/your-org/boilerplate-go/internal/config"
 	"github.com/your-org/boilerplate-go/internal/logger"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
 	"github.com/your-org/boilerplate-go/internal/user/application"
 	"github.com/your-org/boilerplate-go/internal/user/domain"
 )
@@ -65,6 +68,22 @@ func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+// MockAuditRecorder is a mock implementation of the audit Recorder
+type MockAuditRecorder struct {
+	mock.Mock
+}
+
+func (m *MockAuditRecorder) Record(ctx context.Context, record auditDomain.Record) {
+	m.Called(ctx, record)
+}
+
+// createTestRecorder creates a recorder accepting any audit record
+func createTestRecorder() *MockAuditRecorder {
+	recorder := new(MockAuditRecorder)
+	recorder.On("Record", mock.Anything, mock.Anything).Return()
+	return recorder
+}
+
 // createTestLogger creates a logger instance for testing
 func createTestLogger() *logger.Logger {
 	cfg := config.LoggerConfig{
@@ -79,7 +98,7 @@ func createTestLogger() *logger.Logger {
 func TestUserService_CreateUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	testLogger := createTestLogger()
-	service := application.NewUserService(mockRepo, testLogger)
+	service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 	ctx := context.Background()
 
 	t.Run("successful user creation", func(t *testing.T) {
@@ -91,18 +110,48 @@ func TestUserService_CreateUser(t *testing.T) {
 
 		mockRepo.On("GetByEmail", mock.Anything, "john@example.com").Return(nil, errors.New("user not found"))
 		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
-			return u.Name == "John Doe" && u.Email == "john@example.com"
+			return u.Name == "John Doe" && u.Email == "john@example.com" && u.TenantID == tenantDomain.DefaultTenantID
 		})).Return(user, nil)
 
-		result, err := service.CreateUser(ctx, "John Doe", "john@example.com")
+		result, err := service.CreateUser(ctx, "John Doe", "john@example.com", "", "", uuid.Nil)
 
 		assert.NoError(t, err)
 		assert.Equal(t, user, result)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("user creation with password and role", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := application.NewUserService(mockRepo, createTestRecorder(), createTestLogger())
+
+		tenantID := uuid.New()
+		mockRepo.On("GetByEmail", mock.Anything, "jane@example.com").Return(nil, errors.New("user not found"))
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Role == domain.RoleOperator && u.CheckPassword("s3cret-pass") && u.TenantID == tenantID
+		})).Return(&domain.User{ID: 2, Role: domain.RoleOperator}, nil)
+
+		result, err := service.CreateUser(ctx, "Jane Doe", "jane@example.com", "s3cret-pass", domain.RoleOperator, tenantID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleOperator, result.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("user creation with invalid role or short password", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := application.NewUserService(mockRepo, createTestRecorder(), createTestLogger())
+		mockRepo.On("GetByEmail", mock.Anything, "jane@example.com").Return(nil, errors.New("user not found"))
+
+		_, err := service.CreateUser(ctx, "Jane Doe", "jane@example.com", "", "owner", uuid.Nil)
+		assert.EqualError(t, err, "invalid role owner")
+
+		_, err = service.CreateUser(ctx, "Jane Doe", "jane@example.com", "short", "", uuid.Nil)
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
 	t.Run("user creation with empty name", func(t *testing.T) {
-		result, err := service.CreateUser(ctx, "", "john@example.com")
+		result, err := service.CreateUser(ctx, "", "john@example.com", "", "", uuid.Nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -110,7 +159,7 @@ func TestUserService_CreateUser(t *testing.T) {
 	})
 
 	t.Run("user creation with empty email", func(t *testing.T) {
-		result, err := service.CreateUser(ctx, "John Doe", "")
+		result, err := service.CreateUser(ctx, "John Doe", "", "", "", uuid.Nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -127,11 +176,11 @@ func TestUserService_CreateUser(t *testing.T) {
 		// Reset mock for this test
 		mockRepo := new(MockUserRepository)
 		testLogger := createTestLogger()
-		service := application.NewUserService(mockRepo, testLogger)
+		service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 
 		mockRepo.On("GetByEmail", mock.Anything, "john@example.com").Return(existingUser, nil)
 
-		result, err := service.CreateUser(ctx, "John Doe", "john@example.com")
+		result, err := service.CreateUser(ctx, "John Doe", "john@example.com", "", "", uuid.Nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -143,7 +192,7 @@ func TestUserService_CreateUser(t *testing.T) {
 func TestUserService_GetUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	testLogger := createTestLogger()
-	service := application.NewUserService(mockRepo, testLogger)
+	service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 	ctx := context.Background()
 
 	t.Run("successful user retrieval", func(t *testing.T) {
@@ -174,7 +223,7 @@ func TestUserService_GetUser(t *testing.T) {
 func TestUserService_GetUserByEmail(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	testLogger := createTestLogger()
-	service := application.NewUserService(mockRepo, testLogger)
+	service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 	ctx := context.Background()
 
 	t.Run("successful user retrieval by email", func(t *testing.T) {
@@ -205,7 +254,7 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 func TestUserService_UpdateUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	testLogger := createTestLogger()
-	service := application.NewUserService(mockRepo, testLogger)
+	service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 	ctx := context.Background()
 
 	t.Run("successful user update", func(t *testing.T) {
@@ -220,19 +269,40 @@ func TestUserService_UpdateUser(t *testing.T) {
 			return u.ID == 1 && u.Name == "John Smith" && u.Email == "johnsmith@example.com"
 		})).Return(nil)
 
-		result, err := service.UpdateUser(ctx, 1, "John Smith", "johnsmith@example.com")
+		result, err := service.UpdateUser(ctx, 1, "John Smith", "johnsmith@example.com", "", "", uuid.Nil)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "John Smith", result.Name)
 		assert.Equal(t, "johnsmith@example.com", result.Email)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("user update is audited with a diff", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		recorder := new(MockAuditRecorder)
+		service := application.NewUserService(mockRepo, recorder, createTestLogger())
+
+		user := &domain.User{ID: 3, Name: "Jane", Email: "jane@example.com", Role: domain.RoleViewer, TenantID: tenantDomain.DefaultTenantID}
+		mockRepo.On("GetByID", mock.Anything, uint(3)).Return(user, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		recorder.On("Record", mock.Anything, mock.MatchedBy(func(r auditDomain.Record) bool {
+			_, renamed := r.Changes["name"]
+			return r.Action == auditDomain.ActionUserUpdate && r.ResourceID == "3" && !renamed &&
+				r.Changes["role"] == auditDomain.Change{From: domain.RoleViewer, To: domain.RoleOperator} &&
+				r.Changes["password"] == auditDomain.Change{To: auditDomain.Redacted}
+		})).Return()
+
+		_, err := service.UpdateUser(ctx, 3, "", "", "n3w-password", domain.RoleOperator, uuid.Nil)
+
+		assert.NoError(t, err)
+		recorder.AssertExpectations(t)
+	})
 }
 
 func TestUserService_DeleteUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	testLogger := createTestLogger()
-	service := application.NewUserService(mockRepo, testLogger)
+	service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 	ctx := context.Background()
 
 	t.Run("successful user deletion", func(t *testing.T) {
@@ -256,7 +326,7 @@ func TestUserService_ListUsers(t *testing.T) {
 	t.Run("successful user listing", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
 		testLogger := createTestLogger()
-		service := application.NewUserService(mockRepo, testLogger)
+		service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 		ctx := context.Background()
 
 		users := []*domain.User{
@@ -277,7 +347,7 @@ func TestUserService_ListUsers(t *testing.T) {
 	t.Run("user listing with default pagination", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
 		testLogger := createTestLogger()
-		service := application.NewUserService(mockRepo, testLogger)
+		service := application.NewUserService(mockRepo, createTestRecorder(), testLogger)
 		ctx := context.Background()
 
 		users := []*domain.User{
@@ -1,20 +1,74 @@
 package domain
 
 import (
+	"errors"
+	"sync"
 	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User roles, from the most to the least privileged
+const (
+	RoleAdmin    = "admin"    // manages users, tenants and every WhatsApp resource
+	RoleOperator = "operator" // operates instances, messages, opt-outs and webhooks
+	RoleViewer   = "viewer"   // reads instances, messages and events
 )
 
+// MinPasswordLength is the minimum length of a user password
+const MinPasswordLength = 8
+
+// IsValidRole reports whether the role exists
+func IsValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleOperator || role == RoleViewer
+}
+
 // User represents a user entity
 type User struct {
-	ID        uint       `json:"id" gorm:"primaryKey"`
-	Name      string     `json:"name" gorm:"size:255;not null"`
-	Email     string     `json:"email" gorm:"size:255;uniqueIndex;not null"`
-	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Name         string     `json:"name" gorm:"size:255;not null"`
+	Email        string     `json:"email" gorm:"size:255;uniqueIndex;not null"`
+	PasswordHash string     `json:"-" gorm:"size:255;not null;default:''"`
+	Role         string     `json:"role" gorm:"size:20;not null;default:viewer"`
+	TenantID     uuid.UUID  `json:"tenant_id" gorm:"type:uuid;not null;index"` // only admins can select other tenants
+	CreatedAt    *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 // TableName returns the table name for the User entity
 func (User) TableName() string {
 	return "users"
 }
+
+// SetPassword stores the bcrypt hash of the password
+func (u *User) SetPassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return errors.New("password must have at least 8 characters")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// dummyPasswordHash is compared when there is no password hash to check, so
+// that a failed login takes as long for unknown emails as for wrong passwords
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// CheckPassword reports whether the password matches. Users without a
+// password cannot log in
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
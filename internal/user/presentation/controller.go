@@ -31,7 +31,7 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.userService.CreateUser(ctx.Request.Context(), req.Name, req.Email)
+	user, err := c.userService.CreateUser(ctx.Request.Context(), req.Name, req.Email, req.Password, req.Role, req.TenantID)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Failed to create user")
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -39,9 +39,11 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 	}
 
 	response := UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Role:     user.Role,
+		TenantID: user.TenantID,
 	}
 
 	ctx.JSON(http.StatusCreated, response)
@@ -68,9 +70,11 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 	}
 
 	response := UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Role:     user.Role,
+		TenantID: user.TenantID,
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -91,7 +95,7 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.userService.UpdateUser(ctx.Request.Context(), uint(id), req.Name, req.Email)
+	user, err := c.userService.UpdateUser(ctx.Request.Context(), uint(id), req.Name, req.Email, req.Password, req.Role, req.TenantID)
 	if err != nil {
 		if err.Error() == "user not found" {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
@@ -103,9 +107,11 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 	}
 
 	response := UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Role:     user.Role,
+		TenantID: user.TenantID,
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -159,9 +165,11 @@ func (c *UserController) ListUsers(ctx *gin.Context) {
 	userResponses := make([]UserResponse, len(users))
 	for i, user := range users {
 		userResponses[i] = UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
+			ID:       user.ID,
+			Name:     user.Name,
+			Email:    user.Email,
+			Role:     user.Role,
+			TenantID: user.TenantID,
 		}
 	}
 
@@ -1,22 +1,32 @@
 package presentation
 
+import "github.com/google/uuid"
+
 // CreateUserRequest represents the request to create a user
 type CreateUserRequest struct {
-	Name  string `json:"name" binding:"required"`
-	Email string `json:"email" binding:"required,email"`
+	Name     string    `json:"name" binding:"required"`
+	Email    string    `json:"email" binding:"required,email"`
+	Password string    `json:"password" binding:"omitempty,min=8"`
+	Role     string    `json:"role" binding:"omitempty,oneof=admin operator viewer"` // default viewer
+	TenantID uuid.UUID `json:"tenant_id"`                                            // default tenant when omitted
 }
 
 // UpdateUserRequest represents the request to update a user
 type UpdateUserRequest struct {
-	Name  string `json:"name"`
-	Email string `json:"email" binding:"email"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email" binding:"email"`
+	Password string    `json:"password" binding:"omitempty,min=8"`
+	Role     string    `json:"role" binding:"omitempty,oneof=admin operator viewer"`
+	TenantID uuid.UUID `json:"tenant_id"`
 }
 
 // UserResponse represents the user response
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID       uint      `json:"id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	TenantID uuid.UUID `json:"tenant_id"`
 }
 
 // ListUsersResponse represents the response for listing users
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const (
+	// defaultAnalyticsRange é o intervalo consultado quando from não é informado
+	defaultAnalyticsRange = 7 * 24 * time.Hour
+	// maxAnalyticsBuckets limita a quantidade de períodos de uma consulta
+	maxAnalyticsBuckets = 1000
+	// topFailureReasonsLimit é a quantidade de motivos de falha retornados
+	topFailureReasonsLimit = 10
+)
+
+// GetMessageAnalytics calcula as estatísticas de envio das mensagens no
+// intervalo, agrupadas por período, instância, provider e tipo
+func (s *WhatsAppService) GetMessageAnalytics(ctx context.Context, query domain.MessageAnalyticsQuery) (*domain.MessageAnalytics, error) {
+	if query.Bucket == "" {
+		query.Bucket = domain.BucketDay
+	}
+	if query.Bucket.Duration() == 0 {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("invalid bucket: %s", query.Bucket))
+	}
+
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultAnalyticsRange)
+	}
+	query.From, query.To = query.From.UTC(), query.To.UTC()
+
+	if !query.From.Before(query.To) {
+		return nil, apperrors.NewValidationError("from must be before to")
+	}
+	if query.To.Sub(query.From)/query.Bucket.Duration() > maxAnalyticsBuckets {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("range too large for %s buckets (max %d)", query.Bucket, maxAnalyticsBuckets))
+	}
+
+	rows, err := s.messageRepo.AggregateStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.messageRepo.SummarizeStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := s.messageRepo.TopFailureReasons(ctx, query, topFailureReasonsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MessageAnalytics{
+		From:        query.From,
+		To:          query.To,
+		Bucket:      query.Bucket,
+		Totals:      totals,
+		Rows:        rows,
+		TopFailures: failures,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+func TestWhatsAppService_GetMessageAnalytics(t *testing.T) {
+	ctx := context.Background()
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, saoPaulo)
+	to := from.Add(48 * time.Hour)
+	// O intervalo é consultado em UTC
+	query := domain.MessageAnalyticsQuery{InstanceID: "instance-1", From: from.UTC(), To: to.UTC(), Bucket: domain.BucketHour}
+
+	rows := []*domain.MessageStatsRow{{Bucket: from.UTC(), InstanceID: "instance-1", Type: domain.TextMessage}}
+	totals := &domain.MessageStats{Sent: 4, Delivered: 2}
+	failures := []*domain.FailureReason{{Reason: "invalid phone", Count: 2}}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("AggregateStats", ctx, query).Return(rows, nil).Once()
+	messageRepo.On("SummarizeStats", ctx, query).Return(totals, nil).Once()
+	messageRepo.On("TopFailureReasons", ctx, query, 10).Return(failures, nil).Once()
+
+	analytics, err := newServiceWithMessages(messageRepo).GetMessageAnalytics(ctx, domain.MessageAnalyticsQuery{
+		InstanceID: "instance-1",
+		From:       from,
+		To:         to,
+		Bucket:     domain.BucketHour,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.UTC, analytics.From.Location())
+	assert.True(t, analytics.From.Equal(from))
+	assert.Equal(t, domain.BucketHour, analytics.Bucket)
+	assert.Equal(t, rows, analytics.Rows)
+	assert.Equal(t, totals, analytics.Totals)
+	assert.Equal(t, failures, analytics.TopFailures)
+
+	messageRepo.AssertExpectations(t)
+}
+
+func TestWhatsAppService_GetMessageAnalyticsDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	messageRepo := new(MockMessageRepository)
+	// Sem intervalo, são consultados os últimos 7 dias agrupados por dia
+	defaults := mock.MatchedBy(func(query domain.MessageAnalyticsQuery) bool {
+		return query.Bucket == domain.BucketDay && query.To.Sub(query.From) == 7*24*time.Hour &&
+			time.Since(query.To) < time.Minute && query.To.Location() == time.UTC
+	})
+	messageRepo.On("AggregateStats", ctx, defaults).Return([]*domain.MessageStatsRow{}, nil).Once()
+	messageRepo.On("SummarizeStats", ctx, defaults).Return(&domain.MessageStats{}, nil).Once()
+	messageRepo.On("TopFailureReasons", ctx, defaults, 10).Return([]*domain.FailureReason{}, nil).Once()
+
+	analytics, err := newServiceWithMessages(messageRepo).GetMessageAnalytics(ctx, domain.MessageAnalyticsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BucketDay, analytics.Bucket)
+
+	messageRepo.AssertExpectations(t)
+}
+
+func TestWhatsAppService_GetMessageAnalyticsValidation(t *testing.T) {
+	to := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query domain.MessageAnalyticsQuery
+	}{
+		{name: "invalid bucket", query: domain.MessageAnalyticsQuery{Bucket: "month"}},
+		{name: "from after to", query: domain.MessageAnalyticsQuery{From: to.Add(time.Hour), To: to}},
+		{name: "empty range", query: domain.MessageAnalyticsQuery{From: to, To: to}},
+		{name: "too many hourly buckets", query: domain.MessageAnalyticsQuery{From: to.Add(-1001 * time.Hour), To: to, Bucket: domain.BucketHour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(MockMessageRepository)
+			_, err := newServiceWithMessages(messageRepo).GetMessageAnalytics(context.Background(), tt.query)
+			assert.ErrorIs(t, err, apperrors.ErrBadRequest)
+			messageRepo.AssertNotCalled(t, "AggregateStats", mock.Anything, mock.Anything)
+		})
+	}
+
+	// O mesmo intervalo cabe no limite quando agrupado por semana
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("AggregateStats", mock.Anything, mock.Anything).Return([]*domain.MessageStatsRow{}, nil)
+	messageRepo.On("SummarizeStats", mock.Anything, mock.Anything).Return(&domain.MessageStats{}, nil)
+	messageRepo.On("TopFailureReasons", mock.Anything, mock.Anything, 10).Return([]*domain.FailureReason{}, nil)
+	_, err := newServiceWithMessages(messageRepo).GetMessageAnalytics(context.Background(), domain.MessageAnalyticsQuery{
+		From:   to.Add(-1001 * time.Hour),
+		To:     to,
+		Bucket: domain.BucketWeek,
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// instanceAuditState retorna os campos auditados de uma instância. Segredos da
+// configuração são mascarados como nas respostas da API e o token nunca é registrado
+func instanceAuditState(instance *domain.Instance) map[string]any {
+	if instance == nil {
+		return map[string]any{}
+	}
+
+	state := map[string]any{
+		"name":        instance.Name,
+		"provider":    instance.Provider,
+		"instance_id": instance.InstanceID,
+	}
+	for key, value := range domain.MaskConfigSecrets(instance.Config) {
+		state["config."+key] = value
+	}
+	return state
+}
+
+// recordSend registra no log de auditoria o envio de uma mensagem, sem o conteúdo
+func (s *WhatsAppService) recordSend(ctx context.Context, message *domain.Message, status domain.MessageStatus) {
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionMessageSend,
+		ResourceType: auditDomain.ResourceMessage,
+		ResourceID:   message.ID.String(),
+		Summary:      fmt.Sprintf("%s message to %s", message.Type, message.Phone),
+		Changes: map[string]auditDomain.Change{
+			"instance_id": {To: message.InstanceID},
+			"phone":       {To: message.Phone},
+			"type":        {To: message.Type},
+			"status":      {To: status},
+		},
+	})
+}
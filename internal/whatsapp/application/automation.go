@@ -0,0 +1,219 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// ruleTagPattern restringe as etiquetas a caracteres seguros para armazenamento e filtros
+var ruleTagPattern = regexp.MustCompile(`^[\p{L}\p{N}_.:-]{1,50}$`)
+
+// AutomationService gerencia as regras de automação das instâncias e o
+// histórico de regras disparadas. A avaliação é feita pelo RuleEngine
+type AutomationService struct {
+	rules        domain.AutomationRuleRepository
+	executions   domain.RuleExecutionRepository
+	instanceRepo domain.InstanceRepository
+	logger       zerolog.Logger
+}
+
+// NewAutomationService cria um novo serviço de automação
+func NewAutomationService(
+	rules domain.AutomationRuleRepository,
+	executions domain.RuleExecutionRepository,
+	instanceRepo domain.InstanceRepository,
+	logger zerolog.Logger,
+) *AutomationService {
+	return &AutomationService{
+		rules:        rules,
+		executions:   executions,
+		instanceRepo: instanceRepo,
+		logger:       logger.With().Str("service", "automation").Logger(),
+	}
+}
+
+// CreateRule cria uma regra para a instância
+func (s *AutomationService) CreateRule(ctx context.Context, instanceID uuid.UUID, request domain.CreateAutomationRuleRequest) (*domain.AutomationRule, error) {
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("instance")
+	}
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	now := time.Now()
+	rule := &domain.AutomationRule{
+		ID:         uuid.New(),
+		TenantID:   instance.TenantID,
+		InstanceID: instanceID,
+		Name:       strings.TrimSpace(request.Name),
+		Priority:   request.Priority,
+		Active:     active,
+		Trigger:    request.Trigger,
+		Action:     request.Action,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := validateAutomationRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.rules.Save(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save automation rule: %w", err)
+	}
+
+	s.logger.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("instance_id", instanceID.String()).
+		Str("trigger", string(rule.Trigger.Type)).
+		Msg("Automation rule created")
+
+	return rule, nil
+}
+
+// GetRule obtém uma regra da instância
+func (s *AutomationService) GetRule(ctx context.Context, instanceID, ruleID uuid.UUID) (*domain.AutomationRule, error) {
+	rule, err := s.rules.GetByID(ctx, ruleID)
+	if err != nil || rule.InstanceID != instanceID {
+		return nil, apperrors.NewNotFoundError("automation rule")
+	}
+	return rule, nil
+}
+
+// ListRules lista as regras da instância na ordem de avaliação
+func (s *AutomationService) ListRules(ctx context.Context, instanceID uuid.UUID) ([]*domain.AutomationRule, error) {
+	if _, err := s.instanceRepo.GetByID(ctx, instanceID); err != nil {
+		return nil, apperrors.NewNotFoundError("instance")
+	}
+	return s.rules.ListByInstance(ctx, instanceID, false)
+}
+
+// UpdateRule aplica um patch na regra
+func (s *AutomationService) UpdateRule(ctx context.Context, instanceID, ruleID uuid.UUID, request domain.UpdateAutomationRuleRequest) (*domain.AutomationRule, error) {
+	rule, err := s.GetRule(ctx, instanceID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		rule.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Priority != nil {
+		rule.Priority = *request.Priority
+	}
+	if request.Active != nil {
+		rule.Active = *request.Active
+	}
+	if request.Trigger != nil {
+		rule.Trigger = *request.Trigger
+	}
+	if request.Action != nil {
+		rule.Action = *request.Action
+	}
+	if err := validateAutomationRule(rule); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.rules.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update automation rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule remove a regra. O histórico de execuções é mantido
+func (s *AutomationService) DeleteRule(ctx context.Context, instanceID, ruleID uuid.UUID) error {
+	if _, err := s.GetRule(ctx, instanceID, ruleID); err != nil {
+		return err
+	}
+	if err := s.rules.Delete(ctx, ruleID); err != nil {
+		return apperrors.NewNotFoundError("automation rule")
+	}
+
+	s.logger.Info().Str("rule_id", ruleID.String()).Msg("Automation rule deleted")
+	return nil
+}
+
+// ListExecutions lista as regras disparadas na instância, da mais recente para
+// a mais antiga. Retorna também o cursor da próxima página
+func (s *AutomationService) ListExecutions(ctx context.Context, instanceID uuid.UUID, ruleID *uuid.UUID, limit int, cursor string) ([]*domain.RuleExecution, string, error) {
+	if _, err := s.instanceRepo.GetByID(ctx, instanceID); err != nil {
+		return nil, "", apperrors.NewNotFoundError("instance")
+	}
+
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	executions, err := s.executions.ListByInstance(ctx, instanceID, ruleID, limit+1, before)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(executions) > limit {
+		executions = executions[:limit]
+		last := executions[limit-1]
+		next = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return executions, next, nil
+}
+
+// validateAutomationRule verifica a condição e as ações da regra
+func validateAutomationRule(rule *domain.AutomationRule) error {
+	if rule.Name == "" {
+		return apperrors.NewValidationError("name is required")
+	}
+	if err := rule.Trigger.Validate(); err != nil {
+		return apperrors.NewValidationError(err.Error())
+	}
+
+	action := rule.Action
+	if action.Reply == nil && action.Tag == "" && !action.Handoff {
+		return apperrors.NewValidationError("at least one action (reply, tag or handoff) is required")
+	}
+	if action.Tag != "" && !ruleTagPattern.MatchString(action.Tag) {
+		return apperrors.NewValidationError("tag must have up to 50 letters, digits or _.:- characters")
+	}
+
+	if reply := action.Reply; reply != nil {
+		switch reply.Type {
+		case domain.ReplyText, domain.ReplyTemplate:
+			if strings.TrimSpace(reply.Content) == "" {
+				return apperrors.NewValidationError("reply content is required")
+			}
+			if _, err := reply.Render(domain.RuleTemplateData{}); err != nil {
+				return apperrors.NewValidationError(err.Error())
+			}
+		case domain.ReplyMedia:
+			switch reply.MediaType {
+			case domain.ImageMessage, domain.VideoMessage, domain.AudioMessage, domain.DocumentMessage:
+			default:
+				return apperrors.NewValidationError("media reply requires media_type image, video, audio or document")
+			}
+			if reply.MediaURL == nil || *reply.MediaURL == "" {
+				return apperrors.NewValidationError("media reply requires media_url")
+			}
+		default:
+			return apperrors.NewValidationError(fmt.Sprintf("unknown reply type: %s", reply.Type))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// ListConversations lista as conversas da instância por última atividade.
+// Retorna também o cursor da próxima página, vazio quando não há mais itens
+func (s *WhatsAppService) ListConversations(ctx context.Context, instanceID uuid.UUID, limit int, cursor string) ([]*domain.Conversation, string, error) {
+	if _, err := s.instanceRepo.GetByID(ctx, instanceID); err != nil {
+		return nil, "", apperrors.NewNotFoundError("instance")
+	}
+
+	after, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	conversations, err := s.conversationRepo.ListByInstance(ctx, instanceID.String(), limit+1, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(conversations) > limit {
+		conversations = conversations[:limit]
+		last := conversations[limit-1]
+		next = domain.Cursor{Time: last.LastMessageAt, ID: last.ID}.Encode()
+	}
+
+	return conversations, next, nil
+}
+
+// GetConversationThread obtém as mensagens enviadas e recebidas com um
+// telefone, da mais recente para a mais antiga
+func (s *WhatsAppService) GetConversationThread(ctx context.Context, instanceID uuid.UUID, phone string, limit int, cursor string) ([]*domain.Message, string, error) {
+	if _, err := s.instanceRepo.GetByID(ctx, instanceID); err != nil {
+		return nil, "", apperrors.NewNotFoundError("instance")
+	}
+
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	messages, err := s.messageRepo.GetThread(ctx, instanceID.String(), domain.NormalizePhone(phone), limit+1, before)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(messages) > limit {
+		messages = messages[:limit]
+		last := messages[limit-1]
+		next = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return messages, next, nil
+}
+
+// MarkConversationRead zera as mensagens não lidas da conversa
+func (s *WhatsAppService) MarkConversationRead(ctx context.Context, instanceID uuid.UUID, phone string) error {
+	if err := s.conversationRepo.MarkRead(ctx, instanceID.String(), domain.NormalizePhone(phone)); err != nil {
+		return apperrors.NewNotFoundError("conversation")
+	}
+	return nil
+}
+
+// SetConversationHandoff transfere a conversa para atendimento humano, o que
+// suspende as regras de automação, ou a devolve à automação
+func (s *WhatsAppService) SetConversationHandoff(ctx context.Context, instanceID uuid.UUID, phone string, handoff bool) (*domain.Conversation, error) {
+	phone = domain.NormalizePhone(phone)
+
+	var at *time.Time
+	if handoff {
+		now := time.Now()
+		at = &now
+	}
+
+	if err := s.conversationRepo.SetHandoff(ctx, instanceID.String(), phone, at); err != nil {
+		return nil, apperrors.NewNotFoundError("conversation")
+	}
+	return s.conversationRepo.GetByPhone(ctx, instanceID.String(), phone)
+}
+
+// ReceiveWebhook processa uma notificação do provider para a instância,
+// gravando as mensagens recebidas. O token do endereço de callback autentica a
+// notificação: o ID da instância sozinho é conhecido pelos clientes da API
+func (s *WhatsAppService) ReceiveWebhook(ctx context.Context, providerName string, instanceID uuid.UUID, token string, payload []byte) error {
+	instance, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil || instance.Provider != providerName {
+		return apperrors.NewNotFoundError("instance")
+	}
+	if !instance.ValidCallbackToken(token) {
+		return apperrors.NewUnauthorizedError("INVALID_CALLBACK_TOKEN", "invalid callback token")
+	}
+
+	provider, err := s.providerResolver.Resolve(instance)
+	if err != nil {
+		return err
+	}
+	if err := requireFeature(provider, domain.FeatureWebhooks); err != nil {
+		return err
+	}
+	parser, ok := provider.(domain.WebhookParser)
+	if !ok {
+		return apperrors.NewFeatureNotSupportedError(fmt.Sprintf("provider %s does not parse webhooks", providerName))
+	}
+
+	event, err := parser.ParseWebhook(payload)
+	if err != nil {
+		return apperrors.NewValidationError(err.Error())
+	}
+
+	// O ID da instância no provider impede que notificações de outra conta
+	// sejam atribuídas a esta instância
+	if event.ProviderInstanceID != instance.InstanceID {
+		return apperrors.NewValidationError("webhook does not belong to this instance")
+	}
+
+	switch event.Kind {
+	case domain.WebhookMessageReceived:
+		return s.storeInboundMessage(ctx, instance, event.Message)
+	case domain.WebhookMessageStatus:
+		return s.applyStatusUpdate(ctx, instance, event.StatusUpdate)
+	default:
+		return nil
+	}
+}
+
+// storeInboundMessage grava a mensagem recebida, ignorando reentregas do mesmo webhook
+func (s *WhatsAppService) storeInboundMessage(ctx context.Context, instance *domain.Instance, inbound *domain.InboundMessage) error {
+	instanceID := instance.ID.String()
+
+	// Mensagens enviadas pelo próprio número fora da API entram no histórico como enviadas
+	direction, status := domain.DirectionInbound, domain.StatusReceived
+	if inbound.FromMe {
+		direction, status = domain.DirectionOutbound, domain.StatusSent
+	}
+
+	var providerID *string
+	if inbound.ProviderID != "" {
+		providerID = &inbound.ProviderID
+	}
+
+	message := &domain.Message{
+		ID:         uuid.New(),
+		TenantID:   instance.TenantID,
+		InstanceID: instanceID,
+		Provider:   instance.Provider,
+		Phone:      domain.NormalizePhone(inbound.Phone),
+		Direction:  direction,
+		Type:       inbound.Type,
+		Content:    inbound.Content,
+		MediaURL:   inbound.MediaURL,
+		Status:     status,
+		ProviderID: providerID,
+		CreatedAt:  inbound.Timestamp,
+		UpdatedAt:  time.Now(),
+	}
+
+	saved, err := s.messageRepo.SaveInbound(ctx, message)
+	if err != nil {
+		return fmt.Errorf("failed to save inbound message: %w", err)
+	}
+	if !saved {
+		return nil
+	}
+
+	s.logger.Info().
+		Str("message_id", message.ID.String()).
+		Str("instance_id", instanceID).
+		Str("direction", string(direction)).
+		Msg("Inbound message stored")
+
+	// O opt-out é gravado antes do evento para que nenhuma resposta automática chegue ao contato
+	if _, err := s.optOuts.HandleInbound(ctx, instance, message); err != nil {
+		s.logger.Error().Err(err).Str("message_id", message.ID.String()).Msg("Failed to process opt-out keyword")
+	}
+
+	event := domain.NewMessageReceivedEvent(instance.ID, message)
+	event.MediaFileName = inbound.FileName
+	event.MediaMimeType = inbound.MimeType
+	s.publisher.Publish(ctx, event)
+
+	return nil
+}
+
+// applyStatusUpdate registra a confirmação de envio, entrega ou leitura das
+// mensagens. IDs desconhecidos (ex: mensagens enviadas fora da API) são ignorados
+func (s *WhatsAppService) applyStatusUpdate(ctx context.Context, instance *domain.Instance, update *domain.MessageStatusUpdate) error {
+	instanceID := instance.ID.String()
+
+	for _, providerID := range update.ProviderIDs {
+		message, err := s.messageRepo.GetByProviderID(ctx, instanceID, providerID)
+		if err != nil {
+			continue
+		}
+
+		changed, err := s.messageRepo.AdvanceStatus(ctx, message.ID, update.Status, update.Timestamp)
+		if err != nil {
+			return err
+		}
+		if changed {
+			s.logger.Debug().
+				Str("message_id", message.ID.String()).
+				Str("instance_id", instanceID).
+				Str("status", string(update.Status)).
+				Msg("Message status updated")
+
+			s.publisher.Publish(ctx, domain.NewMessageStatusChangedEvent(instance.ID, message, providerID, update.Status, update.Timestamp))
+		}
+	}
+
+	return nil
+}
+
+// NormalizePageLimit aplica o limite padrão e o máximo de itens por página
+func NormalizePageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return limit
+}
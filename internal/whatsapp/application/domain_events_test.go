@@ -0,0 +1,235 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// eventsFixture reúne as dependências do serviço usadas nos testes de eventos
+type eventsFixture struct {
+	instance     *domain.Instance
+	provider     *MockFeatureProvider
+	resolver     *MockProviderResolver
+	instanceRepo *MockInstanceRepository
+	messageRepo  *MockMessageRepository
+	published    []events.Event
+	service      *application.WhatsAppService
+}
+
+// newEventsFixture cria o serviço guardando os eventos publicados, na ordem
+func newEventsFixture() *eventsFixture {
+	f := &eventsFixture{
+		instance:     &domain.Instance{ID: uuid.New(), TenantID: uuid.New(), Name: "vendas", Provider: "z-api", InstanceID: "3C01A2B3"},
+		provider:     new(MockFeatureProvider),
+		resolver:     new(MockProviderResolver),
+		instanceRepo: new(MockInstanceRepository),
+		messageRepo:  new(MockMessageRepository),
+	}
+	f.provider.On("GetName").Return("z-api").Maybe()
+	f.provider.On("GetSupportedFeatures").Return([]domain.ProviderFeature{
+		domain.FeatureTextMessages, domain.FeatureProfileName, domain.FeatureProfilePicture,
+	}).Maybe()
+	f.resolver.On("Resolve", mock.AnythingOfType("*domain.Instance")).Return(f.provider, nil).Maybe()
+	f.instanceRepo.On("GetByID", mock.Anything, f.instance.ID).Return(f.instance, nil).Maybe()
+
+	publisher := new(MockEventPublisher)
+	publisher.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		f.published = append(f.published, args.Get(1).(events.Event))
+	})
+	recorder := new(MockAuditRecorder)
+	recorder.On("Record", mock.Anything, mock.Anything).Maybe()
+
+	f.service = application.NewWhatsAppService(nil, f.resolver, f.messageRepo, nil, f.instanceRepo, nil, nil, nil, publisher, recorder, nil, zerolog.Nop())
+	return f
+}
+
+// sendText envia uma mensagem de texto pela instância do fixture
+func (f *eventsFixture) sendText(ctx context.Context) (*domain.SendMessageResponse, error) {
+	return f.service.SendMessage(ctx, domain.SendMessageRequest{
+		InstanceID:    f.instance.ID.String(),
+		Phone:         "+55 (11) 99999-0000",
+		Type:          domain.TextMessage,
+		Content:       "Pedido confirmado",
+		Transactional: true,
+	})
+}
+
+// assertQueued verifica o evento de mensagem gravada e retorna a mensagem
+func (f *eventsFixture) assertQueued(t *testing.T, event events.Event) *domain.Message {
+	t.Helper()
+	queued, ok := event.(*domain.MessageQueuedEvent)
+	require.True(t, ok, "expected message.queued, got %s", event.GetName())
+	assert.Equal(t, domain.EventMessageQueued, queued.GetName())
+	assert.Equal(t, f.instance.ID, queued.InstanceID)
+	assert.Equal(t, f.instance.TenantID, queued.TenantID)
+	assert.Equal(t, "5511999990000", queued.GetPhone())
+	assert.Equal(t, "Pedido confirmado", queued.Message.Content)
+	assert.Equal(t, domain.DirectionOutbound, queued.Message.Direction)
+	return queued.Message
+}
+
+func TestWhatsAppService_SendMessagePublishesQueuedAndSent(t *testing.T) {
+	ctx := context.Background()
+	f := newEventsFixture()
+	providerID := "3EB0C767D26A"
+
+	f.messageRepo.On("Save", ctx, mock.AnythingOfType("*domain.Message")).Return(nil).Once()
+	f.provider.On("SendMessage", ctx, f.instance, mock.Anything).Return(&domain.SendMessageResponse{Status: domain.StatusSent, ProviderID: &providerID}, nil).Once()
+	f.messageRepo.On("UpdateStatus", ctx, mock.Anything, domain.StatusSent, &providerID, (*string)(nil)).Return(nil).Once()
+
+	response, err := f.sendText(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, f.published, 2)
+	message := f.assertQueued(t, f.published[0])
+	assert.Equal(t, response.ID, message.ID)
+
+	sent, ok := f.published[1].(*domain.MessageSentEvent)
+	require.True(t, ok, "expected message.sent, got %s", f.published[1].GetName())
+	assert.Equal(t, domain.EventMessageSent, sent.GetName())
+	assert.Equal(t, f.instance.ID, sent.InstanceID)
+	assert.Equal(t, f.instance.TenantID, sent.TenantID)
+	assert.Equal(t, message.ID, sent.MessageID)
+	assert.Equal(t, "5511999990000", sent.Phone)
+	assert.Equal(t, domain.StatusSent, sent.Status)
+	assert.Equal(t, &providerID, sent.ProviderID)
+
+	f.provider.AssertExpectations(t)
+	f.messageRepo.AssertExpectations(t)
+}
+
+func TestWhatsAppService_SendMessagePublishesFailed(t *testing.T) {
+	rejected := "invalid phone"
+
+	tests := []struct {
+		name      string
+		response  *domain.SendMessageResponse
+		err       error
+		wantError string
+	}{
+		{name: "provider error", err: errors.New("connection reset"), wantError: "connection reset"},
+		{name: "rejected by provider", response: &domain.SendMessageResponse{Status: domain.StatusFailed, Error: &rejected}, wantError: rejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newEventsFixture()
+
+			f.messageRepo.On("Save", ctx, mock.AnythingOfType("*domain.Message")).Return(nil).Once()
+			f.provider.On("SendMessage", ctx, f.instance, mock.Anything).Return(tt.response, tt.err).Once()
+			f.messageRepo.On("UpdateStatus", ctx, mock.Anything, domain.StatusFailed, (*string)(nil), mock.Anything).Return(nil).Once()
+
+			_, _ = f.sendText(ctx)
+
+			require.Len(t, f.published, 2)
+			message := f.assertQueued(t, f.published[0])
+
+			failed, ok := f.published[1].(*domain.MessageFailedEvent)
+			require.True(t, ok, "expected message.failed, got %s", f.published[1].GetName())
+			assert.Equal(t, domain.EventMessageFailed, failed.GetName())
+			assert.Equal(t, f.instance.ID, failed.InstanceID)
+			assert.Equal(t, f.instance.TenantID, failed.TenantID)
+			assert.Equal(t, message.ID, failed.MessageID)
+			assert.Equal(t, "5511999990000", failed.Phone)
+			assert.Equal(t, tt.wantError, failed.Error)
+
+			f.messageRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWhatsAppService_InstanceLifecyclePublishesEvents(t *testing.T) {
+	f := newEventsFixture()
+	ctx := tenantDomain.NewContext(context.Background(), f.instance.TenantID)
+	request := domain.CreateInstanceRequest{Name: "vendas", Provider: "z-api", InstanceID: "3C01A2B3", Token: "provider-token"}
+
+	f.provider.On("ValidateToken", ctx, "provider-token").Return(nil).Once()
+	f.provider.On("CreateInstance", ctx, request).Return(f.instance, nil).Once()
+	f.provider.On("DeleteInstance", ctx, f.instance).Return(nil).Once()
+	f.instanceRepo.On("Save", ctx, f.instance).Return(nil).Once()
+	f.instanceRepo.On("Delete", ctx, f.instance.ID).Return(nil).Once()
+	f.resolver.On("Invalidate", f.instance.ID).Once()
+	groupRepo := new(MockInstanceGroupRepository)
+	groupRepo.On("RemoveInstance", ctx, f.instance.ID).Return(nil).Once()
+
+	publisher := new(MockEventPublisher)
+	publisher.On("Publish", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		f.published = append(f.published, args.Get(1).(events.Event))
+	})
+	recorder := new(MockAuditRecorder)
+	recorder.On("Record", ctx, mock.Anything)
+	breakers := circuitbreaker.NewRegistry(circuitbreaker.DefaultSettings(), nil)
+	service := application.NewWhatsAppService(nil, f.resolver, nil, nil, f.instanceRepo, groupRepo, nil, nil, publisher, recorder, breakers, zerolog.Nop())
+
+	_, err := service.CreateInstance(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteInstance(ctx, f.instance.ID))
+
+	require.Len(t, f.published, 2)
+	created, ok := f.published[0].(*domain.InstanceCreatedEvent)
+	require.True(t, ok, "expected instance.created, got %s", f.published[0].GetName())
+	assert.Equal(t, domain.EventInstanceCreated, created.GetName())
+	assert.Equal(t, f.instance.ID, created.InstanceID)
+	assert.Equal(t, f.instance.TenantID, created.TenantID)
+	assert.Equal(t, "vendas", created.Name)
+	assert.Equal(t, "z-api", created.Provider)
+
+	deleted, ok := f.published[1].(*domain.InstanceDeletedEvent)
+	require.True(t, ok, "expected instance.deleted, got %s", f.published[1].GetName())
+	assert.Equal(t, domain.EventInstanceDeleted, deleted.GetName())
+	assert.Equal(t, f.instance.ID, deleted.InstanceID)
+	assert.Equal(t, f.instance.TenantID, deleted.TenantID)
+	assert.Equal(t, "vendas", deleted.Name)
+
+	f.provider.AssertExpectations(t)
+	f.instanceRepo.AssertExpectations(t)
+}
+
+func TestWhatsAppService_ProfileUpdatesPublishEvents(t *testing.T) {
+	ctx := context.Background()
+	f := newEventsFixture()
+	nameRequest := domain.UpdateProfileNameRequest{InstanceID: f.instance.ID.String(), Name: "Loja"}
+	pictureRequest := domain.UpdateProfilePictureRequest{InstanceID: f.instance.ID.String(), PictureURL: "https://files.example.com/logo.png"}
+
+	f.provider.On("UpdateProfileName", ctx, f.instance, nameRequest).Return(&domain.UpdateProfileResponse{Success: true}, nil).Once()
+	f.provider.On("UpdateProfilePicture", ctx, f.instance, pictureRequest).Return(&domain.UpdateProfileResponse{Success: true}, nil).Once()
+	// Atualizações recusadas pelo provider não publicam evento
+	f.provider.On("UpdateProfileName", ctx, f.instance, nameRequest).Return(&domain.UpdateProfileResponse{Success: false}, nil).Once()
+
+	_, err := f.service.UpdateProfileName(ctx, nameRequest)
+	require.NoError(t, err)
+	_, err = f.service.UpdateProfilePicture(ctx, pictureRequest)
+	require.NoError(t, err)
+	_, err = f.service.UpdateProfileName(ctx, nameRequest)
+	require.NoError(t, err)
+
+	require.Len(t, f.published, 2)
+	for i, want := range []struct {
+		field domain.ProfileField
+		value string
+	}{{domain.ProfileFieldName, "Loja"}, {domain.ProfileFieldPicture, pictureRequest.PictureURL}} {
+		updated, ok := f.published[i].(*domain.ProfileUpdatedEvent)
+		require.True(t, ok, "expected profile.updated, got %s", f.published[i].GetName())
+		assert.Equal(t, domain.EventProfileUpdated, updated.GetName())
+		assert.Equal(t, f.instance.ID, updated.InstanceID)
+		assert.Equal(t, f.instance.TenantID, updated.TenantID)
+		assert.Equal(t, want.field, updated.Field)
+		assert.Equal(t, want.value, updated.Value)
+	}
+
+	f.provider.AssertExpectations(t)
+}
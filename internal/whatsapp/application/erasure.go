@@ -0,0 +1,285 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const (
+	// receiptChainAttempts limita as tentativas de encadear o recibo quando
+	// exclusões simultâneas do mesmo tenant disputam a mesma sequência
+	receiptChainAttempts = 5
+	// receiptVerifyBatch é a quantidade de recibos lidos por vez na conferência
+	receiptVerifyBatch = 500
+)
+
+// ErasureSettings configura os recibos de exclusão
+type ErasureSettings struct {
+	// HashKey é a chave dos hashes do telefone e da cadeia de recibos. Sem ela
+	// as exclusões ficam indisponíveis; trocá-la invalida os recibos já emitidos
+	HashKey string
+}
+
+// ErasureService atende aos pedidos de exclusão dos dados de um contato:
+// remove as mensagens, conversas, histórico de automação e entregas de webhook
+// do telefone, descarta os arquivos de exportação e de mídia e os eventos em
+// memória que podem contê-lo, anonimiza o log de auditoria e registra um recibo encadeado
+type ErasureService struct {
+	erasures   domain.ErasureRepository
+	receipts   domain.ErasureReceiptRepository
+	exports    *ExportService
+	media      *MediaService
+	stream     *EventStream
+	anonymizer auditDomain.Anonymizer
+	audit      auditDomain.Recorder
+	key        []byte
+	logger     zerolog.Logger
+}
+
+// NewErasureService cria um novo serviço de exclusão de dados
+func NewErasureService(
+	erasures domain.ErasureRepository,
+	receipts domain.ErasureReceiptRepository,
+	exports *ExportService,
+	media *MediaService,
+	stream *EventStream,
+	anonymizer auditDomain.Anonymizer,
+	audit auditDomain.Recorder,
+	settings ErasureSettings,
+	logger zerolog.Logger,
+) *ErasureService {
+	return &ErasureService{
+		erasures:   erasures,
+		receipts:   receipts,
+		exports:    exports,
+		media:      media,
+		stream:     stream,
+		anonymizer: anonymizer,
+		audit:      audit,
+		key:        []byte(settings.HashKey),
+		logger:     logger.With().Str("service", "erasure").Logger(),
+	}
+}
+
+// Erase apaga os dados do telefone no tenant da requisição e retorna o recibo
+// com o que foi removido. Repetir o pedido é seguro: um novo recibo é emitido
+// com o que ainda restava
+func (s *ErasureService) Erase(ctx context.Context, request domain.ErasureRequest) (*domain.ErasureReceipt, error) {
+	if err := s.requireKey(); err != nil {
+		return nil, err
+	}
+
+	phone := domain.NormalizePhone(request.Phone)
+	if phone == "" {
+		return nil, apperrors.NewValidationError("phone is required")
+	}
+
+	tenantID := contextTenant(ctx)
+	ctx = tenantDomain.NewContext(ctx, tenantID)
+
+	removed, err := s.erasures.Erase(ctx, tenantID, phone, request.RemoveOptOuts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Com os dados removidos, o restante precisa terminar mesmo que o cliente desconecte
+	ctx = context.WithoutCancel(ctx)
+
+	if removed.ExportFiles, err = s.exports.DiscardFiles(ctx, phone); err != nil {
+		return nil, fmt.Errorf("failed to discard export files: %w", err)
+	}
+	// Sem as mensagens, os arquivos de mídia baixados ficaram sem dono
+	if removed.MediaFiles, err = s.media.DiscardOrphans(ctx); err != nil {
+		return nil, fmt.Errorf("failed to discard media files: %w", err)
+	}
+	if removed.AuditEntries, err = s.anonymizer.Anonymize(ctx, tenantID, phone); err != nil {
+		return nil, fmt.Errorf("failed to anonymize audit log: %w", err)
+	}
+	removed.StreamEvents = int64(s.stream.Forget(tenantID, phone))
+
+	receipt, err := s.issueReceipt(ctx, tenantID, phone, *removed)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionContactErase,
+		ResourceType: auditDomain.ResourceErasure,
+		ResourceID:   receipt.ID.String(),
+		Summary:      fmt.Sprintf("contact data erased, receipt %d", receipt.Sequence),
+		Changes: map[string]auditDomain.Change{
+			"messages":      {To: removed.Messages},
+			"conversations": {To: removed.Conversations},
+			"opt_outs":      {To: removed.OptOuts},
+		},
+	})
+
+	s.logger.Info().
+		Str("tenant_id", tenantID.String()).
+		Str("receipt_id", receipt.ID.String()).
+		Int64("messages", removed.Messages).
+		Int64("conversations", removed.Conversations).
+		Msg("Contact data erased")
+
+	return receipt, nil
+}
+
+// GetReceipt obtém um recibo do tenant
+func (s *ErasureService) GetReceipt(ctx context.Context, id uuid.UUID) (*domain.ErasureReceipt, error) {
+	receipt, err := s.receipts.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("erasure receipt")
+	}
+	return receipt, nil
+}
+
+// ListReceipts lista os recibos do tenant do mais recente para o mais antigo
+func (s *ErasureService) ListReceipts(ctx context.Context, limit int, cursor string) ([]*domain.ErasureReceipt, string, error) {
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	receipts, err := s.receipts.List(ctx, limit+1, before)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(receipts) > limit {
+		receipts = receipts[:limit]
+		last := receipts[limit-1]
+		next = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return receipts, next, nil
+}
+
+// VerifyReceipts percorre a cadeia de recibos do tenant conferindo a
+// sequência, o encadeamento e o hash de cada recibo
+func (s *ErasureService) VerifyReceipts(ctx context.Context) (*domain.ErasureVerification, error) {
+	if err := s.requireKey(); err != nil {
+		return nil, err
+	}
+
+	tenantID := contextTenant(ctx)
+	verification := &domain.ErasureVerification{Valid: true}
+
+	var previous *domain.ErasureReceipt
+	for {
+		var after int64
+		if previous != nil {
+			after = previous.Sequence
+		}
+
+		receipts, err := s.receipts.ListChain(ctx, tenantID, after, receiptVerifyBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, receipt := range receipts {
+			verification.Receipts++
+			if reason := chainBreak(previous, receipt, s.key); reason != "" {
+				verification.Valid = false
+				verification.BrokenAt = &receipt.Sequence
+				verification.Reason = reason
+				return verification, nil
+			}
+			previous = receipt
+		}
+
+		if len(receipts) < receiptVerifyBatch {
+			return verification, nil
+		}
+	}
+}
+
+// issueReceipt emite o recibo encadeado ao último recibo do tenant, tentando
+// novamente quando outra exclusão ocupa a sequência antes
+func (s *ErasureService) issueReceipt(ctx context.Context, tenantID uuid.UUID, phone string, removed domain.ErasureCounts) (*domain.ErasureReceipt, error) {
+	salt, err := newReceiptSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &domain.ErasureReceipt{
+		ID:        uuid.New(),
+		Version:   domain.ErasureReceiptVersion,
+		TenantID:  tenantID,
+		PhoneHash: domain.HashErasedPhone(s.key, salt, phone),
+		PhoneSalt: salt,
+		Removed:   removed,
+		ActorType: "system",
+		CreatedAt: time.Now(),
+	}
+	if principal := authDomain.FromContext(ctx); principal != nil {
+		receipt.ActorType = string(principal.Type)
+		receipt.ActorID = principal.ID
+	}
+
+	for attempt := 0; attempt < receiptChainAttempts; attempt++ {
+		previous, err := s.receipts.Last(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		receipt.Chain(previous, s.key)
+		saved, err := s.receipts.Save(ctx, receipt)
+		if err != nil {
+			return nil, err
+		}
+		if saved {
+			return receipt, nil
+		}
+	}
+
+	return nil, apperrors.NewConflictError("erasure receipt chain is busy, try again")
+}
+
+// chainBreak retorna o motivo pelo qual o recibo não continua a cadeia, ou
+// vazio quando está íntegro
+func chainBreak(previous, receipt *domain.ErasureReceipt, key []byte) string {
+	expected := domain.ErasureReceipt{Version: receipt.Version}
+	expected.Chain(previous, key)
+
+	switch {
+	case receipt.Sequence != expected.Sequence:
+		return fmt.Sprintf("expected receipt %d, found %d", expected.Sequence, receipt.Sequence)
+	case receipt.PrevHash != expected.PrevHash:
+		return "previous hash does not match the previous receipt"
+	case receipt.Hash != receipt.ComputeHash(key):
+		return "receipt content does not match its hash"
+	}
+	return ""
+}
+
+// requireKey recusa as operações que dependem da chave dos recibos quando ela
+// não foi configurada
+func (s *ErasureService) requireKey() error {
+	if len(s.key) == 0 {
+		return apperrors.NewUnavailableError("ERASURE_NOT_CONFIGURED", "erasure hash key is not configured")
+	}
+	return nil
+}
+
+// newReceiptSalt gera o salt aleatório do hash do telefone
+func newReceiptSalt() (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate receipt salt: %w", err)
+	}
+	return hex.EncodeToString(salt), nil
+}
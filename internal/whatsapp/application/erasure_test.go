@@ -0,0 +1,351 @@
+package application_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const erasedPhone = "5511999990000"
+
+// testErasureKey é a chave dos hashes dos recibos nos testes
+var testErasureKey = []byte("erasure-test-key")
+
+// newTestErasureService cria o serviço de exclusão com os serviços de
+// exportação e de mídia sobre os mocks informados
+func newTestErasureService(
+	erasures *MockErasureRepository,
+	receipts *MockErasureReceiptRepository,
+	jobs *MockExportJobRepository,
+	mediaRepo *MockMediaRepository,
+	storage *MockFileStorage,
+	anonymizer *MockAuditAnonymizer,
+	recorder *MockAuditRecorder,
+	stream *application.EventStream,
+	hashKey string,
+) *application.ErasureService {
+	exports := application.NewExportService(jobs, new(MockMessageRepository), storage, recorder, application.ExportSettings{}, zerolog.Nop())
+	media := application.NewMediaService(mediaRepo, storage, application.MediaSettings{}, zerolog.Nop())
+	return application.NewErasureService(erasures, receipts, exports, media, stream, anonymizer, recorder, application.ErasureSettings{
+		HashKey: hashKey,
+	}, zerolog.Nop())
+}
+
+// expectNothingToDiscard configura a exclusão do telefone sem exportações,
+// mídias nem registros de auditoria a tratar
+func expectNothingToDiscard(jobs *MockExportJobRepository, mediaRepo *MockMediaRepository, anonymizer *MockAuditAnonymizer, phone string) {
+	jobs.On("ListCompleted", mock.Anything, phone).Return([]*domain.ExportJob{}, nil)
+	mediaRepo.On("ListOrphaned", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return([]*domain.Media{}, nil)
+	anonymizer.On("Anonymize", mock.Anything, mock.Anything, phone).Return(int64(0), nil)
+}
+
+// receiptChain emite recibos encadeados do tenant com a chave dos testes
+func receiptChain(tenantID uuid.UUID, count int) []*domain.ErasureReceipt {
+	var previous *domain.ErasureReceipt
+	chain := make([]*domain.ErasureReceipt, count)
+	for i := range chain {
+		receipt := &domain.ErasureReceipt{
+			ID:        uuid.New(),
+			Version:   domain.ErasureReceiptVersion,
+			TenantID:  tenantID,
+			PhoneHash: domain.HashErasedPhone(testErasureKey, "salt", erasedPhone),
+			PhoneSalt: "salt",
+			Removed:   domain.ErasureCounts{Messages: 3},
+			ActorType: "system",
+			CreatedAt: time.Now(),
+		}
+		receipt.Chain(previous, testErasureKey)
+		chain[i] = receipt
+		previous = receipt
+	}
+	return chain
+}
+
+func TestErasureService_EraseIssuesReceipt(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	filtered := &domain.ExportJob{ID: uuid.New(), TenantID: tenantID, Status: domain.ExportCompleted, Filter: domain.ExportFilter{Phone: erasedPhone}, FileName: "filtered.csv"}
+	unfiltered := &domain.ExportJob{ID: uuid.New(), TenantID: tenantID, Status: domain.ExportCompleted, FileName: "unfiltered.csv"}
+	// Mídia recebida cuja mensagem é apagada com o telefone
+	received := &domain.Media{ID: uuid.New(), TenantID: tenantID, StorageKey: "received.png", CreatedAt: time.Now()}
+
+	erasures := new(MockErasureRepository)
+	receipts := new(MockErasureReceiptRepository)
+	jobs := new(MockExportJobRepository)
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	anonymizer := new(MockAuditAnonymizer)
+	recorder := new(MockAuditRecorder)
+
+	erasures.On("Erase", inTenant(tenantID), tenantID, erasedPhone, false).
+		Return(&domain.ErasureCounts{Messages: 3, Conversations: 1, OptOutsKept: 1}, nil).Once()
+	jobs.On("ListCompleted", inTenant(tenantID), erasedPhone).Return([]*domain.ExportJob{filtered, unfiltered}, nil).Once()
+	storage.On("Remove", "filtered.csv").Return(nil).Once()
+	storage.On("Remove", "unfiltered.csv").Return(nil).Once()
+	jobs.On("Update", mock.Anything, filtered).Return(nil).Once()
+	jobs.On("Update", mock.Anything, unfiltered).Return(nil).Once()
+	mediaRepo.On("ListOrphaned", inTenant(tenantID), mock.AnythingOfType("time.Time"), 100).Return([]*domain.Media{received}, nil).Once()
+	storage.On("Remove", "received.png").Return(nil).Once()
+	mediaRepo.On("Delete", mock.Anything, received.ID).Return(nil).Once()
+	anonymizer.On("Anonymize", mock.Anything, tenantID, erasedPhone).Return(int64(2), nil).Once()
+	receipts.On("Last", mock.Anything, tenantID).Return(nil, nil).Once()
+	receipts.On("Save", mock.Anything, mock.AnythingOfType("*domain.ErasureReceipt")).Return(true, nil).Once()
+	recorder.On("Record", mock.Anything, mock.MatchedBy(func(record auditDomain.Record) bool {
+		return record.Action == auditDomain.ActionContactErase
+	})).Once()
+
+	stream, _ := newTestStream(t, application.EventStreamSettings{})
+	service := newTestErasureService(erasures, receipts, jobs, mediaRepo, storage, anonymizer, recorder, stream, string(testErasureKey))
+
+	receipt, err := service.Erase(ctx, domain.ErasureRequest{Phone: "+55 (11) 99999-0000"})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), receipt.Removed.Messages)
+	assert.Equal(t, int64(2), receipt.Removed.AuditEntries)
+	assert.Equal(t, int64(2), receipt.Removed.ExportFiles)
+	assert.Equal(t, int64(1), receipt.Removed.MediaFiles)
+	assert.Equal(t, domain.ExportExpired, filtered.Status)
+	assert.Empty(t, filtered.FileName)
+
+	// O recibo identifica o telefone sem guardá-lo
+	assert.Equal(t, int64(1), receipt.Sequence)
+	assert.Empty(t, receipt.PrevHash)
+	assert.Equal(t, domain.ErasureReceiptV2, receipt.Version)
+	assert.True(t, receipt.MatchesPhone(testErasureKey, erasedPhone))
+	assert.False(t, receipt.MatchesPhone(testErasureKey, "5511888880000"))
+	assert.False(t, receipt.MatchesPhone([]byte("other-key"), erasedPhone), "the hash cannot be checked without the key")
+	assert.NotContains(t, receipt.PhoneHash, erasedPhone)
+
+	record := recorder.Calls[0].Arguments.Get(1).(auditDomain.Record)
+	assert.Equal(t, receipt.ID.String(), record.ResourceID)
+	assert.NotContains(t, record.Summary, erasedPhone)
+
+	erasures.AssertExpectations(t)
+	receipts.AssertExpectations(t)
+	jobs.AssertExpectations(t)
+	mediaRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+	anonymizer.AssertExpectations(t)
+	recorder.AssertExpectations(t)
+}
+
+func TestErasureService_ForgetsStreamEvents(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	erasures := new(MockErasureRepository)
+	receipts := new(MockErasureReceiptRepository)
+	jobs := new(MockExportJobRepository)
+	mediaRepo := new(MockMediaRepository)
+	anonymizer := new(MockAuditAnonymizer)
+	recorder := new(MockAuditRecorder)
+	erasures.On("Erase", mock.Anything, tenantID, erasedPhone, false).Return(&domain.ErasureCounts{}, nil)
+	expectNothingToDiscard(jobs, mediaRepo, anonymizer, erasedPhone)
+	receipts.On("Last", mock.Anything, tenantID).Return(nil, nil)
+	receipts.On("Save", mock.Anything, mock.AnythingOfType("*domain.ErasureReceipt")).Return(true, nil)
+	recorder.On("Record", mock.Anything, mock.AnythingOfType("domain.Record"))
+
+	stream, bus := newTestStream(t, application.EventStreamSettings{})
+	service := newTestErasureService(erasures, receipts, jobs, mediaRepo, new(MockFileStorage), anonymizer, recorder, stream, string(testErasureKey))
+
+	erased := domain.NewMessageReceivedEvent(uuid.New(), &domain.Message{TenantID: tenantID, Phone: erasedPhone})
+	kept := domain.NewMessageReceivedEvent(uuid.New(), &domain.Message{TenantID: tenantID, Phone: "5511888880000"})
+	last := domain.NewMessageReceivedEvent(uuid.New(), &domain.Message{TenantID: uuid.New(), Phone: erasedPhone})
+	first := domain.NewMessageReceivedEvent(uuid.New(), &domain.Message{TenantID: tenantID, Phone: "5511777770000"})
+	for _, event := range []*domain.MessageReceivedEvent{first, erased, kept, last} {
+		require.NoError(t, bus.PublishEvent(context.Background(), event))
+	}
+
+	// O gravador do replay consome os eventos de forma assíncrona
+	require.Eventually(t, func() bool {
+		subscription, err := stream.Subscribe(application.StreamFilter{}, first.GetID())
+		require.NoError(t, err)
+		defer subscription.Close()
+		return len(subscription.Replayed) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	receipt, err := service.Erase(ctx, domain.ErasureRequest{Phone: erasedPhone})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), receipt.Removed.StreamEvents)
+
+	subscription, err := stream.Subscribe(application.StreamFilter{}, first.GetID())
+	require.NoError(t, err)
+	defer subscription.Close()
+	require.Len(t, subscription.Replayed, 2)
+	assert.Equal(t, kept.GetID(), subscription.Replayed[0].GetID())
+	assert.Equal(t, last.GetID(), subscription.Replayed[1].GetID())
+}
+
+func TestErasureService_RetriesChainConflicts(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	previous := receiptChain(tenantID, 1)[0]
+
+	erasures := new(MockErasureRepository)
+	receipts := new(MockErasureReceiptRepository)
+	jobs := new(MockExportJobRepository)
+	mediaRepo := new(MockMediaRepository)
+	anonymizer := new(MockAuditAnonymizer)
+	recorder := new(MockAuditRecorder)
+	erasures.On("Erase", mock.Anything, tenantID, erasedPhone, false).Return(&domain.ErasureCounts{}, nil)
+	expectNothingToDiscard(jobs, mediaRepo, anonymizer, erasedPhone)
+	receipts.On("Last", mock.Anything, tenantID).Return(previous, nil)
+	// Exclusões simultâneas ocupam a sequência nas duas primeiras tentativas
+	receipts.On("Save", mock.Anything, mock.AnythingOfType("*domain.ErasureReceipt")).Return(false, nil).Twice()
+	receipts.On("Save", mock.Anything, mock.AnythingOfType("*domain.ErasureReceipt")).Return(true, nil).Once()
+	recorder.On("Record", mock.Anything, mock.AnythingOfType("domain.Record"))
+
+	stream, _ := newTestStream(t, application.EventStreamSettings{})
+	service := newTestErasureService(erasures, receipts, jobs, mediaRepo, new(MockFileStorage), anonymizer, recorder, stream, string(testErasureKey))
+
+	receipt, err := service.Erase(ctx, domain.ErasureRequest{Phone: erasedPhone})
+	require.NoError(t, err)
+	assert.Equal(t, previous.Sequence+1, receipt.Sequence)
+	assert.Equal(t, previous.Hash, receipt.PrevHash)
+	receipts.AssertNumberOfCalls(t, "Last", 3)
+	receipts.AssertNumberOfCalls(t, "Save", 3)
+
+	// A cadeia continua disputada depois de todas as tentativas
+	receipts.On("Save", mock.Anything, mock.AnythingOfType("*domain.ErasureReceipt")).Return(false, nil)
+	_, err = service.Erase(ctx, domain.ErasureRequest{Phone: erasedPhone})
+	assert.True(t, errors.Is(err, apperrors.ErrConflict))
+
+	_, err = service.Erase(ctx, domain.ErasureRequest{Phone: "ext."})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+}
+
+func TestErasureService_VerifyDetectsTampering(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	receipts := new(MockErasureReceiptRepository)
+	stream, _ := newTestStream(t, application.EventStreamSettings{})
+	service := newTestErasureService(new(MockErasureRepository), receipts, new(MockExportJobRepository), new(MockMediaRepository),
+		new(MockFileStorage), new(MockAuditAnonymizer), new(MockAuditRecorder), stream, string(testErasureKey))
+
+	verify := func(chain []*domain.ErasureReceipt) *domain.ErasureVerification {
+		receipts.On("ListChain", ctx, tenantID, int64(0), 500).Return(chain, nil).Once()
+		verification, err := service.VerifyReceipts(ctx)
+		require.NoError(t, err)
+		return verification
+	}
+
+	chain := receiptChain(tenantID, 3)
+	verification := verify(chain)
+	assert.True(t, verification.Valid)
+	assert.Equal(t, int64(3), verification.Receipts)
+
+	// Alterar o conteúdo de um recibo invalida o seu hash
+	chain[1].Removed.Messages = 0
+	verification = verify(chain)
+	assert.False(t, verification.Valid)
+	require.NotNil(t, verification.BrokenAt)
+	assert.Equal(t, int64(2), *verification.BrokenAt)
+
+	// Os arquivos de mídia entram no hash mesmo quando nenhum foi removido
+	chain[1].Removed.Messages = 3
+	chain[1].Removed.MediaFiles = 2
+	verification = verify(chain)
+	assert.False(t, verification.Valid)
+	assert.Equal(t, int64(2), *verification.BrokenAt)
+	chain[1].Removed.Messages = 0
+	chain[1].Removed.MediaFiles = 0
+
+	// Sem a chave do servidor não é possível recalcular um hash válido
+	chain[1].Hash = chain[1].ComputeHash([]byte("guessed-key"))
+	verification = verify(chain)
+	assert.False(t, verification.Valid)
+	assert.Equal(t, int64(2), *verification.BrokenAt)
+
+	// Recalcular o hash do recibo alterado quebra o encadeamento do seguinte
+	chain[1].Hash = chain[1].ComputeHash(testErasureKey)
+	verification = verify(chain)
+	assert.False(t, verification.Valid)
+	assert.Equal(t, int64(3), *verification.BrokenAt)
+
+	// Remover um recibo deixa uma lacuna na sequência
+	verification = verify([]*domain.ErasureReceipt{chain[0], chain[2]})
+	assert.False(t, verification.Valid)
+	assert.Equal(t, int64(3), *verification.BrokenAt)
+
+	receipts.AssertExpectations(t)
+}
+
+func TestErasureService_VerifiesLegacyReceipts(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	// Recibo emitido antes da chave, com hashes SHA-256 sem chave
+	legacy := &domain.ErasureReceipt{
+		ID:        uuid.New(),
+		Version:   domain.ErasureReceiptV1,
+		TenantID:  tenantID,
+		PhoneSalt: "salt",
+		ActorType: "system",
+		CreatedAt: time.Now(),
+	}
+	sum := sha256.Sum256([]byte("salt:" + erasedPhone))
+	legacy.PhoneHash = hex.EncodeToString(sum[:])
+	legacy.Chain(nil, nil)
+	assert.True(t, legacy.MatchesPhone(testErasureKey, erasedPhone))
+
+	erasures := new(MockErasureRepository)
+	receipts := new(MockErasureReceiptRepository)
+	jobs := new(MockExportJobRepository)
+	mediaRepo := new(MockMediaRepository)
+	anonymizer := new(MockAuditAnonymizer)
+	recorder := new(MockAuditRecorder)
+	erasures.On("Erase", mock.Anything, tenantID, "5511888880000", false).Return(&domain.ErasureCounts{}, nil)
+	expectNothingToDiscard(jobs, mediaRepo, anonymizer, "5511888880000")
+	receipts.On("Last", mock.Anything, tenantID).Return(legacy, nil).Once()
+	receipts.On("Save", mock.Anything, mock.AnythingOfType("*domain.ErasureReceipt")).Return(true, nil).Once()
+	recorder.On("Record", mock.Anything, mock.AnythingOfType("domain.Record"))
+
+	stream, _ := newTestStream(t, application.EventStreamSettings{})
+	service := newTestErasureService(erasures, receipts, jobs, mediaRepo, new(MockFileStorage), anonymizer, recorder, stream, string(testErasureKey))
+
+	receipt, err := service.Erase(ctx, domain.ErasureRequest{Phone: "5511888880000"})
+	require.NoError(t, err)
+	assert.Equal(t, legacy.Hash, receipt.PrevHash)
+
+	receipts.On("ListChain", ctx, tenantID, int64(0), 500).Return([]*domain.ErasureReceipt{legacy, receipt}, nil).Once()
+	verification, err := service.VerifyReceipts(ctx)
+	require.NoError(t, err)
+	assert.True(t, verification.Valid)
+	assert.Equal(t, int64(2), verification.Receipts)
+
+	receipts.AssertExpectations(t)
+}
+
+func TestErasureService_RequiresHashKey(t *testing.T) {
+	erasures := new(MockErasureRepository)
+	receipts := new(MockErasureReceiptRepository)
+	stream, _ := newTestStream(t, application.EventStreamSettings{})
+	service := newTestErasureService(erasures, receipts, new(MockExportJobRepository), new(MockMediaRepository),
+		new(MockFileStorage), new(MockAuditAnonymizer), new(MockAuditRecorder), stream, "")
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+
+	_, err := service.Erase(ctx, domain.ErasureRequest{Phone: erasedPhone})
+	assert.True(t, errors.Is(err, apperrors.ErrUnavailable))
+
+	_, err = service.VerifyReceipts(ctx)
+	assert.True(t, errors.Is(err, apperrors.ErrUnavailable))
+
+	erasures.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	receipts.AssertNotCalled(t, "ListChain", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,299 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// EventStreamSettings configura o stream de eventos em tempo real
+type EventStreamSettings struct {
+	Enabled    bool
+	Heartbeat  time.Duration         // intervalo entre as mensagens de keep-alive
+	BufferSize int                   // eventos guardados por cliente e tipo de evento
+	ReplaySize int                   // eventos recentes mantidos para clientes que reconectam
+	Overflow   events.OverflowPolicy // o que fazer quando o cliente não acompanha os eventos
+}
+
+// StreamFilter seleciona os eventos entregues a um cliente. Listas vazias e
+// tenant nulo aceitam tudo
+type StreamFilter struct {
+	TenantID    *uuid.UUID
+	InstanceIDs []uuid.UUID
+	Events      []string
+}
+
+// Matches indica se o evento passa pelo filtro
+func (f StreamFilter) Matches(event events.Event) bool {
+	if len(f.Events) > 0 && !containsString(f.Events, event.GetName()) {
+		return false
+	}
+	if f.TenantID == nil && len(f.InstanceIDs) == 0 {
+		return true
+	}
+
+	instanceEvent, ok := event.(domain.InstanceEvent)
+	if !ok {
+		return false
+	}
+	if f.TenantID != nil && instanceEvent.GetTenantID() != *f.TenantID {
+		return false
+	}
+	if len(f.InstanceIDs) == 0 {
+		return true
+	}
+	for _, id := range f.InstanceIDs {
+		if id == instanceEvent.GetInstanceID() {
+			return true
+		}
+	}
+	return false
+}
+
+// EventStream entrega os eventos de domínio aos clientes conectados por SSE
+// ou WebSocket. Mantém os eventos recentes para que um cliente que reconecta
+// com o ID do último evento recebido não perca o que aconteceu no intervalo
+type EventStream struct {
+	bus      *events.ChannelEventBus
+	replay   *events.ReplayBuffer
+	settings EventStreamSettings
+	logger   zerolog.Logger
+
+	mu            sync.Mutex
+	recorders     []topicSubscriber
+	subscriptions map[*StreamSubscription]struct{}
+	wg            sync.WaitGroup
+}
+
+// NewEventStream cria um novo stream de eventos
+func NewEventStream(bus *events.ChannelEventBus, settings EventStreamSettings, logger zerolog.Logger) *EventStream {
+	if settings.Heartbeat <= 0 {
+		settings.Heartbeat = 15 * time.Second
+	}
+	if settings.BufferSize <= 0 {
+		settings.BufferSize = 256
+	}
+	if settings.ReplaySize <= 0 {
+		settings.ReplaySize = 1000
+	}
+
+	return &EventStream{
+		bus:           bus,
+		replay:        events.NewReplayBuffer(settings.ReplaySize),
+		settings:      settings,
+		logger:        logger.With().Str("component", "event_stream").Logger(),
+		subscriptions: make(map[*StreamSubscription]struct{}),
+	}
+}
+
+// Settings retorna a configuração do stream
+func (s *EventStream) Settings() EventStreamSettings {
+	return s.settings
+}
+
+// Start passa a guardar os eventos publicados para o replay
+func (s *EventStream) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range domain.WebhookEventTypes {
+		// O gravador nunca é desconectado: sob pressão perde os eventos mais antigos
+		recorder := s.bus.SubscribeChannelWithOptions(name, events.SubscribeOptions{
+			BufferSize: s.settings.BufferSize,
+			Overflow:   events.OverflowDropOldest,
+		})
+		s.recorders = append(s.recorders, topicSubscriber{topic: name, subscriber: recorder})
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for event := range recorder.Channel() {
+				s.replay.Add(event)
+			}
+		}()
+	}
+
+	s.logger.Info().Int("replay_size", s.settings.ReplaySize).Msg("Event stream started")
+}
+
+// Stop encerra as assinaturas abertas, o que termina as conexões dos clientes
+func (s *EventStream) Stop() {
+	s.mu.Lock()
+	for _, recorder := range s.recorders {
+		s.bus.UnsubscribeChannel(recorder.topic, recorder.subscriber)
+	}
+	s.recorders = nil
+
+	subscriptions := make([]*StreamSubscription, 0, len(s.subscriptions))
+	for subscription := range s.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	s.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.Close()
+	}
+	s.wg.Wait()
+
+	s.logger.Info().Msg("Event stream stopped")
+}
+
+// Forget descarta do replay os eventos do telefone no tenant, para que não
+// sejam reenviados a clientes que reconectam. Retorna quantos foram descartados
+func (s *EventStream) Forget(tenantID uuid.UUID, phone string) int {
+	return s.replay.Remove(func(event events.Event) bool {
+		contact, ok := event.(domain.ContactEvent)
+		return ok && contact.GetTenantID() == tenantID && contact.GetPhone() == phone
+	})
+}
+
+// Subscribe abre uma assinatura com os eventos que passam pelo filtro. Com
+// lastEventID, a assinatura começa pelos eventos publicados depois dele
+func (s *EventStream) Subscribe(filter StreamFilter, lastEventID string) (*StreamSubscription, error) {
+	for _, name := range filter.Events {
+		if !domain.IsWebhookEventType(name) || name == domain.WebhookAllEvents {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("unknown event: %s", name))
+		}
+	}
+
+	topics := filter.Events
+	if len(topics) == 0 {
+		topics = domain.WebhookEventTypes
+	}
+
+	subscription := &StreamSubscription{
+		events: make(chan events.Event),
+		done:   make(chan struct{}),
+		stream: s,
+	}
+
+	// A assinatura ao barramento vem antes da leitura do replay para que nenhum
+	// evento publicado entre as duas se perca; os repetidos são descartados pelo ID
+	for _, topic := range topics {
+		subscription.subscribers = append(subscription.subscribers, topicSubscriber{
+			topic: topic,
+			subscriber: s.bus.SubscribeChannelWithOptions(topic, events.SubscribeOptions{
+				BufferSize: s.settings.BufferSize,
+				Overflow:   s.settings.Overflow,
+			}),
+		})
+	}
+
+	replayed := make(map[string]struct{})
+	if lastEventID != "" {
+		missed, found := s.replay.Since(lastEventID)
+		subscription.ReplayGap = !found
+		for _, event := range missed {
+			if filter.Matches(event) {
+				subscription.Replayed = append(subscription.Replayed, event)
+			}
+			replayed[event.GetID()] = struct{}{}
+		}
+	}
+
+	var forwarders sync.WaitGroup
+	for _, subscriber := range subscription.subscribers {
+		forwarders.Add(1)
+		go func(subscriber *events.ChannelSubscriber) {
+			defer forwarders.Done()
+			subscription.forward(subscriber, filter, replayed)
+		}(subscriber.subscriber)
+	}
+	go func() {
+		forwarders.Wait()
+		close(subscription.events)
+	}()
+
+	s.mu.Lock()
+	s.subscriptions[subscription] = struct{}{}
+	s.mu.Unlock()
+
+	return subscription, nil
+}
+
+// topicSubscriber guarda o tópico de um assinante do barramento, necessário
+// para removê-lo ao encerrar
+type topicSubscriber struct {
+	topic      string
+	subscriber *events.ChannelSubscriber
+}
+
+// StreamSubscription é a assinatura de um cliente conectado ao stream
+type StreamSubscription struct {
+	// Replayed contém os eventos publicados depois do último recebido pelo cliente
+	Replayed []events.Event
+	// ReplayGap indica que o último evento recebido não está mais guardado e
+	// o cliente pode ter perdido eventos
+	ReplayGap bool
+
+	events      chan events.Event
+	subscribers []topicSubscriber
+	stream      *EventStream
+	done        chan struct{}
+	closeOnce   sync.Once
+	overflowed  atomic.Bool
+}
+
+// Events retorna os eventos ao vivo. O canal é fechado quando a assinatura termina
+func (s *StreamSubscription) Events() <-chan events.Event {
+	return s.events
+}
+
+// Overflowed indica que a assinatura foi encerrada porque o cliente não
+// acompanhou os eventos
+func (s *StreamSubscription) Overflowed() bool {
+	return s.overflowed.Load()
+}
+
+// Close encerra a assinatura e remove seus assinantes do barramento
+func (s *StreamSubscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		for _, subscriber := range s.subscribers {
+			s.stream.bus.UnsubscribeChannel(subscriber.topic, subscriber.subscriber)
+		}
+
+		s.stream.mu.Lock()
+		delete(s.stream.subscriptions, s)
+		s.stream.mu.Unlock()
+	})
+}
+
+// forward repassa os eventos de um tipo ao canal da assinatura
+func (s *StreamSubscription) forward(subscriber *events.ChannelSubscriber, filter StreamFilter, replayed map[string]struct{}) {
+	for event := range subscriber.Channel() {
+		if _, ok := replayed[event.GetID()]; ok || !filter.Matches(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+
+	// O canal do barramento só fecha sozinho pela política de desconexão
+	if subscriber.Overflowed() {
+		s.overflowed.Store(true)
+		s.stream.logger.Warn().Uint64("dropped", subscriber.Dropped()).Msg("Event stream client disconnected for falling behind")
+	}
+	s.Close()
+}
+
+// containsString indica se o valor está na lista
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+func newTestStream(t *testing.T, settings application.EventStreamSettings) (*application.EventStream, *events.ChannelEventBus) {
+	bus := events.NewChannelEventBus(nil)
+	stream := application.NewEventStream(bus, settings, zerolog.Nop())
+	stream.Start()
+	t.Cleanup(stream.Stop)
+	return stream, bus
+}
+
+func receiveEvent(t *testing.T, subscription *application.StreamSubscription) events.Event {
+	t.Helper()
+	select {
+	case event := <-subscription.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return nil
+	}
+}
+
+func TestEventStream_FiltersByInstanceAndEvent(t *testing.T) {
+	stream, bus := newTestStream(t, application.EventStreamSettings{})
+	instanceID := uuid.New()
+
+	subscription, err := stream.Subscribe(application.StreamFilter{
+		InstanceIDs: []uuid.UUID{instanceID},
+		Events:      []string{domain.EventMessageReceived},
+	}, "")
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	ctx := context.Background()
+	require.NoError(t, bus.PublishEvent(ctx, domain.NewMessageReceivedEvent(uuid.New(), &domain.Message{})))
+	require.NoError(t, bus.PublishEvent(ctx, domain.NewInstanceStatusChangedEvent(&domain.Instance{ID: instanceID}, domain.InstanceConnected, 0)))
+	expected := domain.NewMessageReceivedEvent(instanceID, &domain.Message{})
+	require.NoError(t, bus.PublishEvent(ctx, expected))
+
+	assert.Equal(t, expected.GetID(), receiveEvent(t, subscription).GetID())
+}
+
+func TestEventStream_RejectsUnknownEvent(t *testing.T) {
+	stream, _ := newTestStream(t, application.EventStreamSettings{})
+
+	_, err := stream.Subscribe(application.StreamFilter{Events: []string{"message.unknown"}}, "")
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "VALIDATION_ERROR", appErr.Code)
+}
+
+func TestEventStream_ReplaysMissedEvents(t *testing.T) {
+	stream, bus := newTestStream(t, application.EventStreamSettings{})
+	instanceID := uuid.New()
+
+	var published []events.Event
+	for i := 0; i < 3; i++ {
+		event := domain.NewMessageReceivedEvent(instanceID, &domain.Message{})
+		require.NoError(t, bus.PublishEvent(context.Background(), event))
+		published = append(published, event)
+	}
+
+	// O gravador do replay consome os eventos de forma assíncrona
+	require.Eventually(t, func() bool {
+		subscription, err := stream.Subscribe(application.StreamFilter{}, published[0].GetID())
+		require.NoError(t, err)
+		defer subscription.Close()
+		return len(subscription.Replayed) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	subscription, err := stream.Subscribe(application.StreamFilter{}, published[0].GetID())
+	require.NoError(t, err)
+	defer subscription.Close()
+	assert.False(t, subscription.ReplayGap)
+	assert.Equal(t, published[1].GetID(), subscription.Replayed[0].GetID())
+	assert.Equal(t, published[2].GetID(), subscription.Replayed[1].GetID())
+
+	unknown, err := stream.Subscribe(application.StreamFilter{}, "evicted")
+	require.NoError(t, err)
+	defer unknown.Close()
+	assert.True(t, unknown.ReplayGap)
+	assert.Empty(t, unknown.Replayed)
+}
+
+func TestEventStream_DisconnectsSlowConsumer(t *testing.T) {
+	stream, bus := newTestStream(t, application.EventStreamSettings{
+		BufferSize: 2,
+		Overflow:   events.OverflowDisconnect,
+	})
+
+	subscription, err := stream.Subscribe(application.StreamFilter{Events: []string{domain.EventMessageReceived}}, "")
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, bus.PublishEvent(context.Background(), domain.NewMessageReceivedEvent(uuid.New(), &domain.Message{})))
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, open := <-subscription.Events():
+			if !open {
+				assert.True(t, subscription.Overflowed())
+				return
+			}
+		case <-deadline:
+			t.Fatal("slow consumer was not disconnected")
+		}
+	}
+}
+
+func TestEventStream_CloseUnsubscribesFromBus(t *testing.T) {
+	bus := events.NewChannelEventBus(nil)
+	stream := application.NewEventStream(bus, application.EventStreamSettings{}, zerolog.Nop())
+	stream.Start()
+
+	subscription, err := stream.Subscribe(application.StreamFilter{}, "")
+	require.NoError(t, err)
+	for _, name := range domain.WebhookEventTypes {
+		assert.Equal(t, 2, bus.SubscriberCount(name), name)
+	}
+
+	// Clientes que desconectam não deixam assinantes no barramento
+	subscription.Close()
+	for _, name := range domain.WebhookEventTypes {
+		assert.Equal(t, 1, bus.SubscriberCount(name), name)
+	}
+
+	stream.Stop()
+	for _, name := range domain.WebhookEventTypes {
+		assert.Zero(t, bus.SubscriberCount(name), name)
+	}
+}
@@ -0,0 +1,501 @@
+package application
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// exportCSVHeader lista as colunas do CSV de mensagens
+var exportCSVHeader = []string{
+	"id", "instance_id", "phone", "direction", "type", "status", "content", "media_url",
+	"error", "created_at", "sent_at", "delivered_at", "read_at", "failed_at",
+}
+
+// expiredExportBatch limita as exportações vencidas removidas por rodada
+const expiredExportBatch = 100
+
+// ExportSettings configura a geração e a validade das exportações
+type ExportSettings struct {
+	TTL         time.Duration // tempo em que o arquivo gerado fica disponível para download
+	StaleAfter  time.Duration // exportações em andamento há mais tempo são retomadas
+	StreamLimit int64         // mensagens exportadas diretamente na resposta; acima disso é preciso criar uma exportação
+	PageSize    int           // mensagens lidas do banco por consulta
+}
+
+// ExportService exporta o histórico de mensagens em CSV ou JSONL, diretamente
+// na resposta para intervalos pequenos ou em background para os grandes
+type ExportService struct {
+	jobs     domain.ExportJobRepository
+	messages domain.MessageRepository
+	storage  domain.ExportStorage
+	audit    auditDomain.Recorder
+	settings ExportSettings
+	logger   zerolog.Logger
+}
+
+// NewExportService cria um novo serviço de exportação
+func NewExportService(
+	jobs domain.ExportJobRepository,
+	messages domain.MessageRepository,
+	storage domain.ExportStorage,
+	audit auditDomain.Recorder,
+	settings ExportSettings,
+	logger zerolog.Logger,
+) *ExportService {
+	if settings.TTL <= 0 {
+		settings.TTL = 24 * time.Hour
+	}
+	if settings.StaleAfter <= 0 {
+		settings.StaleAfter = 30 * time.Minute
+	}
+	if settings.StreamLimit <= 0 {
+		settings.StreamLimit = 10000
+	}
+	if settings.PageSize <= 0 {
+		settings.PageSize = 500
+	}
+
+	return &ExportService{
+		jobs:     jobs,
+		messages: messages,
+		storage:  storage,
+		audit:    audit,
+		settings: settings,
+		logger:   logger.With().Str("service", "export").Logger(),
+	}
+}
+
+// CreateJob agenda a exportação das mensagens que atendem ao filtro
+func (s *ExportService) CreateJob(ctx context.Context, request domain.CreateExportRequest) (*domain.ExportJob, error) {
+	filter, err := exportFilter(request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &domain.ExportJob{
+		ID:        uuid.New(),
+		TenantID:  contextTenant(ctx),
+		Format:    request.Format,
+		Filter:    filter,
+		Status:    domain.ExportPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.jobs.Save(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.recordExport(ctx, job.ID.String(), request.Format, filter)
+	s.logger.Info().Str("export_id", job.ID.String()).Str("format", string(job.Format)).Msg("Export job created")
+
+	return job, nil
+}
+
+// GetJob obtém uma exportação
+func (s *ExportService) GetJob(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error) {
+	job, err := s.jobs.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("export")
+	}
+	return job, nil
+}
+
+// ListJobs lista as exportações do tenant da mais recente para a mais antiga
+func (s *ExportService) ListJobs(ctx context.Context, limit int, cursor string) ([]*domain.ExportJob, string, error) {
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	jobs, err := s.jobs.List(ctx, limit+1, before)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+		last := jobs[limit-1]
+		next = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return jobs, next, nil
+}
+
+// OpenFile abre o arquivo de uma exportação concluída para download
+func (s *ExportService) OpenFile(ctx context.Context, id uuid.UUID) (*domain.ExportJob, io.ReadCloser, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch job.Status {
+	case domain.ExportCompleted:
+		// O arquivo vence antes de a limpeza marcar a exportação como expirada
+		if job.ExpiresAt != nil && !job.ExpiresAt.After(time.Now()) {
+			return nil, nil, apperrors.NewNotFoundError("export file")
+		}
+	case domain.ExportExpired:
+		return nil, nil, apperrors.NewNotFoundError("export file")
+	default:
+		return nil, nil, apperrors.NewConflictError(fmt.Sprintf("export is %s", job.Status))
+	}
+
+	file, err := s.storage.Open(job.FileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, file, nil
+}
+
+// Stream valida a exportação direta e retorna a função que escreve as
+// mensagens, permitindo responder com erro antes de iniciar a resposta.
+// Exportações acima do limite devem ser feitas em background
+func (s *ExportService) Stream(ctx context.Context, request domain.CreateExportRequest) (func(w io.Writer) error, error) {
+	filter, err := exportFilter(request)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.messages.Count(ctx, filter.MessageFilter())
+	if err != nil {
+		return nil, err
+	}
+	if total > s.settings.StreamLimit {
+		return nil, apperrors.NewValidationError(fmt.Sprintf(
+			"%d messages match the filter, above the limit of %d for direct export: create an export job",
+			total, s.settings.StreamLimit,
+		))
+	}
+
+	s.recordExport(ctx, "", request.Format, filter)
+
+	return func(w io.Writer) error {
+		_, err := s.writeMessages(ctx, w, request.Format, filter)
+		return err
+	}, nil
+}
+
+// ProcessNext gera o arquivo da próxima exportação pendente. Retorna false
+// quando não há exportações a processar
+func (s *ExportService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.jobs.ClaimNext(ctx, time.Now().Add(-s.settings.StaleAfter))
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	logger := s.logger.With().Str("export_id", job.ID.String()).Logger()
+	fileName := job.ID.String() + "." + string(job.Format)
+	job.FileName = fileName
+
+	rows, size, err := s.writeFile(tenantDomain.NewContext(ctx, job.TenantID), job)
+
+	// O resultado é gravado mesmo com o contexto encerrado
+	saveCtx := context.WithoutCancel(ctx)
+	now := time.Now()
+	switch {
+	case err == nil:
+		expiresAt := now.Add(s.settings.TTL)
+		job.Status = domain.ExportCompleted
+		job.Rows = rows
+		job.SizeBytes = size
+		job.ExpiresAt = &expiresAt
+		job.CompletedAt = &now
+		logger.Info().Int64("rows", rows).Int64("size_bytes", size).Msg("Export job completed")
+	case ctx.Err() != nil:
+		// Encerramento da aplicação: a exportação volta para a fila
+		job.Status = domain.ExportPending
+		job.FileName = ""
+		logger.Info().Msg("Export job interrupted")
+	default:
+		message := err.Error()
+		job.Status = domain.ExportFailed
+		job.FileName = ""
+		job.Error = &message
+		job.CompletedAt = &now
+		logger.Error().Err(err).Msg("Export job failed")
+	}
+
+	if job.Status != domain.ExportCompleted {
+		if removeErr := s.storage.Remove(fileName); removeErr != nil {
+			logger.Warn().Err(removeErr).Msg("Failed to remove partial export file")
+		}
+	}
+	if updateErr := s.jobs.Update(saveCtx, job); updateErr != nil {
+		return true, updateErr
+	}
+
+	return true, nil
+}
+
+// PurgeExpired remove os arquivos das exportações vencidas
+func (s *ExportService) PurgeExpired(ctx context.Context) error {
+	jobs, err := s.jobs.ListExpired(ctx, time.Now(), expiredExportBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := s.storage.Remove(job.FileName); err != nil {
+			s.logger.Warn().Err(err).Str("export_id", job.ID.String()).Msg("Failed to remove expired export file")
+			continue
+		}
+
+		job.Status = domain.ExportExpired
+		job.FileName = ""
+		if err := s.jobs.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DiscardFiles remove os arquivos das exportações concluídas que podem conter
+// mensagens do telefone, que passam a constar como vencidas. Retorna quantos
+// arquivos foram removidos
+func (s *ExportService) DiscardFiles(ctx context.Context, phone string) (int64, error) {
+	jobs, err := s.jobs.ListCompleted(ctx, phone)
+	if err != nil {
+		return 0, err
+	}
+
+	var discarded int64
+	for _, job := range jobs {
+		if err := s.storage.Remove(job.FileName); err != nil {
+			return discarded, err
+		}
+
+		job.Status = domain.ExportExpired
+		job.FileName = ""
+		if err := s.jobs.Update(ctx, job); err != nil {
+			return discarded, err
+		}
+		discarded++
+	}
+
+	return discarded, nil
+}
+
+// writeFile grava as mensagens da exportação no armazenamento
+func (s *ExportService) writeFile(ctx context.Context, job *domain.ExportJob) (int64, int64, error) {
+	file, err := s.storage.Create(job.FileName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	counter := &countingWriter{w: file}
+	rows, err := s.writeMessages(ctx, counter, job.Format, job.Filter)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close export file: %w", closeErr)
+	}
+	return rows, counter.n, err
+}
+
+// writeMessages percorre as mensagens do filtro com paginação por cursor,
+// da mais recente para a mais antiga, sem carregar o resultado em memória
+func (s *ExportService) writeMessages(ctx context.Context, w io.Writer, format domain.ExportFormat, filter domain.ExportFilter) (int64, error) {
+	encoder := newExportEncoder(w, format)
+	if err := encoder.Begin(); err != nil {
+		return 0, err
+	}
+
+	var rows int64
+	var before *domain.Cursor
+	for {
+		if err := ctx.Err(); err != nil {
+			return rows, err
+		}
+
+		messages, err := s.messages.Search(ctx, filter.MessageFilter(), s.settings.PageSize, before)
+		if err != nil {
+			return rows, err
+		}
+
+		for _, message := range messages {
+			if err := encoder.Encode(message); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if err := encoder.Flush(); err != nil {
+			return rows, err
+		}
+
+		if len(messages) < s.settings.PageSize {
+			return rows, nil
+		}
+		last := messages[len(messages)-1]
+		before = &domain.Cursor{Time: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// recordExport registra no log de auditoria a exportação de mensagens
+func (s *ExportService) recordExport(ctx context.Context, exportID string, format domain.ExportFormat, filter domain.ExportFilter) {
+	changes := map[string]auditDomain.Change{"format": {To: format}}
+	if filter.InstanceID != "" {
+		changes["instance_id"] = auditDomain.Change{To: filter.InstanceID}
+	}
+	if filter.Phone != "" {
+		changes["phone"] = auditDomain.Change{To: filter.Phone}
+	}
+	if filter.Status != "" {
+		changes["status"] = auditDomain.Change{To: filter.Status}
+	}
+	if filter.From != nil {
+		changes["from"] = auditDomain.Change{To: filter.From.UTC().Format(time.RFC3339)}
+	}
+	if filter.To != nil {
+		changes["to"] = auditDomain.Change{To: filter.To.UTC().Format(time.RFC3339)}
+	}
+
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionMessageExport,
+		ResourceType: auditDomain.ResourceExport,
+		ResourceID:   exportID,
+		Summary:      fmt.Sprintf("%s message export", format),
+		Changes:      changes,
+	})
+}
+
+// exportFilter valida o formato e o filtro da requisição
+func exportFilter(request domain.CreateExportRequest) (domain.ExportFilter, error) {
+	if !request.Format.IsValid() {
+		return domain.ExportFilter{}, apperrors.NewValidationError(fmt.Sprintf("invalid format: %s", request.Format))
+	}
+
+	filter := domain.ExportFilter{
+		InstanceID: request.InstanceID,
+		Phone:      request.Phone,
+		Status:     request.Status,
+		From:       request.From,
+		To:         request.To,
+	}
+	if err := validateMessageFilter(filter.MessageFilter()); err != nil {
+		return domain.ExportFilter{}, err
+	}
+	if filter.Phone != "" {
+		filter.Phone = domain.NormalizePhone(filter.Phone)
+	}
+
+	return filter, nil
+}
+
+// exportEncoder escreve as mensagens no formato da exportação
+type exportEncoder interface {
+	Begin() error
+	Encode(message *domain.Message) error
+	Flush() error
+}
+
+// newExportEncoder cria o encoder do formato
+func newExportEncoder(w io.Writer, format domain.ExportFormat) exportEncoder {
+	if format == domain.ExportJSONL {
+		return &jsonlEncoder{encoder: json.NewEncoder(w)}
+	}
+	return &csvEncoder{writer: csv.NewWriter(w)}
+}
+
+// csvEncoder escreve uma linha por mensagem, com datas em RFC 3339
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Begin() error {
+	return e.writer.Write(exportCSVHeader)
+}
+
+func (e *csvEncoder) Encode(message *domain.Message) error {
+	return e.writer.Write(escapeCSVFormulas([]string{
+		message.ID.String(),
+		message.InstanceID,
+		message.Phone,
+		string(message.Direction),
+		string(message.Type),
+		string(message.Status),
+		message.Content,
+		stringValue(message.MediaURL),
+		stringValue(message.Error),
+		formatExportTime(&message.CreatedAt),
+		formatExportTime(message.SentAt),
+		formatExportTime(message.DeliveredAt),
+		formatExportTime(message.ReadAt),
+		formatExportTime(message.FailedAt),
+	}))
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// escapeCSVFormulas prefixa com apóstrofo as células que planilhas
+// interpretariam como fórmula, já que o conteúdo vem de contatos
+func escapeCSVFormulas(cells []string) []string {
+	for i, cell := range cells {
+		if cell != "" && strings.IndexByte("=+-@\t\r", cell[0]) >= 0 {
+			cells[i] = "'" + cell
+		}
+	}
+	return cells
+}
+
+// jsonlEncoder escreve uma mensagem JSON por linha
+type jsonlEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlEncoder) Begin() error {
+	return nil
+}
+
+func (e *jsonlEncoder) Encode(message *domain.Message) error {
+	return e.encoder.Encode(message)
+}
+
+func (e *jsonlEncoder) Flush() error {
+	return nil
+}
+
+// countingWriter conta os bytes escritos no arquivo
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// formatExportTime formata uma data opcional em RFC 3339 (UTC)
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// stringValue retorna o valor de um texto opcional
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,222 @@
+package application_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const exportPhone = "5511999990000"
+
+// exportMessages cria mensagens do telefone da mais recente para a mais
+// antiga, a ordem em que a busca do repositório as retorna
+func exportMessages(tenantID uuid.UUID, count int) []*domain.Message {
+	start := time.Now().Add(-time.Hour)
+	messages := make([]*domain.Message, count)
+	for i := range messages {
+		messages[count-1-i] = &domain.Message{
+			ID:         uuid.New(),
+			TenantID:   tenantID,
+			InstanceID: "instance-1",
+			Phone:      exportPhone,
+			Direction:  domain.DirectionOutbound,
+			Type:       domain.TextMessage,
+			Status:     domain.StatusDelivered,
+			Content:    "Pedido, confirmado",
+			CreatedAt:  start.Add(time.Duration(i) * time.Second),
+		}
+	}
+	return messages
+}
+
+// pageCursor aponta para a última mensagem da página
+func pageCursor(page []*domain.Message) *domain.Cursor {
+	last := page[len(page)-1]
+	return &domain.Cursor{Time: last.CreatedAt, ID: last.ID}
+}
+
+// inTenant verifica se o contexto está no escopo do tenant
+func inTenant(tenantID uuid.UUID) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		scoped, ok := tenantDomain.FromContext(ctx)
+		return ok && scoped == tenantID
+	})
+}
+
+func TestExportService_JobGeneratesCSV(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	filter := domain.MessageFilter{Phone: exportPhone}
+	messages := exportMessages(tenantID, 5)
+	// Conteúdo que planilhas executariam como fórmula
+	messages[4].Content = "=HYPERLINK(\"http://evil.example\")"
+
+	jobs := new(MockExportJobRepository)
+	messageRepo := new(MockMessageRepository)
+	storage := new(MockFileStorage)
+	recorder := new(MockAuditRecorder)
+
+	jobs.On("Save", ctx, mock.MatchedBy(func(job *domain.ExportJob) bool {
+		return job.TenantID == tenantID && job.Status == domain.ExportPending && job.Filter.Phone == exportPhone
+	})).Return(nil).Once()
+	recorder.On("Record", ctx, mock.MatchedBy(func(record auditDomain.Record) bool {
+		return record.Action == auditDomain.ActionMessageExport && record.Changes["phone"].To == exportPhone
+	})).Once()
+
+	service := application.NewExportService(jobs, messageRepo, storage, recorder, application.ExportSettings{PageSize: 2}, zerolog.Nop())
+
+	job, err := service.CreateJob(ctx, domain.CreateExportRequest{Format: domain.ExportCSV, Phone: "+55 11 99999-0000"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportPending, job.Status)
+	assert.Equal(t, job.ID.String(), recorder.Calls[0].Arguments.Get(1).(auditDomain.Record).ResourceID)
+
+	// Antes da geração o arquivo não está disponível
+	jobs.On("GetByID", ctx, job.ID).Return(job, nil).Once()
+	_, _, err = service.OpenFile(ctx, job.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrConflict))
+
+	// O worker processa sem tenant no contexto, buscando as mensagens no tenant da exportação
+	claimed := *job
+	claimed.Status = domain.ExportRunning
+	var file bytes.Buffer
+	jobs.On("ClaimNext", context.Background(), mock.AnythingOfType("time.Time")).Return(&claimed, nil).Once()
+	jobs.On("ClaimNext", context.Background(), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+	storage.On("Create", job.ID.String()+".csv").Return(closingBuffer{&file}, nil).Once()
+	messageRepo.On("Search", inTenant(tenantID), filter, 2, (*domain.Cursor)(nil)).Return(messages[:2], nil).Once()
+	messageRepo.On("Search", inTenant(tenantID), filter, 2, pageCursor(messages[:2])).Return(messages[2:4], nil).Once()
+	messageRepo.On("Search", inTenant(tenantID), filter, 2, pageCursor(messages[2:4])).Return(messages[4:], nil).Once()
+	jobs.On("Update", mock.Anything, &claimed).Return(nil).Once()
+
+	processed, err := service.ProcessNext(context.Background())
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	processed, err = service.ProcessNext(context.Background())
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	assert.Equal(t, domain.ExportCompleted, claimed.Status)
+	assert.Equal(t, int64(5), claimed.Rows)
+	assert.Equal(t, int64(file.Len()), claimed.SizeBytes)
+	assert.NotNil(t, claimed.ExpiresAt)
+
+	jobs.On("GetByID", ctx, job.ID).Return(&claimed, nil).Once()
+	storage.On("Open", claimed.FileName).Return(io.NopCloser(bytes.NewReader(file.Bytes())), nil).Once()
+
+	completed, reader, err := service.OpenFile(ctx, job.ID)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, domain.ExportCompleted, completed.Status)
+
+	records, err := csv.NewReader(reader).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	assert.Equal(t, "id", records[0][0])
+	assert.Equal(t, "Pedido, confirmado", records[1][6])
+	assert.Equal(t, "'=HYPERLINK(\"http://evil.example\")", records[5][6], "formulas are escaped")
+
+	storage.AssertNotCalled(t, "Remove", mock.Anything)
+	jobs.AssertExpectations(t)
+	messageRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+	recorder.AssertExpectations(t)
+}
+
+func TestExportService_PurgeExpired(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	expiresAt := time.Now().Add(-time.Minute)
+	expired := &domain.ExportJob{ID: uuid.New(), TenantID: tenantID, Status: domain.ExportCompleted, FileName: "expired.jsonl", ExpiresAt: &expiresAt}
+	locked := &domain.ExportJob{ID: uuid.New(), TenantID: tenantID, Status: domain.ExportCompleted, FileName: "locked.jsonl", ExpiresAt: &expiresAt}
+
+	jobs := new(MockExportJobRepository)
+	storage := new(MockFileStorage)
+	service := application.NewExportService(jobs, new(MockMessageRepository), storage, new(MockAuditRecorder), application.ExportSettings{}, zerolog.Nop())
+
+	// O arquivo vencido não é entregue mesmo antes da limpeza
+	jobs.On("GetByID", ctx, expired.ID).Return(expired, nil).Once()
+	_, _, err := service.OpenFile(ctx, expired.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+	storage.AssertNotCalled(t, "Open", mock.Anything)
+
+	ctx = context.Background()
+	jobs.On("ListExpired", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*domain.ExportJob{expired, locked}, nil).Once()
+	storage.On("Remove", "expired.jsonl").Return(nil).Once()
+	// Um arquivo que não pôde ser removido fica para a próxima rodada
+	storage.On("Remove", "locked.jsonl").Return(errors.New("permission denied")).Once()
+	jobs.On("Update", ctx, expired).Return(nil).Once()
+
+	require.NoError(t, service.PurgeExpired(ctx))
+
+	assert.Equal(t, domain.ExportExpired, expired.Status)
+	assert.Empty(t, expired.FileName)
+	assert.Equal(t, domain.ExportCompleted, locked.Status)
+	jobs.AssertNotCalled(t, "Update", ctx, locked)
+
+	jobs.On("GetByID", ctx, expired.ID).Return(expired, nil).Once()
+	_, _, err = service.OpenFile(ctx, expired.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	jobs.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestExportService_Stream(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	messages := exportMessages(tenantID, 3)
+
+	messageRepo := new(MockMessageRepository)
+	recorder := new(MockAuditRecorder)
+	messageRepo.On("Count", ctx, domain.MessageFilter{}).Return(int64(3), nil).Once()
+	messageRepo.On("Search", ctx, domain.MessageFilter{}, 2, (*domain.Cursor)(nil)).Return(messages[:2], nil).Once()
+	messageRepo.On("Search", ctx, domain.MessageFilter{}, 2, pageCursor(messages[:2])).Return(messages[2:], nil).Once()
+	recorder.On("Record", ctx, mock.AnythingOfType("domain.Record")).Once()
+
+	service := application.NewExportService(new(MockExportJobRepository), messageRepo, new(MockFileStorage), recorder,
+		application.ExportSettings{PageSize: 2, StreamLimit: 3}, zerolog.Nop())
+
+	write, err := service.Stream(ctx, domain.CreateExportRequest{Format: domain.ExportJSONL})
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	require.NoError(t, write(&output))
+
+	var lines int
+	scanner := bufio.NewScanner(&output)
+	for scanner.Scan() {
+		var message domain.Message
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &message))
+		lines++
+	}
+	assert.Equal(t, 3, lines)
+
+	// Acima do limite a exportação precisa ser feita em background
+	messageRepo.On("Count", ctx, domain.MessageFilter{Phone: exportPhone}).Return(int64(4), nil).Once()
+	_, err = service.Stream(ctx, domain.CreateExportRequest{Format: domain.ExportCSV, Phone: exportPhone})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	_, err = service.Stream(ctx, domain.CreateExportRequest{Format: "xlsx"})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	messageRepo.AssertExpectations(t)
+	recorder.AssertExpectations(t)
+}
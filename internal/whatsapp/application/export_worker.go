@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ExportWorker gera em background os arquivos das exportações pendentes e
+// remove os arquivos vencidos
+type ExportWorker struct {
+	service      *ExportService
+	pollInterval time.Duration
+	logger       zerolog.Logger
+	cancel       context.CancelFunc
+	done         chan struct{}
+}
+
+// NewExportWorker cria um novo worker de exportação
+func NewExportWorker(service *ExportService, pollInterval time.Duration, logger zerolog.Logger) *ExportWorker {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	return &ExportWorker{
+		service:      service,
+		pollInterval: pollInterval,
+		logger:       logger.With().Str("component", "export_worker").Logger(),
+	}
+}
+
+// Start inicia o worker em background
+func (w *ExportWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.run(ctx)
+
+	w.logger.Info().Dur("poll_interval", w.pollInterval).Msg("Export worker started")
+}
+
+// Stop interrompe o worker; a exportação em andamento volta para a fila
+func (w *ExportWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		w.logger.Info().Msg("Export worker stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run processa a fila a cada intervalo até o contexto ser cancelado
+func (w *ExportWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.processPending(ctx)
+			if err := w.service.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+				w.logger.Error().Err(err).Msg("Failed to remove expired exports")
+			}
+		}
+	}
+}
+
+// processPending gera as exportações pendentes até esvaziar a fila
+func (w *ExportWorker) processPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.service.ProcessNext(ctx)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("Failed to process export job")
+			return
+		}
+		if !processed {
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure/providers"
+)
+
+// newConfigService cria o serviço com o registro de providers contendo a Z-API
+func newConfigService(t *testing.T, instanceRepo *MockInstanceRepository, recorder *MockAuditRecorder) *application.WhatsAppService {
+	registry := infrastructure.NewDefaultProviderRegistry(zerolog.Nop())
+	require.NoError(t, registry.Register(providers.NewZAPIProvider(zerolog.Nop())))
+	return application.NewWhatsAppService(registry, nil, nil, nil, instanceRepo, nil, nil, nil, nil, recorder, nil, zerolog.Nop())
+}
+
+func TestMergeInstanceConfig(t *testing.T) {
+	current := map[string]any{"base_url": "https://api.z-api.io", "timeout": "10s"}
+
+	merged := domain.MergeInstanceConfig(current, domain.UpdateInstanceConfigRequest{
+		"timeout":      nil,
+		"client_token": "secret",
+		"base_url":     "https://proxy.example.com",
+		"missing":      nil,
+	})
+
+	assert.Equal(t, map[string]any{"base_url": "https://proxy.example.com", "client_token": "secret"}, merged)
+	// A configuração atual não é alterada
+	assert.Equal(t, map[string]any{"base_url": "https://api.z-api.io", "timeout": "10s"}, current)
+
+	assert.Equal(t, map[string]any{"timeout": "5s"}, domain.MergeInstanceConfig(nil, domain.UpdateInstanceConfigRequest{"timeout": "5s"}))
+	assert.Empty(t, domain.MergeInstanceConfig(map[string]any{"timeout": "5s"}, domain.UpdateInstanceConfigRequest{"timeout": nil}))
+}
+
+func TestWhatsAppService_UpdateInstanceConfig(t *testing.T) {
+	ctx := context.Background()
+	instance := &domain.Instance{
+		ID:       uuid.New(),
+		Name:     "vendas",
+		Provider: "z-api",
+		Config:   map[string]any{"base_url": "https://api.z-api.io", "timeout": "10s"},
+	}
+	want := map[string]any{"base_url": "https://api.z-api.io", "client_token": "secret"}
+
+	instanceRepo := new(MockInstanceRepository)
+	recorder := new(MockAuditRecorder)
+	instanceRepo.On("GetByID", ctx, instance.ID).Return(instance, nil)
+	instanceRepo.On("Update", ctx, mock.MatchedBy(func(updated *domain.Instance) bool {
+		return assert.ObjectsAreEqual(want, updated.Config)
+	})).Return(nil).Once()
+	recorder.On("Record", ctx, mock.MatchedBy(func(record auditDomain.Record) bool {
+		return record.Action == auditDomain.ActionInstanceUpdate && record.ResourceID == instance.ID.String()
+	})).Once()
+
+	service := newConfigService(t, instanceRepo, recorder)
+
+	updated, err := service.UpdateInstanceConfig(ctx, instance.ID, domain.UpdateInstanceConfigRequest{"timeout": nil, "client_token": "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, want, updated.Config)
+
+	instanceRepo.AssertExpectations(t)
+	recorder.AssertExpectations(t)
+}
+
+func TestWhatsAppService_UpdateInstanceConfigValidation(t *testing.T) {
+	ctx := context.Background()
+	instance := &domain.Instance{ID: uuid.New(), Provider: "z-api", Config: map[string]any{"timeout": "10s"}}
+
+	tests := []struct {
+		name    string
+		patch   domain.UpdateInstanceConfigRequest
+		message string
+	}{
+		{name: "unknown key", patch: domain.UpdateInstanceConfigRequest{"webhook": "https://example.com"}, message: `unknown key "webhook"`},
+		{name: "wrong type", patch: domain.UpdateInstanceConfigRequest{"base_url": 10.0}, message: `key "base_url": expected string`},
+		{name: "invalid duration", patch: domain.UpdateInstanceConfigRequest{"timeout": "soon"}, message: `key "timeout": expected duration`},
+		{name: "negative duration", patch: domain.UpdateInstanceConfigRequest{"timeout": -1.0}, message: `key "timeout": duration must not be negative`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instanceRepo := new(MockInstanceRepository)
+			instanceRepo.On("GetByID", ctx, instance.ID).Return(instance, nil)
+
+			_, err := newConfigService(t, instanceRepo, new(MockAuditRecorder)).UpdateInstanceConfig(ctx, instance.ID, tt.patch)
+			require.ErrorIs(t, err, apperrors.ErrBadRequest)
+			assert.Contains(t, err.Error(), tt.message)
+
+			instanceRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			assert.Equal(t, map[string]any{"timeout": "10s"}, instance.Config)
+		})
+	}
+}
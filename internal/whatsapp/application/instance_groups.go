@@ -0,0 +1,199 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// CreateInstanceGroup cria um novo grupo de instâncias
+func (s *WhatsAppService) CreateInstanceGroup(ctx context.Context, request domain.InstanceGroupRequest) (*domain.InstanceGroup, error) {
+	if err := s.validateGroupMembers(ctx, request.Members); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	group := &domain.InstanceGroup{
+		ID:        uuid.New(),
+		TenantID:  contextTenant(ctx),
+		Name:      request.Name,
+		Policy:    request.Policy,
+		Members:   request.Members,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.groupRepo.Save(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to save instance group: %w", err)
+	}
+
+	s.logger.Info().
+		Str("group_id", group.ID.String()).
+		Str("policy", string(group.Policy)).
+		Int("members", len(group.Members)).
+		Msg("Instance group created")
+
+	return group, nil
+}
+
+// GetInstanceGroup obtém um grupo de instâncias por ID
+func (s *WhatsAppService) GetInstanceGroup(ctx context.Context, id uuid.UUID) (*domain.InstanceGroup, error) {
+	group, err := s.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("instance group")
+	}
+	return group, nil
+}
+
+// GetAllInstanceGroups obtém todos os grupos de instâncias
+func (s *WhatsAppService) GetAllInstanceGroups(ctx context.Context) ([]*domain.InstanceGroup, error) {
+	return s.groupRepo.GetAll(ctx)
+}
+
+// UpdateInstanceGroup substitui o nome, a política e os membros de um grupo
+func (s *WhatsAppService) UpdateInstanceGroup(ctx context.Context, id uuid.UUID, request domain.InstanceGroupRequest) (*domain.InstanceGroup, error) {
+	group, err := s.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("instance group")
+	}
+
+	if err := s.validateGroupMembers(ctx, request.Members); err != nil {
+		return nil, err
+	}
+
+	group.Name = request.Name
+	group.Policy = request.Policy
+	group.Members = request.Members
+	group.UpdatedAt = time.Now()
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to update instance group: %w", err)
+	}
+
+	return group, nil
+}
+
+// DeleteInstanceGroup remove um grupo de instâncias
+func (s *WhatsAppService) DeleteInstanceGroup(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.groupRepo.GetByID(ctx, id); err != nil {
+		return apperrors.NewNotFoundError("instance group")
+	}
+
+	return s.groupRepo.Delete(ctx, id)
+}
+
+// validateGroupMembers garante que os membros existem e não se repetem
+func (s *WhatsAppService) validateGroupMembers(ctx context.Context, members []domain.InstanceGroupMember) error {
+	seen := make(map[uuid.UUID]bool, len(members))
+	for _, member := range members {
+		if seen[member.InstanceID] {
+			return apperrors.NewValidationError(fmt.Sprintf("instance %s is listed more than once", member.InstanceID))
+		}
+		seen[member.InstanceID] = true
+
+		if _, err := s.instanceRepo.GetByID(ctx, member.InstanceID); err != nil {
+			return apperrors.NewValidationError(fmt.Sprintf("instance %s not found", member.InstanceID))
+		}
+	}
+	return nil
+}
+
+// sendThroughGroup envia a mensagem pela instância escolhida pela política do
+// grupo, tentando as próximas instâncias saudáveis quando o envio falha. O
+// envio tem uma única mensagem, transferida para a instância de cada tentativa,
+// e só é registrado como falha quando todas as instâncias falham
+func (s *WhatsAppService) sendThroughGroup(ctx context.Context, request domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	groupID, err := uuid.Parse(request.GroupID)
+	if err != nil {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("invalid group ID: %v", err))
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("instance group")
+	}
+
+	instances := make(map[uuid.UUID]*domain.Instance, len(group.Members))
+	for _, member := range group.Members {
+		instance, err := s.instanceRepo.GetByID(ctx, member.InstanceID)
+		if err != nil {
+			s.logger.Warn().Err(err).
+				Str("group_id", group.ID.String()).
+				Str("instance_id", member.InstanceID.String()).
+				Msg("Group member not found, skipping")
+			continue
+		}
+		instances[instance.ID] = instance
+	}
+
+	// O opt-out de qualquer membro bloqueia o grupo, senão o failover o contornaria
+	if !request.Transactional {
+		memberIDs := make([]uuid.UUID, 0, len(group.Members))
+		for _, member := range group.Members {
+			memberIDs = append(memberIDs, member.InstanceID)
+		}
+		if err := s.optOuts.Check(ctx, group.TenantID, request.Phone, memberIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := s.router.Candidates(group, instances, request.Phone)
+	if len(candidates) == 0 {
+		return nil, apperrors.NewUnavailableError("NO_HEALTHY_INSTANCE",
+			fmt.Sprintf("no connected instance available in group %s", group.Name))
+	}
+
+	var message *domain.Message
+	var attempted *domain.Instance
+	var lastResponse *domain.SendMessageResponse
+	var lastErr error
+	for attempt, instance := range candidates {
+		var response *domain.SendMessageResponse
+		provider, err := s.resolveSender(instance, request.Type)
+		if err == nil {
+			if message == nil {
+				if message, err = s.queueMessage(ctx, instance, request); err != nil {
+					return nil, err
+				}
+			} else if err = s.reassignMessage(ctx, message, instance); err != nil {
+				return s.completeSend(ctx, attempted, message, nil, err)
+			}
+
+			attempted = instance
+			response, err = s.deliver(ctx, provider, instance, request)
+			if err == nil && response.Status != domain.StatusFailed {
+				return s.completeSend(ctx, instance, message, response, nil)
+			}
+		}
+
+		lastResponse, lastErr = response, err
+		s.logger.Warn().
+			Err(err).
+			Str("group_id", group.ID.String()).
+			Str("instance_id", instance.ID.String()).
+			Int("attempt", attempt+1).
+			Int("candidates", len(candidates)).
+			Msg("Send through group member failed, trying next instance")
+	}
+
+	// Nenhuma instância chegou a receber a mensagem
+	if message == nil {
+		return nil, lastErr
+	}
+	return s.completeSend(ctx, attempted, message, lastResponse, lastErr)
+}
+
+// reassignMessage transfere a mensagem para a instância da próxima tentativa
+func (s *WhatsAppService) reassignMessage(ctx context.Context, message *domain.Message, instance *domain.Instance) error {
+	if err := s.messageRepo.Reassign(ctx, message.ID, instance.ID.String(), instance.Provider); err != nil {
+		return fmt.Errorf("failed to update message: %w", err)
+	}
+	message.InstanceID = instance.ID.String()
+	message.Provider = instance.Provider
+	return nil
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// InstanceRouter escolhe, de acordo com a política do grupo, a ordem em que as
+// instâncias saudáveis serão tentadas no envio de uma mensagem
+type InstanceRouter struct {
+	counters map[uuid.UUID]uint64
+	mu       sync.Mutex
+}
+
+// NewInstanceRouter cria um novo roteador de instâncias
+func NewInstanceRouter() *InstanceRouter {
+	return &InstanceRouter{
+		counters: make(map[uuid.UUID]uint64),
+	}
+}
+
+// Candidates retorna as instâncias saudáveis do grupo na ordem de tentativa.
+// A primeira é a escolhida pela política; as demais servem de failover
+func (r *InstanceRouter) Candidates(group *domain.InstanceGroup, instances map[uuid.UUID]*domain.Instance, phone string) []*domain.Instance {
+	members := make([]domain.InstanceGroupMember, len(group.Members))
+	copy(members, group.Members)
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Priority < members[j].Priority
+	})
+
+	healthy := make([]*domain.Instance, 0, len(members))
+	for _, member := range members {
+		instance, ok := instances[member.InstanceID]
+		if ok && isHealthy(instance) {
+			healthy = append(healthy, instance)
+		}
+	}
+
+	if len(healthy) <= 1 {
+		return healthy
+	}
+
+	switch group.Policy {
+	case domain.RoutingRoundRobin:
+		offset := int(r.next(group.ID) % uint64(len(healthy)))
+		return append(healthy[offset:], healthy[:offset]...)
+	case domain.RoutingSticky:
+		return orderByRendezvous(healthy, phone)
+	default:
+		return healthy
+	}
+}
+
+// next incrementa e retorna o contador de rodízio do grupo
+func (r *InstanceRouter) next(groupID uuid.UUID) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.counters[groupID]
+	r.counters[groupID] = current + 1
+	return current
+}
+
+// isHealthy indica se a instância pode receber envios
+func isHealthy(instance *domain.Instance) bool {
+	return instance.Status == domain.InstanceConnected
+}
+
+// orderByRendezvous ordena as instâncias por rendezvous hashing do destinatário:
+// o mesmo telefone sempre prefere a mesma instância, e quando ela fica
+// indisponível apenas os destinatários dela são redistribuídos
+func orderByRendezvous(instances []*domain.Instance, phone string) []*domain.Instance {
+	key := domain.NormalizePhone(phone)
+	scores := make(map[uuid.UUID]uint64, len(instances))
+	for _, instance := range instances {
+		hash := fnv.New64a()
+		hash.Write([]byte(key))
+		hash.Write(instance.ID[:])
+		scores[instance.ID] = hash.Sum64()
+	}
+
+	ordered := make([]*domain.Instance, len(instances))
+	copy(ordered, instances)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return scores[ordered[i].ID] > scores[ordered[j].ID]
+	})
+	return ordered
+}
@@ -0,0 +1,205 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+func newTestGroup(policy domain.RoutingPolicy, statuses ...domain.InstanceStatus) (*domain.InstanceGroup, map[uuid.UUID]*domain.Instance, []*domain.Instance) {
+	group := &domain.InstanceGroup{ID: uuid.New(), Policy: policy}
+	instances := make(map[uuid.UUID]*domain.Instance)
+	ordered := make([]*domain.Instance, 0, len(statuses))
+
+	for i, status := range statuses {
+		instance := &domain.Instance{ID: uuid.New(), Status: status}
+		instances[instance.ID] = instance
+		ordered = append(ordered, instance)
+		group.Members = append(group.Members, domain.InstanceGroupMember{InstanceID: instance.ID, Priority: i})
+	}
+
+	return group, instances, ordered
+}
+
+func TestInstanceRouter_PriorityFailover(t *testing.T) {
+	router := application.NewInstanceRouter()
+	group, instances, ordered := newTestGroup(domain.RoutingPriority,
+		domain.InstanceDisconnected, domain.InstanceConnected, domain.InstanceConnected)
+
+	candidates := router.Candidates(group, instances, "5511999999999")
+
+	assert.Len(t, candidates, 2)
+	assert.Equal(t, ordered[1].ID, candidates[0].ID)
+	assert.Equal(t, ordered[2].ID, candidates[1].ID)
+}
+
+func TestInstanceRouter_RoundRobin(t *testing.T) {
+	router := application.NewInstanceRouter()
+	group, instances, ordered := newTestGroup(domain.RoutingRoundRobin,
+		domain.InstanceConnected, domain.InstanceConnected, domain.InstanceConnected)
+
+	var first []uuid.UUID
+	for i := 0; i < 3; i++ {
+		first = append(first, router.Candidates(group, instances, "5511999999999")[0].ID)
+	}
+
+	assert.ElementsMatch(t, []uuid.UUID{ordered[0].ID, ordered[1].ID, ordered[2].ID}, first)
+}
+
+func TestInstanceRouter_StickyPerRecipient(t *testing.T) {
+	router := application.NewInstanceRouter()
+	group, instances, _ := newTestGroup(domain.RoutingSticky,
+		domain.InstanceConnected, domain.InstanceConnected, domain.InstanceConnected)
+
+	chosen := router.Candidates(group, instances, "+55 (11) 99999-9999")[0]
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, chosen.ID, router.Candidates(group, instances, "5511999999999")[0].ID)
+	}
+
+	// Quando a instância escolhida cai, o destinatário vai para outra e volta depois
+	chosen.Status = domain.InstanceDisconnected
+	fallback := router.Candidates(group, instances, "5511999999999")[0]
+	assert.NotEqual(t, chosen.ID, fallback.ID)
+
+	chosen.Status = domain.InstanceConnected
+	assert.Equal(t, chosen.ID, router.Candidates(group, instances, "5511999999999")[0].ID)
+}
+
+func TestInstanceRouter_NoHealthyInstance(t *testing.T) {
+	router := application.NewInstanceRouter()
+	group, instances, _ := newTestGroup(domain.RoutingPriority,
+		domain.InstanceDisconnected, domain.InstanceError)
+
+	assert.Empty(t, router.Candidates(group, instances, "5511999999999"))
+}
+
+// groupSendFixture monta um grupo por prioridade com duas instâncias conectadas
+// e um serviço que guarda os eventos publicados
+type groupSendFixture struct {
+	ordered     []*domain.Instance
+	provider    *MockFeatureProvider
+	messageRepo *MockMessageRepository
+	recorder    *MockAuditRecorder
+	published   []events.Event
+	service     *application.WhatsAppService
+	request     domain.SendMessageRequest
+}
+
+func newGroupSendFixture(ctx context.Context) *groupSendFixture {
+	group, instances, ordered := newTestGroup(domain.RoutingPriority, domain.InstanceConnected, domain.InstanceConnected)
+	f := &groupSendFixture{
+		ordered:     ordered,
+		provider:    new(MockFeatureProvider),
+		messageRepo: new(MockMessageRepository),
+		recorder:    new(MockAuditRecorder),
+		request: domain.SendMessageRequest{
+			GroupID:       group.ID.String(),
+			Phone:         "5511999990000",
+			Type:          domain.TextMessage,
+			Content:       "Pedido confirmado",
+			Transactional: true,
+		},
+	}
+
+	groupRepo := new(MockInstanceGroupRepository)
+	groupRepo.On("GetByID", ctx, group.ID).Return(group, nil)
+	instanceRepo := new(MockInstanceRepository)
+	resolver := new(MockProviderResolver)
+	for _, instance := range ordered {
+		instance.Provider = "z-api"
+		instanceRepo.On("GetByID", ctx, instance.ID).Return(instances[instance.ID], nil)
+		resolver.On("Resolve", instance).Return(f.provider, nil)
+	}
+	f.provider.On("GetName").Return("z-api").Maybe()
+	f.provider.On("GetSupportedFeatures").Return([]domain.ProviderFeature{domain.FeatureTextMessages}).Maybe()
+
+	publisher := new(MockEventPublisher)
+	publisher.On("Publish", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		f.published = append(f.published, args.Get(1).(events.Event))
+	})
+
+	f.service = application.NewWhatsAppService(nil, resolver, f.messageRepo, nil, instanceRepo, groupRepo, nil, nil, publisher, f.recorder, nil, zerolog.Nop())
+	return f
+}
+
+func TestWhatsAppService_GroupSendKeepsOneMessageAcrossFailover(t *testing.T) {
+	ctx := context.Background()
+	f := newGroupSendFixture(ctx)
+	first, second := f.ordered[0], f.ordered[1]
+	providerID := "3EB0C767D26A"
+
+	var saved *domain.Message
+	f.messageRepo.On("Save", ctx, mock.AnythingOfType("*domain.Message")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*domain.Message)
+	}).Return(nil).Once()
+	f.provider.On("SendMessage", ctx, first, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+	f.messageRepo.On("Reassign", ctx, mock.Anything, second.ID.String(), "z-api").Return(nil).Once()
+	f.provider.On("SendMessage", ctx, second, mock.Anything).Return(&domain.SendMessageResponse{Status: domain.StatusSent, ProviderID: &providerID}, nil).Once()
+	f.messageRepo.On("UpdateStatus", ctx, mock.Anything, domain.StatusSent, &providerID, (*string)(nil)).Return(nil).Once()
+	f.recorder.On("Record", ctx, mock.MatchedBy(func(record auditDomain.Record) bool {
+		return record.Changes["instance_id"].To == second.ID.String() && record.Changes["status"].To == domain.StatusSent
+	})).Once()
+
+	response, err := f.service.SendMessage(ctx, f.request)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, saved.ID, response.ID)
+	assert.Equal(t, second.ID.String(), response.InstanceID)
+	f.messageRepo.AssertCalled(t, "Reassign", ctx, saved.ID, second.ID.String(), "z-api")
+
+	// A tentativa que falhou não gera evento de falha
+	require.Len(t, f.published, 2)
+	assert.Equal(t, domain.EventMessageQueued, f.published[0].GetName())
+	sent, ok := f.published[1].(*domain.MessageSentEvent)
+	require.True(t, ok, "expected message.sent, got %s", f.published[1].GetName())
+	assert.Equal(t, second.ID, sent.InstanceID)
+	assert.Equal(t, saved.ID, sent.MessageID)
+
+	f.provider.AssertExpectations(t)
+	f.messageRepo.AssertExpectations(t)
+	f.recorder.AssertExpectations(t)
+	f.messageRepo.AssertNotCalled(t, "UpdateStatus", ctx, mock.Anything, domain.StatusFailed, mock.Anything, mock.Anything)
+}
+
+func TestWhatsAppService_GroupSendFailsOnlyWhenEveryMemberFails(t *testing.T) {
+	ctx := context.Background()
+	f := newGroupSendFixture(ctx)
+	first, second := f.ordered[0], f.ordered[1]
+	rejected := "invalid phone"
+
+	f.messageRepo.On("Save", ctx, mock.AnythingOfType("*domain.Message")).Return(nil).Once()
+	f.provider.On("SendMessage", ctx, first, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+	f.messageRepo.On("Reassign", ctx, mock.Anything, second.ID.String(), "z-api").Return(nil).Once()
+	f.provider.On("SendMessage", ctx, second, mock.Anything).Return(&domain.SendMessageResponse{Status: domain.StatusFailed, Error: &rejected}, nil).Once()
+	f.messageRepo.On("UpdateStatus", ctx, mock.Anything, domain.StatusFailed, (*string)(nil), &rejected).Return(nil).Once()
+	f.recorder.On("Record", ctx, mock.MatchedBy(func(record auditDomain.Record) bool {
+		return record.Changes["instance_id"].To == second.ID.String() && record.Changes["status"].To == domain.StatusFailed
+	})).Once()
+
+	response, err := f.service.SendMessage(ctx, f.request)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, response.Status)
+
+	// Um único evento de falha, com a instância da última tentativa
+	require.Len(t, f.published, 2)
+	assert.Equal(t, domain.EventMessageQueued, f.published[0].GetName())
+	failed, ok := f.published[1].(*domain.MessageFailedEvent)
+	require.True(t, ok, "expected message.failed, got %s", f.published[1].GetName())
+	assert.Equal(t, second.ID, failed.InstanceID)
+	assert.Equal(t, rejected, failed.Error)
+
+	f.provider.AssertExpectations(t)
+	f.messageRepo.AssertExpectations(t)
+	f.recorder.AssertExpectations(t)
+}
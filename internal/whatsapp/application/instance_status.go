@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// RefreshInstanceStatus consulta o status da instância no provider, persiste as
+// mudanças e publica EventInstanceStatusChanged quando o status muda
+func (s *WhatsAppService) RefreshInstanceStatus(ctx context.Context, instance *domain.Instance) (*domain.InstanceInfo, error) {
+	provider, err := s.providerResolver.Resolve(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := provider.GetInstanceStatus(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, downtime := instance.ApplyStatus(info, time.Now())
+	if err := s.instanceRepo.UpdateStatus(ctx, instance); err != nil {
+		return nil, fmt.Errorf("failed to update instance status: %w", err)
+	}
+
+	if previous != instance.Status {
+		event := s.logger.Info().
+			Str("instance_id", instance.ID.String()).
+			Str("previous_status", string(previous)).
+			Str("status", string(instance.Status))
+		if downtime > 0 {
+			event = event.Dur("downtime", downtime)
+		}
+		event.Msg("Instance status changed")
+
+		s.publisher.Publish(ctx, domain.NewInstanceStatusChangedEvent(instance, previous, downtime.Seconds()))
+	}
+
+	info.ID = instance.ID
+	info.Name = instance.Name
+	return info, nil
+}
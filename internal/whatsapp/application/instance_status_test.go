@@ -0,0 +1,176 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// newStatusService cria o serviço com as dependências da verificação de status
+func newStatusService(instanceRepo *MockInstanceRepository, resolver *MockProviderResolver, publisher *MockEventPublisher) *application.WhatsAppService {
+	return application.NewWhatsAppService(nil, resolver, nil, nil, instanceRepo, nil, nil, nil, publisher, nil, nil, zerolog.Nop())
+}
+
+// statusChanged verifica o evento de mudança de status publicado
+func statusChanged(previous, status domain.InstanceStatus, downtimeSeconds float64) interface{} {
+	return mock.MatchedBy(func(event *domain.InstanceStatusChangedEvent) bool {
+		return event.PreviousStatus == previous && event.Status == status && event.DowntimeSeconds == downtimeSeconds
+	})
+}
+
+func TestInstance_ApplyStatus(t *testing.T) {
+	checkedAt := time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC)
+	disconnectedAt := checkedAt.Add(-10 * time.Minute)
+	phone := "5511999999999"
+
+	tests := []struct {
+		name               string
+		status             domain.InstanceStatus
+		disconnectedAt     *time.Time
+		info               domain.InstanceInfo
+		wantDisconnectedAt *time.Time
+		wantDowntime       time.Duration
+	}{
+		{
+			name:               "disconnects",
+			status:             domain.InstanceConnected,
+			info:               domain.InstanceInfo{Status: domain.InstanceDisconnected},
+			wantDisconnectedAt: &checkedAt,
+		},
+		{
+			name:               "error while connected counts as disconnection",
+			status:             domain.InstanceConnected,
+			info:               domain.InstanceInfo{Status: domain.InstanceError},
+			wantDisconnectedAt: &checkedAt,
+		},
+		{
+			name:               "keeps the start of the disconnection",
+			status:             domain.InstanceDisconnected,
+			disconnectedAt:     &disconnectedAt,
+			info:               domain.InstanceInfo{Status: domain.InstanceConnecting},
+			wantDisconnectedAt: &disconnectedAt,
+		},
+		{
+			name:           "reconnects",
+			status:         domain.InstanceConnecting,
+			disconnectedAt: &disconnectedAt,
+			info:           domain.InstanceInfo{Status: domain.InstanceConnected, Phone: &phone},
+			wantDowntime:   10 * time.Minute,
+		},
+		{
+			name:   "never connected",
+			status: domain.InstanceConnecting,
+			info:   domain.InstanceInfo{Status: domain.InstanceDisconnected},
+		},
+		{
+			name:   "stays connected",
+			status: domain.InstanceConnected,
+			info:   domain.InstanceInfo{Status: domain.InstanceConnected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &domain.Instance{Status: tt.status, DisconnectedAt: tt.disconnectedAt}
+
+			previous, downtime := instance.ApplyStatus(&tt.info, checkedAt)
+
+			assert.Equal(t, tt.status, previous)
+			assert.Equal(t, tt.info.Status, instance.Status)
+			assert.Equal(t, tt.wantDisconnectedAt, instance.DisconnectedAt)
+			assert.Equal(t, tt.wantDowntime, downtime)
+			assert.Equal(t, &checkedAt, instance.StatusCheckedAt)
+			if tt.info.Phone != nil {
+				assert.Equal(t, tt.info.Phone, instance.Phone)
+			}
+		})
+	}
+}
+
+func TestWhatsAppService_RefreshInstanceStatusPublishesChanges(t *testing.T) {
+	ctx := context.Background()
+	instance := &domain.Instance{ID: uuid.New(), TenantID: uuid.New(), Name: "vendas", Provider: "z-api", Status: domain.InstanceConnected}
+	failure := "session closed"
+
+	provider := new(MockProvider)
+	resolver := new(MockProviderResolver)
+	instanceRepo := new(MockInstanceRepository)
+	publisher := new(MockEventPublisher)
+	resolver.On("Resolve", instance).Return(provider, nil)
+	instanceRepo.On("UpdateStatus", ctx, instance).Return(nil)
+	provider.On("GetInstanceStatus", ctx, instance).Return(&domain.InstanceInfo{Status: domain.InstanceDisconnected, Error: &failure}, nil).Once()
+	provider.On("GetInstanceStatus", ctx, instance).Return(&domain.InstanceInfo{Status: domain.InstanceDisconnected, Error: &failure}, nil).Once()
+	publisher.On("Publish", ctx, statusChanged(domain.InstanceConnected, domain.InstanceDisconnected, 0)).Once()
+
+	service := newStatusService(instanceRepo, resolver, publisher)
+
+	info, err := service.RefreshInstanceStatus(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, instance.ID, info.ID)
+	assert.Equal(t, "vendas", info.Name)
+	assert.Equal(t, &failure, instance.Error)
+	require.NotNil(t, instance.DisconnectedAt)
+
+	// O status é persistido em toda verificação, mas só as mudanças geram eventos
+	_, err = service.RefreshInstanceStatus(ctx, instance)
+	require.NoError(t, err)
+
+	// Ao reconectar, o evento informa quanto tempo a instância ficou desconectada
+	disconnectedAt := time.Now().Add(-time.Hour)
+	instance.DisconnectedAt = &disconnectedAt
+	provider.On("GetInstanceStatus", ctx, instance).Return(&domain.InstanceInfo{Status: domain.InstanceConnected}, nil).Once()
+	publisher.On("Publish", ctx, mock.MatchedBy(func(event *domain.InstanceStatusChangedEvent) bool {
+		return event.Status == domain.InstanceConnected && event.DowntimeSeconds >= time.Hour.Seconds() &&
+			event.TenantID == instance.TenantID && event.InstanceID == instance.ID
+	})).Once()
+
+	_, err = service.RefreshInstanceStatus(ctx, instance)
+	require.NoError(t, err)
+	assert.Nil(t, instance.DisconnectedAt)
+	assert.Nil(t, instance.Error)
+
+	instanceRepo.AssertNumberOfCalls(t, "UpdateStatus", 3)
+	publisher.AssertNumberOfCalls(t, "Publish", 2)
+	provider.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestStatusMonitor_CheckAllKeepsStatusOnProviderFailure(t *testing.T) {
+	ctx := context.Background()
+	unreachable := &domain.Instance{ID: uuid.New(), Provider: "z-api", Status: domain.InstanceConnected}
+	connected := &domain.Instance{ID: uuid.New(), Provider: "z-api", Status: domain.InstanceConnecting}
+
+	provider := new(MockProvider)
+	resolver := new(MockProviderResolver)
+	instanceRepo := new(MockInstanceRepository)
+	publisher := new(MockEventPublisher)
+	instanceRepo.On("GetAll", ctx).Return([]*domain.Instance{unreachable, connected}, nil).Once()
+	resolver.On("Resolve", mock.AnythingOfType("*domain.Instance")).Return(provider, nil)
+	provider.On("GetInstanceStatus", mock.Anything, unreachable).Return(nil, errors.New("timeout")).Once()
+	provider.On("GetInstanceStatus", mock.Anything, connected).Return(&domain.InstanceInfo{Status: domain.InstanceConnected}, nil).Once()
+	instanceRepo.On("UpdateStatus", mock.Anything, connected).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, statusChanged(domain.InstanceConnecting, domain.InstanceConnected, 0)).Once()
+
+	monitor := application.NewStatusMonitor(newStatusService(instanceRepo, resolver, publisher), application.StatusMonitorSettings{}, zerolog.Nop())
+	monitor.CheckAll(ctx)
+
+	// Uma falha ao consultar o provider não marca a instância como desconectada
+	assert.Equal(t, domain.InstanceConnected, unreachable.Status)
+	assert.Nil(t, unreachable.DisconnectedAt)
+	instanceRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, unreachable)
+	assert.Equal(t, domain.InstanceConnected, connected.Status)
+
+	instanceRepo.AssertExpectations(t)
+	provider.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
@@ -0,0 +1,398 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const (
+	// sniffLength é a quantidade de bytes lida para identificar o tipo do conteúdo
+	sniffLength = 512
+	// orphanGrace protege as mídias recém-baixadas, ainda não associadas à mensagem
+	orphanGrace = 5 * time.Minute
+	// orphanBatch é a quantidade de mídias sem mensagem removidas por vez
+	orphanBatch = 100
+)
+
+// MediaSettings configura os limites da mídia e como ela chega aos provedores
+type MediaSettings struct {
+	Limits domain.MediaLimits
+	// PublicURL é a URL pública da API; com SigningKey, os provedores baixam a
+	// mídia por uma URL assinada. Sem elas, a mídia é enviada em base64
+	PublicURL  string
+	SigningKey []byte
+	URLTTL     time.Duration // validade da URL assinada
+}
+
+// MediaService guarda os arquivos enviados para a API e os entrega aos
+// provedores no envio de mensagens
+type MediaService struct {
+	media    domain.MediaRepository
+	storage  domain.MediaStorage
+	settings MediaSettings
+	logger   zerolog.Logger
+}
+
+// NewMediaService cria um novo serviço de mídia
+func NewMediaService(media domain.MediaRepository, storage domain.MediaStorage, settings MediaSettings, logger zerolog.Logger) *MediaService {
+	if settings.URLTTL <= 0 {
+		settings.URLTTL = 15 * time.Minute
+	}
+	settings.PublicURL = strings.TrimRight(settings.PublicURL, "/")
+
+	return &MediaService{
+		media:    media,
+		storage:  storage,
+		settings: settings,
+		logger:   logger.With().Str("service", "media").Logger(),
+	}
+}
+
+// Upload valida e guarda um arquivo. O tipo MIME informado é conferido com o
+// conteúdo quando ele pode ser identificado, e o tamanho é limitado pelo tipo
+// de mensagem
+func (s *MediaService) Upload(ctx context.Context, upload domain.MediaUpload) (*domain.Media, error) {
+	if !upload.Type.IsMedia() {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("message type %s does not carry media", upload.Type))
+	}
+
+	head, err := readHead(upload.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := mediaFileName(upload.FileName, "file")
+	mimeType, err := mediaMimeType(upload.Type, upload.MimeType, fileName, head)
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+	if err := s.settings.Limits.Validate(upload.Type, mimeType, 0); err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+
+	return s.save(ctx, upload.Type, withExtension(fileName, mimeType), mimeType, nil, head, upload.Content)
+}
+
+// StoreInbound guarda a mídia de uma mensagem recebida. Ao contrário do
+// Upload, aceita formatos que não enviamos (ex: figurinhas em WebP); os tipos
+// fora da lista de recebimento são guardados como application/octet-stream. O
+// tamanho continua limitado pelo tipo
+func (s *MediaService) StoreInbound(ctx context.Context, messageID uuid.UUID, upload domain.MediaUpload) (*domain.Media, error) {
+	head, err := readHead(upload.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := mediaFileName(upload.FileName, string(upload.Type))
+	mimeType := parseMimeType(upload.MimeType)
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType = parseMimeType(http.DetectContentType(head))
+	}
+	mimeType = domain.InboundMimeType(mimeType)
+
+	return s.save(ctx, upload.Type, withExtension(fileName, mimeType), mimeType, &messageID, head, upload.Content)
+}
+
+// GetMedia obtém os metadados de uma mídia do tenant
+func (s *MediaService) GetMedia(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	media, err := s.media.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("media")
+	}
+	return media, nil
+}
+
+// OpenMedia abre o conteúdo de uma mídia do tenant
+func (s *MediaService) OpenMedia(ctx context.Context, id uuid.UUID) (*domain.Media, io.ReadCloser, error) {
+	media, err := s.GetMedia(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.storage.Open(media.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return media, file, nil
+}
+
+// OpenSigned abre o conteúdo de uma mídia pela URL assinada, usada pelos
+// provedores para baixar o arquivo sem credenciais
+func (s *MediaService) OpenSigned(ctx context.Context, id uuid.UUID, expires, signature string) (*domain.Media, io.ReadCloser, error) {
+	if !s.validSignature(id, expires, signature, time.Now()) {
+		return nil, nil, apperrors.NewForbiddenError("INVALID_SIGNATURE", "Invalid or expired media signature")
+	}
+	return s.OpenMedia(ctx, id)
+}
+
+// DeleteMedia remove uma mídia. Mensagens já enviadas não são afetadas
+func (s *MediaService) DeleteMedia(ctx context.Context, id uuid.UUID) error {
+	media, err := s.GetMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.media.Delete(ctx, id); err != nil {
+		return apperrors.NewNotFoundError("media")
+	}
+	if err := s.storage.Remove(media.StorageKey); err != nil {
+		s.logger.Warn().Err(err).Str("media_id", id.String()).Msg("Failed to remove media file")
+	}
+
+	s.logger.Info().Str("media_id", id.String()).Msg("Media removed")
+	return nil
+}
+
+// DiscardOrphans remove os arquivos das mensagens recebidas que foram removidas
+// ou tiveram a mídia apagada pela retenção. Mídias recentes de mensagens
+// existentes são mantidas: o download pode ainda não ter sido associado à mensagem
+func (s *MediaService) DiscardOrphans(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-orphanGrace)
+
+	var discarded int64
+	for {
+		orphans, err := s.media.ListOrphaned(ctx, before, orphanBatch)
+		if err != nil {
+			return discarded, err
+		}
+
+		for _, media := range orphans {
+			if err := s.storage.Remove(media.StorageKey); err != nil {
+				return discarded, err
+			}
+			if err := s.media.Delete(ctx, media.ID); err != nil {
+				return discarded, err
+			}
+			discarded++
+		}
+
+		if len(orphans) < orphanBatch {
+			return discarded, nil
+		}
+	}
+}
+
+// ResolveForSend troca o media_id da requisição pela URL entregue ao
+// provedor: uma URL assinada, quando a API tem URL pública, ou o conteúdo em base64
+func (s *MediaService) ResolveForSend(ctx context.Context, request *domain.SendMessageRequest) error {
+	media, err := s.GetMedia(ctx, *request.MediaID)
+	if err != nil {
+		return err
+	}
+	if media.Type != request.Type {
+		return apperrors.NewValidationError(fmt.Sprintf("media was uploaded as %s, not %s", media.Type, request.Type))
+	}
+
+	var location string
+	if s.settings.PublicURL != "" && len(s.settings.SigningKey) > 0 {
+		location = s.SignedURL(media, time.Now())
+	} else if location, err = s.dataURI(media); err != nil {
+		return err
+	}
+
+	request.MediaURL = &location
+	if request.FileName == "" {
+		request.FileName = media.FileName
+	}
+	return nil
+}
+
+// SignedURL monta a URL pública e temporária do conteúdo da mídia
+func (s *MediaService) SignedURL(media *domain.Media, now time.Time) string {
+	expires := strconv.FormatInt(now.Add(s.settings.URLTTL).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(media.ID, expires))
+
+	return fmt.Sprintf("%s/whatsapp/public/media/%s/%s?%s",
+		s.settings.PublicURL, media.ID, url.PathEscape(media.FileName), query.Encode())
+}
+
+// save grava o conteúdo no armazenamento e os metadados da mídia
+func (s *MediaService) save(ctx context.Context, messageType domain.MessageType, fileName, mimeType string, messageID *uuid.UUID, head []byte, rest io.Reader) (*domain.Media, error) {
+	media := &domain.Media{
+		ID:        uuid.New(),
+		TenantID:  contextTenant(ctx),
+		Type:      messageType,
+		FileName:  fileName,
+		MimeType:  mimeType,
+		MessageID: messageID,
+		CreatedAt: time.Now(),
+	}
+	media.StorageKey = media.ID.String() + filepath.Ext(media.FileName)
+
+	if err := s.store(media, io.MultiReader(bytes.NewReader(head), rest)); err != nil {
+		return nil, err
+	}
+
+	if err := s.media.Save(ctx, media); err != nil {
+		_ = s.storage.Remove(media.StorageKey)
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("media_id", media.ID.String()).
+		Str("type", string(media.Type)).
+		Str("mime_type", media.MimeType).
+		Int64("size_bytes", media.SizeBytes).
+		Msg("Media stored")
+
+	return media, nil
+}
+
+// store grava o conteúdo no armazenamento, calculando o tamanho e o checksum.
+// Arquivos acima do limite do tipo são descartados
+func (s *MediaService) store(media *domain.Media, content io.Reader) error {
+	file, err := s.storage.Create(media.StorageKey)
+	if err != nil {
+		return err
+	}
+
+	limit := s.settings.Limits[media.Type]
+	if limit > 0 {
+		content = io.LimitReader(content, limit+1)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), content)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close media file: %w", closeErr)
+	}
+	if err == nil && limit > 0 && size > limit {
+		err = apperrors.NewValidationError(fmt.Sprintf("%s files are limited to %d bytes", media.Type, limit))
+	}
+	if err != nil {
+		_ = s.storage.Remove(media.StorageKey)
+		return err
+	}
+
+	media.SizeBytes = size
+	media.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// dataURI lê a mídia e a codifica em base64 no formato aceito pelos provedores
+func (s *MediaService) dataURI(media *domain.Media) (string, error) {
+	file, err := s.storage.Open(media.StorageKey)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read media: %w", err)
+	}
+	return "data:" + media.MimeType + ";base64," + base64.StdEncoding.EncodeToString(content), nil
+}
+
+// sign calcula a assinatura da URL da mídia
+func (s *MediaService) sign(id uuid.UUID, expires string) string {
+	mac := hmac.New(sha256.New, s.settings.SigningKey)
+	mac.Write([]byte(id.String() + ":" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validSignature confere a assinatura e a validade da URL da mídia
+func (s *MediaService) validSignature(id uuid.UUID, expires, signature string, now time.Time) bool {
+	if len(s.settings.SigningKey) == 0 {
+		return false
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > unix {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(s.sign(id, expires)), []byte(signature)) == 1
+}
+
+// readHead lê o início do conteúdo, usado para identificar o tipo do arquivo
+func readHead(content io.Reader) ([]byte, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read media: %w", err)
+	}
+	if n == 0 {
+		return nil, apperrors.NewValidationError("file is empty")
+	}
+	return head[:n], nil
+}
+
+// mediaFileName descarta diretórios do nome informado, usando fallback quando
+// não há nome
+func mediaFileName(name, fallback string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return fallback
+	}
+	return name
+}
+
+// mediaMimeType define o tipo MIME do arquivo: o informado pelo cliente, o da
+// extensão ou o identificado pelo conteúdo. Imagens são sempre conferidas com
+// o conteúdo, que as identifica com segurança
+func mediaMimeType(messageType domain.MessageType, declared, fileName string, head []byte) (string, error) {
+	mimeType := parseMimeType(declared)
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType = parseMimeType(mime.TypeByExtension(filepath.Ext(fileName)))
+	}
+
+	sniffed := parseMimeType(http.DetectContentType(head))
+	if mimeType == "" {
+		mimeType = sniffed
+	}
+
+	if messageType == domain.ImageMessage && sniffed != mimeType {
+		return "", fmt.Errorf("file content is %s, not %s", sniffed, mimeType)
+	}
+	return mimeType, nil
+}
+
+// parseMimeType descarta os parâmetros do tipo MIME (ex: charset)
+func parseMimeType(value string) string {
+	if value == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// withExtension acrescenta ao nome a extensão do tipo MIME, quando ele não tem
+// uma. Provedores identificam documentos pela extensão
+func withExtension(fileName, mimeType string) string {
+	if filepath.Ext(fileName) != "" {
+		return fileName
+	}
+	if extension := domain.MediaExtension(mimeType); extension != "" {
+		return fileName + extension
+	}
+	if extensions, _ := mime.ExtensionsByType(mimeType); len(extensions) > 0 {
+		return fileName + extensions[0]
+	}
+	return fileName
+}
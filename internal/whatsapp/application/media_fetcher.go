@@ -0,0 +1,344 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// orphanSweepInterval é o intervalo entre as varreduras de mídias sem mensagem
+const orphanSweepInterval = 10 * time.Minute
+
+// MediaFetcherSettings configura o download da mídia recebida e as novas tentativas
+type MediaFetcherSettings struct {
+	PollInterval   time.Duration // intervalo entre as buscas por downloads vencidos
+	Timeout        time.Duration // tempo máximo de cada download
+	BatchSize      int           // downloads reservados por busca
+	MaxAttempts    int           // tentativas antes de o download ser marcado como falha
+	InitialBackoff time.Duration // espera antes da primeira nova tentativa, dobrada a cada tentativa
+	MaxBackoff     time.Duration // espera máxima entre tentativas
+	// AllowPrivateNetworks permite baixar de endereços internos (ex: provider
+	// simulado em desenvolvimento). Desligado, uma notificação forjada não faz
+	// a API buscar serviços da rede interna
+	AllowPrivateNetworks bool
+}
+
+// MediaFetcher baixa para o armazenamento da API a mídia das mensagens
+// recebidas, que o provider entrega por URLs temporárias. Os downloads são
+// feitos em background, com novas tentativas, e a mensagem registra o
+// resultado. Também descarta os arquivos das mensagens removidas
+type MediaFetcher struct {
+	downloads domain.MediaDownloadRepository
+	messages  domain.MessageRepository
+	media     *MediaService
+	client    *http.Client
+	settings  MediaFetcherSettings
+	logger    zerolog.Logger
+	wake      chan struct{}
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewMediaFetcher cria um novo serviço de download da mídia recebida
+func NewMediaFetcher(
+	downloads domain.MediaDownloadRepository,
+	messages domain.MessageRepository,
+	media *MediaService,
+	settings MediaFetcherSettings,
+	logger zerolog.Logger,
+) *MediaFetcher {
+	if settings.PollInterval <= 0 {
+		settings.PollInterval = 5 * time.Second
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = time.Minute
+	}
+	if settings.BatchSize <= 0 {
+		settings.BatchSize = 10
+	}
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = 6
+	}
+	if settings.InitialBackoff <= 0 {
+		settings.InitialBackoff = 30 * time.Second
+	}
+	if settings.MaxBackoff < settings.InitialBackoff {
+		settings.MaxBackoff = settings.InitialBackoff
+	}
+
+	return &MediaFetcher{
+		downloads: downloads,
+		messages:  messages,
+		media:     media,
+		client:    newOutboundClient(settings.Timeout, settings.AllowPrivateNetworks),
+		settings:  settings,
+		logger:    logger.With().Str("component", "media_fetcher").Logger(),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent agenda o download da mídia das mensagens recebidas. É registrado
+// como handler do barramento de eventos
+func (f *MediaFetcher) HandleEvent(event events.Event) {
+	received, ok := event.(*domain.MessageReceivedEvent)
+	if !ok {
+		return
+	}
+	message := received.Message
+	if !message.Type.IsMedia() || message.MediaURL == nil || *message.MediaURL == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), f.settings.Timeout)
+	defer cancel()
+	ctx = tenantDomain.NewContext(ctx, message.TenantID)
+
+	now := time.Now()
+	download := &domain.MediaDownload{
+		ID:            uuid.New(),
+		TenantID:      message.TenantID,
+		MessageID:     message.ID,
+		Type:          message.Type,
+		URL:           *message.MediaURL,
+		FileName:      received.MediaFileName,
+		MimeType:      received.MediaMimeType,
+		Status:        domain.MediaDownloadPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := f.downloads.Save(ctx, download); err != nil {
+		f.logger.Error().Err(err).Str("message_id", message.ID.String()).Msg("Failed to enqueue media download")
+		return
+	}
+
+	pending := &domain.MessageMedia{Status: domain.MediaDownloadPending}
+	if err := f.messages.UpdateMedia(ctx, message.ID, nil, pending); err != nil {
+		f.logger.Warn().Err(err).Str("message_id", message.ID.String()).Msg("Failed to mark media download as pending")
+	}
+
+	f.Wake()
+}
+
+// Wake antecipa a próxima busca por downloads vencidos
+func (f *MediaFetcher) Wake() {
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start inicia os downloads em background
+func (f *MediaFetcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.done = make(chan struct{})
+
+	go f.run(ctx)
+
+	f.logger.Info().Dur("poll_interval", f.settings.PollInterval).Msg("Media fetcher started")
+}
+
+// Stop interrompe os downloads e aguarda os que estão em andamento terminarem
+func (f *MediaFetcher) Stop(ctx context.Context) error {
+	if f.cancel == nil {
+		return nil
+	}
+	f.cancel()
+
+	select {
+	case <-f.done:
+		f.logger.Info().Msg("Media fetcher stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run processa os downloads vencidos a cada intervalo ou quando acordado e,
+// com menos frequência, descarta as mídias sem mensagem
+func (f *MediaFetcher) run(ctx context.Context) {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.settings.PollInterval)
+	defer ticker.Stop()
+
+	var lastSweep time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-f.wake:
+		}
+
+		f.DownloadDue(ctx)
+
+		if time.Since(lastSweep) >= orphanSweepInterval {
+			lastSweep = time.Now()
+			if discarded, err := f.media.DiscardOrphans(ctx); err != nil {
+				f.logger.Error().Err(err).Msg("Failed to discard orphaned media")
+			} else if discarded > 0 {
+				f.logger.Info().Int64("discarded", discarded).Msg("Orphaned media discarded")
+			}
+		}
+	}
+}
+
+// DownloadDue baixa a mídia dos downloads vencidos até esvaziar a fila
+func (f *MediaFetcher) DownloadDue(ctx context.Context) {
+	// A reserva precisa durar mais que o download para outra réplica não
+	// baixar a mesma mídia enquanto ele está em andamento
+	lease := 2 * f.settings.Timeout
+
+	for ctx.Err() == nil {
+		due, err := f.downloads.ClaimDue(ctx, time.Now(), lease, f.settings.BatchSize)
+		if err != nil {
+			f.logger.Error().Err(err).Msg("Failed to claim media downloads")
+			return
+		}
+		if len(due) == 0 {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, download := range due {
+			wg.Add(1)
+			go func(download *domain.MediaDownload) {
+				defer wg.Done()
+				f.attempt(ctx, download)
+			}(download)
+		}
+		wg.Wait()
+
+		if len(due) < f.settings.BatchSize {
+			return
+		}
+	}
+}
+
+// attempt baixa a mídia uma vez e agenda a próxima tentativa em caso de falha.
+// Arquivos recusados (ex: acima do limite) e mensagens removidas não são tentados de novo
+func (f *MediaFetcher) attempt(ctx context.Context, download *domain.MediaDownload) {
+	ctx = tenantDomain.NewContext(ctx, download.TenantID)
+	// O resultado é gravado mesmo que o serviço esteja sendo encerrado
+	recordCtx := context.WithoutCancel(ctx)
+
+	media, err := f.store(ctx, download)
+	download.Attempts++
+	permanent := errors.Is(err, apperrors.ErrBadRequest) || errors.Is(err, apperrors.ErrNotFound)
+
+	if err == nil {
+		stored := &domain.MessageMedia{
+			Status:    domain.MediaDownloadStored,
+			FileName:  media.FileName,
+			MimeType:  media.MimeType,
+			SizeBytes: media.SizeBytes,
+			SHA256:    media.SHA256,
+		}
+		if err = f.messages.UpdateMedia(recordCtx, download.MessageID, &media.ID, stored); err != nil {
+			// A mensagem foi removida durante o download
+			_ = f.media.DeleteMedia(recordCtx, media.ID)
+			permanent = true
+		}
+	}
+
+	switch {
+	case err == nil:
+		download.Status = domain.MediaDownloadStored
+		download.NextAttemptAt = nil
+		download.LastError = nil
+	case permanent || download.Attempts >= f.settings.MaxAttempts:
+		message := err.Error()
+		download.Status = domain.MediaDownloadFailed
+		download.NextAttemptAt = nil
+		download.LastError = &message
+
+		failed := &domain.MessageMedia{Status: domain.MediaDownloadFailed}
+		_ = f.messages.UpdateMedia(recordCtx, download.MessageID, nil, failed)
+	default:
+		message := err.Error()
+		next := time.Now().Add(WebhookBackoff(download.Attempts, f.settings.InitialBackoff, f.settings.MaxBackoff))
+		download.Status = domain.MediaDownloadPending
+		download.NextAttemptAt = &next
+		download.LastError = &message
+	}
+
+	if err := f.downloads.RecordAttempt(recordCtx, download); err != nil {
+		f.logger.Error().Err(err).Str("download_id", download.ID.String()).Msg("Failed to record media download")
+		return
+	}
+
+	if err != nil {
+		f.logger.Warn().
+			Err(err).
+			Str("download_id", download.ID.String()).
+			Str("message_id", download.MessageID.String()).
+			Int("attempts", download.Attempts).
+			Str("status", string(download.Status)).
+			Msg("Media download failed")
+	}
+}
+
+// store baixa a mídia e a guarda no armazenamento
+func (f *MediaFetcher) store(ctx context.Context, download *domain.MediaDownload) (*domain.Media, error) {
+	if _, err := f.messages.GetByID(ctx, download.MessageID); err != nil {
+		return nil, apperrors.NewNotFoundError("message")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, download.URL, nil)
+	if err != nil {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("invalid media URL: %v", err))
+	}
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	upload := domain.MediaUpload{
+		Type:     download.Type,
+		FileName: download.FileName,
+		MimeType: download.MimeType,
+		Content:  response.Body,
+	}
+	if upload.FileName == "" {
+		upload.FileName = responseFileName(response)
+	}
+	if upload.MimeType == "" {
+		upload.MimeType = response.Header.Get("Content-Type")
+	}
+
+	return f.media.StoreInbound(ctx, download.MessageID, upload)
+}
+
+// responseFileName obtém o nome do arquivo do Content-Disposition ou, sem ele,
+// do caminho da URL quando tem extensão
+func responseFileName(response *http.Response) string {
+	if _, params, err := mime.ParseMediaType(response.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		return params["filename"]
+	}
+	if name, err := url.PathUnescape(path.Base(response.Request.URL.Path)); err == nil && path.Ext(name) != "" {
+		return name
+	}
+	return ""
+}
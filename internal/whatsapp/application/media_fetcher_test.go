@@ -0,0 +1,321 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// newTestMediaFetcher cria o fetcher com o serviço de mídia sobre os mocks.
+// O servidor de teste é local: a proteção contra a rede interna fica desligada
+func newTestMediaFetcher(downloads *MockMediaDownloadRepository, messages *MockMessageRepository, mediaRepo *MockMediaRepository, storage *MockFileStorage) *application.MediaFetcher {
+	media := application.NewMediaService(mediaRepo, storage, application.MediaSettings{Limits: testMediaLimits}, zerolog.Nop())
+	return application.NewMediaFetcher(downloads, messages, media, application.MediaFetcherSettings{
+		BatchSize:            1,
+		MaxAttempts:          2,
+		InitialBackoff:       time.Minute,
+		AllowPrivateNetworks: true,
+	}, zerolog.Nop())
+}
+
+// pendingDownload cria um download pendente da mídia de uma mensagem recebida
+func pendingDownload(message *domain.Message, url, fileName string) *domain.MediaDownload {
+	now := time.Now()
+	return &domain.MediaDownload{
+		ID:            uuid.New(),
+		TenantID:      message.TenantID,
+		MessageID:     message.ID,
+		Type:          message.Type,
+		URL:           url,
+		FileName:      fileName,
+		Status:        domain.MediaDownloadPending,
+		NextAttemptAt: &now,
+	}
+}
+
+// expectClaim faz a próxima busca por downloads vencidos retornar o download
+func expectClaim(downloads *MockMediaDownloadRepository, download *domain.MediaDownload) {
+	downloads.On("ClaimDue", mock.Anything, mock.AnythingOfType("time.Time"), 2*time.Minute, 1).Return([]*domain.MediaDownload{download}, nil).Once()
+	downloads.On("ClaimDue", mock.Anything, mock.AnythingOfType("time.Time"), 2*time.Minute, 1).Return([]*domain.MediaDownload{}, nil).Once()
+}
+
+func TestMediaFetcher_HandleEventEnqueuesDownload(t *testing.T) {
+	tenantID := uuid.New()
+	mediaURL := "https://files.example.com/photo.png"
+	message := &domain.Message{ID: uuid.New(), TenantID: tenantID, Type: domain.ImageMessage, MediaURL: &mediaURL}
+
+	downloads := new(MockMediaDownloadRepository)
+	messages := new(MockMessageRepository)
+	downloads.On("Save", inTenant(tenantID), mock.MatchedBy(func(download *domain.MediaDownload) bool {
+		return download.MessageID == message.ID && download.URL == mediaURL && download.FileName == "foto.png" &&
+			download.Status == domain.MediaDownloadPending && download.NextAttemptAt != nil
+	})).Return(nil).Once()
+	messages.On("UpdateMedia", inTenant(tenantID), message.ID, (*uuid.UUID)(nil), &domain.MessageMedia{Status: domain.MediaDownloadPending}).Return(nil).Once()
+
+	fetcher := newTestMediaFetcher(downloads, messages, new(MockMediaRepository), new(MockFileStorage))
+
+	event := domain.NewMessageReceivedEvent(uuid.New(), message)
+	event.MediaFileName = "foto.png"
+	fetcher.HandleEvent(event)
+
+	// Mensagens de texto não têm mídia para baixar
+	text := &domain.Message{ID: uuid.New(), TenantID: tenantID, Type: domain.TextMessage}
+	fetcher.HandleEvent(domain.NewMessageReceivedEvent(uuid.New(), text))
+
+	downloads.AssertExpectations(t)
+	messages.AssertExpectations(t)
+}
+
+func TestMediaFetcher_StoresInboundMedia(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(pngContent)
+	}))
+	t.Cleanup(server.Close)
+
+	tenantID := uuid.New()
+	message := &domain.Message{ID: uuid.New(), TenantID: tenantID, Type: domain.ImageMessage}
+	download := pendingDownload(message, server.URL+"/files/photo.png", "")
+	checksum := sha256.Sum256(pngContent)
+	stored := &domain.MessageMedia{
+		Status:    domain.MediaDownloadStored,
+		FileName:  "photo.png",
+		MimeType:  "image/png",
+		SizeBytes: int64(len(pngContent)),
+		SHA256:    hex.EncodeToString(checksum[:]),
+	}
+
+	downloads := new(MockMediaDownloadRepository)
+	messages := new(MockMessageRepository)
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	var file bytes.Buffer
+	expectClaim(downloads, download)
+	messages.On("GetByID", inTenant(tenantID), message.ID).Return(message, nil).Once()
+	storage.On("Create", fileWithExtension(".png")).Return(closingBuffer{&file}, nil).Once()
+	mediaRepo.On("Save", inTenant(tenantID), mock.MatchedBy(func(media *domain.Media) bool {
+		return media.TenantID == tenantID && media.MessageID != nil && *media.MessageID == message.ID
+	})).Return(nil).Once()
+	messages.On("UpdateMedia", mock.Anything, message.ID, mock.AnythingOfType("*uuid.UUID"), stored).Return(nil).Once()
+	downloads.On("RecordAttempt", mock.Anything, download).Return(nil).Once()
+
+	fetcher := newTestMediaFetcher(downloads, messages, mediaRepo, storage)
+	fetcher.DownloadDue(context.Background())
+
+	assert.Equal(t, domain.MediaDownloadStored, download.Status)
+	assert.Equal(t, 1, download.Attempts)
+	assert.Nil(t, download.NextAttemptAt)
+	assert.Equal(t, pngContent, file.Bytes())
+
+	// A mensagem aponta para a mídia gravada
+	saved := mediaRepo.Calls[0].Arguments.Get(1).(*domain.Media)
+	var updated *uuid.UUID
+	for _, call := range messages.Calls {
+		if call.Method == "UpdateMedia" {
+			updated = call.Arguments.Get(2).(*uuid.UUID)
+		}
+	}
+	require.NotNil(t, updated)
+	assert.Equal(t, saved.ID, *updated)
+
+	downloads.AssertExpectations(t)
+	messages.AssertExpectations(t)
+	mediaRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestMediaFetcher_StoresUnlistedTypesAsBinary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><script>alert(1)</script></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	message := &domain.Message{ID: uuid.New(), TenantID: uuid.New(), Type: domain.DocumentMessage}
+	download := pendingDownload(message, server.URL+"/files/page", "pagina.html")
+
+	downloads := new(MockMediaDownloadRepository)
+	messages := new(MockMessageRepository)
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	expectClaim(downloads, download)
+	messages.On("GetByID", mock.Anything, message.ID).Return(message, nil).Once()
+	storage.On("Create", mock.AnythingOfType("string")).Return(closingBuffer{&bytes.Buffer{}}, nil).Once()
+	mediaRepo.On("Save", mock.Anything, mock.MatchedBy(func(media *domain.Media) bool {
+		return media.MimeType == "application/octet-stream"
+	})).Return(nil).Once()
+	messages.On("UpdateMedia", mock.Anything, message.ID, mock.AnythingOfType("*uuid.UUID"), mock.MatchedBy(func(media *domain.MessageMedia) bool {
+		return media.Status == domain.MediaDownloadStored && media.MimeType == "application/octet-stream"
+	})).Return(nil).Once()
+	downloads.On("RecordAttempt", mock.Anything, download).Return(nil).Once()
+
+	fetcher := newTestMediaFetcher(downloads, messages, mediaRepo, storage)
+	fetcher.DownloadDue(context.Background())
+
+	assert.Equal(t, domain.MediaDownloadStored, download.Status)
+	mediaRepo.AssertExpectations(t)
+	messages.AssertExpectations(t)
+}
+
+func TestMediaFetcher_RetriesFailedDownloads(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("%PDF-1.4 contract"))
+	}))
+	t.Cleanup(server.Close)
+
+	message := &domain.Message{ID: uuid.New(), TenantID: uuid.New(), Type: domain.DocumentMessage}
+	download := pendingDownload(message, server.URL+"/files/download", "contrato.pdf")
+
+	downloads := new(MockMediaDownloadRepository)
+	messages := new(MockMessageRepository)
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	messages.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	downloads.On("RecordAttempt", mock.Anything, download).Return(nil)
+
+	fetcher := newTestMediaFetcher(downloads, messages, mediaRepo, storage)
+
+	expectClaim(downloads, download)
+	fetcher.DownloadDue(context.Background())
+
+	assert.Equal(t, domain.MediaDownloadPending, download.Status)
+	assert.Equal(t, 1, download.Attempts)
+	require.NotNil(t, download.LastError)
+	assert.Contains(t, *download.LastError, "502")
+	assert.True(t, download.NextAttemptAt.After(time.Now().Add(30*time.Second)), "retry waits for the backoff")
+	messages.AssertNotCalled(t, "UpdateMedia", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	storage.On("Create", fileWithExtension(".pdf")).Return(closingBuffer{&bytes.Buffer{}}, nil).Once()
+	mediaRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Media")).Return(nil).Once()
+	messages.On("UpdateMedia", mock.Anything, message.ID, mock.AnythingOfType("*uuid.UUID"), mock.MatchedBy(func(media *domain.MessageMedia) bool {
+		return media.Status == domain.MediaDownloadStored && media.FileName == "contrato.pdf" && media.MimeType == "application/pdf"
+	})).Return(nil).Once()
+
+	expectClaim(downloads, download)
+	fetcher.DownloadDue(context.Background())
+
+	assert.Equal(t, domain.MediaDownloadStored, download.Status)
+	assert.Equal(t, 2, download.Attempts)
+	assert.Nil(t, download.LastError)
+
+	downloads.AssertExpectations(t)
+	messages.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestMediaFetcher_MarksFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large.png" {
+			_, _ = w.Write(append(pngContent, make([]byte, 1024)...))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	tenantID := uuid.New()
+	large := &domain.Message{ID: uuid.New(), TenantID: tenantID, Type: domain.ImageMessage}
+	broken := &domain.Message{ID: uuid.New(), TenantID: tenantID, Type: domain.ImageMessage}
+	removed := &domain.Message{ID: uuid.New(), TenantID: tenantID, Type: domain.ImageMessage}
+	largeDownload := pendingDownload(large, server.URL+"/large.png", "")
+	brokenDownload := pendingDownload(broken, server.URL+"/broken.png", "")
+	removedDownload := pendingDownload(removed, server.URL+"/removed.png", "")
+	failed := &domain.MessageMedia{Status: domain.MediaDownloadFailed}
+
+	downloads := new(MockMediaDownloadRepository)
+	messages := new(MockMessageRepository)
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	messages.On("GetByID", mock.Anything, large.ID).Return(large, nil)
+	messages.On("GetByID", mock.Anything, broken.ID).Return(broken, nil)
+	messages.On("GetByID", mock.Anything, removed.ID).Return(nil, errors.New("record not found"))
+	downloads.On("RecordAttempt", mock.Anything, mock.AnythingOfType("*domain.MediaDownload")).Return(nil)
+	for _, message := range []*domain.Message{large, broken, removed} {
+		messages.On("UpdateMedia", mock.Anything, message.ID, (*uuid.UUID)(nil), failed).Return(nil).Once()
+	}
+
+	fetcher := newTestMediaFetcher(downloads, messages, mediaRepo, storage)
+
+	// Arquivos acima do limite não são tentados de novo, e o arquivo parcial é removido
+	storage.On("Create", fileWithExtension(".png")).Return(closingBuffer{&bytes.Buffer{}}, nil).Once()
+	storage.On("Remove", fileWithExtension(".png")).Return(nil).Once()
+	expectClaim(downloads, largeDownload)
+	fetcher.DownloadDue(context.Background())
+	assert.Equal(t, domain.MediaDownloadFailed, largeDownload.Status)
+	assert.Equal(t, 1, largeDownload.Attempts)
+	assert.Nil(t, largeDownload.NextAttemptAt)
+
+	// Mensagens removidas antes do download também não
+	expectClaim(downloads, removedDownload)
+	fetcher.DownloadDue(context.Background())
+	assert.Equal(t, domain.MediaDownloadFailed, removedDownload.Status)
+	assert.Equal(t, 1, removedDownload.Attempts)
+
+	// Os erros do provider são tentados até o limite de tentativas
+	expectClaim(downloads, brokenDownload)
+	fetcher.DownloadDue(context.Background())
+	assert.Equal(t, domain.MediaDownloadPending, brokenDownload.Status)
+	expectClaim(downloads, brokenDownload)
+	fetcher.DownloadDue(context.Background())
+	assert.Equal(t, domain.MediaDownloadFailed, brokenDownload.Status)
+	assert.Equal(t, 2, brokenDownload.Attempts)
+	assert.Nil(t, brokenDownload.NextAttemptAt)
+
+	mediaRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	messages.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestMediaService_DiscardOrphansRemovesFiles(t *testing.T) {
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+	messageID := uuid.New()
+	orphan := &domain.Media{ID: uuid.New(), MessageID: &messageID, StorageKey: "orphan.png"}
+
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	// Mídias recém-baixadas ainda podem não estar associadas à mensagem
+	mediaRepo.On("ListOrphaned", ctx, mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-4 * time.Minute))
+	}), 100).Return([]*domain.Media{orphan}, nil).Once()
+	storage.On("Remove", "orphan.png").Return(nil).Once()
+	mediaRepo.On("Delete", ctx, orphan.ID).Return(nil).Once()
+
+	media := application.NewMediaService(mediaRepo, storage, application.MediaSettings{}, zerolog.Nop())
+	discarded, err := media.DiscardOrphans(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), discarded)
+
+	// Sem conseguir remover o arquivo, o registro da mídia é mantido
+	failing := &domain.Media{ID: uuid.New(), MessageID: &messageID, StorageKey: "locked.png"}
+	mediaRepo.On("ListOrphaned", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*domain.Media{failing}, nil).Once()
+	storage.On("Remove", "locked.png").Return(errors.New("permission denied")).Once()
+
+	_, err = media.DiscardOrphans(ctx)
+	assert.Error(t, err)
+	mediaRepo.AssertNotCalled(t, "Delete", ctx, failing.ID)
+
+	mediaRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
@@ -0,0 +1,261 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// pngContent é o início de um PNG, suficiente para identificar o conteúdo
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+// testMediaLimits limita o tamanho das mídias nos testes
+var testMediaLimits = domain.MediaLimits{domain.ImageMessage: 1024, domain.DocumentMessage: 1024}
+
+// fileWithExtension verifica a extensão do arquivo gravado no armazenamento
+func fileWithExtension(extension string) interface{} {
+	return mock.MatchedBy(func(name string) bool {
+		return path.Ext(name) == extension
+	})
+}
+
+func TestMediaService_UploadStoresContentAndChecksum(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	var image, document, text bytes.Buffer
+	storage.On("Create", fileWithExtension(".png")).Return(closingBuffer{&image}, nil).Once()
+	storage.On("Create", fileWithExtension(".pdf")).Return(closingBuffer{&document}, nil).Once()
+	storage.On("Create", fileWithExtension(".txt")).Return(closingBuffer{&text}, nil).Once()
+	mediaRepo.On("Save", ctx, mock.AnythingOfType("*domain.Media")).Return(nil)
+
+	service := application.NewMediaService(mediaRepo, storage, application.MediaSettings{Limits: testMediaLimits}, zerolog.Nop())
+
+	media, err := service.Upload(ctx, domain.MediaUpload{
+		Type:     domain.ImageMessage,
+		FileName: "../../photo.png",
+		MimeType: "image/png",
+		Content:  bytes.NewReader(pngContent),
+	})
+	require.NoError(t, err)
+
+	checksum := sha256.Sum256(pngContent)
+	assert.Equal(t, tenantID, media.TenantID)
+	assert.Equal(t, hex.EncodeToString(checksum[:]), media.SHA256)
+	assert.Equal(t, int64(len(pngContent)), media.SizeBytes)
+	assert.Equal(t, "photo.png", media.FileName)
+	assert.Equal(t, media.ID.String()+".png", media.StorageKey)
+	assert.Equal(t, pngContent, image.Bytes())
+	storage.AssertCalled(t, "Create", media.StorageKey)
+
+	// Sem tipo informado, o tipo vem da extensão e o nome ganha a extensão do tipo
+	pdf, err := service.Upload(ctx, domain.MediaUpload{
+		Type:     domain.DocumentMessage,
+		FileName: "report.pdf",
+		MimeType: "application/octet-stream",
+		Content:  strings.NewReader("%PDF-1.4 report"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", pdf.MimeType)
+	assert.Equal(t, "%PDF-1.4 report", document.String())
+
+	notes, err := service.Upload(ctx, domain.MediaUpload{
+		Type:     domain.DocumentMessage,
+		FileName: "notes",
+		MimeType: "text/plain; charset=utf-8",
+		Content:  strings.NewReader("meeting notes"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", notes.MimeType)
+	assert.Equal(t, ".txt", path.Ext(notes.FileName))
+
+	// A mídia de outro tenant não é encontrada
+	otherCtx := tenantDomain.NewContext(context.Background(), uuid.New())
+	mediaRepo.On("GetByID", otherCtx, media.ID).Return(nil, errors.New("record not found")).Once()
+	_, err = service.GetMedia(otherCtx, media.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	mediaRepo.AssertNumberOfCalls(t, "Save", 3)
+	storage.AssertExpectations(t)
+	storage.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestMediaService_UploadRejectsInvalidFiles(t *testing.T) {
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+
+	cases := map[string]domain.MediaUpload{
+		"text message":       {Type: domain.TextMessage, FileName: "a.png", MimeType: "image/png", Content: bytes.NewReader(pngContent)},
+		"empty file":         {Type: domain.ImageMessage, FileName: "a.png", MimeType: "image/png", Content: bytes.NewReader(nil)},
+		"content mismatch":   {Type: domain.ImageMessage, FileName: "a.png", MimeType: "image/png", Content: strings.NewReader("not an image")},
+		"unsupported type":   {Type: domain.DocumentMessage, FileName: "a.exe", MimeType: "application/x-msdownload", Content: strings.NewReader("MZ")},
+		"image as document":  {Type: domain.DocumentMessage, FileName: "a.png", MimeType: "image/png", Content: bytes.NewReader(pngContent)},
+		"unknown media type": {Type: "sticker", FileName: "a.png", MimeType: "image/png", Content: bytes.NewReader(pngContent)},
+	}
+	for name, upload := range cases {
+		t.Run(name, func(t *testing.T) {
+			mediaRepo := new(MockMediaRepository)
+			storage := new(MockFileStorage)
+			service := application.NewMediaService(mediaRepo, storage, application.MediaSettings{Limits: testMediaLimits}, zerolog.Nop())
+
+			_, err := service.Upload(ctx, upload)
+			assert.True(t, errors.Is(err, apperrors.ErrBadRequest), err)
+
+			// Arquivos recusados na validação nem chegam ao armazenamento
+			storage.AssertNotCalled(t, "Create", mock.Anything)
+			mediaRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMediaService_UploadDiscardsOversizedFiles(t *testing.T) {
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	storage.On("Create", fileWithExtension(".png")).Return(closingBuffer{&bytes.Buffer{}}, nil).Once()
+	storage.On("Remove", fileWithExtension(".png")).Return(nil).Once()
+
+	service := application.NewMediaService(mediaRepo, storage, application.MediaSettings{Limits: testMediaLimits}, zerolog.Nop())
+
+	_, err := service.Upload(ctx, domain.MediaUpload{
+		Type:     domain.ImageMessage,
+		FileName: "a.png",
+		MimeType: "image/png",
+		Content:  io.MultiReader(bytes.NewReader(pngContent), bytes.NewReader(make([]byte, 1024))),
+	})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	// O arquivo parcial é removido do armazenamento
+	storage.AssertExpectations(t)
+	assert.Equal(t, storage.Calls[0].Arguments.String(0), storage.Calls[1].Arguments.String(0))
+	mediaRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestMediaService_ResolveForSendInlinesWithoutPublicURL(t *testing.T) {
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+	media := &domain.Media{ID: uuid.New(), Type: domain.ImageMessage, FileName: "photo.png", MimeType: "image/png", StorageKey: "photo-key.png"}
+
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	mediaRepo.On("GetByID", ctx, media.ID).Return(media, nil)
+	storage.On("Open", "photo-key.png").Return(io.NopCloser(bytes.NewReader(pngContent)), nil).Once()
+
+	service := application.NewMediaService(mediaRepo, storage, application.MediaSettings{}, zerolog.Nop())
+
+	request := domain.SendMessageRequest{Type: domain.ImageMessage, MediaID: &media.ID}
+	require.NoError(t, service.ResolveForSend(ctx, &request))
+
+	require.NotNil(t, request.MediaURL)
+	assert.Equal(t, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(pngContent), *request.MediaURL)
+	assert.Equal(t, "photo.png", request.FileName)
+
+	// O tipo da mensagem precisa ser o mesmo do envio da mídia
+	request = domain.SendMessageRequest{Type: domain.DocumentMessage, MediaID: &media.ID}
+	err := service.ResolveForSend(ctx, &request)
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	storage.AssertExpectations(t)
+}
+
+func TestMediaService_SignedURLs(t *testing.T) {
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+	media := &domain.Media{ID: uuid.New(), Type: domain.DocumentMessage, FileName: "contrato final.pdf", MimeType: "application/pdf", StorageKey: "contract-key.pdf"}
+
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	mediaRepo.On("GetByID", ctx, media.ID).Return(media, nil).Once()
+	// O download público não tem tenant no contexto
+	mediaRepo.On("GetByID", context.Background(), media.ID).Return(media, nil).Once()
+	storage.On("Open", "contract-key.pdf").Return(io.NopCloser(strings.NewReader("%PDF-1.4 contract")), nil).Once()
+
+	service := application.NewMediaService(mediaRepo, storage, application.MediaSettings{
+		PublicURL:  "https://api.example.com/api/v1/",
+		SigningKey: []byte("secret"),
+		URLTTL:     time.Minute,
+	}, zerolog.Nop())
+
+	request := domain.SendMessageRequest{Type: domain.DocumentMessage, MediaID: &media.ID, FileName: "contrato.pdf"}
+	require.NoError(t, service.ResolveForSend(ctx, &request))
+	assert.Equal(t, "contrato.pdf", request.FileName)
+
+	signed, err := url.Parse(*request.MediaURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/whatsapp/public/media/"+media.ID.String()+"/contrato final.pdf", signed.Path)
+	expires, signature := signed.Query().Get("expires"), signed.Query().Get("signature")
+
+	opened, file, err := service.OpenSigned(context.Background(), media.ID, expires, signature)
+	require.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	assert.Equal(t, media.ID, opened.ID)
+	assert.Equal(t, "%PDF-1.4 contract", string(content))
+
+	_, _, err = service.OpenSigned(context.Background(), media.ID, expires, strings.Repeat("0", len(signature)))
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+	_, _, err = service.OpenSigned(context.Background(), uuid.New(), expires, signature)
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+
+	expired, err := url.Parse(service.SignedURL(media, time.Now().Add(-time.Hour)))
+	require.NoError(t, err)
+	_, _, err = service.OpenSigned(context.Background(), media.ID, expired.Query().Get("expires"), expired.Query().Get("signature"))
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+
+	// Assinaturas recusadas não consultam o repositório
+	mediaRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestMediaService_DeleteRemovesFile(t *testing.T) {
+	ctx := tenantDomain.NewContext(context.Background(), uuid.New())
+	media := &domain.Media{ID: uuid.New(), Type: domain.ImageMessage, StorageKey: "photo-key.png"}
+
+	mediaRepo := new(MockMediaRepository)
+	storage := new(MockFileStorage)
+	mediaRepo.On("GetByID", ctx, media.ID).Return(media, nil).Once()
+	mediaRepo.On("Delete", ctx, media.ID).Return(nil).Once()
+	storage.On("Remove", "photo-key.png").Return(nil).Once()
+
+	service := application.NewMediaService(mediaRepo, storage, application.MediaSettings{}, zerolog.Nop())
+	require.NoError(t, service.DeleteMedia(ctx, media.ID))
+
+	mediaRepo.On("GetByID", ctx, media.ID).Return(nil, errors.New("record not found")).Once()
+	err := service.DeleteMedia(ctx, media.ID)
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	mediaRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestSendMessageRequest_ValidateMedia(t *testing.T) {
+	link := "https://example.com/a.png"
+	id := uuid.New()
+
+	assert.NoError(t, (&domain.SendMessageRequest{Type: domain.TextMessage}).ValidateMedia())
+	assert.NoError(t, (&domain.SendMessageRequest{Type: domain.ImageMessage, MediaURL: &link}).ValidateMedia())
+	assert.NoError(t, (&domain.SendMessageRequest{Type: domain.ImageMessage, MediaID: &id}).ValidateMedia())
+
+	assert.Error(t, (&domain.SendMessageRequest{Type: domain.TextMessage, MediaID: &id}).ValidateMedia())
+	assert.Error(t, (&domain.SendMessageRequest{Type: domain.ImageMessage}).ValidateMedia())
+	assert.Error(t, (&domain.SendMessageRequest{Type: domain.ImageMessage, MediaURL: &link, MediaID: &id}).ValidateMedia())
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// SearchMessages busca mensagens com filtros e paginação por cursor. O total
+// exige uma contagem extra e só é calculado quando includeTotal é verdadeiro
+func (s *WhatsAppService) SearchMessages(ctx context.Context, filter domain.MessageFilter, limit int, cursor string, includeTotal bool) (*domain.MessageSearchResult, error) {
+	if err := validateMessageFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter.Phone != "" {
+		filter.Phone = domain.NormalizePhone(filter.Phone)
+	}
+
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	messages, err := s.messageRepo.Search(ctx, filter, limit+1, before)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.MessageSearchResult{Messages: messages}
+	if len(messages) > limit {
+		result.Messages = messages[:limit]
+		last := result.Messages[limit-1]
+		result.NextCursor = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	if includeTotal {
+		total, err := s.messageRepo.Count(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	return result, nil
+}
+
+// validateMessageFilter rejeita valores desconhecidos, que resultariam em uma busca sempre vazia
+func validateMessageFilter(filter domain.MessageFilter) error {
+	switch filter.Status {
+	case "", domain.StatusPending, domain.StatusSent, domain.StatusDelivered,
+		domain.StatusRead, domain.StatusFailed, domain.StatusReceived:
+	default:
+		return apperrors.NewValidationError(fmt.Sprintf("invalid status: %s", filter.Status))
+	}
+
+	switch filter.Type {
+	case "", domain.TextMessage, domain.ImageMessage, domain.DocumentMessage,
+		domain.AudioMessage, domain.VideoMessage:
+	default:
+		return apperrors.NewValidationError(fmt.Sprintf("invalid type: %s", filter.Type))
+	}
+
+	switch filter.Direction {
+	case "", domain.DirectionInbound, domain.DirectionOutbound:
+	default:
+		return apperrors.NewValidationError(fmt.Sprintf("invalid direction: %s", filter.Direction))
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return apperrors.NewValidationError("from must be before to")
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// newServiceWithMessages cria o serviço apenas com o repositório de mensagens
+func newServiceWithMessages(messageRepo domain.MessageRepository) *application.WhatsAppService {
+	return application.NewWhatsAppService(nil, nil, messageRepo, nil, nil, nil, nil, nil, nil, nil, nil, zerolog.Nop())
+}
+
+// decodedCursorOf verifica se o cursor recebido pelo repositório aponta para
+// a mensagem, com a precisão de segundos do cursor codificado
+func decodedCursorOf(message *domain.Message) interface{} {
+	return mock.MatchedBy(func(cursor *domain.Cursor) bool {
+		return cursor != nil && cursor.ID == message.ID && cursor.Time.Equal(message.CreatedAt.Truncate(time.Second))
+	})
+}
+
+func TestCursor_EncodeRoundTrip(t *testing.T) {
+	cursor := domain.Cursor{Time: time.Date(2024, time.March, 4, 13, 0, 0, 500, time.UTC), ID: uuid.New()}
+
+	decoded, err := domain.DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	// As datas são gravadas em segundos, a mesma precisão do cursor
+	assert.True(t, decoded.Time.Equal(cursor.Time.Truncate(time.Second)))
+	assert.Equal(t, cursor.ID, decoded.ID)
+
+	empty, err := domain.DecodeCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	for _, invalid := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "YWJjOjEyMw"} {
+		_, err := domain.DecodeCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestWhatsAppService_SearchMessagesPaginates(t *testing.T) {
+	ctx := context.Background()
+	filter := domain.MessageFilter{Phone: exportPhone}
+	messages := exportMessages(uuid.New(), 5)
+
+	messageRepo := new(MockMessageRepository)
+	// Uma mensagem a mais indica que existe uma próxima página
+	messageRepo.On("Search", ctx, filter, 3, (*domain.Cursor)(nil)).Return(messages[:3], nil).Once()
+	messageRepo.On("Search", ctx, filter, 3, decodedCursorOf(messages[1])).Return(messages[2:], nil).Once()
+	messageRepo.On("Count", ctx, filter).Return(int64(5), nil).Once()
+
+	service := newServiceWithMessages(messageRepo)
+
+	page, err := service.SearchMessages(ctx, domain.MessageFilter{Phone: "+55 11 99999-0000"}, 2, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, messages[:2], page.Messages)
+	assert.Nil(t, page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	// O cursor aponta para a última mensagem entregue, não para a mensagem extra
+	cursor, err := domain.DecodeCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, messages[1].ID, cursor.ID)
+
+	page, err = service.SearchMessages(ctx, filter, 2, page.NextCursor, true)
+	require.NoError(t, err)
+	assert.Equal(t, messages[2:4], page.Messages)
+	require.NotNil(t, page.Total)
+	assert.Equal(t, int64(5), *page.Total)
+
+	messageRepo.AssertExpectations(t)
+}
+
+func TestWhatsAppService_SearchMessagesLastPage(t *testing.T) {
+	ctx := context.Background()
+	messages := exportMessages(uuid.New(), 2)
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("Search", ctx, domain.MessageFilter{}, 3, (*domain.Cursor)(nil)).Return(messages, nil).Once()
+
+	page, err := newServiceWithMessages(messageRepo).SearchMessages(ctx, domain.MessageFilter{}, 2, "", false)
+	require.NoError(t, err)
+	assert.Len(t, page.Messages, 2)
+	assert.Empty(t, page.NextCursor)
+
+	messageRepo.AssertNotCalled(t, "Count", ctx, domain.MessageFilter{})
+}
+
+func TestWhatsAppService_SearchMessagesValidation(t *testing.T) {
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter domain.MessageFilter
+		cursor string
+	}{
+		{name: "invalid cursor", cursor: "not base64!"},
+		{name: "invalid status", filter: domain.MessageFilter{Status: "archived"}},
+		{name: "invalid type", filter: domain.MessageFilter{Type: "sticker"}},
+		{name: "invalid direction", filter: domain.MessageFilter{Direction: "both"}},
+		{name: "from after to", filter: domain.MessageFilter{From: &from, To: &to}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(MockMessageRepository)
+			_, err := newServiceWithMessages(messageRepo).SearchMessages(context.Background(), tt.filter, 10, tt.cursor, false)
+			assert.ErrorIs(t, err, apperrors.ErrBadRequest)
+			messageRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
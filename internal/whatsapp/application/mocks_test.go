@@ -0,0 +1,680 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// MockInstanceRepository é um mock de InstanceRepository
+type MockInstanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockInstanceRepository) Save(ctx context.Context, instance *domain.Instance) error {
+	args := m.Called(ctx, instance)
+	return args.Error(0)
+}
+
+func (m *MockInstanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Instance, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Instance), args.Error(1)
+}
+
+func (m *MockInstanceRepository) GetByToken(ctx context.Context, token string) (*domain.Instance, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Instance), args.Error(1)
+}
+
+func (m *MockInstanceRepository) GetByInstanceID(ctx context.Context, instanceID string) (*domain.Instance, error) {
+	args := m.Called(ctx, instanceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Instance), args.Error(1)
+}
+
+func (m *MockInstanceRepository) GetAll(ctx context.Context) ([]*domain.Instance, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Instance), args.Error(1)
+}
+
+func (m *MockInstanceRepository) Update(ctx context.Context, instance *domain.Instance) error {
+	args := m.Called(ctx, instance)
+	return args.Error(0)
+}
+
+func (m *MockInstanceRepository) UpdateStatus(ctx context.Context, instance *domain.Instance) error {
+	args := m.Called(ctx, instance)
+	return args.Error(0)
+}
+
+func (m *MockInstanceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockProviderResolver é um mock de InstanceProviderResolver
+type MockProviderResolver struct {
+	mock.Mock
+}
+
+func (m *MockProviderResolver) Resolve(instance *domain.Instance) (domain.WhatsAppProvider, error) {
+	args := m.Called(instance)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.WhatsAppProvider), args.Error(1)
+}
+
+func (m *MockProviderResolver) Invalidate(instanceID uuid.UUID) {
+	m.Called(instanceID)
+}
+
+// MockProvider é um mock de WhatsAppProvider
+type MockProvider struct {
+	mock.Mock
+}
+
+func (m *MockProvider) GetName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockProvider) SendMessage(ctx context.Context, instance *domain.Instance, request domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, instance, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockProvider) GetInstanceStatus(ctx context.Context, instance *domain.Instance) (*domain.InstanceInfo, error) {
+	args := m.Called(ctx, instance)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InstanceInfo), args.Error(1)
+}
+
+func (m *MockProvider) CreateInstance(ctx context.Context, request domain.CreateInstanceRequest) (*domain.Instance, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Instance), args.Error(1)
+}
+
+func (m *MockProvider) DeleteInstance(ctx context.Context, instance *domain.Instance) error {
+	args := m.Called(ctx, instance)
+	return args.Error(0)
+}
+
+func (m *MockProvider) ValidateToken(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockProvider) UpdateProfileName(ctx context.Context, instance *domain.Instance, request domain.UpdateProfileNameRequest) (*domain.UpdateProfileResponse, error) {
+	args := m.Called(ctx, instance, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UpdateProfileResponse), args.Error(1)
+}
+
+func (m *MockProvider) UpdateProfilePicture(ctx context.Context, instance *domain.Instance, request domain.UpdateProfilePictureRequest) (*domain.UpdateProfileResponse, error) {
+	args := m.Called(ctx, instance, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UpdateProfileResponse), args.Error(1)
+}
+
+// MockFeatureProvider é um mock de provider que declara as funcionalidades suportadas
+type MockFeatureProvider struct {
+	MockProvider
+}
+
+func (m *MockFeatureProvider) GetSupportedFeatures() []domain.ProviderFeature {
+	args := m.Called()
+	return args.Get(0).([]domain.ProviderFeature)
+}
+
+// MockEventPublisher é um mock de EventPublisher
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event events.Event) {
+	m.Called(ctx, event)
+}
+
+// MockMessageRepository é um mock de MessageRepository
+type MockMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockMessageRepository) Save(ctx context.Context, message *domain.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) SaveInbound(ctx context.Context, message *domain.Message) (bool, error) {
+	args := m.Called(ctx, message)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetByInstanceID(ctx context.Context, instanceID string, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, instanceID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetByProviderID(ctx context.Context, instanceID, providerID string) (*domain.Message, error) {
+	args := m.Called(ctx, instanceID, providerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) Search(ctx context.Context, filter domain.MessageFilter, limit int, before *domain.Cursor) ([]*domain.Message, error) {
+	args := m.Called(ctx, filter, limit, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) Count(ctx context.Context, filter domain.MessageFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetThread(ctx context.Context, instanceID, phone string, limit int, before *domain.Cursor) ([]*domain.Message, error) {
+	args := m.Called(ctx, instanceID, phone, limit, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.MessageStatus, providerID *string, errorMsg *string) error {
+	args := m.Called(ctx, id, status, providerID, errorMsg)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) Reassign(ctx context.Context, id uuid.UUID, instanceID, provider string) error {
+	args := m.Called(ctx, id, instanceID, provider)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) AdvanceStatus(ctx context.Context, id uuid.UUID, status domain.MessageStatus, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, status, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) UpdateMedia(ctx context.Context, id uuid.UUID, mediaID *uuid.UUID, media *domain.MessageMedia) error {
+	args := m.Called(ctx, id, mediaID, media)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) AggregateStats(ctx context.Context, query domain.MessageAnalyticsQuery) ([]*domain.MessageStatsRow, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MessageStatsRow), args.Error(1)
+}
+
+func (m *MockMessageRepository) SummarizeStats(ctx context.Context, query domain.MessageAnalyticsQuery) (*domain.MessageStats, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageStats), args.Error(1)
+}
+
+func (m *MockMessageRepository) TopFailureReasons(ctx context.Context, query domain.MessageAnalyticsQuery, limit int) ([]*domain.FailureReason, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FailureReason), args.Error(1)
+}
+
+func (m *MockMessageRepository) CountRetention(ctx context.Context, target domain.RetentionTarget) (int64, error) {
+	args := m.Called(ctx, target)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageRepository) RedactContent(ctx context.Context, target domain.RetentionTarget, limit int) (int64, error) {
+	args := m.Called(ctx, target, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageRepository) DeleteMessages(ctx context.Context, target domain.RetentionTarget, limit int) (int64, error) {
+	args := m.Called(ctx, target, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockConversationRepository é um mock de ConversationRepository
+type MockConversationRepository struct {
+	mock.Mock
+}
+
+func (m *MockConversationRepository) ListByInstance(ctx context.Context, instanceID string, limit int, after *domain.Cursor) ([]*domain.Conversation, error) {
+	args := m.Called(ctx, instanceID, limit, after)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Conversation), args.Error(1)
+}
+
+func (m *MockConversationRepository) GetByPhone(ctx context.Context, instanceID, phone string) (*domain.Conversation, error) {
+	args := m.Called(ctx, instanceID, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Conversation), args.Error(1)
+}
+
+func (m *MockConversationRepository) MarkRead(ctx context.Context, instanceID, phone string) error {
+	args := m.Called(ctx, instanceID, phone)
+	return args.Error(0)
+}
+
+func (m *MockConversationRepository) AddTag(ctx context.Context, instanceID, phone, tag string) error {
+	args := m.Called(ctx, instanceID, phone, tag)
+	return args.Error(0)
+}
+
+func (m *MockConversationRepository) SetHandoff(ctx context.Context, instanceID, phone string, at *time.Time) error {
+	args := m.Called(ctx, instanceID, phone, at)
+	return args.Error(0)
+}
+
+// MockAutomationRuleRepository é um mock de AutomationRuleRepository
+type MockAutomationRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockAutomationRuleRepository) Save(ctx context.Context, rule *domain.AutomationRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAutomationRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.AutomationRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AutomationRule), args.Error(1)
+}
+
+func (m *MockAutomationRuleRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID, activeOnly bool) ([]*domain.AutomationRule, error) {
+	args := m.Called(ctx, instanceID, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AutomationRule), args.Error(1)
+}
+
+func (m *MockAutomationRuleRepository) Update(ctx context.Context, rule *domain.AutomationRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAutomationRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockRuleExecutionRepository é um mock de RuleExecutionRepository
+type MockRuleExecutionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleExecutionRepository) Save(ctx context.Context, execution *domain.RuleExecution) error {
+	args := m.Called(ctx, execution)
+	return args.Error(0)
+}
+
+func (m *MockRuleExecutionRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID, ruleID *uuid.UUID, limit int, before *domain.Cursor) ([]*domain.RuleExecution, error) {
+	args := m.Called(ctx, instanceID, ruleID, limit, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RuleExecution), args.Error(1)
+}
+
+// MockMessageSender é um mock de MessageSender
+type MockMessageSender struct {
+	mock.Mock
+}
+
+func (m *MockMessageSender) SendMessage(ctx context.Context, request domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+// MockRetentionPolicyRepository é um mock de RetentionPolicyRepository
+type MockRetentionPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionPolicyRepository) Save(ctx context.Context, policy *domain.RetentionPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockRetentionPolicyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RetentionPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionPolicyRepository) List(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockExportJobRepository é um mock de ExportJobRepository
+type MockExportJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockExportJobRepository) Save(ctx context.Context, job *domain.ExportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockExportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExportJob), args.Error(1)
+}
+
+func (m *MockExportJobRepository) List(ctx context.Context, limit int, before *domain.Cursor) ([]*domain.ExportJob, error) {
+	args := m.Called(ctx, limit, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ExportJob), args.Error(1)
+}
+
+func (m *MockExportJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.ExportJob, error) {
+	args := m.Called(ctx, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExportJob), args.Error(1)
+}
+
+func (m *MockExportJobRepository) Update(ctx context.Context, job *domain.ExportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockExportJobRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ExportJob), args.Error(1)
+}
+
+func (m *MockExportJobRepository) ListCompleted(ctx context.Context, phone string) ([]*domain.ExportJob, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ExportJob), args.Error(1)
+}
+
+// MockFileStorage é um mock do armazenamento de exportações e mídias
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) Create(name string) (io.WriteCloser, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.WriteCloser), args.Error(1)
+}
+
+func (m *MockFileStorage) Open(name string) (io.ReadCloser, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockFileStorage) Remove(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+// closingBuffer guarda em memória o arquivo criado no armazenamento
+type closingBuffer struct {
+	*bytes.Buffer
+}
+
+func (closingBuffer) Close() error {
+	return nil
+}
+
+// MockAuditRecorder é um mock do Recorder de auditoria
+type MockAuditRecorder struct {
+	mock.Mock
+}
+
+func (m *MockAuditRecorder) Record(ctx context.Context, record auditDomain.Record) {
+	m.Called(ctx, record)
+}
+
+// MockErasureRepository é um mock de ErasureRepository
+type MockErasureRepository struct {
+	mock.Mock
+}
+
+func (m *MockErasureRepository) Erase(ctx context.Context, tenantID uuid.UUID, phone string, removeOptOuts bool) (*domain.ErasureCounts, error) {
+	args := m.Called(ctx, tenantID, phone, removeOptOuts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureCounts), args.Error(1)
+}
+
+// MockErasureReceiptRepository é um mock de ErasureReceiptRepository
+type MockErasureReceiptRepository struct {
+	mock.Mock
+}
+
+func (m *MockErasureReceiptRepository) Save(ctx context.Context, receipt *domain.ErasureReceipt) (bool, error) {
+	args := m.Called(ctx, receipt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockErasureReceiptRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureReceipt), args.Error(1)
+}
+
+func (m *MockErasureReceiptRepository) Last(ctx context.Context, tenantID uuid.UUID) (*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureReceipt), args.Error(1)
+}
+
+func (m *MockErasureReceiptRepository) List(ctx context.Context, limit int, before *domain.Cursor) ([]*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, limit, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ErasureReceipt), args.Error(1)
+}
+
+func (m *MockErasureReceiptRepository) ListChain(ctx context.Context, tenantID uuid.UUID, afterSequence int64, limit int) ([]*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, tenantID, afterSequence, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ErasureReceipt), args.Error(1)
+}
+
+// MockAuditAnonymizer é um mock do Anonymizer de auditoria
+type MockAuditAnonymizer struct {
+	mock.Mock
+}
+
+func (m *MockAuditAnonymizer) Anonymize(ctx context.Context, tenantID uuid.UUID, value string) (int64, error) {
+	args := m.Called(ctx, tenantID, value)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockMediaRepository é um mock de MediaRepository
+type MockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaRepository) Save(ctx context.Context, media *domain.Media) error {
+	args := m.Called(ctx, media)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) ListOrphaned(ctx context.Context, before time.Time, limit int) ([]*domain.Media, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Media), args.Error(1)
+}
+
+// MockMediaDownloadRepository é um mock de MediaDownloadRepository
+type MockMediaDownloadRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaDownloadRepository) Save(ctx context.Context, download *domain.MediaDownload) error {
+	args := m.Called(ctx, download)
+	return args.Error(0)
+}
+
+func (m *MockMediaDownloadRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.MediaDownload, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MediaDownload), args.Error(1)
+}
+
+func (m *MockMediaDownloadRepository) RecordAttempt(ctx context.Context, download *domain.MediaDownload) error {
+	args := m.Called(ctx, download)
+	return args.Error(0)
+}
+
+// MockInstanceGroupRepository é um mock de InstanceGroupRepository
+type MockInstanceGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockInstanceGroupRepository) Save(ctx context.Context, group *domain.InstanceGroup) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockInstanceGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InstanceGroup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InstanceGroup), args.Error(1)
+}
+
+func (m *MockInstanceGroupRepository) GetAll(ctx context.Context) ([]*domain.InstanceGroup, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.InstanceGroup), args.Error(1)
+}
+
+func (m *MockInstanceGroupRepository) Update(ctx context.Context, group *domain.InstanceGroup) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockInstanceGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockInstanceGroupRepository) RemoveInstance(ctx context.Context, instanceID uuid.UUID) error {
+	args := m.Called(ctx, instanceID)
+	return args.Error(0)
+}
@@ -0,0 +1,302 @@
+package application
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// Limites do telefone normalizado aceito na lista de opt-out
+const (
+	minOptOutPhoneLength = 8
+	maxOptOutPhoneLength = 20
+)
+
+// OptOutSettings configura a detecção automática de opt-out
+type OptOutSettings struct {
+	Keywords []string // mensagens que incluem o contato na lista; vazio desativa a detecção
+	Global   bool     // o opt-out por palavra-chave vale para todas as instâncias
+}
+
+// OptOutService gerencia a lista de telefones que não devem receber mensagens,
+// alimentada pela API, por importação de CSV e pelas palavras de opt-out
+// enviadas pelos contatos
+type OptOutService struct {
+	optOuts      domain.OptOutRepository
+	instanceRepo domain.InstanceRepository
+	publisher    domain.EventPublisher
+	settings     OptOutSettings
+	logger       zerolog.Logger
+}
+
+// NewOptOutService cria um novo serviço de opt-out
+func NewOptOutService(
+	optOuts domain.OptOutRepository,
+	instanceRepo domain.InstanceRepository,
+	publisher domain.EventPublisher,
+	settings OptOutSettings,
+	logger zerolog.Logger,
+) *OptOutService {
+	return &OptOutService{
+		optOuts:      optOuts,
+		instanceRepo: instanceRepo,
+		publisher:    publisher,
+		settings:     settings,
+		logger:       logger.With().Str("service", "opt_out").Logger(),
+	}
+}
+
+// Create inclui um telefone na lista. Sem instância, o envio é bloqueado em todas
+func (s *OptOutService) Create(ctx context.Context, request domain.CreateOptOutRequest) (*domain.OptOut, error) {
+	if err := s.requireInstance(ctx, request.InstanceID); err != nil {
+		return nil, err
+	}
+
+	phone, err := normalizeOptOutPhone(request.Phone)
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+
+	optOut := &domain.OptOut{
+		ID:         uuid.New(),
+		TenantID:   contextTenant(ctx),
+		InstanceID: request.InstanceID,
+		Phone:      phone,
+		Source:     domain.OptOutAPI,
+		Note:       strings.TrimSpace(request.Note),
+		CreatedAt:  time.Now(),
+	}
+
+	created, err := s.optOuts.Save(ctx, optOut)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, apperrors.NewConflictError("phone is already opted out")
+	}
+
+	s.logger.Info().
+		Str("opt_out_id", optOut.ID.String()).
+		Bool("global", optOut.InstanceID == nil).
+		Msg("Phone opted out")
+
+	return optOut, nil
+}
+
+// List lista as entradas da instância ou, sem instância, as que valem para
+// todas. Retorna também o cursor da próxima página
+func (s *OptOutService) List(ctx context.Context, filter domain.OptOutFilter, limit int, cursor string) ([]*domain.OptOut, string, error) {
+	if err := s.requireInstance(ctx, filter.InstanceID); err != nil {
+		return nil, "", err
+	}
+	if filter.Phone != "" {
+		filter.Phone = domain.NormalizePhone(filter.Phone)
+	}
+
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	optOuts, err := s.optOuts.List(ctx, filter, limit+1, before)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(optOuts) > limit {
+		optOuts = optOuts[:limit]
+		last := optOuts[limit-1]
+		next = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return optOuts, next, nil
+}
+
+// Delete remove uma entrada, voltando a permitir o envio ao telefone
+func (s *OptOutService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.optOuts.Delete(ctx, id); err != nil {
+		return apperrors.NewNotFoundError("opt-out")
+	}
+
+	s.logger.Info().Str("opt_out_id", id.String()).Msg("Opt-out removed")
+	return nil
+}
+
+// Import inclui na lista os telefones da primeira coluna do CSV. Uma linha de
+// cabeçalho é ignorada; linhas inválidas são informadas sem interromper a importação
+func (s *OptOutService) Import(ctx context.Context, instanceID *uuid.UUID, reader io.Reader) (*domain.OptOutImportResult, error) {
+	if err := s.requireInstance(ctx, instanceID); err != nil {
+		return nil, err
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	result := &domain.OptOutImportResult{Invalid: []domain.OptOutImportError{}}
+	tenantID := contextTenant(ctx)
+	now := time.Now()
+
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("invalid CSV at line %d: %v", line, err))
+		}
+
+		value := strings.TrimSpace(record[0])
+		if value == "" {
+			continue
+		}
+
+		phone, err := normalizeOptOutPhone(value)
+		if err != nil {
+			// A primeira linha sem telefone é tratada como cabeçalho
+			if line == 1 && domain.NormalizePhone(value) == "" {
+				continue
+			}
+			result.Invalid = append(result.Invalid, domain.OptOutImportError{Line: line, Value: value, Error: err.Error()})
+			continue
+		}
+
+		optOut := &domain.OptOut{
+			ID:         uuid.New(),
+			TenantID:   tenantID,
+			InstanceID: instanceID,
+			Phone:      phone,
+			Source:     domain.OptOutImport,
+			CreatedAt:  now,
+		}
+		if len(record) > 1 {
+			optOut.Note = strings.TrimSpace(record[1])
+		}
+
+		created, err := s.optOuts.Save(ctx, optOut)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	s.logger.Info().
+		Bool("global", instanceID == nil).
+		Int("imported", result.Imported).
+		Int("skipped", result.Skipped).
+		Int("invalid", len(result.Invalid)).
+		Msg("Opt-out list imported")
+
+	return result, nil
+}
+
+// Check recusa o envio ao telefone quando ele está na lista global do tenant
+// ou de uma das instâncias que podem enviar a mensagem
+func (s *OptOutService) Check(ctx context.Context, tenantID uuid.UUID, phone string, instanceIDs []uuid.UUID) error {
+	optOut, err := s.optOuts.Find(ctx, tenantID, domain.NormalizePhone(phone), instanceIDs)
+	if err != nil {
+		return fmt.Errorf("failed to check opt-out list: %w", err)
+	}
+	if optOut != nil {
+		return apperrors.NewForbiddenError(domain.ErrCodeRecipientOptedOut,
+			"recipient has opted out of receiving messages; set transactional to send anyway")
+	}
+	return nil
+}
+
+// HandleInbound inclui o contato na lista quando a mensagem recebida é uma das
+// palavras de opt-out. Retorna a entrada criada ou nil
+func (s *OptOutService) HandleInbound(ctx context.Context, instance *domain.Instance, message *domain.Message) (*domain.OptOut, error) {
+	if message.Direction != domain.DirectionInbound || message.Phone == "" {
+		return nil, nil
+	}
+
+	keyword, ok := s.matchKeyword(message.Content)
+	if !ok {
+		return nil, nil
+	}
+
+	optOut := &domain.OptOut{
+		ID:        uuid.New(),
+		TenantID:  instance.TenantID,
+		Phone:     message.Phone,
+		Source:    domain.OptOutKeyword,
+		Keyword:   keyword,
+		CreatedAt: time.Now(),
+	}
+	if !s.settings.Global {
+		instanceID := instance.ID
+		optOut.InstanceID = &instanceID
+	}
+
+	created, err := s.optOuts.Save(ctx, optOut)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, nil
+	}
+
+	s.logger.Info().
+		Str("opt_out_id", optOut.ID.String()).
+		Str("instance_id", instance.ID.String()).
+		Str("message_id", message.ID.String()).
+		Str("keyword", keyword).
+		Msg("Contact opted out by keyword")
+
+	s.publisher.Publish(ctx, domain.NewContactOptedOutEvent(instance.ID, optOut, message.ID))
+
+	return optOut, nil
+}
+
+// matchKeyword indica se a mensagem inteira é uma das palavras de opt-out,
+// sem diferenciar maiúsculas e minúsculas
+func (s *OptOutService) matchKeyword(content string) (string, bool) {
+	normalized := strings.TrimSpace(content)
+	if normalized == "" {
+		return "", false
+	}
+	for _, keyword := range s.settings.Keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" && strings.EqualFold(normalized, keyword) {
+			return keyword, true
+		}
+	}
+	return "", false
+}
+
+// requireInstance verifica a instância do escopo, quando informada
+func (s *OptOutService) requireInstance(ctx context.Context, instanceID *uuid.UUID) error {
+	if instanceID == nil {
+		return nil
+	}
+	if _, err := s.instanceRepo.GetByID(ctx, *instanceID); err != nil {
+		return apperrors.NewNotFoundError("instance")
+	}
+	return nil
+}
+
+// normalizeOptOutPhone normaliza o telefone e verifica o tamanho
+func normalizeOptOutPhone(phone string) (string, error) {
+	normalized := domain.NormalizePhone(phone)
+	if len(normalized) < minOptOutPhoneLength || len(normalized) > maxOptOutPhoneLength {
+		return "", fmt.Errorf("phone must have between %d and %d digits", minOptOutPhoneLength, maxOptOutPhoneLength)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,164 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+type memoryOptOuts struct {
+	domain.OptOutRepository
+	items []*domain.OptOut
+}
+
+func (m *memoryOptOuts) Save(ctx context.Context, optOut *domain.OptOut) (bool, error) {
+	for _, item := range m.items {
+		if item.TenantID == optOut.TenantID && item.Phone == optOut.Phone && sameOptOutScope(item.InstanceID, optOut.InstanceID) {
+			return false, nil
+		}
+	}
+	m.items = append(m.items, optOut)
+	return true, nil
+}
+
+func (m *memoryOptOuts) Find(ctx context.Context, tenantID uuid.UUID, phone string, instanceIDs []uuid.UUID) (*domain.OptOut, error) {
+	for _, item := range m.items {
+		if item.TenantID != tenantID || item.Phone != phone {
+			continue
+		}
+		if item.InstanceID == nil {
+			return item, nil
+		}
+		for _, id := range instanceIDs {
+			if *item.InstanceID == id {
+				return item, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func sameOptOutScope(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event events.Event) {
+	p.events = append(p.events, event)
+}
+
+func newTestOptOutService(settings application.OptOutSettings) (*application.OptOutService, *memoryOptOuts, *recordingPublisher) {
+	optOuts := &memoryOptOuts{}
+	publisher := &recordingPublisher{}
+	return application.NewOptOutService(optOuts, nil, publisher, settings, zerolog.Nop()), optOuts, publisher
+}
+
+func inboundMessage(instance *domain.Instance, content string) *domain.Message {
+	return &domain.Message{
+		ID:         uuid.New(),
+		InstanceID: instance.ID.String(),
+		Phone:      "5511999990000",
+		Direction:  domain.DirectionInbound,
+		Type:       domain.TextMessage,
+		Content:    content,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func TestOptOutService_KeywordBlocksInstance(t *testing.T) {
+	service, _, publisher := newTestOptOutService(application.OptOutSettings{Keywords: []string{"STOP", "SAIR"}})
+	instance := &domain.Instance{ID: uuid.New(), TenantID: uuid.New()}
+	ctx := context.Background()
+
+	optOut, err := service.HandleInbound(ctx, instance, inboundMessage(instance, "  sair "))
+	require.NoError(t, err)
+	require.NotNil(t, optOut)
+	assert.Equal(t, domain.OptOutKeyword, optOut.Source)
+	assert.Equal(t, "SAIR", optOut.Keyword)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, domain.EventContactOptedOut, publisher.events[0].GetName())
+
+	// Repetir a palavra não gera outro evento
+	optOut, err = service.HandleInbound(ctx, instance, inboundMessage(instance, "STOP"))
+	require.NoError(t, err)
+	assert.Nil(t, optOut)
+	assert.Len(t, publisher.events, 1)
+
+	err = service.Check(ctx, instance.TenantID, "+55 (11) 99999-0000", []uuid.UUID{instance.ID})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, domain.ErrCodeRecipientOptedOut, appErr.Code)
+
+	// O opt-out por instância não bloqueia as demais
+	assert.NoError(t, service.Check(ctx, instance.TenantID, "5511999990000", []uuid.UUID{uuid.New()}))
+}
+
+func TestOptOutService_IgnoresMessagesThatOnlyContainKeyword(t *testing.T) {
+	service, optOuts, _ := newTestOptOutService(application.OptOutSettings{Keywords: []string{"STOP"}})
+	instance := &domain.Instance{ID: uuid.New()}
+
+	optOut, err := service.HandleInbound(context.Background(), instance, inboundMessage(instance, "please don't stop"))
+	require.NoError(t, err)
+	assert.Nil(t, optOut)
+	assert.Empty(t, optOuts.items)
+}
+
+func TestOptOutService_GlobalScope(t *testing.T) {
+	service, _, publisher := newTestOptOutService(application.OptOutSettings{Keywords: []string{"CANCELAR"}, Global: true})
+	instance := &domain.Instance{ID: uuid.New(), TenantID: uuid.New()}
+	ctx := context.Background()
+
+	optOut, err := service.HandleInbound(ctx, instance, inboundMessage(instance, "cancelar"))
+	require.NoError(t, err)
+	require.NotNil(t, optOut)
+	assert.Nil(t, optOut.InstanceID)
+	assert.Equal(t, instance.TenantID, optOut.TenantID)
+	event := publisher.events[0].(*domain.ContactOptedOutEvent)
+	assert.True(t, event.Global)
+	assert.Equal(t, instance.TenantID, event.GetTenantID())
+
+	assert.Error(t, service.Check(ctx, instance.TenantID, "5511999990000", []uuid.UUID{uuid.New()}))
+
+	// O opt-out global não vale para instâncias de outros tenants
+	assert.NoError(t, service.Check(ctx, uuid.New(), "5511999990000", []uuid.UUID{uuid.New()}))
+}
+
+func TestOptOutService_Import(t *testing.T) {
+	service, optOuts, _ := newTestOptOutService(application.OptOutSettings{})
+	csv := "phone,note\n+55 11 98888-0001,cliente\n5511988880002\n123\n\n5511988880001\n"
+
+	tenantID := uuid.New()
+
+	result, err := service.Import(tenantDomain.NewContext(context.Background(), tenantID), nil, strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Skipped)
+	require.Len(t, result.Invalid, 1)
+	assert.Equal(t, 4, result.Invalid[0].Line)
+	require.Len(t, optOuts.items, 2)
+	assert.Equal(t, "5511988880001", optOuts.items[0].Phone)
+	assert.Equal(t, "cliente", optOuts.items[0].Note)
+	assert.Equal(t, domain.OptOutImport, optOuts.items[0].Source)
+	assert.Equal(t, tenantID, optOuts.items[0].TenantID)
+}
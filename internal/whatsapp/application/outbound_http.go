@@ -0,0 +1,39 @@
+package application
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// newOutboundClient cria o cliente HTTP das chamadas a endereços informados
+// por clientes ou providers. Sem allowPrivateNetworks, conexões à rede interna
+// são recusadas, inclusive depois de redirecionamentos
+func newOutboundClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = denyPrivateNetworks
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// denyPrivateNetworks recusa conexões a endereços internos, conferindo o IP já
+// resolvido para que um nome apontando para a rede interna também seja recusado
+func denyPrivateNetworks(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("connection to %s is not allowed", host)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+)
+
+// providerHealthTracker guarda o último erro de health check de cada provider
+type providerHealthTracker struct {
+	lastErrors map[string]providerError
+	mu         sync.RWMutex
+}
+
+type providerError struct {
+	message string
+	at      time.Time
+}
+
+func newProviderHealthTracker() *providerHealthTracker {
+	return &providerHealthTracker{lastErrors: make(map[string]providerError)}
+}
+
+func (t *providerHealthTracker) record(provider string, err error, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastErrors[provider] = providerError{message: err.Error(), at: at}
+}
+
+func (t *providerHealthTracker) last(provider string) (providerError, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	last, ok := t.lastErrors[provider]
+	return last, ok
+}
+
+// GetProviderFeatures retorna as funcionalidades suportadas por um provider
+func (s *WhatsAppService) GetProviderFeatures(providerName string) ([]domain.ProviderFeature, error) {
+	provider, exists := s.providerRegistry.Get(providerName)
+	if !exists {
+		return nil, apperrors.NewNotFoundError(fmt.Sprintf("provider %s", providerName))
+	}
+
+	return providerFeatures(provider), nil
+}
+
+// CheckProviderHealth verifica a saúde de um provider específico
+func (s *WhatsAppService) CheckProviderHealth(ctx context.Context, providerName string) error {
+	provider, exists := s.providerRegistry.Get(providerName)
+	if !exists {
+		return apperrors.NewNotFoundError(fmt.Sprintf("provider %s", providerName))
+	}
+
+	return checkHealth(ctx, provider)
+}
+
+// CheckAllProvidersHealth verifica a saúde de todos os providers
+func (s *WhatsAppService) CheckAllProvidersHealth(ctx context.Context) map[string]error {
+	results := make(map[string]error)
+	for name, provider := range s.providerRegistry.GetAll() {
+		results[name] = checkHealth(ctx, provider)
+	}
+	return results
+}
+
+// GetProviderHealth verifica um provider e retorna latência, último erro e o
+// estado do seu circuit breaker
+func (s *WhatsAppService) GetProviderHealth(ctx context.Context, providerName string) (*domain.ProviderHealth, error) {
+	provider, exists := s.providerRegistry.Get(providerName)
+	if !exists {
+		return nil, apperrors.NewNotFoundError(fmt.Sprintf("provider %s", providerName))
+	}
+
+	return s.providerHealth(ctx, provider), nil
+}
+
+// GetAllProvidersHealth verifica todos os providers, ordenados por nome
+func (s *WhatsAppService) GetAllProvidersHealth(ctx context.Context) []*domain.ProviderHealth {
+	providers := s.providerRegistry.GetAll()
+
+	results := make([]*domain.ProviderHealth, 0, len(providers))
+	for _, provider := range providers {
+		results = append(results, s.providerHealth(ctx, provider))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Provider < results[j].Provider
+	})
+	return results
+}
+
+// providerHealth executa o health check do provider e combina o resultado com
+// o estado do circuit breaker, que reflete as chamadas reais
+func (s *WhatsAppService) providerHealth(ctx context.Context, provider domain.WhatsAppProvider) *domain.ProviderHealth {
+	name := provider.GetName()
+
+	started := time.Now()
+	err := checkHealth(ctx, provider)
+	checkedAt := time.Now()
+
+	health := &domain.ProviderHealth{
+		Provider:     name,
+		Status:       domain.ProviderHealthy,
+		LatencyMs:    checkedAt.Sub(started).Milliseconds(),
+		CircuitState: string(circuitbreaker.StateClosed),
+		CheckedAt:    checkedAt,
+	}
+
+	if err != nil {
+		s.health.record(name, err, checkedAt)
+		message := err.Error()
+		health.Error = &message
+		health.Status = domain.ProviderUnhealthy
+	}
+
+	var lastError string
+	var lastErrorAt time.Time
+	if last, ok := s.health.last(name); ok {
+		lastError, lastErrorAt = last.message, last.at
+	}
+
+	circuit := s.breakers.Get(name).Snapshot()
+	health.CircuitState = string(circuit.State)
+	if circuit.LastFailureAt != nil && circuit.LastFailureAt.After(lastErrorAt) {
+		lastError, lastErrorAt = circuit.LastError, *circuit.LastFailureAt
+	}
+	if circuit.State != circuitbreaker.StateClosed && health.Status == domain.ProviderHealthy {
+		health.Status = domain.ProviderDegraded
+	}
+
+	if lastError != "" {
+		health.LastError = &lastError
+		health.LastErrorAt = &lastErrorAt
+	}
+
+	return health
+}
+
+// checkHealth executa o health check do provider, considerando saudáveis os
+// providers que não implementam a verificação
+func checkHealth(ctx context.Context, provider domain.WhatsAppProvider) error {
+	if healthProvider, ok := provider.(interface{ HealthCheck(context.Context) error }); ok {
+		return healthProvider.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// providerFeatures retorna as funcionalidades declaradas pelo provider
+func providerFeatures(provider domain.WhatsAppProvider) []domain.ProviderFeature {
+	if extendedProvider, ok := provider.(interface {
+		GetSupportedFeatures() []domain.ProviderFeature
+	}); ok {
+		return extendedProvider.GetSupportedFeatures()
+	}
+
+	// Features básicas para providers que não implementam a interface estendida
+	return []domain.ProviderFeature{
+		domain.FeatureTextMessages,
+		domain.FeatureStatusCheck,
+	}
+}
+
+// requireFeature rejeita a operação antes de chamar o provider quando ele não
+// declara a funcionalidade necessária
+func requireFeature(provider domain.WhatsAppProvider, feature domain.ProviderFeature) error {
+	for _, supported := range providerFeatures(provider) {
+		if supported == feature {
+			return nil
+		}
+	}
+	return apperrors.NewFeatureNotSupportedError(
+		fmt.Sprintf("provider %s does not support %s", provider.GetName(), feature))
+}
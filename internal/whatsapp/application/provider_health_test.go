@@ -0,0 +1,96 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// assertFeatureNotSupported verifica se a operação foi rejeitada por falta da funcionalidade
+func assertFeatureNotSupported(t *testing.T, err error) {
+	t.Helper()
+	require.ErrorIs(t, err, apperrors.ErrBadRequest)
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "FEATURE_NOT_SUPPORTED", appErr.Code)
+}
+
+func TestWhatsAppService_SendMessageRequiresFeature(t *testing.T) {
+	ctx := context.Background()
+	instance := &domain.Instance{ID: uuid.New(), TenantID: uuid.New(), Provider: "z-api"}
+	mediaURL := "https://files.example.com/video.mp4"
+
+	provider := new(MockFeatureProvider)
+	provider.On("GetName").Return("z-api")
+	provider.On("GetSupportedFeatures").Return([]domain.ProviderFeature{domain.FeatureTextMessages, domain.FeatureImageMessages})
+	resolver := new(MockProviderResolver)
+	resolver.On("Resolve", instance).Return(provider, nil)
+	instanceRepo := new(MockInstanceRepository)
+	instanceRepo.On("GetByID", ctx, instance.ID).Return(instance, nil)
+	messageRepo := new(MockMessageRepository)
+
+	service := application.NewWhatsAppService(nil, resolver, messageRepo, nil, instanceRepo, nil, nil, nil, nil, nil, nil, zerolog.Nop())
+
+	for _, messageType := range []domain.MessageType{domain.VideoMessage, domain.AudioMessage, domain.DocumentMessage} {
+		_, err := service.SendMessage(ctx, domain.SendMessageRequest{
+			InstanceID:    instance.ID.String(),
+			Phone:         "5511999999999",
+			Type:          messageType,
+			MediaURL:      &mediaURL,
+			Transactional: true,
+		})
+		assertFeatureNotSupported(t, err)
+	}
+
+	// A mensagem é rejeitada antes de ser gravada ou enviada ao provider
+	messageRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	provider.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWhatsAppService_ProfileUpdatesRequireFeature(t *testing.T) {
+	ctx := context.Background()
+	instance := &domain.Instance{ID: uuid.New(), Provider: "z-api"}
+	nameRequest := domain.UpdateProfileNameRequest{InstanceID: instance.ID.String(), Name: "Loja"}
+	pictureRequest := domain.UpdateProfilePictureRequest{InstanceID: instance.ID.String(), PictureURL: "https://files.example.com/logo.png"}
+
+	instanceRepo := new(MockInstanceRepository)
+	instanceRepo.On("GetByID", ctx, instance.ID).Return(instance, nil)
+
+	// Providers sem a interface estendida suportam apenas texto e status
+	basic := new(MockProvider)
+	basic.On("GetName").Return("basic")
+	resolver := new(MockProviderResolver)
+	resolver.On("Resolve", instance).Return(basic, nil).Twice()
+	service := newTestWhatsAppService(instanceRepo, resolver)
+
+	_, err := service.UpdateProfileName(ctx, nameRequest)
+	assertFeatureNotSupported(t, err)
+	_, err = service.UpdateProfilePicture(ctx, pictureRequest)
+	assertFeatureNotSupported(t, err)
+	basic.AssertNotCalled(t, "UpdateProfileName", mock.Anything, mock.Anything, mock.Anything)
+	basic.AssertNotCalled(t, "UpdateProfilePicture", mock.Anything, mock.Anything, mock.Anything)
+
+	extended := new(MockFeatureProvider)
+	extended.On("GetName").Return("z-api")
+	extended.On("GetSupportedFeatures").Return([]domain.ProviderFeature{domain.FeatureTextMessages, domain.FeatureProfileName})
+	extended.On("UpdateProfileName", ctx, instance, nameRequest).Return(&domain.UpdateProfileResponse{Success: false}, nil).Once()
+	resolver.On("Resolve", instance).Return(extended, nil)
+
+	_, err = service.UpdateProfileName(ctx, nameRequest)
+	require.NoError(t, err)
+	_, err = service.UpdateProfilePicture(ctx, pictureRequest)
+	assertFeatureNotSupported(t, err)
+
+	extended.AssertExpectations(t)
+	extended.AssertNotCalled(t, "UpdateProfilePicture", mock.Anything, mock.Anything, mock.Anything)
+}
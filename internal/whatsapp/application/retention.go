@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// RetentionSettings configura a aplicação das políticas em lotes
+type RetentionSettings struct {
+	BatchSize  int           // mensagens alteradas ou removidas por transação
+	BatchPause time.Duration // espera entre lotes, limitando a carga no banco
+}
+
+// RetentionService gerencia as políticas de retenção de mensagens e as aplica,
+// apagando o conteúdo e removendo as mensagens vencidas
+type RetentionService struct {
+	policies     domain.RetentionPolicyRepository
+	messages     domain.MessageRepository
+	instanceRepo domain.InstanceRepository
+	settings     RetentionSettings
+	logger       zerolog.Logger
+}
+
+// NewRetentionService cria um novo serviço de retenção
+func NewRetentionService(
+	policies domain.RetentionPolicyRepository,
+	messages domain.MessageRepository,
+	instanceRepo domain.InstanceRepository,
+	settings RetentionSettings,
+	logger zerolog.Logger,
+) *RetentionService {
+	if settings.BatchSize <= 0 {
+		settings.BatchSize = 1000
+	}
+
+	return &RetentionService{
+		policies:     policies,
+		messages:     messages,
+		instanceRepo: instanceRepo,
+		settings:     settings,
+		logger:       logger.With().Str("service", "retention").Logger(),
+	}
+}
+
+// SetPolicy cria ou substitui a política do tenant ou, com instance_id, da instância
+func (s *RetentionService) SetPolicy(ctx context.Context, request domain.RetentionPolicyRequest) (*domain.RetentionPolicy, error) {
+	policy, err := s.newPolicy(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policies.Save(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("policy_id", policy.ID.String()).
+		Int("content_days", policy.ContentDays).
+		Int("metadata_days", policy.MetadataDays).
+		Msg("Retention policy saved")
+
+	return policy, nil
+}
+
+// ListPolicies lista as políticas do tenant
+func (s *RetentionService) ListPolicies(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	return s.policies.List(ctx)
+}
+
+// DeletePolicy remove uma política; as mensagens passam a seguir a política do
+// tenant ou, sem ela, a ser mantidas
+func (s *RetentionService) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	if err := s.policies.Delete(ctx, id); err != nil {
+		return apperrors.NewNotFoundError("retention policy")
+	}
+
+	s.logger.Info().Str("policy_id", id.String()).Msg("Retention policy removed")
+	return nil
+}
+
+// DryRun informa quantas mensagens a política teria o conteúdo apagado e
+// quantas seriam removidas se fosse aplicada agora, sem alterar nada
+func (s *RetentionService) DryRun(ctx context.Context, request domain.RetentionPolicyRequest) (*domain.RetentionReport, error) {
+	policy, err := s.newPolicy(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := s.policies.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := newRetentionReport(policy, time.Now())
+	report.DryRun = true
+
+	base := retentionScope(policy, policies)
+	if target, ok := deleteTarget(base, report); ok {
+		if report.MessagesDeleted, err = s.messages.CountRetention(ctx, target); err != nil {
+			return nil, err
+		}
+	}
+	if target, ok := redactTarget(base, report); ok {
+		if report.ContentRedacted, err = s.messages.CountRetention(ctx, target); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// Purge aplica as políticas de todos os tenants. Falhas de uma política são
+// registradas sem interromper as demais
+func (s *RetentionService) Purge(ctx context.Context) ([]*domain.RetentionReport, error) {
+	policies, err := s.policies.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reports := make([]*domain.RetentionReport, 0, len(policies))
+	for _, policy := range policies {
+		if ctx.Err() != nil {
+			return reports, ctx.Err()
+		}
+
+		report, err := s.apply(ctx, policy, policies, now)
+		if err != nil {
+			s.logger.Error().
+				Err(err).
+				Str("policy_id", policy.ID.String()).
+				Str("tenant_id", policy.TenantID.String()).
+				Msg("Failed to apply retention policy")
+		}
+		if report.ContentRedacted > 0 || report.MessagesDeleted > 0 {
+			s.logger.Info().
+				Str("policy_id", policy.ID.String()).
+				Str("tenant_id", policy.TenantID.String()).
+				Int64("content_redacted", report.ContentRedacted).
+				Int64("messages_deleted", report.MessagesDeleted).
+				Msg("Retention policy applied")
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// apply remove as mensagens vencidas e depois apaga o conteúdo das restantes,
+// evitando alterar mensagens que seriam removidas em seguida
+func (s *RetentionService) apply(ctx context.Context, policy *domain.RetentionPolicy, policies []*domain.RetentionPolicy, now time.Time) (*domain.RetentionReport, error) {
+	report := newRetentionReport(policy, now)
+	report.PolicyID = &policy.ID
+
+	var err error
+	base := retentionScope(policy, policies)
+	if target, ok := deleteTarget(base, report); ok {
+		if report.MessagesDeleted, err = s.inBatches(ctx, target, s.messages.DeleteMessages); err != nil {
+			return report, err
+		}
+	}
+	if target, ok := redactTarget(base, report); ok {
+		if report.ContentRedacted, err = s.inBatches(ctx, target, s.messages.RedactContent); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// inBatches repete a operação até um lote vir incompleto, com uma pausa entre
+// os lotes para não competir com o envio de mensagens
+func (s *RetentionService) inBatches(
+	ctx context.Context,
+	target domain.RetentionTarget,
+	operation func(ctx context.Context, target domain.RetentionTarget, limit int) (int64, error),
+) (int64, error) {
+	var total int64
+	for {
+		affected, err := operation(ctx, target, s.settings.BatchSize)
+		total += affected
+		if err != nil {
+			return total, err
+		}
+		if affected < int64(s.settings.BatchSize) {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-time.After(s.settings.BatchPause):
+		}
+	}
+}
+
+// newPolicy valida a requisição e monta a política no tenant da requisição
+func (s *RetentionService) newPolicy(ctx context.Context, request domain.RetentionPolicyRequest) (*domain.RetentionPolicy, error) {
+	if request.InstanceID != nil {
+		if _, err := s.instanceRepo.GetByID(ctx, *request.InstanceID); err != nil {
+			return nil, apperrors.NewNotFoundError("instance")
+		}
+	}
+
+	now := time.Now()
+	policy := &domain.RetentionPolicy{
+		ID:           uuid.New(),
+		TenantID:     contextTenant(ctx),
+		InstanceID:   request.InstanceID,
+		ContentDays:  request.ContentDays,
+		MetadataDays: request.MetadataDays,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+
+	return policy, nil
+}
+
+// newRetentionReport monta o relatório com os prazos da política
+func newRetentionReport(policy *domain.RetentionPolicy, now time.Time) *domain.RetentionReport {
+	contentBefore, deleteBefore := policy.Cutoffs(now)
+	return &domain.RetentionReport{
+		TenantID:      policy.TenantID,
+		InstanceID:    policy.InstanceID,
+		ContentBefore: contentBefore,
+		DeleteBefore:  deleteBefore,
+	}
+}
+
+// retentionScope seleciona as mensagens da instância da política ou, na
+// política do tenant, as das instâncias sem política própria
+func retentionScope(policy *domain.RetentionPolicy, policies []*domain.RetentionPolicy) domain.RetentionTarget {
+	target := domain.RetentionTarget{TenantID: policy.TenantID}
+	if policy.InstanceID != nil {
+		target.InstanceIDs = []string{policy.InstanceID.String()}
+		return target
+	}
+
+	for _, other := range policies {
+		if other.TenantID == policy.TenantID && other.InstanceID != nil {
+			target.ExcludeInstanceIDs = append(target.ExcludeInstanceIDs, other.InstanceID.String())
+		}
+	}
+	return target
+}
+
+// deleteTarget seleciona as mensagens a remover, quando a política remove mensagens
+func deleteTarget(base domain.RetentionTarget, report *domain.RetentionReport) (domain.RetentionTarget, bool) {
+	if report.DeleteBefore == nil {
+		return base, false
+	}
+	base.Before = *report.DeleteBefore
+	return base, true
+}
+
+// redactTarget seleciona as mensagens cujo conteúdo deve ser apagado, sem
+// incluir as que já serão removidas
+func redactTarget(base domain.RetentionTarget, report *domain.RetentionReport) (domain.RetentionTarget, bool) {
+	if report.ContentBefore == nil {
+		return base, false
+	}
+	base.Before = *report.ContentBefore
+	base.NotBefore = report.DeleteBefore
+	base.WithContent = true
+	return base, true
+}
@@ -0,0 +1,173 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// retentionTarget verifica o escopo e os prazos do alvo passado ao repositório
+func retentionTarget(tenantID uuid.UUID, instanceIDs, excludeInstanceIDs []string, beforeDays, notBeforeDays int, withContent bool) interface{} {
+	return mock.MatchedBy(func(target domain.RetentionTarget) bool {
+		if target.TenantID != tenantID || target.WithContent != withContent {
+			return false
+		}
+		if !assert.ObjectsAreEqual(instanceIDs, target.InstanceIDs) || !assert.ObjectsAreEqual(excludeInstanceIDs, target.ExcludeInstanceIDs) {
+			return false
+		}
+		if !daysAgo(target.Before, beforeDays) {
+			return false
+		}
+		if notBeforeDays == 0 {
+			return target.NotBefore == nil
+		}
+		return target.NotBefore != nil && daysAgo(*target.NotBefore, notBeforeDays)
+	})
+}
+
+// daysAgo indica se a data corresponde a days dias atrás
+func daysAgo(at time.Time, days int) bool {
+	expected := time.Now().AddDate(0, 0, -days)
+	return at.After(expected.Add(-time.Minute)) && at.Before(expected.Add(time.Minute))
+}
+
+func TestRetentionService_SetPolicy(t *testing.T) {
+	tenantID := uuid.New()
+	instanceID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	policies := new(MockRetentionPolicyRepository)
+	instanceRepo := new(MockInstanceRepository)
+	service := application.NewRetentionService(policies, new(MockMessageRepository), instanceRepo, application.RetentionSettings{}, zerolog.Nop())
+
+	_, err := service.SetPolicy(ctx, domain.RetentionPolicyRequest{})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	_, err = service.SetPolicy(ctx, domain.RetentionPolicyRequest{ContentDays: 90, MetadataDays: 30})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+
+	unknown := uuid.New()
+	instanceRepo.On("GetByID", ctx, unknown).Return(nil, errors.New("record not found"))
+	_, err = service.SetPolicy(ctx, domain.RetentionPolicyRequest{InstanceID: &unknown, ContentDays: 30})
+	assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+	instanceRepo.On("GetByID", ctx, instanceID).Return(&domain.Instance{ID: instanceID, TenantID: tenantID}, nil)
+	policies.On("Save", ctx, mock.MatchedBy(func(policy *domain.RetentionPolicy) bool {
+		return policy.TenantID == tenantID && policy.InstanceID != nil && *policy.InstanceID == instanceID &&
+			policy.ContentDays == 90 && policy.MetadataDays == 365
+	})).Return(nil).Once()
+
+	policy, err := service.SetPolicy(ctx, domain.RetentionPolicyRequest{InstanceID: &instanceID, ContentDays: 90, MetadataDays: 365})
+	require.NoError(t, err)
+	assert.Equal(t, tenantID, policy.TenantID)
+
+	policies.On("Delete", ctx, unknown).Return(errors.New("record not found"))
+	policies.On("Delete", ctx, policy.ID).Return(nil)
+	assert.True(t, errors.Is(service.DeletePolicy(ctx, unknown), apperrors.ErrNotFound))
+	assert.NoError(t, service.DeletePolicy(ctx, policy.ID))
+
+	policies.AssertExpectations(t)
+	instanceRepo.AssertExpectations(t)
+}
+
+func TestRetentionService_Purge(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	instanceB := uuid.New()
+	tenantPolicy := &domain.RetentionPolicy{ID: uuid.New(), TenantID: tenantID, ContentDays: 30, MetadataDays: 365}
+	instancePolicy := &domain.RetentionPolicy{ID: uuid.New(), TenantID: tenantID, InstanceID: &instanceB, MetadataDays: 7}
+	excluded := []string{instanceB.String()}
+
+	policies := new(MockRetentionPolicyRepository)
+	messages := new(MockMessageRepository)
+	policies.On("List", ctx).Return([]*domain.RetentionPolicy{tenantPolicy, instancePolicy}, nil)
+
+	// A política do tenant ignora a instância B, que segue a própria política
+	messages.On("DeleteMessages", ctx, retentionTarget(tenantID, nil, excluded, 365, 0, false), 2).Return(int64(1), nil).Once()
+	// O conteúdo é apagado em lotes até um lote vir incompleto
+	messages.On("RedactContent", ctx, retentionTarget(tenantID, nil, excluded, 30, 365, true), 2).Return(int64(2), nil).Once()
+	messages.On("RedactContent", ctx, retentionTarget(tenantID, nil, excluded, 30, 365, true), 2).Return(int64(1), nil).Once()
+	messages.On("DeleteMessages", ctx, retentionTarget(tenantID, excluded, nil, 7, 0, false), 2).Return(int64(1), nil).Once()
+
+	service := application.NewRetentionService(policies, messages, new(MockInstanceRepository), application.RetentionSettings{BatchSize: 2}, zerolog.Nop())
+
+	reports, err := service.Purge(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	assert.Equal(t, &tenantPolicy.ID, reports[0].PolicyID)
+	assert.Equal(t, int64(1), reports[0].MessagesDeleted)
+	assert.Equal(t, int64(3), reports[0].ContentRedacted)
+	assert.Equal(t, &instancePolicy.ID, reports[1].PolicyID)
+	assert.Equal(t, int64(1), reports[1].MessagesDeleted)
+	assert.Equal(t, int64(0), reports[1].ContentRedacted)
+
+	messages.AssertExpectations(t)
+}
+
+func TestRetentionService_PurgeContinuesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	failing := &domain.RetentionPolicy{ID: uuid.New(), TenantID: uuid.New(), MetadataDays: 30}
+	healthy := &domain.RetentionPolicy{ID: uuid.New(), TenantID: uuid.New(), MetadataDays: 30}
+
+	policies := new(MockRetentionPolicyRepository)
+	messages := new(MockMessageRepository)
+	policies.On("List", ctx).Return([]*domain.RetentionPolicy{failing, healthy}, nil)
+	messages.On("DeleteMessages", ctx, retentionTarget(failing.TenantID, nil, nil, 30, 0, false), 2).Return(int64(0), errors.New("connection reset")).Once()
+	messages.On("DeleteMessages", ctx, retentionTarget(healthy.TenantID, nil, nil, 30, 0, false), 2).Return(int64(1), nil).Once()
+
+	service := application.NewRetentionService(policies, messages, new(MockInstanceRepository), application.RetentionSettings{BatchSize: 2}, zerolog.Nop())
+
+	reports, err := service.Purge(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Zero(t, reports[0].MessagesDeleted)
+	assert.Equal(t, int64(1), reports[1].MessagesDeleted)
+
+	messages.AssertExpectations(t)
+}
+
+func TestRetentionService_DryRun(t *testing.T) {
+	tenantID := uuid.New()
+	instanceB := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+	excluded := []string{instanceB.String()}
+
+	policies := new(MockRetentionPolicyRepository)
+	messages := new(MockMessageRepository)
+	policies.On("List", ctx).Return([]*domain.RetentionPolicy{
+		{ID: uuid.New(), TenantID: tenantID, InstanceID: &instanceB, MetadataDays: 365},
+	}, nil)
+	messages.On("CountRetention", ctx, retentionTarget(tenantID, nil, excluded, 365, 0, false)).Return(int64(1), nil).Once()
+	messages.On("CountRetention", ctx, retentionTarget(tenantID, nil, excluded, 30, 365, true)).Return(int64(4), nil).Once()
+
+	service := application.NewRetentionService(policies, messages, new(MockInstanceRepository), application.RetentionSettings{BatchSize: 2}, zerolog.Nop())
+
+	report, err := service.DryRun(ctx, domain.RetentionPolicyRequest{ContentDays: 30, MetadataDays: 365})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, tenantID, report.TenantID)
+	assert.Equal(t, int64(1), report.MessagesDeleted)
+	assert.Equal(t, int64(4), report.ContentRedacted)
+
+	// A simulação não altera nenhuma mensagem
+	messages.AssertNotCalled(t, "DeleteMessages", mock.Anything, mock.Anything, mock.Anything)
+	messages.AssertNotCalled(t, "RedactContent", mock.Anything, mock.Anything, mock.Anything)
+	messages.AssertExpectations(t)
+
+	_, err = service.DryRun(ctx, domain.RetentionPolicyRequest{})
+	assert.True(t, errors.Is(err, apperrors.ErrBadRequest))
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RetentionWorker aplica periodicamente as políticas de retenção de todos os tenants
+type RetentionWorker struct {
+	service  *RetentionService
+	interval time.Duration
+	logger   zerolog.Logger
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewRetentionWorker cria um novo worker de retenção
+func NewRetentionWorker(service *RetentionService, interval time.Duration, logger zerolog.Logger) *RetentionWorker {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &RetentionWorker{
+		service:  service,
+		interval: interval,
+		logger:   logger.With().Str("component", "retention_worker").Logger(),
+	}
+}
+
+// Start inicia o worker em background
+func (w *RetentionWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.run(ctx)
+
+	w.logger.Info().Dur("interval", w.interval).Msg("Retention worker started")
+}
+
+// Stop interrompe o worker e aguarda o lote em andamento terminar
+func (w *RetentionWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		w.logger.Info().Msg("Retention worker stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run aplica as políticas a cada intervalo até o contexto ser cancelado
+func (w *RetentionWorker) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.service.Purge(ctx); err != nil && ctx.Err() == nil {
+				w.logger.Error().Err(err).Msg("Failed to apply retention policies")
+			}
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// ruleEvaluationTimeout limita a avaliação das regras e o envio da resposta
+const ruleEvaluationTimeout = 30 * time.Second
+
+// Ações registradas no histórico de regras disparadas
+const (
+	RuleActionReply   = "reply"
+	RuleActionTag     = "tag"
+	RuleActionHandoff = "handoff"
+)
+
+// MessageSender envia mensagens; implementado pelo WhatsAppService
+type MessageSender interface {
+	SendMessage(ctx context.Context, request domain.SendMessageRequest) (*domain.SendMessageResponse, error)
+}
+
+// RuleEngine avalia as regras de automação da instância a cada mensagem
+// recebida e executa as ações da primeira regra que atende à condição
+type RuleEngine struct {
+	rules         domain.AutomationRuleRepository
+	executions    domain.RuleExecutionRepository
+	conversations domain.ConversationRepository
+	messages      domain.MessageRepository
+	sender        MessageSender
+	logger        zerolog.Logger
+}
+
+// NewRuleEngine cria um novo motor de regras
+func NewRuleEngine(
+	rules domain.AutomationRuleRepository,
+	executions domain.RuleExecutionRepository,
+	conversations domain.ConversationRepository,
+	messages domain.MessageRepository,
+	sender MessageSender,
+	logger zerolog.Logger,
+) *RuleEngine {
+	return &RuleEngine{
+		rules:         rules,
+		executions:    executions,
+		conversations: conversations,
+		messages:      messages,
+		sender:        sender,
+		logger:        logger.With().Str("component", "rule_engine").Logger(),
+	}
+}
+
+// HandleEvent avalia as regras para as mensagens recebidas. É registrado como
+// handler do barramento de eventos
+func (e *RuleEngine) HandleEvent(event events.Event) {
+	received, ok := event.(*domain.MessageReceivedEvent)
+	if !ok || received.Message == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ruleEvaluationTimeout)
+	defer cancel()
+
+	if _, err := e.Evaluate(ctx, received.InstanceID, received.Message); err != nil {
+		e.logger.Error().Err(err).Str("message_id", received.Message.ID.String()).Msg("Failed to evaluate automation rules")
+	}
+}
+
+// Evaluate avalia as regras ativas da instância para a mensagem. Retorna a
+// execução registrada ou nil quando nenhuma regra disparou. Mensagens enviadas
+// e conversas em atendimento humano são ignoradas
+func (e *RuleEngine) Evaluate(ctx context.Context, instanceID uuid.UUID, message *domain.Message) (*domain.RuleExecution, error) {
+	if message.Direction != domain.DirectionInbound {
+		return nil, nil
+	}
+
+	conversation, err := e.conversations.GetByPhone(ctx, instanceID.String(), message.Phone)
+	if err == nil && conversation.HandoffAt != nil {
+		return nil, nil
+	}
+
+	rules, err := e.rules.ListByInstance(ctx, instanceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		matched, err := e.matches(ctx, rule, message)
+		if err != nil {
+			e.logger.Warn().Err(err).Str("rule_id", rule.ID.String()).Msg("Failed to evaluate automation rule")
+			continue
+		}
+		if matched {
+			return e.execute(ctx, rule, message), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// matches indica se a mensagem atende à condição da regra
+func (e *RuleEngine) matches(ctx context.Context, rule *domain.AutomationRule, message *domain.Message) (bool, error) {
+	location, err := rule.Trigger.Location()
+	if err != nil {
+		return false, err
+	}
+	receivedAt := message.CreatedAt.In(location)
+
+	switch rule.Trigger.Type {
+	case domain.TriggerKeyword, domain.TriggerRegex:
+		return rule.Trigger.MatchesContent(message.Content), nil
+	case domain.TriggerFirstMessageOfDay:
+		startOfDay := time.Date(receivedAt.Year(), receivedAt.Month(), receivedAt.Day(), 0, 0, 0, 0, location)
+		// As datas são gravadas com precisão de segundos, então o intervalo inclui
+		// o segundo da mensagem e a própria mensagem é excluída pelo ID. Mensagens
+		// recebidas no mesmo segundo contam como anteriores
+		endOfSecond := message.CreatedAt.Truncate(time.Second).Add(time.Second)
+		earlier, err := e.messages.Count(ctx, domain.MessageFilter{
+			InstanceID: message.InstanceID,
+			Phone:      message.Phone,
+			Direction:  domain.DirectionInbound,
+			From:       &startOfDay,
+			To:         &endOfSecond,
+			ExcludeID:  message.ID,
+		})
+		if err != nil {
+			return false, err
+		}
+		return earlier == 0, nil
+	case domain.TriggerOutsideBusinessHours:
+		return !rule.Trigger.BusinessHours.Contains(receivedAt), nil
+	default:
+		return false, fmt.Errorf("unknown trigger type: %s", rule.Trigger.Type)
+	}
+}
+
+// execute executa as ações da regra e registra o resultado. Uma ação com
+// falha não impede as demais
+func (e *RuleEngine) execute(ctx context.Context, rule *domain.AutomationRule, message *domain.Message) *domain.RuleExecution {
+	execution := &domain.RuleExecution{
+		ID:         uuid.New(),
+		RuleID:     rule.ID,
+		RuleName:   rule.Name,
+		InstanceID: rule.InstanceID,
+		Phone:      message.Phone,
+		MessageID:  message.ID,
+		Actions:    []string{},
+		CreatedAt:  time.Now(),
+	}
+	var failures []string
+
+	if reply := rule.Action.Reply; reply != nil {
+		replyID, err := e.sendReply(ctx, rule, reply, message)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("reply: %v", err))
+		} else {
+			execution.ReplyMessageID = &replyID
+			execution.Actions = append(execution.Actions, RuleActionReply)
+		}
+	}
+
+	if rule.Action.Tag != "" {
+		if err := e.conversations.AddTag(ctx, message.InstanceID, message.Phone, rule.Action.Tag); err != nil {
+			failures = append(failures, fmt.Sprintf("tag: %v", err))
+		} else {
+			execution.Actions = append(execution.Actions, RuleActionTag)
+		}
+	}
+
+	if rule.Action.Handoff {
+		now := time.Now()
+		if err := e.conversations.SetHandoff(ctx, message.InstanceID, message.Phone, &now); err != nil {
+			failures = append(failures, fmt.Sprintf("handoff: %v", err))
+		} else {
+			execution.Actions = append(execution.Actions, RuleActionHandoff)
+		}
+	}
+
+	if len(failures) > 0 {
+		joined := strings.Join(failures, "; ")
+		execution.Error = &joined
+	}
+
+	if err := e.executions.Save(ctx, execution); err != nil {
+		e.logger.Error().Err(err).Str("rule_id", rule.ID.String()).Msg("Failed to record rule execution")
+	}
+
+	e.logger.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("instance_id", rule.InstanceID.String()).
+		Str("message_id", message.ID.String()).
+		Strs("actions", execution.Actions).
+		Bool("failed", execution.Error != nil).
+		Msg("Automation rule fired")
+
+	return execution
+}
+
+// sendReply envia a resposta da regra ao contato
+func (e *RuleEngine) sendReply(ctx context.Context, rule *domain.AutomationRule, reply *domain.RuleReply, message *domain.Message) (uuid.UUID, error) {
+	location, err := rule.Trigger.Location()
+	if err != nil {
+		location = time.UTC
+	}
+	content, err := reply.Render(domain.RuleTemplateData{
+		Phone:      message.Phone,
+		Message:    message.Content,
+		InstanceID: message.InstanceID,
+		Now:        message.CreatedAt.In(location),
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	request := domain.SendMessageRequest{
+		InstanceID: rule.InstanceID.String(),
+		Phone:      message.Phone,
+		Type:       domain.TextMessage,
+		Content:    content,
+	}
+	if reply.Type == domain.ReplyMedia {
+		request.Type = reply.MediaType
+		request.MediaURL = reply.MediaURL
+	}
+
+	response, err := e.sender.SendMessage(ctx, request)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if response.Status == domain.StatusFailed {
+		if response.Error != nil {
+			return response.ID, fmt.Errorf("%s", *response.Error)
+		}
+		return response.ID, fmt.Errorf("provider rejected the reply")
+	}
+	return response.ID, nil
+}
@@ -0,0 +1,223 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+const rulePhone = "5511999999999"
+
+// inboundRuleMessage cria uma mensagem recebida pela instância
+func inboundRuleMessage(instanceID uuid.UUID, content string, at time.Time) *domain.Message {
+	return &domain.Message{
+		ID:         uuid.New(),
+		InstanceID: instanceID.String(),
+		Phone:      rulePhone,
+		Direction:  domain.DirectionInbound,
+		Type:       domain.TextMessage,
+		Content:    content,
+		CreatedAt:  at,
+	}
+}
+
+func TestRuleEngine_KeywordRepliesWithTemplate(t *testing.T) {
+	ctx := context.Background()
+	instanceID := uuid.New()
+	rule := &domain.AutomationRule{
+		ID:         uuid.New(),
+		InstanceID: instanceID,
+		Name:       "menu",
+		Trigger:    domain.RuleTrigger{Type: domain.TriggerKeyword, Keywords: []string{"menu"}},
+		Action: domain.RuleAction{
+			Reply: &domain.RuleReply{Type: domain.ReplyTemplate, Content: "Olá {{.Phone}}, você escreveu {{.Message}}"},
+			Tag:   "menu",
+		},
+	}
+
+	rules := new(MockAutomationRuleRepository)
+	executions := new(MockRuleExecutionRepository)
+	conversations := new(MockConversationRepository)
+	sender := new(MockMessageSender)
+	rules.On("ListByInstance", ctx, instanceID, true).Return([]*domain.AutomationRule{rule}, nil)
+	conversations.On("GetByPhone", ctx, instanceID.String(), rulePhone).Return(&domain.Conversation{}, nil)
+	conversations.On("AddTag", ctx, instanceID.String(), rulePhone, "menu").Return(nil).Once()
+	sender.On("SendMessage", ctx, domain.SendMessageRequest{
+		InstanceID: instanceID.String(),
+		Phone:      rulePhone,
+		Type:       domain.TextMessage,
+		Content:    "Olá 5511999999999, você escreveu  MENU ",
+	}).Return(&domain.SendMessageResponse{ID: uuid.New(), Status: domain.StatusSent}, nil).Once()
+	executions.On("Save", ctx, mock.AnythingOfType("*domain.RuleExecution")).Return(nil).Once()
+
+	engine := application.NewRuleEngine(rules, executions, conversations, new(MockMessageRepository), sender, zerolog.Nop())
+
+	execution, err := engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, " MENU ", time.Now()))
+	require.NoError(t, err)
+	require.NotNil(t, execution)
+	assert.Equal(t, []string{application.RuleActionReply, application.RuleActionTag}, execution.Actions)
+	assert.NotNil(t, execution.ReplyMessageID)
+	assert.Nil(t, execution.Error)
+
+	execution, err = engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "menu principal", time.Now()))
+	require.NoError(t, err)
+	assert.Nil(t, execution, "keywords must match the whole message")
+
+	sender.AssertExpectations(t)
+	conversations.AssertExpectations(t)
+	executions.AssertExpectations(t)
+}
+
+func TestRuleEngine_FirstMatchingRuleWins(t *testing.T) {
+	ctx := context.Background()
+	instanceID := uuid.New()
+	first := &domain.AutomationRule{
+		ID:         uuid.New(),
+		InstanceID: instanceID,
+		Name:       "atendente",
+		Trigger:    domain.RuleTrigger{Type: domain.TriggerRegex, Pattern: `(?i)atendente|humano`},
+		Action:     domain.RuleAction{Handoff: true},
+	}
+	second := &domain.AutomationRule{
+		ID:         uuid.New(),
+		InstanceID: instanceID,
+		Name:       "boas-vindas",
+		Trigger:    domain.RuleTrigger{Type: domain.TriggerFirstMessageOfDay},
+		Action:     domain.RuleAction{Reply: &domain.RuleReply{Type: domain.ReplyText, Content: "Bem-vindo!"}},
+	}
+	handoffAt := time.Now()
+
+	rules := new(MockAutomationRuleRepository)
+	executions := new(MockRuleExecutionRepository)
+	conversations := new(MockConversationRepository)
+	sender := new(MockMessageSender)
+	rules.On("ListByInstance", ctx, instanceID, true).Return([]*domain.AutomationRule{first, second}, nil).Once()
+	conversations.On("GetByPhone", ctx, instanceID.String(), rulePhone).Return(&domain.Conversation{}, nil).Once()
+	conversations.On("SetHandoff", ctx, instanceID.String(), rulePhone, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+	conversations.On("GetByPhone", ctx, instanceID.String(), rulePhone).Return(&domain.Conversation{HandoffAt: &handoffAt}, nil).Once()
+	executions.On("Save", ctx, mock.AnythingOfType("*domain.RuleExecution")).Return(nil).Once()
+
+	engine := application.NewRuleEngine(rules, executions, conversations, new(MockMessageRepository), sender, zerolog.Nop())
+
+	execution, err := engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "quero falar com um atendente", time.Now()))
+	require.NoError(t, err)
+	require.NotNil(t, execution)
+	assert.Equal(t, first.ID, execution.RuleID)
+	assert.Equal(t, []string{application.RuleActionHandoff}, execution.Actions)
+
+	// Em atendimento humano nenhuma regra responde
+	execution, err = engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "oi", time.Now()))
+	require.NoError(t, err)
+	assert.Nil(t, execution)
+
+	sender.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+	rules.AssertExpectations(t)
+	conversations.AssertExpectations(t)
+	executions.AssertExpectations(t)
+}
+
+func TestRuleEngine_FirstMessageOfDay(t *testing.T) {
+	ctx := context.Background()
+	instanceID := uuid.New()
+	rule := &domain.AutomationRule{
+		ID:         uuid.New(),
+		InstanceID: instanceID,
+		Name:       "boas-vindas",
+		Trigger:    domain.RuleTrigger{Type: domain.TriggerFirstMessageOfDay, Timezone: "America/Sao_Paulo"},
+		Action:     domain.RuleAction{Reply: &domain.RuleReply{Type: domain.ReplyText, Content: "Bem-vindo!"}},
+	}
+
+	rules := new(MockAutomationRuleRepository)
+	executions := new(MockRuleExecutionRepository)
+	conversations := new(MockConversationRepository)
+	messages := new(MockMessageRepository)
+	sender := new(MockMessageSender)
+	rules.On("ListByInstance", ctx, instanceID, true).Return([]*domain.AutomationRule{rule}, nil)
+	conversations.On("GetByPhone", ctx, instanceID.String(), rulePhone).Return(&domain.Conversation{}, nil)
+	first := inboundRuleMessage(instanceID, "oi", time.Date(2026, 3, 10, 9, 30, 15, 700_000_000, time.UTC))
+	messages.On("Count", ctx, mock.MatchedBy(func(filter domain.MessageFilter) bool {
+		// O segundo da mensagem entra no intervalo e a própria mensagem é ignorada
+		return filter.InstanceID == instanceID.String() && filter.Phone == rulePhone &&
+			filter.Direction == domain.DirectionInbound && filter.ExcludeID == first.ID &&
+			filter.From != nil && filter.To != nil && filter.To.Equal(time.Date(2026, 3, 10, 9, 30, 16, 0, time.UTC))
+	})).Return(int64(0), nil).Once()
+	messages.On("Count", ctx, mock.AnythingOfType("domain.MessageFilter")).Return(int64(1), nil).Once()
+	sender.On("SendMessage", ctx, mock.AnythingOfType("domain.SendMessageRequest")).
+		Return(&domain.SendMessageResponse{ID: uuid.New(), Status: domain.StatusSent}, nil).Once()
+	executions.On("Save", ctx, mock.AnythingOfType("*domain.RuleExecution")).Return(nil).Once()
+
+	engine := application.NewRuleEngine(rules, executions, conversations, messages, sender, zerolog.Nop())
+
+	execution, err := engine.Evaluate(ctx, instanceID, first)
+	require.NoError(t, err)
+	require.NotNil(t, execution)
+
+	execution, err = engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "oi de novo", time.Now()))
+	require.NoError(t, err)
+	assert.Nil(t, execution)
+
+	messages.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
+
+func TestRuleEngine_OutsideBusinessHours(t *testing.T) {
+	ctx := context.Background()
+	instanceID := uuid.New()
+	rule := &domain.AutomationRule{
+		ID:         uuid.New(),
+		InstanceID: instanceID,
+		Name:       "fora do horário",
+		Trigger: domain.RuleTrigger{
+			Type:     domain.TriggerOutsideBusinessHours,
+			Timezone: "America/Sao_Paulo",
+			BusinessHours: &domain.BusinessHours{
+				Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+				Start: "09:00",
+				End:   "18:00",
+			},
+		},
+		Action: domain.RuleAction{Reply: &domain.RuleReply{Type: domain.ReplyText, Content: "Estamos fechados"}},
+	}
+
+	rules := new(MockAutomationRuleRepository)
+	executions := new(MockRuleExecutionRepository)
+	conversations := new(MockConversationRepository)
+	sender := new(MockMessageSender)
+	rules.On("ListByInstance", ctx, instanceID, true).Return([]*domain.AutomationRule{rule}, nil)
+	conversations.On("GetByPhone", ctx, instanceID.String(), rulePhone).Return(&domain.Conversation{}, nil)
+	sender.On("SendMessage", ctx, mock.AnythingOfType("domain.SendMessageRequest")).
+		Return(&domain.SendMessageResponse{ID: uuid.New(), Status: domain.StatusSent}, nil).Twice()
+	executions.On("Save", ctx, mock.AnythingOfType("*domain.RuleExecution")).Return(nil).Twice()
+
+	engine := application.NewRuleEngine(rules, executions, conversations, new(MockMessageRepository), sender, zerolog.Nop())
+
+	// Segunda-feira, 10h em São Paulo (13h UTC)
+	open := time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC)
+	execution, err := engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "oi", open))
+	require.NoError(t, err)
+	assert.Nil(t, execution)
+
+	// Segunda-feira, 19h em São Paulo
+	closed := time.Date(2024, time.March, 4, 22, 0, 0, 0, time.UTC)
+	execution, err = engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "oi", closed))
+	require.NoError(t, err)
+	assert.NotNil(t, execution)
+
+	// Sábado
+	weekend := time.Date(2024, time.March, 9, 13, 0, 0, 0, time.UTC)
+	execution, err = engine.Evaluate(ctx, instanceID, inboundRuleMessage(instanceID, "oi", weekend))
+	require.NoError(t, err)
+	assert.NotNil(t, execution)
+
+	sender.AssertExpectations(t)
+	executions.AssertExpectations(t)
+}
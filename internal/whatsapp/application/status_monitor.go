@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// StatusMonitorSettings configura a verificação periódica de status das instâncias
+type StatusMonitorSettings struct {
+	Interval time.Duration // intervalo entre as rodadas de verificação
+	Timeout  time.Duration // tempo máximo de cada consulta ao provider
+}
+
+// StatusMonitor consulta periodicamente o status de todas as instâncias,
+// mantendo o banco atualizado sem depender de chamadas ao endpoint de status
+type StatusMonitor struct {
+	service  *WhatsAppService
+	settings StatusMonitorSettings
+	logger   zerolog.Logger
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewStatusMonitor cria um novo monitor de status
+func NewStatusMonitor(service *WhatsAppService, settings StatusMonitorSettings, logger zerolog.Logger) *StatusMonitor {
+	if settings.Interval <= 0 {
+		settings.Interval = time.Minute
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = 10 * time.Second
+	}
+
+	return &StatusMonitor{
+		service:  service,
+		settings: settings,
+		logger:   logger.With().Str("component", "status_monitor").Logger(),
+	}
+}
+
+// Start inicia o monitor em background
+func (m *StatusMonitor) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+
+	go m.run(ctx)
+
+	m.logger.Info().Dur("interval", m.settings.Interval).Msg("Instance status monitor started")
+}
+
+// Stop interrompe o monitor e aguarda a rodada em andamento terminar
+func (m *StatusMonitor) Stop(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+
+	select {
+	case <-m.done:
+		m.logger.Info().Msg("Instance status monitor stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run executa uma rodada a cada intervalo até o contexto ser cancelado
+func (m *StatusMonitor) run(ctx context.Context) {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.settings.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.CheckAll(ctx)
+		}
+	}
+}
+
+// CheckAll verifica o status de todas as instâncias uma vez
+func (m *StatusMonitor) CheckAll(ctx context.Context) {
+	instances, err := m.service.GetAllInstances(ctx)
+	if err != nil {
+		m.logger.Error().Err(err).Msg("Failed to list instances for status check")
+		return
+	}
+
+	for _, instance := range instances {
+		if ctx.Err() != nil {
+			return
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, m.settings.Timeout)
+		_, err := m.service.RefreshInstanceStatus(checkCtx, instance)
+		cancel()
+
+		// Falhas na consulta não alteram o status: uma indisponibilidade do
+		// provider não significa que a instância foi desconectada
+		if err != nil {
+			m.logger.Warn().
+				Err(err).
+				Str("instance_id", instance.ID.String()).
+				Msg("Failed to check instance status")
+		}
+	}
+}
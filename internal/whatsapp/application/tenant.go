@@ -0,0 +1,18 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+)
+
+// contextTenant retorna o tenant da requisição. Chamadas internas, sem tenant
+// no contexto, criam recursos no tenant padrão
+func contextTenant(ctx context.Context) uuid.UUID {
+	if tenantID, ok := tenantDomain.FromContext(ctx); ok {
+		return tenantID
+	}
+	return tenantDomain.DefaultTenantID
+}
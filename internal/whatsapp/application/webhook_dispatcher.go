@@ -0,0 +1,354 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// Cabeçalhos enviados em cada entrega de webhook
+const (
+	WebhookHeaderID        = "X-Webhook-ID" // ID da entrega
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// maxWebhookResponseBody limita o trecho da resposta do cliente lido e descartado
+const maxWebhookResponseBody = 64 << 10
+
+// WebhookDispatcherSettings configura o envio e as novas tentativas das entregas
+type WebhookDispatcherSettings struct {
+	PollInterval   time.Duration // intervalo entre as buscas por entregas vencidas
+	Timeout        time.Duration // tempo máximo de cada requisição
+	BatchSize      int           // entregas reservadas por busca
+	MaxAttempts    int           // tentativas antes de a entrega ser marcada como falha
+	InitialBackoff time.Duration // espera antes da primeira nova tentativa, dobrada a cada tentativa
+	MaxBackoff     time.Duration // espera máxima entre tentativas
+	// AllowPrivateNetworks permite entregas a endereços internos (ex: um
+	// receptor local em desenvolvimento); em produção deve ficar desligado
+	AllowPrivateNetworks bool
+}
+
+// WebhookDispatcher transforma os eventos de domínio em entregas para as
+// assinaturas interessadas e as envia em background, com novas tentativas
+type WebhookDispatcher struct {
+	subscriptions domain.WebhookSubscriptionRepository
+	deliveries    domain.WebhookDeliveryRepository
+	client        *http.Client
+	settings      WebhookDispatcherSettings
+	logger        zerolog.Logger
+	wake          chan struct{}
+	cancel        context.CancelFunc
+	done          chan struct{}
+}
+
+// NewWebhookDispatcher cria um novo despachante de webhooks
+func NewWebhookDispatcher(
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	settings WebhookDispatcherSettings,
+	logger zerolog.Logger,
+) *WebhookDispatcher {
+	if settings.PollInterval <= 0 {
+		settings.PollInterval = 2 * time.Second
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = 10 * time.Second
+	}
+	if settings.BatchSize <= 0 {
+		settings.BatchSize = 50
+	}
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = 8
+	}
+	if settings.InitialBackoff <= 0 {
+		settings.InitialBackoff = 10 * time.Second
+	}
+	if settings.MaxBackoff < settings.InitialBackoff {
+		settings.MaxBackoff = settings.InitialBackoff
+	}
+
+	return &WebhookDispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        newOutboundClient(settings.Timeout, settings.AllowPrivateNetworks),
+		settings:      settings,
+		logger:        logger.With().Str("component", "webhook_dispatcher").Logger(),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent cria as entregas do evento para as assinaturas ativas que o
+// selecionaram. É registrado como handler do barramento de eventos
+func (d *WebhookDispatcher) HandleEvent(event events.Event) {
+	instanceEvent, ok := event.(domain.InstanceEvent)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.settings.Timeout)
+	defer cancel()
+
+	subscriptions, err := d.subscriptions.ListActive(ctx, instanceEvent.GetTenantID(), instanceEvent.GetInstanceID())
+	if err != nil {
+		d.logger.Error().Err(err).Str("event", event.GetName()).Msg("Failed to list webhook subscriptions")
+		return
+	}
+
+	var payload []byte
+	enqueued := 0
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.GetName(), instanceEvent.GetInstanceID()) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				d.logger.Error().Err(err).Str("event", event.GetName()).Msg("Failed to marshal webhook payload")
+				return
+			}
+		}
+
+		now := time.Now()
+		delivery := &domain.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.GetID(),
+			EventName:      event.GetName(),
+			Payload:        payload,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  &now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := d.deliveries.Save(ctx, delivery); err != nil {
+			d.logger.Error().Err(err).Str("subscription_id", subscription.ID.String()).Msg("Failed to enqueue webhook delivery")
+			continue
+		}
+		enqueued++
+	}
+
+	if enqueued > 0 {
+		d.Wake()
+	}
+}
+
+// Wake antecipa a próxima busca por entregas vencidas
+func (d *WebhookDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start inicia o envio das entregas em background
+func (d *WebhookDispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.run(ctx)
+
+	d.logger.Info().Dur("poll_interval", d.settings.PollInterval).Msg("Webhook dispatcher started")
+}
+
+// Stop interrompe o envio e aguarda as entregas em andamento terminarem
+func (d *WebhookDispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	select {
+	case <-d.done:
+		d.logger.Info().Msg("Webhook dispatcher stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run processa as entregas vencidas a cada intervalo ou quando acordado
+func (d *WebhookDispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.settings.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+
+		d.DispatchDue(ctx)
+	}
+}
+
+// DispatchDue envia as entregas vencidas até esvaziar a fila
+func (d *WebhookDispatcher) DispatchDue(ctx context.Context) {
+	// A reserva precisa durar mais que a requisição para outra réplica não
+	// reenviar a entrega enquanto ela está em andamento
+	lease := 2 * d.settings.Timeout
+
+	for ctx.Err() == nil {
+		due, err := d.deliveries.ClaimDue(ctx, time.Now(), lease, d.settings.BatchSize)
+		if err != nil {
+			d.logger.Error().Err(err).Msg("Failed to claim webhook deliveries")
+			return
+		}
+		if len(due) == 0 {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range due {
+			wg.Add(1)
+			go func(delivery *domain.WebhookDelivery) {
+				defer wg.Done()
+				d.attempt(ctx, delivery)
+			}(delivery)
+		}
+		wg.Wait()
+
+		if len(due) < d.settings.BatchSize {
+			return
+		}
+	}
+}
+
+// attempt envia a entrega uma vez e agenda a próxima tentativa em caso de falha
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *domain.WebhookDelivery) {
+	// O resultado é gravado mesmo que o despachante esteja sendo encerrado
+	recordCtx := context.WithoutCancel(ctx)
+
+	subscription, err := d.subscriptions.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil || !subscription.Active {
+		message := "webhook subscription is inactive or was deleted"
+		delivery.Status = domain.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = &message
+		if err := d.deliveries.RecordAttempt(recordCtx, delivery); err != nil {
+			d.logger.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to record webhook delivery")
+		}
+		return
+	}
+
+	startedAt := time.Now()
+	code, sendErr := d.send(ctx, subscription, delivery, startedAt)
+	latency := time.Since(startedAt).Milliseconds()
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &startedAt
+	delivery.LatencyMs = &latency
+	delivery.ResponseCode = nil
+	if code > 0 {
+		delivery.ResponseCode = &code
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+	case delivery.Attempts >= d.settings.MaxAttempts:
+		message := sendErr.Error()
+		delivery.Status = domain.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = &message
+	default:
+		message := sendErr.Error()
+		next := time.Now().Add(WebhookBackoff(delivery.Attempts, d.settings.InitialBackoff, d.settings.MaxBackoff))
+		delivery.Status = domain.DeliveryPending
+		delivery.NextAttemptAt = &next
+		delivery.LastError = &message
+	}
+
+	if err := d.deliveries.RecordAttempt(recordCtx, delivery); err != nil {
+		d.logger.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to record webhook delivery")
+		return
+	}
+
+	if sendErr != nil {
+		d.logger.Warn().
+			Err(sendErr).
+			Str("delivery_id", delivery.ID.String()).
+			Str("subscription_id", subscription.ID.String()).
+			Int("attempts", delivery.Attempts).
+			Str("status", string(delivery.Status)).
+			Msg("Webhook delivery failed")
+	}
+}
+
+// send faz a requisição assinada. Retorna o código HTTP, ou zero quando não
+// houve resposta, e erro para falhas de rede e respostas fora da faixa 2xx
+func (d *WebhookDispatcher) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery, now time.Time) (int, error) {
+	timestamp := now.Unix()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookHeaderID, delivery.ID.String())
+	request.Header.Set(WebhookHeaderEvent, delivery.EventName)
+	request.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(WebhookHeaderSignature, SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxWebhookResponseBody))
+
+	// O corpo da resposta não é guardado: ele pode trazer dados do serviço do
+	// cliente, que ficariam expostos na listagem de entregas
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// SignWebhookPayload calcula a assinatura enviada em X-Webhook-Signature:
+// HMAC-SHA256 de "<timestamp>.<corpo>" com o segredo da assinatura, em hexadecimal
+// e prefixado por "sha256=". O timestamp permite ao cliente recusar reenvios antigos
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookBackoff calcula a espera antes da próxima tentativa, dobrando a cada
+// tentativa já feita e limitada por maxBackoff
+func WebhookBackoff(attempts int, initial, maxBackoff time.Duration) time.Duration {
+	backoff := initial
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
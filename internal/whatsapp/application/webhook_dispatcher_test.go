@@ -0,0 +1,172 @@
+package application_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+type memorySubscriptions struct {
+	domain.WebhookSubscriptionRepository
+	items []*domain.WebhookSubscription
+}
+
+func (m *memorySubscriptions) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	for _, item := range m.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func (m *memorySubscriptions) ListActive(ctx context.Context, tenantID, instanceID uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	return m.items, nil
+}
+
+type memoryDeliveries struct {
+	domain.WebhookDeliveryRepository
+	mu    sync.Mutex
+	items []*domain.WebhookDelivery
+}
+
+func (m *memoryDeliveries) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = append(m.items, delivery)
+	return nil
+}
+
+func (m *memoryDeliveries) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*domain.WebhookDelivery
+	for _, item := range m.items {
+		if item.Status == domain.DeliveryPending && item.NextAttemptAt != nil && !item.NextAttemptAt.After(now) {
+			next := now.Add(lease)
+			item.NextAttemptAt = &next
+			due = append(due, item)
+		}
+	}
+	return due, nil
+}
+
+func (m *memoryDeliveries) RecordAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return nil
+}
+
+func newTestDispatcher(t *testing.T, handler http.HandlerFunc) (*application.WebhookDispatcher, *memoryDeliveries, uuid.UUID) {
+	return newTestDispatcherWithSettings(t, handler, application.WebhookDispatcherSettings{
+		MaxAttempts:    2,
+		InitialBackoff: time.Minute,
+		// O servidor de teste é local: a proteção contra a rede interna fica desligada
+		AllowPrivateNetworks: true,
+	})
+}
+
+func newTestDispatcherWithSettings(t *testing.T, handler http.HandlerFunc, settings application.WebhookDispatcherSettings) (*application.WebhookDispatcher, *memoryDeliveries, uuid.UUID) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	instanceID := uuid.New()
+	subscriptions := &memorySubscriptions{items: []*domain.WebhookSubscription{{
+		ID:     uuid.New(),
+		URL:    server.URL,
+		Events: []string{domain.EventMessageReceived},
+		Secret: "whsec_test",
+		Active: true,
+	}}}
+	deliveries := &memoryDeliveries{}
+
+	dispatcher := application.NewWebhookDispatcher(subscriptions, deliveries, settings, zerolog.Nop())
+
+	return dispatcher, deliveries, instanceID
+}
+
+func TestWebhookDispatcher_DeliversSignedEvent(t *testing.T) {
+	var headers http.Header
+	var body []byte
+	dispatcher, deliveries, instanceID := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	dispatcher.HandleEvent(domain.NewMessageReceivedEvent(instanceID, &domain.Message{ID: uuid.New(), Content: "oi"}))
+	dispatcher.HandleEvent(domain.NewInstanceStatusChangedEvent(&domain.Instance{ID: instanceID}, domain.InstanceConnected, 0))
+	dispatcher.DispatchDue(context.Background())
+
+	require.Len(t, deliveries.items, 1, "only the subscribed event is enqueued")
+	delivery := deliveries.items[0]
+	assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	require.NotNil(t, delivery.ResponseCode)
+	assert.Equal(t, http.StatusNoContent, *delivery.ResponseCode)
+
+	timestamp, err := strconv.ParseInt(headers.Get(application.WebhookHeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, application.SignWebhookPayload("whsec_test", timestamp, body), headers.Get(application.WebhookHeaderSignature))
+	assert.Equal(t, domain.EventMessageReceived, headers.Get(application.WebhookHeaderEvent))
+	assert.Equal(t, delivery.ID.String(), headers.Get(application.WebhookHeaderID))
+}
+
+func TestWebhookDispatcher_RetriesThenFails(t *testing.T) {
+	dispatcher, deliveries, instanceID := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+
+	dispatcher.HandleEvent(domain.NewMessageReceivedEvent(instanceID, &domain.Message{ID: uuid.New()}))
+	dispatcher.DispatchDue(context.Background())
+
+	delivery := deliveries.items[0]
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), *delivery.NextAttemptAt, 5*time.Second)
+	require.NotNil(t, delivery.LastError)
+	assert.Equal(t, "unexpected status 500", *delivery.LastError, "the response body is not stored")
+
+	now := time.Now()
+	delivery.NextAttemptAt = &now
+	dispatcher.DispatchDue(context.Background())
+
+	assert.Equal(t, domain.DeliveryFailed, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, application.WebhookBackoff(1, 10*time.Second, time.Hour))
+	assert.Equal(t, 40*time.Second, application.WebhookBackoff(3, 10*time.Second, time.Hour))
+	assert.Equal(t, time.Hour, application.WebhookBackoff(20, 10*time.Second, time.Hour))
+}
+
+func TestWebhookDispatcher_RefusesPrivateNetworks(t *testing.T) {
+	called := false
+	dispatcher, deliveries, instanceID := newTestDispatcherWithSettings(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}, application.WebhookDispatcherSettings{MaxAttempts: 1})
+
+	dispatcher.HandleEvent(domain.NewMessageReceivedEvent(instanceID, &domain.Message{ID: uuid.New()}))
+	dispatcher.DispatchDue(context.Background())
+
+	delivery := deliveries.items[0]
+	assert.False(t, called)
+	assert.Equal(t, domain.DeliveryFailed, delivery.Status)
+	assert.Nil(t, delivery.ResponseCode)
+	require.NotNil(t, delivery.LastError)
+	assert.Contains(t, *delivery.LastError, "is not allowed")
+}
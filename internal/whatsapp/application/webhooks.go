@@ -0,0 +1,253 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// WebhookService gerencia as assinaturas de webhook das aplicações cliente e
+// o histórico de entregas. O envio é feito pelo WebhookDispatcher
+type WebhookService struct {
+	subscriptions domain.WebhookSubscriptionRepository
+	deliveries    domain.WebhookDeliveryRepository
+	instanceRepo  domain.InstanceRepository
+	logger        zerolog.Logger
+}
+
+// NewWebhookService cria um novo serviço de webhooks
+func NewWebhookService(
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	instanceRepo domain.InstanceRepository,
+	logger zerolog.Logger,
+) *WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		instanceRepo:  instanceRepo,
+		logger:        logger.With().Str("service", "webhooks").Logger(),
+	}
+}
+
+// CreateSubscription cria uma assinatura. O segredo é retornado apenas nesta resposta
+func (s *WebhookService) CreateSubscription(ctx context.Context, request domain.CreateWebhookSubscriptionRequest) (*domain.WebhookSubscriptionResponse, error) {
+	if err := validateWebhookURL(request.URL); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookEvents(request.Events); err != nil {
+		return nil, err
+	}
+	if request.InstanceID != nil {
+		if _, err := s.instanceRepo.GetByID(ctx, *request.InstanceID); err != nil {
+			return nil, apperrors.NewNotFoundError("instance")
+		}
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	now := time.Now()
+	subscription := &domain.WebhookSubscription{
+		ID:          uuid.New(),
+		TenantID:    contextTenant(ctx),
+		InstanceID:  request.InstanceID,
+		URL:         request.URL,
+		Events:      request.Events,
+		Secret:      secret,
+		Description: request.Description,
+		Active:      active,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.subscriptions.Save(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	s.logger.Info().
+		Str("subscription_id", subscription.ID.String()).
+		Strs("events", subscription.Events).
+		Msg("Webhook subscription created")
+
+	return &domain.WebhookSubscriptionResponse{WebhookSubscription: subscription, Secret: secret}, nil
+}
+
+// GetSubscription obtém uma assinatura por ID
+func (s *WebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("webhook subscription")
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions lista as assinaturas da instância ou, sem instância, todas elas
+func (s *WebhookService) ListSubscriptions(ctx context.Context, instanceID *uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	return s.subscriptions.List(ctx, instanceID)
+}
+
+// UpdateSubscription aplica um patch na assinatura
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, request domain.UpdateWebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("webhook subscription")
+	}
+
+	if request.URL != nil {
+		if err := validateWebhookURL(*request.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *request.URL
+	}
+	if request.Events != nil {
+		if err := validateWebhookEvents(request.Events); err != nil {
+			return nil, err
+		}
+		subscription.Events = request.Events
+	}
+	if request.Secret != nil {
+		if *request.Secret == "" {
+			return nil, apperrors.NewValidationError("secret must not be empty")
+		}
+		subscription.Secret = *request.Secret
+	}
+	if request.Description != nil {
+		subscription.Description = *request.Description
+	}
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteSubscription remove a assinatura e o histórico de entregas
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return apperrors.NewNotFoundError("webhook subscription")
+	}
+
+	s.logger.Info().Str("subscription_id", id.String()).Msg("Webhook subscription deleted")
+	return nil
+}
+
+// ListDeliveries lista as entregas da assinatura, da mais recente para a mais
+// antiga. Retorna também o cursor da próxima página
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status domain.WebhookDeliveryStatus, limit int, cursor string) ([]*domain.WebhookDelivery, string, error) {
+	if _, err := s.subscriptions.GetByID(ctx, subscriptionID); err != nil {
+		return nil, "", apperrors.NewNotFoundError("webhook subscription")
+	}
+
+	switch status {
+	case "", domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryFailed:
+	default:
+		return nil, "", apperrors.NewValidationError(fmt.Sprintf("invalid status: %s", status))
+	}
+
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", apperrors.NewValidationError(err.Error())
+	}
+
+	limit = NormalizePageLimit(limit)
+	deliveries, err := s.deliveries.ListBySubscription(ctx, subscriptionID, status, limit+1, before)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		last := deliveries[limit-1]
+		next = domain.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return deliveries, next, nil
+}
+
+// Redeliver agenda um novo envio do evento de uma entrega. A entrega original
+// é mantida no histórico e o evento mantém o mesmo ID, permitindo que o
+// cliente descarte duplicatas
+func (s *WebhookService) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	original, err := s.deliveries.GetByID(ctx, deliveryID)
+	if err != nil || original.SubscriptionID != subscriptionID {
+		return nil, apperrors.NewNotFoundError("webhook delivery")
+	}
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventName:      original.EventName,
+		Payload:        original.Payload,
+		Status:         domain.DeliveryPending,
+		NextAttemptAt:  &now,
+		RedeliveryOf:   &original.ID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.deliveries.Save(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// validateWebhookURL aceita apenas URLs HTTP(S) absolutas
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return apperrors.NewValidationError("url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// validateWebhookEvents exige ao menos um evento conhecido
+func validateWebhookEvents(names []string) error {
+	if len(names) == 0 {
+		return apperrors.NewValidationError("at least one event is required")
+	}
+	for _, name := range names {
+		if !domain.IsWebhookEventType(name) {
+			return apperrors.NewValidationError(fmt.Sprintf("unknown event: %s", name))
+		}
+	}
+	return nil
+}
+
+// generateWebhookSecret gera um segredo aleatório de 256 bits
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
@@ -2,34 +2,66 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
 )
 
 // WhatsAppService gerencia todas as operações do WhatsApp
 type WhatsAppService struct {
 	providerRegistry domain.ProviderRegistry
+	providerResolver domain.InstanceProviderResolver
 	messageRepo      domain.MessageRepository
+	conversationRepo domain.ConversationRepository
 	instanceRepo     domain.InstanceRepository
+	groupRepo        domain.InstanceGroupRepository
+	optOuts          *OptOutService
+	media            *MediaService
+	publisher        domain.EventPublisher
+	audit            auditDomain.Recorder
+	breakers         *circuitbreaker.Registry
+	health           *providerHealthTracker
+	router           *InstanceRouter
 	logger           zerolog.Logger
 }
 
 // NewWhatsAppService cria uma nova instância do serviço
 func NewWhatsAppService(
 	providerRegistry domain.ProviderRegistry,
+	providerResolver domain.InstanceProviderResolver,
 	messageRepo domain.MessageRepository,
+	conversationRepo domain.ConversationRepository,
 	instanceRepo domain.InstanceRepository,
+	groupRepo domain.InstanceGroupRepository,
+	optOuts *OptOutService,
+	media *MediaService,
+	publisher domain.EventPublisher,
+	audit auditDomain.Recorder,
+	breakers *circuitbreaker.Registry,
 	logger zerolog.Logger,
 ) *WhatsAppService {
 	return &WhatsAppService{
 		providerRegistry: providerRegistry,
+		providerResolver: providerResolver,
 		messageRepo:      messageRepo,
+		conversationRepo: conversationRepo,
 		instanceRepo:     instanceRepo,
+		groupRepo:        groupRepo,
+		optOuts:          optOuts,
+		media:            media,
+		publisher:        publisher,
+		audit:            audit,
+		breakers:         breakers,
+		health:           newProviderHealthTracker(),
+		router:           NewInstanceRouter(),
 		logger:           logger.With().Str("service", "whatsapp").Logger(),
 	}
 }
@@ -52,9 +84,17 @@ func (s *WhatsAppService) GetProviders() []string {
 
 // CreateInstance cria uma nova instância do WhatsApp
 func (s *WhatsAppService) CreateInstance(ctx context.Context, request domain.CreateInstanceRequest) (*domain.Instance, error) {
-	provider, exists := s.providerRegistry.Get(request.Provider)
-	if !exists {
-		return nil, fmt.Errorf("provider %s not found", request.Provider)
+	if err := s.validateInstanceConfig(request.Provider, request.Config); err != nil {
+		return nil, err
+	}
+
+	// Resolve um cliente com a configuração informada, ainda sem instância persistida
+	provider, err := s.providerResolver.Resolve(&domain.Instance{
+		Provider: request.Provider,
+		Config:   request.Config,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Valida o token
@@ -67,6 +107,7 @@ func (s *WhatsAppService) CreateInstance(ctx context.Context, request domain.Cre
 	if err != nil {
 		return nil, fmt.Errorf("failed to create instance in provider: %w", err)
 	}
+	instance.TenantID = contextTenant(ctx)
 
 	// Salva no banco de dados
 	if err := s.instanceRepo.Save(ctx, instance); err != nil {
@@ -78,6 +119,15 @@ func (s *WhatsAppService) CreateInstance(ctx context.Context, request domain.Cre
 		Str("provider", request.Provider).
 		Msg("Instance created successfully")
 
+	s.publisher.Publish(ctx, domain.NewInstanceCreatedEvent(instance))
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionInstanceCreate,
+		ResourceType: auditDomain.ResourceInstance,
+		ResourceID:   instance.ID.String(),
+		Summary:      instance.Name,
+		Changes:      auditDomain.Diff(nil, instanceAuditState(instance)),
+	})
+
 	return instance, nil
 }
 
@@ -91,16 +141,82 @@ func (s *WhatsAppService) GetAllInstances(ctx context.Context) ([]*domain.Instan
 	return s.instanceRepo.GetAll(ctx)
 }
 
+// UpdateInstanceConfig aplica um patch na configuração de uma instância
+func (s *WhatsAppService) UpdateInstanceConfig(ctx context.Context, id uuid.UUID, patch domain.UpdateInstanceConfigRequest) (*domain.Instance, error) {
+	instance, err := s.instanceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("instance")
+	}
+
+	config := domain.MergeInstanceConfig(instance.Config, patch)
+	if err := s.validateInstanceConfig(instance.Provider, config); err != nil {
+		return nil, err
+	}
+	before := instanceAuditState(instance)
+
+	instance.Config = config
+	instance.UpdatedAt = time.Now()
+	if err := s.instanceRepo.Update(ctx, instance); err != nil {
+		return nil, fmt.Errorf("failed to update instance config: %w", err)
+	}
+
+	// O resolver recria o cliente na próxima chamada, pois a configuração mudou
+	s.logger.Info().
+		Str("instance_id", id.String()).
+		Int("config_keys", len(config)).
+		Msg("Instance config updated")
+
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionInstanceUpdate,
+		ResourceType: auditDomain.ResourceInstance,
+		ResourceID:   id.String(),
+		Summary:      instance.Name,
+		Changes:      auditDomain.Diff(before, instanceAuditState(instance)),
+	})
+
+	return instance, nil
+}
+
+// GetProviderConfigFields retorna as chaves de configuração aceitas por um provider
+func (s *WhatsAppService) GetProviderConfigFields(providerName string) ([]domain.ProviderConfigField, error) {
+	provider, exists := s.providerRegistry.Get(providerName)
+	if !exists {
+		return nil, apperrors.NewNotFoundError(fmt.Sprintf("provider %s", providerName))
+	}
+
+	if configurable, ok := provider.(interface {
+		GetConfigFields() []domain.ProviderConfigField
+	}); ok {
+		return configurable.GetConfigFields(), nil
+	}
+
+	return []domain.ProviderConfigField{}, nil
+}
+
+// validateInstanceConfig valida a configuração contra as chaves conhecidas pelo provider
+func (s *WhatsAppService) validateInstanceConfig(providerName string, config map[string]any) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	fields, err := s.GetProviderConfigFields(providerName)
+	if err != nil {
+		return err
+	}
+
+	return domain.ValidateInstanceConfig(config, fields)
+}
+
 // DeleteInstance remove uma instância
 func (s *WhatsAppService) DeleteInstance(ctx context.Context, id uuid.UUID) error {
 	instance, err := s.instanceRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("instance not found: %w", err)
+		return apperrors.NewNotFoundError("instance")
 	}
 
-	provider, exists := s.providerRegistry.Get(instance.Provider)
-	if !exists {
-		return fmt.Errorf("provider %s not found", instance.Provider)
+	provider, err := s.providerResolver.Resolve(instance)
+	if err != nil {
+		return err
 	}
 
 	// Remove do provedor
@@ -112,73 +228,179 @@ func (s *WhatsAppService) DeleteInstance(ctx context.Context, id uuid.UUID) erro
 	if err := s.instanceRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete instance: %w", err)
 	}
+	s.providerResolver.Invalidate(id)
+	s.breakers.Remove(domain.InstanceBreakerName(provider.GetName(), instance.InstanceID))
+
+	if err := s.groupRepo.RemoveInstance(ctx, id); err != nil {
+		s.logger.Warn().Err(err).Str("instance_id", id.String()).Msg("Failed to remove instance from groups")
+	}
 
 	s.logger.Info().
 		Str("instance_id", id.String()).
 		Msg("Instance deleted successfully")
 
+	s.publisher.Publish(ctx, domain.NewInstanceDeletedEvent(instance))
+	s.audit.Record(ctx, auditDomain.Record{
+		Action:       auditDomain.ActionInstanceDelete,
+		ResourceType: auditDomain.ResourceInstance,
+		ResourceID:   id.String(),
+		Summary:      instance.Name,
+		Changes:      auditDomain.Diff(instanceAuditState(instance), nil),
+	})
+
 	return nil
 }
 
-// SendMessage envia uma mensagem
+// SendMessage envia uma mensagem por uma instância específica ou por um grupo de
+// instâncias. Telefones na lista de opt-out são recusados, exceto em mensagens transacionais
 func (s *WhatsAppService) SendMessage(ctx context.Context, request domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
-	s.logger.Info().Str("instance_id", request.InstanceID).Msg("DEBUG: SendMessage called")
+	if request.InstanceID != "" && request.GroupID != "" {
+		return nil, apperrors.NewValidationError("inform either instance_id or group_id, not both")
+	}
+
+	if err := request.ValidateMedia(); err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+	if request.MediaID != nil {
+		if err := s.media.ResolveForSend(ctx, &request); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.GroupID != "" {
+		return s.sendThroughGroup(ctx, request)
+	}
+
+	if request.InstanceID == "" {
+		return nil, apperrors.NewValidationError("instance_id or group_id is required")
+	}
 
 	// Converte o instance_id string para UUID
 	instanceUUID, err := uuid.Parse(request.InstanceID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid instance ID: %w", err)
+		return nil, apperrors.NewValidationError(fmt.Sprintf("invalid instance ID: %v", err))
 	}
 
-	s.logger.Info().Str("uuid", instanceUUID.String()).Msg("DEBUG: Parsed UUID, calling GetByID")
-
 	// Busca a instância por UUID
 	instance, err := s.instanceRepo.GetByID(ctx, instanceUUID)
 	if err != nil {
-		return nil, fmt.Errorf("instance not found: %w", err)
+		return nil, apperrors.NewNotFoundError("instance")
 	}
 
-	provider, exists := s.providerRegistry.Get(instance.Provider)
-	if !exists {
-		return nil, fmt.Errorf("provider %s not found", instance.Provider)
+	if !request.Transactional {
+		if err := s.optOuts.Check(ctx, instance.TenantID, request.Phone, []uuid.UUID{instance.ID}); err != nil {
+			return nil, err
+		}
 	}
 
+	return s.sendThroughInstance(ctx, instance, request)
+}
+
+// sendThroughInstance registra e envia a mensagem pela instância informada
+func (s *WhatsAppService) sendThroughInstance(ctx context.Context, instance *domain.Instance, request domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	provider, err := s.resolveSender(instance, request.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.queueMessage(ctx, instance, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.deliver(ctx, provider, instance, request)
+	return s.completeSend(ctx, instance, message, response, err)
+}
+
+// resolveSender retorna o provider da instância, exigindo suporte ao tipo da mensagem
+func (s *WhatsAppService) resolveSender(instance *domain.Instance, messageType domain.MessageType) (domain.WhatsAppProvider, error) {
+	provider, err := s.providerResolver.Resolve(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireFeature(provider, domain.MessageFeature(messageType)); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// queueMessage grava a mensagem pendente da instância e publica o evento correspondente
+func (s *WhatsAppService) queueMessage(ctx context.Context, instance *domain.Instance, request domain.SendMessageRequest) (*domain.Message, error) {
 	// Cria a mensagem no banco de dados
 	message := &domain.Message{
 		ID:         uuid.New(),
-		InstanceID: request.InstanceID,
-		Phone:      request.Phone,
+		TenantID:   instance.TenantID,
+		InstanceID: instance.ID.String(),
+		Provider:   instance.Provider,
+		Phone:      domain.NormalizePhone(request.Phone),
+		Direction:  domain.DirectionOutbound,
 		Type:       request.Type,
 		Content:    request.Content,
 		MediaURL:   request.MediaURL,
+		MediaID:    request.MediaID,
 		Status:     domain.StatusPending,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
 
+	if message.MediaID != nil {
+		// A URL resolvida é assinada e temporária, ou o próprio conteúdo em base64
+		message.MediaURL = nil
+	}
+
 	if err := s.messageRepo.Save(ctx, message); err != nil {
 		return nil, fmt.Errorf("failed to save message: %w", err)
 	}
+	s.publisher.Publish(ctx, domain.NewMessageQueuedEvent(instance.ID, message))
+	return message, nil
+}
 
-	// Envia através do provedor
-	response, err := provider.SendMessage(ctx, instance, request)
+// deliver envia a mensagem através do provider da instância
+func (s *WhatsAppService) deliver(ctx context.Context, provider domain.WhatsAppProvider, instance *domain.Instance, request domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	request.InstanceID = instance.ID.String()
+	request.GroupID = ""
+	return provider.SendMessage(ctx, instance, request)
+}
+
+// completeSend grava o resultado do envio, publicando o evento e o registro de
+// auditoria da mensagem
+func (s *WhatsAppService) completeSend(ctx context.Context, instance *domain.Instance, message *domain.Message, response *domain.SendMessageResponse, err error) (*domain.SendMessageResponse, error) {
 	if err != nil {
 		// Atualiza status para erro
 		errorMsg := err.Error()
 		_ = s.messageRepo.UpdateStatus(ctx, message.ID, domain.StatusFailed, nil, &errorMsg)
+		s.publisher.Publish(ctx, domain.NewMessageFailedEvent(instance.ID, message, errorMsg))
+		s.recordSend(ctx, message, domain.StatusFailed)
+		if errors.Is(err, domain.ErrCircuitOpen) {
+			return nil, apperrors.NewUnavailableError("CIRCUIT_OPEN", errorMsg)
+		}
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
 	// Atualiza o status da mensagem
 	_ = s.messageRepo.UpdateStatus(ctx, message.ID, response.Status, response.ProviderID, response.Error)
+	if response.Status == domain.StatusFailed {
+		errorMsg := ""
+		if response.Error != nil {
+			errorMsg = *response.Error
+		}
+		s.publisher.Publish(ctx, domain.NewMessageFailedEvent(instance.ID, message, errorMsg))
+	} else {
+		s.publisher.Publish(ctx, domain.NewMessageSentEvent(instance.ID, message, response))
+	}
 
 	s.logger.Info().
 		Str("message_id", message.ID.String()).
-		Str("instance_id", request.InstanceID).
-		Str("phone", request.Phone).
-		Msg("Message sent successfully")
+		Str("instance_id", message.InstanceID).
+		Str("phone", message.Phone).
+		Str("status", string(response.Status)).
+		Msg("Message sent")
+
+	s.recordSend(ctx, message, response.Status)
 
 	response.ID = message.ID
+	response.InstanceID = message.InstanceID
 	return response, nil
 }
 
@@ -196,68 +418,10 @@ func (s *WhatsAppService) GetMessagesByInstance(ctx context.Context, instanceID
 func (s *WhatsAppService) GetInstanceStatus(ctx context.Context, instanceID string) (*domain.InstanceInfo, error) {
 	instance, err := s.instanceRepo.GetByInstanceID(ctx, instanceID)
 	if err != nil {
-		return nil, fmt.Errorf("instance not found: %w", err)
+		return nil, apperrors.NewNotFoundError("instance")
 	}
 
-	provider, exists := s.providerRegistry.Get(instance.Provider)
-	if !exists {
-		return nil, fmt.Errorf("provider %s not found", instance.Provider)
-	}
-
-	return provider.GetInstanceStatus(ctx, instance)
-}
-
-// GetProviderFeatures retorna as funcionalidades suportadas por um provider
-func (s *WhatsAppService) GetProviderFeatures(providerName string) ([]domain.ProviderFeature, error) {
-	provider, exists := s.providerRegistry.Get(providerName)
-	if !exists {
-		return nil, fmt.Errorf("provider %s not found", providerName)
-	}
-
-	// Verifica se o provider implementa a interface estendida
-	if extendedProvider, ok := provider.(interface {
-		GetSupportedFeatures() []domain.ProviderFeature
-	}); ok {
-		return extendedProvider.GetSupportedFeatures(), nil
-	}
-
-	// Retorna features básicas para providers que não implementam a interface estendida
-	return []domain.ProviderFeature{
-		domain.FeatureTextMessages,
-		domain.FeatureStatusCheck,
-	}, nil
-}
-
-// CheckProviderHealth verifica a saúde de um provider específico
-func (s *WhatsAppService) CheckProviderHealth(ctx context.Context, providerName string) error {
-	provider, exists := s.providerRegistry.Get(providerName)
-	if !exists {
-		return fmt.Errorf("provider %s not found", providerName)
-	}
-
-	// Verifica se o provider implementa health check
-	if healthProvider, ok := provider.(interface{ HealthCheck(context.Context) error }); ok {
-		return healthProvider.HealthCheck(ctx)
-	}
-
-	// Para providers que não implementam health check, assumimos que estão ok
-	return nil
-}
-
-// CheckAllProvidersHealth verifica a saúde de todos os providers
-func (s *WhatsAppService) CheckAllProvidersHealth(ctx context.Context) map[string]error {
-	results := make(map[string]error)
-	providers := s.providerRegistry.GetAll()
-
-	for name, provider := range providers {
-		if healthProvider, ok := provider.(interface{ HealthCheck(context.Context) error }); ok {
-			results[name] = healthProvider.HealthCheck(ctx)
-		} else {
-			results[name] = nil // Assume que está ok
-		}
-	}
-
-	return results
+	return s.RefreshInstanceStatus(ctx, instance)
 }
 
 // UpdateProfileName atualiza o nome do perfil de uma instância
@@ -266,20 +430,22 @@ func (s *WhatsAppService) UpdateProfileName(ctx context.Context, request domain.
 	// Converte o instance_id string para UUID
 	instanceUUID, err := uuid.Parse(request.InstanceID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid instance ID: %w", err)
+		return nil, apperrors.NewValidationError(fmt.Sprintf("invalid instance ID: %v", err))
 	}
 
-	s.logger.Info().Str("uuid", instanceUUID.String()).Msg("DEBUG: Parsed UUID, calling GetByID")
-
-	// Busca a instância pela instanceID string
+	// Busca a instância pelo UUID
 	instance, err := s.instanceRepo.GetByID(ctx, instanceUUID)
 	if err != nil {
-		return nil, fmt.Errorf("instance not found: %w", err)
+		return nil, apperrors.NewNotFoundError("instance")
 	}
 
-	provider, exists := s.providerRegistry.Get(instance.Provider)
-	if !exists {
-		return nil, fmt.Errorf("provider %s not found", instance.Provider)
+	provider, err := s.providerResolver.Resolve(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireFeature(provider, domain.FeatureProfileName); err != nil {
+		return nil, err
 	}
 
 	// Envia através do provedor
@@ -299,6 +465,17 @@ func (s *WhatsAppService) UpdateProfileName(ctx context.Context, request domain.
 		Bool("success", response.Success).
 		Msg("Profile name update completed")
 
+	if response.Success {
+		s.publisher.Publish(ctx, domain.NewProfileUpdatedEvent(instance, domain.ProfileFieldName, request.Name))
+		s.audit.Record(ctx, auditDomain.Record{
+			Action:       auditDomain.ActionProfileNameUpdate,
+			ResourceType: auditDomain.ResourceInstance,
+			ResourceID:   instance.ID.String(),
+			Summary:      instance.Name,
+			Changes:      map[string]auditDomain.Change{"profile_name": {To: request.Name}},
+		})
+	}
+
 	return response, nil
 }
 
@@ -308,20 +485,22 @@ func (s *WhatsAppService) UpdateProfilePicture(ctx context.Context, request doma
 	// Converte o instance_id string para UUID
 	instanceUUID, err := uuid.Parse(request.InstanceID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid instance ID: %w", err)
+		return nil, apperrors.NewValidationError(fmt.Sprintf("invalid instance ID: %v", err))
 	}
 
-	s.logger.Info().Str("uuid", instanceUUID.String()).Msg("DEBUG: Parsed UUID, calling GetByID")
-
-	// Busca a instância pela instanceID string
+	// Busca a instância pelo UUID
 	instance, err := s.instanceRepo.GetByID(ctx, instanceUUID)
 	if err != nil {
-		return nil, fmt.Errorf("instance not found: %w", err)
+		return nil, apperrors.NewNotFoundError("instance")
 	}
 
-	provider, exists := s.providerRegistry.Get(instance.Provider)
-	if !exists {
-		return nil, fmt.Errorf("provider %s not found", instance.Provider)
+	provider, err := s.providerResolver.Resolve(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireFeature(provider, domain.FeatureProfilePicture); err != nil {
+		return nil, err
 	}
 
 	// Envia através do provedor
@@ -341,5 +520,16 @@ func (s *WhatsAppService) UpdateProfilePicture(ctx context.Context, request doma
 		Bool("success", response.Success).
 		Msg("Profile picture update completed")
 
+	if response.Success {
+		s.publisher.Publish(ctx, domain.NewProfileUpdatedEvent(instance, domain.ProfileFieldPicture, request.PictureURL))
+		s.audit.Record(ctx, auditDomain.Record{
+			Action:       auditDomain.ActionProfilePictureUpdate,
+			ResourceType: auditDomain.ResourceInstance,
+			ResourceID:   instance.ID.String(),
+			Summary:      instance.Name,
+			Changes:      map[string]auditDomain.Change{"profile_picture": {To: request.PictureURL}},
+		})
+	}
+
 	return response, nil
 }
@@ -0,0 +1,134 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure/providers"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+)
+
+// newTestWhatsAppService cria o serviço apenas com as dependências usadas pelos testes
+func newTestWhatsAppService(instanceRepo domain.InstanceRepository, resolver domain.InstanceProviderResolver) *application.WhatsAppService {
+	return application.NewWhatsAppService(nil, resolver, nil, nil, instanceRepo, nil, nil, nil, nil, nil, nil, zerolog.Nop())
+}
+
+func TestWhatsAppService_ReceiveWebhookAuthenticatesCallback(t *testing.T) {
+	instance := &domain.Instance{
+		ID:         uuid.New(),
+		Provider:   "z-api",
+		InstanceID: "3C01A2B3",
+		Token:      "provider-token",
+	}
+	presence := []byte(`{"type":"PresenceChatCallback","instanceId":"3C01A2B3"}`)
+
+	tests := []struct {
+		name    string
+		token   string
+		payload []byte
+		wantErr error
+	}{
+		{name: "valid token", token: instance.CallbackToken(), payload: presence},
+		{name: "missing token", token: "", payload: presence, wantErr: apperrors.ErrUnauthorized},
+		{name: "token of another instance", token: (&domain.Instance{ID: uuid.New(), Token: "provider-token"}).CallbackToken(), payload: presence, wantErr: apperrors.ErrUnauthorized},
+		{name: "missing provider instance", token: instance.CallbackToken(), payload: []byte(`{"type":"PresenceChatCallback"}`), wantErr: apperrors.ErrBadRequest},
+		{name: "other provider instance", token: instance.CallbackToken(), payload: []byte(`{"type":"PresenceChatCallback","instanceId":"OTHER"}`), wantErr: apperrors.ErrBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instanceRepo := new(MockInstanceRepository)
+			resolver := new(MockProviderResolver)
+			instanceRepo.On("GetByID", context.Background(), instance.ID).Return(instance, nil)
+			resolver.On("Resolve", instance).Return(providers.NewZAPIProvider(zerolog.Nop()), nil).Maybe()
+
+			service := newTestWhatsAppService(instanceRepo, resolver)
+			err := service.ReceiveWebhook(context.Background(), "z-api", instance.ID, tt.token, tt.payload)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantErr == apperrors.ErrUnauthorized {
+				resolver.AssertNotCalled(t, "Resolve", instance)
+			}
+			instanceRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWhatsAppService_UnknownInstanceIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	instanceRepo := new(MockInstanceRepository)
+	instanceRepo.On("GetByID", ctx, id).Return(nil, errors.New("record not found"))
+	instanceRepo.On("GetByInstanceID", ctx, "3C01A2B3").Return(nil, errors.New("record not found"))
+	service := newTestWhatsAppService(instanceRepo, new(MockProviderResolver))
+
+	err := service.DeleteInstance(ctx, id)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	_, err = service.SendMessage(ctx, domain.SendMessageRequest{
+		InstanceID: id.String(),
+		Phone:      "5511999999999",
+		Type:       domain.TextMessage,
+		Content:    "Olá",
+	})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	_, err = service.GetInstanceStatus(ctx, "3C01A2B3")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	instanceRepo.AssertExpectations(t)
+}
+
+func TestWhatsAppService_DeleteInstanceRemovesBreaker(t *testing.T) {
+	ctx := context.Background()
+	instance := &domain.Instance{ID: uuid.New(), Name: "vendas", Provider: "z-api", InstanceID: "3C01A2B3"}
+	breakerName := domain.InstanceBreakerName("z-api", instance.InstanceID)
+
+	provider := new(MockProvider)
+	resolver := new(MockProviderResolver)
+	instanceRepo := new(MockInstanceRepository)
+	groupRepo := new(MockInstanceGroupRepository)
+	publisher := new(MockEventPublisher)
+	recorder := new(MockAuditRecorder)
+	provider.On("GetName").Return("z-api")
+	provider.On("DeleteInstance", ctx, instance).Return(nil).Once()
+	resolver.On("Resolve", instance).Return(provider, nil).Once()
+	resolver.On("Invalidate", instance.ID).Once()
+	instanceRepo.On("GetByID", ctx, instance.ID).Return(instance, nil).Once()
+	instanceRepo.On("Delete", ctx, instance.ID).Return(nil).Once()
+	groupRepo.On("RemoveInstance", ctx, instance.ID).Return(nil).Once()
+	publisher.On("Publish", ctx, mock.AnythingOfType("*domain.InstanceDeletedEvent")).Once()
+	recorder.On("Record", ctx, mock.Anything).Once()
+
+	breakers := circuitbreaker.NewRegistry(circuitbreaker.DefaultSettings(), nil)
+	breakers.Get("z-api")
+	breakers.Get(breakerName)
+
+	service := application.NewWhatsAppService(nil, resolver, nil, nil, instanceRepo, groupRepo, nil, nil, publisher, recorder, breakers, zerolog.Nop())
+	require.NoError(t, service.DeleteInstance(ctx, instance.ID))
+
+	// Só o breaker da instância deixa o registro; o do provider continua
+	snapshots := breakers.Snapshots()
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "z-api", snapshots[0].Name)
+
+	provider.AssertExpectations(t)
+	resolver.AssertExpectations(t)
+	instanceRepo.AssertExpectations(t)
+	groupRepo.AssertExpectations(t)
+}
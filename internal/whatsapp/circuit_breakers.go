@@ -0,0 +1,82 @@
+package whatsapp
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/your-org/boilerplate-go/internal/config"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+)
+
+// newCircuitBreakerRegistry cria o registro de circuit breakers compartilhado
+// pelos clientes dos providers, registrando as transições em log e métricas
+func newCircuitBreakerRegistry(cfg *config.Config, logger zerolog.Logger) (*circuitbreaker.Registry, error) {
+	meter := otel.Meter("whatsapp")
+
+	transitions, err := meter.Int64Counter(
+		"whatsapp.circuit_breaker.transitions",
+		metric.WithDescription("Number of circuit breaker state transitions"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	breakerLogger := logger.With().Str("component", "circuit_breaker").Logger()
+	onStateChange := func(name string, from, to circuitbreaker.State) {
+		event := breakerLogger.Info()
+		if to == circuitbreaker.StateOpen {
+			event = breakerLogger.Warn()
+		}
+		event.
+			Str("breaker", name).
+			Str("from", string(from)).
+			Str("to", string(to)).
+			Msg("Circuit breaker state changed")
+
+		transitions.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String("breaker", name),
+			attribute.String("to", string(to)),
+		))
+	}
+
+	registry := circuitbreaker.NewRegistry(circuitbreaker.Settings{
+		FailureThreshold:    cfg.WhatsApp.CircuitBreaker.FailureThreshold,
+		OpenTimeout:         cfg.WhatsApp.CircuitBreaker.OpenTimeout,
+		HalfOpenMaxRequests: cfg.WhatsApp.CircuitBreaker.HalfOpenMaxRequests,
+	}, onStateChange)
+
+	// 0 = closed, 1 = half_open, 2 = open
+	_, err = meter.Int64ObservableGauge(
+		"whatsapp.circuit_breaker.state",
+		metric.WithDescription("Circuit breaker state (0 closed, 1 half-open, 2 open)"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			for _, snapshot := range registry.Snapshots() {
+				observer.Observe(stateValue(snapshot.State), metric.WithAttributes(
+					attribute.String("breaker", snapshot.Name),
+				))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// stateValue converte o estado do breaker no valor numérico da métrica
+func stateValue(state circuitbreaker.State) int64 {
+	switch state {
+	case circuitbreaker.StateOpen:
+		return 2
+	case circuitbreaker.StateHalfOpen:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// AnalyticsBucket define o período de agrupamento das estatísticas
+type AnalyticsBucket string
+
+const (
+	BucketHour AnalyticsBucket = "hour"
+	BucketDay  AnalyticsBucket = "day"
+	BucketWeek AnalyticsBucket = "week"
+)
+
+// Duration retorna a duração do período, usada para limitar o tamanho das consultas
+func (b AnalyticsBucket) Duration() time.Duration {
+	switch b {
+	case BucketHour:
+		return time.Hour
+	case BucketDay:
+		return 24 * time.Hour
+	case BucketWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// MessageAnalyticsQuery representa os filtros da consulta de estatísticas.
+// O intervalo considera a data de criação das mensagens, em UTC
+type MessageAnalyticsQuery struct {
+	InstanceID string
+	Provider   string
+	Type       MessageType
+	From       time.Time // inclusivo
+	To         time.Time // exclusivo
+	Bucket     AnalyticsBucket
+}
+
+// MessageStats representa os contadores de um conjunto de mensagens. Sent,
+// Delivered, Read e Failed consideram apenas mensagens enviadas
+type MessageStats struct {
+	Received  int64 `json:"received"`
+	Sent      int64 `json:"sent"`
+	Delivered int64 `json:"delivered"`
+	Read      int64 `json:"read"`
+	Failed    int64 `json:"failed"`
+	// DeliveryRate é Delivered/Sent e ReadRate é Read/Delivered
+	DeliveryRate float64 `json:"delivery_rate"`
+	ReadRate     float64 `json:"read_rate"`
+	// Tempos médios a partir do envio; nulos quando não há confirmações no período
+	AvgDeliverySeconds *float64 `json:"avg_delivery_seconds"`
+	AvgReadSeconds     *float64 `json:"avg_read_seconds"`
+}
+
+// ComputeRates calcula as taxas de entrega e leitura a partir dos contadores
+func (s *MessageStats) ComputeRates() {
+	s.DeliveryRate, s.ReadRate = 0, 0
+	if s.Sent > 0 {
+		s.DeliveryRate = float64(s.Delivered) / float64(s.Sent)
+	}
+	if s.Delivered > 0 {
+		s.ReadRate = float64(s.Read) / float64(s.Delivered)
+	}
+}
+
+// MessageStatsRow representa as estatísticas de uma instância, provider e tipo
+// de mensagem em um período
+type MessageStatsRow struct {
+	Bucket     time.Time   `json:"bucket"`
+	InstanceID string      `json:"instance_id"`
+	Provider   string      `json:"provider"`
+	Type       MessageType `json:"type"`
+	MessageStats
+}
+
+// FailureReason representa um motivo de falha e a quantidade de mensagens afetadas
+type FailureReason struct {
+	Reason string `json:"reason"`
+	Count  int64  `json:"count"`
+}
+
+// MessageAnalytics representa o resultado da consulta de estatísticas
+type MessageAnalytics struct {
+	From        time.Time          `json:"from"`
+	To          time.Time          `json:"to"`
+	Bucket      AnalyticsBucket    `json:"bucket"`
+	Totals      *MessageStats      `json:"totals"`
+	Rows        []*MessageStatsRow `json:"rows"`
+	TopFailures []*FailureReason   `json:"top_failures"`
+}
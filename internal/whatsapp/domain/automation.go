@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleTriggerType representa a condição que dispara uma regra de automação
+type RuleTriggerType string
+
+const (
+	// TriggerKeyword dispara quando a mensagem é igual a uma das palavras-chave,
+	// sem diferenciar maiúsculas e minúsculas
+	TriggerKeyword RuleTriggerType = "keyword"
+	// TriggerRegex dispara quando a mensagem atende à expressão regular
+	TriggerRegex RuleTriggerType = "regex"
+	// TriggerFirstMessageOfDay dispara na primeira mensagem do contato no dia
+	TriggerFirstMessageOfDay RuleTriggerType = "first_message_of_day"
+	// TriggerOutsideBusinessHours dispara nas mensagens recebidas fora do horário de atendimento
+	TriggerOutsideBusinessHours RuleTriggerType = "outside_business_hours"
+)
+
+// RuleReplyType representa a forma da resposta automática
+type RuleReplyType string
+
+const (
+	ReplyText     RuleReplyType = "text"
+	ReplyTemplate RuleReplyType = "template" // texto com variáveis de text/template, ex: {{.Phone}}
+	ReplyMedia    RuleReplyType = "media"
+)
+
+// BusinessHours representa o horário de atendimento, no fuso horário da regra
+type BusinessHours struct {
+	Days  []time.Weekday `json:"days"`  // 0 = domingo
+	Start string         `json:"start"` // HH:MM
+	End   string         `json:"end"`   // HH:MM, exclusivo
+}
+
+// Contains indica se o momento está dentro do horário de atendimento
+func (h *BusinessHours) Contains(at time.Time) bool {
+	open := false
+	for _, day := range h.Days {
+		if day == at.Weekday() {
+			open = true
+			break
+		}
+	}
+	if !open {
+		return false
+	}
+
+	clock := at.Format("15:04")
+	return clock >= h.Start && clock < h.End
+}
+
+// RuleTrigger representa a condição de uma regra
+type RuleTrigger struct {
+	Type          RuleTriggerType `json:"type"`
+	Keywords      []string        `json:"keywords,omitempty"`       // keyword
+	Pattern       string          `json:"pattern,omitempty"`        // regex
+	BusinessHours *BusinessHours  `json:"business_hours,omitempty"` // outside_business_hours
+	// Timezone é o fuso IANA usado para o dia e o horário de atendimento. Padrão: UTC
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Location retorna o fuso horário da regra
+func (t *RuleTrigger) Location() (*time.Location, error) {
+	if t.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(t.Timezone)
+}
+
+// Validate verifica se a condição está completa
+func (t *RuleTrigger) Validate() error {
+	if _, err := t.Location(); err != nil {
+		return fmt.Errorf("invalid timezone: %s", t.Timezone)
+	}
+
+	switch t.Type {
+	case TriggerKeyword:
+		if len(t.Keywords) == 0 {
+			return fmt.Errorf("keyword trigger requires keywords")
+		}
+	case TriggerRegex:
+		if t.Pattern == "" {
+			return fmt.Errorf("regex trigger requires a pattern")
+		}
+		if _, err := regexp.Compile(t.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	case TriggerFirstMessageOfDay:
+	case TriggerOutsideBusinessHours:
+		hours := t.BusinessHours
+		if hours == nil || len(hours.Days) == 0 {
+			return fmt.Errorf("outside_business_hours trigger requires business_hours with days")
+		}
+		for _, day := range hours.Days {
+			if day < time.Sunday || day > time.Saturday {
+				return fmt.Errorf("invalid business day: %d", day)
+			}
+		}
+		start, errStart := time.Parse("15:04", hours.Start)
+		end, errEnd := time.Parse("15:04", hours.End)
+		if errStart != nil || errEnd != nil || !start.Before(end) {
+			return fmt.Errorf("business_hours start and end must be HH:MM with start before end")
+		}
+	default:
+		return fmt.Errorf("unknown trigger type: %s", t.Type)
+	}
+	return nil
+}
+
+// MatchesContent indica se o conteúdo atende às condições de texto da regra.
+// As condições que dependem do histórico ou do horário são avaliadas pelo motor de regras
+func (t *RuleTrigger) MatchesContent(content string) bool {
+	switch t.Type {
+	case TriggerKeyword:
+		normalized := strings.TrimSpace(content)
+		for _, keyword := range t.Keywords {
+			if strings.EqualFold(normalized, strings.TrimSpace(keyword)) {
+				return true
+			}
+		}
+		return false
+	case TriggerRegex:
+		pattern, err := regexp.Compile(t.Pattern)
+		return err == nil && pattern.MatchString(content)
+	default:
+		return true
+	}
+}
+
+// RuleReply representa a resposta enviada quando a regra dispara
+type RuleReply struct {
+	Type      RuleReplyType `json:"type"`
+	Content   string        `json:"content"`              // texto, template ou legenda da mídia
+	MediaType MessageType   `json:"media_type,omitempty"` // media: image, video, audio ou document
+	MediaURL  *string       `json:"media_url,omitempty"`  // media
+}
+
+// RuleTemplateData contém as variáveis disponíveis nas respostas do tipo template
+type RuleTemplateData struct {
+	Phone      string    // telefone do contato
+	Message    string    // conteúdo da mensagem recebida
+	InstanceID string    // instância que recebeu a mensagem
+	Now        time.Time // momento da mensagem no fuso horário da regra
+}
+
+// Render gera o texto da resposta. Templates são executados com os dados da mensagem
+func (r *RuleReply) Render(data RuleTemplateData) (string, error) {
+	if r.Type != ReplyTemplate {
+		return r.Content, nil
+	}
+
+	tmpl, err := template.New("reply").Option("missingkey=error").Parse(r.Content)
+	if err != nil {
+		return "", fmt.Errorf("invalid reply template: %w", err)
+	}
+
+	var content strings.Builder
+	if err := tmpl.Execute(&content, data); err != nil {
+		return "", fmt.Errorf("failed to render reply template: %w", err)
+	}
+	return content.String(), nil
+}
+
+// RuleAction representa o que é feito quando a regra dispara. Ao menos uma
+// das ações deve ser informada
+type RuleAction struct {
+	Reply   *RuleReply `json:"reply,omitempty"`
+	Tag     string     `json:"tag,omitempty"`     // etiqueta adicionada à conversa
+	Handoff bool       `json:"handoff,omitempty"` // transfere a conversa para atendimento humano
+}
+
+// AutomationRule representa uma regra de resposta automática de uma instância.
+// As regras ativas são avaliadas por prioridade a cada mensagem recebida e
+// apenas a primeira que atende à condição dispara
+type AutomationRule struct {
+	ID         uuid.UUID   `json:"id"`
+	TenantID   uuid.UUID   `json:"tenant_id"`
+	InstanceID uuid.UUID   `json:"instance_id"`
+	Name       string      `json:"name"`
+	Priority   int         `json:"priority"` // menor valor é avaliado primeiro
+	Active     bool        `json:"active"`
+	Trigger    RuleTrigger `json:"trigger"`
+	Action     RuleAction  `json:"action"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// CreateAutomationRuleRequest representa uma requisição para criar uma regra
+type CreateAutomationRuleRequest struct {
+	Name     string      `json:"name" binding:"required"`
+	Priority int         `json:"priority"`
+	Active   *bool       `json:"active,omitempty"` // padrão: true
+	Trigger  RuleTrigger `json:"trigger"`
+	Action   RuleAction  `json:"action"`
+}
+
+// UpdateAutomationRuleRequest aplica um patch em uma regra. Campos ausentes são mantidos
+type UpdateAutomationRuleRequest struct {
+	Name     *string      `json:"name,omitempty"`
+	Priority *int         `json:"priority,omitempty"`
+	Active   *bool        `json:"active,omitempty"`
+	Trigger  *RuleTrigger `json:"trigger,omitempty"`
+	Action   *RuleAction  `json:"action,omitempty"`
+}
+
+// RuleExecution registra uma regra disparada e o resultado das suas ações
+type RuleExecution struct {
+	ID             uuid.UUID  `json:"id"`
+	RuleID         uuid.UUID  `json:"rule_id"`
+	RuleName       string     `json:"rule_name"`
+	InstanceID     uuid.UUID  `json:"instance_id"`
+	Phone          string     `json:"phone"`
+	MessageID      uuid.UUID  `json:"message_id"`                 // mensagem recebida que disparou a regra
+	ReplyMessageID *uuid.UUID `json:"reply_message_id,omitempty"` // resposta enviada
+	Actions        []string   `json:"actions"`                    // ações executadas: reply, tag, handoff
+	Error          *string    `json:"error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// AutomationRuleRepository define a interface para persistência de regras de automação
+type AutomationRuleRepository interface {
+	Save(ctx context.Context, rule *AutomationRule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*AutomationRule, error)
+	// ListByInstance lista as regras da instância por prioridade
+	ListByInstance(ctx context.Context, instanceID uuid.UUID, activeOnly bool) ([]*AutomationRule, error)
+	Update(ctx context.Context, rule *AutomationRule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// RuleExecutionRepository define a interface para persistência do histórico de regras disparadas
+type RuleExecutionRepository interface {
+	Save(ctx context.Context, execution *RuleExecution) error
+	// ListByInstance lista as execuções da instância, opcionalmente de uma
+	// regra, da mais recente para a mais antiga
+	ListByInstance(ctx context.Context, instanceID uuid.UUID, ruleID *uuid.UUID, limit int, before *Cursor) ([]*RuleExecution, error)
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// conversationPreviewLength é o tamanho máximo, em caracteres, da prévia da última mensagem
+const conversationPreviewLength = 100
+
+// Conversation representa o chat de uma instância com um telefone, mantido
+// a cada mensagem gravada
+type Conversation struct {
+	ID                 uuid.UUID        `json:"id"`
+	TenantID           uuid.UUID        `json:"tenant_id"`
+	InstanceID         string           `json:"instance_id"`
+	Phone              string           `json:"phone"`
+	LastMessageID      uuid.UUID        `json:"last_message_id"`
+	LastMessagePreview string           `json:"last_message_preview"`
+	LastMessageType    MessageType      `json:"last_message_type"`
+	LastDirection      MessageDirection `json:"last_direction"`
+	LastMessageAt      time.Time        `json:"last_message_at"`
+	UnreadCount        int              `json:"unread_count"` // mensagens recebidas ainda não lidas
+	Tags               []string         `json:"tags"`
+	HandoffAt          *time.Time       `json:"handoff_at,omitempty"` // em atendimento humano desde; suspende as regras de automação
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// ConversationRepository define a interface de leitura das conversas. As
+// conversas são criadas e atualizadas pelo MessageRepository ao gravar mensagens
+type ConversationRepository interface {
+	// ListByInstance lista as conversas da instância da mais recente para a mais antiga
+	ListByInstance(ctx context.Context, instanceID string, limit int, after *Cursor) ([]*Conversation, error)
+	GetByPhone(ctx context.Context, instanceID, phone string) (*Conversation, error)
+	// MarkRead zera o contador de não lidas da conversa
+	MarkRead(ctx context.Context, instanceID, phone string) error
+	// AddTag adiciona a etiqueta à conversa, se ainda não estiver presente
+	AddTag(ctx context.Context, instanceID, phone, tag string) error
+	// SetHandoff transfere a conversa para atendimento humano ou, com nil, a devolve à automação
+	SetHandoff(ctx context.Context, instanceID, phone string, at *time.Time) error
+}
+
+// MessagePreview gera a prévia exibida na lista de conversas
+func MessagePreview(message *Message) string {
+	content := strings.TrimSpace(message.Content)
+	if content == "" && message.Type != TextMessage {
+		return "[" + string(message.Type) + "]"
+	}
+
+	if utf8.RuneCountInString(content) <= conversationPreviewLength {
+		return content
+	}
+	runes := []rune(content)
+	return string(runes[:conversationPreviewLength]) + "…"
+}
+
+// Cursor identifica a posição de um item em listagens ordenadas por
+// (data, id) decrescentes; o id desempata itens com a mesma data
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+}
+
+// Encode serializa o cursor em uma string opaca para o cliente
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.Time.Unix(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor lê um cursor gerado por Encode; string vazia resulta em nil
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	unix, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &Cursor{Time: time.Unix(seconds, 0), ID: parsedID}, nil
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErasureRequest representa o pedido de exclusão dos dados de um contato
+// (LGPD/GDPR). Por padrão o telefone continua na lista de opt-out, para que o
+// contato não volte a receber mensagens; remove_opt_outs também o remove dela
+type ErasureRequest struct {
+	Phone         string `json:"phone" binding:"required"`
+	RemoveOptOuts bool   `json:"remove_opt_outs"`
+}
+
+// ErasureCounts informa o que foi removido ou anonimizado em cada repositório
+type ErasureCounts struct {
+	Messages          int64 `json:"messages"`
+	Conversations     int64 `json:"conversations"`
+	RuleExecutions    int64 `json:"rule_executions"`
+	WebhookDeliveries int64 `json:"webhook_deliveries"`
+	OptOuts           int64 `json:"opt_outs"`
+	OptOutsKept       int64 `json:"opt_outs_kept"` // mantidos para continuar bloqueando o envio
+	AuditEntries      int64 `json:"audit_entries"` // anonimizadas
+	ExportFiles       int64 `json:"export_files"`
+	MediaFiles        int64 `json:"media_files"`   // arquivos das mídias recebidas
+	StreamEvents      int64 `json:"stream_events"` // descartados do replay do stream de eventos
+}
+
+// Versões do cálculo dos hashes do recibo. Na versão 1 os hashes eram SHA-256
+// sem chave; a partir da 2 são HMAC-SHA256 com a chave do servidor, já que o
+// telefone tem poucos dígitos e sem chave seria descoberto por força bruta
+const (
+	ErasureReceiptV1 = 1
+	ErasureReceiptV2 = 2
+
+	ErasureReceiptVersion = ErasureReceiptV2 // versão dos recibos emitidos
+)
+
+// ErasureReceipt é o comprovante de uma exclusão. O telefone não é guardado:
+// PhoneHash é o HMAC do telefone com um salt aleatório do próprio recibo,
+// o que permite ao servidor confirmar a que telefone ele se refere sem revelá-lo.
+// Os recibos de um tenant formam uma cadeia: cada um guarda o hash do
+// anterior, de modo que alterar ou remover um recibo quebra a cadeia
+type ErasureReceipt struct {
+	ID        uuid.UUID     `json:"id"`
+	Version   int           `json:"version"`
+	TenantID  uuid.UUID     `json:"tenant_id"`
+	Sequence  int64         `json:"sequence"`
+	PhoneHash string        `json:"phone_hash"`
+	PhoneSalt string        `json:"phone_salt"`
+	Removed   ErasureCounts `json:"removed"`
+	ActorType string        `json:"actor_type"`
+	ActorID   string        `json:"actor_id,omitempty"`
+	PrevHash  string        `json:"prev_hash"`
+	Hash      string        `json:"hash"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// HashErasedPhone calcula o hash do telefone com o salt do recibo e a chave do servidor
+func HashErasedPhone(key []byte, salt, phone string) string {
+	return receiptHash(ErasureReceiptVersion, key, salt+":"+phone)
+}
+
+// MatchesPhone indica se o recibo se refere ao telefone
+func (r *ErasureReceipt) MatchesPhone(key []byte, phone string) bool {
+	expected := receiptHash(r.Version, key, r.PhoneSalt+":"+NormalizePhone(phone))
+	return hmac.Equal([]byte(r.PhoneHash), []byte(expected))
+}
+
+// Chain encadeia o recibo ao último recibo do tenant (nil no primeiro),
+// definindo a sequência, o hash anterior e o próprio hash
+func (r *ErasureReceipt) Chain(previous *ErasureReceipt, key []byte) {
+	r.Sequence = 1
+	r.PrevHash = ""
+	if previous != nil {
+		r.Sequence = previous.Sequence + 1
+		r.PrevHash = previous.Hash
+	}
+	r.Hash = r.ComputeHash(key)
+}
+
+// ComputeHash calcula o hash do conteúdo do recibo, incluindo o hash anterior,
+// conforme a versão do recibo
+func (r *ErasureReceipt) ComputeHash(key []byte) string {
+	fields := []string{
+		fmt.Sprintf("v%d", r.Version),
+		r.ID.String(),
+		r.TenantID.String(),
+		fmt.Sprint(r.Sequence),
+		r.PhoneHash,
+		r.PhoneSalt,
+		fmt.Sprint(
+			r.Removed.Messages, r.Removed.Conversations, r.Removed.RuleExecutions,
+			r.Removed.WebhookDeliveries, r.Removed.OptOuts, r.Removed.OptOutsKept,
+			r.Removed.AuditEntries, r.Removed.ExportFiles, r.Removed.StreamEvents,
+		),
+		r.ActorType,
+		r.ActorID,
+		fmt.Sprint(r.CreatedAt.Unix()),
+		r.PrevHash,
+	}
+	// Na versão 1 o campo foi incluído depois dos primeiros recibos e só entra
+	// no hash quando há arquivos; a partir da versão 2 entra sempre, para que
+	// zerá-lo também invalide o recibo
+	if r.Version != ErasureReceiptV1 || r.Removed.MediaFiles > 0 {
+		fields = append(fields, "media_files="+fmt.Sprint(r.Removed.MediaFiles))
+	}
+	return receiptHash(r.Version, key, strings.Join(fields, "|"))
+}
+
+// receiptHash calcula o hash da versão do recibo: SHA-256 sem chave na
+// versão 1, mantida para conferir os recibos já emitidos, e HMAC-SHA256 nas demais
+func receiptHash(version int, key []byte, content string) string {
+	if version == ErasureReceiptV1 {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ErasureVerification é o resultado da conferência da cadeia de recibos
+type ErasureVerification struct {
+	Receipts int64  `json:"receipts"`
+	Valid    bool   `json:"valid"`
+	BrokenAt *int64 `json:"broken_at,omitempty"` // sequência do primeiro recibo inválido
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ErasureRepository remove e anonimiza os dados de um telefone nas tabelas do
+// módulo em uma única transação
+type ErasureRepository interface {
+	// Erase remove as mensagens, as conversas, o histórico de regras disparadas
+	// e as entregas de webhook do telefone no tenant e, com removeOptOuts, as
+	// entradas de opt-out. Retorna o que foi removido
+	Erase(ctx context.Context, tenantID uuid.UUID, phone string, removeOptOuts bool) (*ErasureCounts, error)
+}
+
+// ErasureReceiptRepository define a interface para persistência dos recibos.
+// Os recibos nunca são alterados nem removidos
+type ErasureReceiptRepository interface {
+	// Save grava o recibo encadeado. Retorna false quando outro recibo já ocupa
+	// a mesma sequência do tenant, caso em que o recibo deve ser reencadeado
+	Save(ctx context.Context, receipt *ErasureReceipt) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*ErasureReceipt, error)
+	// Last retorna o último recibo do tenant ou nil quando não há
+	Last(ctx context.Context, tenantID uuid.UUID) (*ErasureReceipt, error)
+	// List lista os recibos do tenant do contexto, do mais recente para o mais antigo
+	List(ctx context.Context, limit int, before *Cursor) ([]*ErasureReceipt, error)
+	// ListChain lista os recibos do tenant em ordem de sequência, a partir da
+	// sequência seguinte a afterSequence
+	ListChain(ctx context.Context, tenantID uuid.UUID, afterSequence int64, limit int) ([]*ErasureReceipt, error)
+}
@@ -0,0 +1,390 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// Nomes dos eventos publicados pelo módulo WhatsApp
+const (
+	EventInstanceCreated       = "whatsapp.instance.created"
+	EventInstanceDeleted       = "whatsapp.instance.deleted"
+	EventInstanceStatusChanged = "whatsapp.instance.status_changed"
+	EventProfileUpdated        = "whatsapp.instance.profile_updated"
+	EventMessageQueued         = "whatsapp.message.queued"
+	EventMessageSent           = "whatsapp.message.sent"
+	EventMessageFailed         = "whatsapp.message.failed"
+	EventMessageReceived       = "whatsapp.message.received"
+	EventMessageStatusChanged  = "whatsapp.message.status_changed"
+	EventContactOptedOut       = "whatsapp.contact.opted_out"
+)
+
+// EventPublisher publica eventos de domínio para outras partes do sistema
+type EventPublisher interface {
+	Publish(ctx context.Context, event events.Event)
+}
+
+// InstanceEvent é implementado pelos eventos relativos a uma instância,
+// permitindo filtrar assinaturas por tenant e por instância
+type InstanceEvent interface {
+	events.Event
+	GetInstanceID() uuid.UUID
+	GetTenantID() uuid.UUID
+}
+
+// ContactEvent é implementado pelos eventos relativos a um contato, permitindo
+// descartá-los quando os dados do contato são apagados
+type ContactEvent interface {
+	InstanceEvent
+	GetPhone() string
+}
+
+// TenantScope identifica o tenant dono da instância do evento
+type TenantScope struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+// GetTenantID retorna o tenant do evento
+func (s TenantScope) GetTenantID() uuid.UUID {
+	return s.TenantID
+}
+
+var (
+	_ InstanceEvent = (*InstanceCreatedEvent)(nil)
+	_ InstanceEvent = (*InstanceDeletedEvent)(nil)
+	_ InstanceEvent = (*InstanceStatusChangedEvent)(nil)
+	_ InstanceEvent = (*ProfileUpdatedEvent)(nil)
+	_ InstanceEvent = (*MessageQueuedEvent)(nil)
+	_ InstanceEvent = (*MessageSentEvent)(nil)
+	_ InstanceEvent = (*MessageFailedEvent)(nil)
+	_ InstanceEvent = (*MessageReceivedEvent)(nil)
+	_ InstanceEvent = (*MessageStatusChangedEvent)(nil)
+	_ InstanceEvent = (*ContactOptedOutEvent)(nil)
+
+	_ ContactEvent = (*MessageQueuedEvent)(nil)
+	_ ContactEvent = (*MessageSentEvent)(nil)
+	_ ContactEvent = (*MessageFailedEvent)(nil)
+	_ ContactEvent = (*MessageReceivedEvent)(nil)
+	_ ContactEvent = (*MessageStatusChangedEvent)(nil)
+	_ ContactEvent = (*ContactOptedOutEvent)(nil)
+)
+
+// InstanceStatusChangedEvent é publicado quando o status de uma instância muda
+type InstanceStatusChangedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID     uuid.UUID      `json:"instance_id"`
+	Provider       string         `json:"provider"`
+	PreviousStatus InstanceStatus `json:"previous_status"`
+	Status         InstanceStatus `json:"status"`
+	Error          *string        `json:"error,omitempty"`
+	// DowntimeSeconds é preenchido quando a instância volta a se conectar
+	DowntimeSeconds float64 `json:"downtime_seconds,omitempty"`
+}
+
+// NewInstanceStatusChangedEvent cria o evento de mudança de status da instância
+func NewInstanceStatusChangedEvent(instance *Instance, previous InstanceStatus, downtimeSeconds float64) *InstanceStatusChangedEvent {
+	return &InstanceStatusChangedEvent{
+		BaseEvent:       events.NewBaseEvent(EventInstanceStatusChanged),
+		TenantScope:     TenantScope{TenantID: instance.TenantID},
+		InstanceID:      instance.ID,
+		Provider:        instance.Provider,
+		PreviousStatus:  previous,
+		Status:          instance.Status,
+		Error:           instance.Error,
+		DowntimeSeconds: downtimeSeconds,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *InstanceStatusChangedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// MessageReceivedEvent é publicado quando uma mensagem recebida é armazenada
+type MessageReceivedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID `json:"instance_id"`
+	Message    *Message  `json:"message"`
+	// Nome e tipo da mídia informados pelo provider, usados no download da mídia
+	MediaFileName string `json:"-"`
+	MediaMimeType string `json:"-"`
+}
+
+// NewMessageReceivedEvent cria o evento de mensagem recebida
+func NewMessageReceivedEvent(instanceID uuid.UUID, message *Message) *MessageReceivedEvent {
+	return &MessageReceivedEvent{
+		BaseEvent:   events.NewBaseEvent(EventMessageReceived),
+		TenantScope: TenantScope{TenantID: message.TenantID},
+		InstanceID:  instanceID,
+		Message:     message,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *MessageReceivedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// GetPhone retorna o telefone do contato do evento
+func (e *MessageReceivedEvent) GetPhone() string {
+	return e.Message.Phone
+}
+
+// MessageStatusChangedEvent é publicado quando o provider confirma o envio,
+// a entrega ou a leitura de uma mensagem
+type MessageStatusChangedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID     uuid.UUID     `json:"instance_id"`
+	MessageID      uuid.UUID     `json:"message_id"`
+	ProviderID     string        `json:"provider_id"`
+	Phone          string        `json:"phone"`
+	PreviousStatus MessageStatus `json:"previous_status"`
+	Status         MessageStatus `json:"status"`
+	ChangedAt      time.Time     `json:"changed_at"`
+}
+
+// NewMessageStatusChangedEvent cria o evento de mudança de status da mensagem
+func NewMessageStatusChangedEvent(instanceID uuid.UUID, message *Message, providerID string, status MessageStatus, changedAt time.Time) *MessageStatusChangedEvent {
+	return &MessageStatusChangedEvent{
+		BaseEvent:      events.NewBaseEvent(EventMessageStatusChanged),
+		TenantScope:    TenantScope{TenantID: message.TenantID},
+		InstanceID:     instanceID,
+		MessageID:      message.ID,
+		ProviderID:     providerID,
+		Phone:          message.Phone,
+		PreviousStatus: message.Status,
+		Status:         status,
+		ChangedAt:      changedAt,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *MessageStatusChangedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// GetPhone retorna o telefone do contato do evento
+func (e *MessageStatusChangedEvent) GetPhone() string {
+	return e.Phone
+}
+
+// InstanceCreatedEvent é publicado quando uma instância é criada
+type InstanceCreatedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID `json:"instance_id"`
+	Name       string    `json:"name"`
+	Provider   string    `json:"provider"`
+}
+
+// NewInstanceCreatedEvent cria o evento de instância criada
+func NewInstanceCreatedEvent(instance *Instance) *InstanceCreatedEvent {
+	return &InstanceCreatedEvent{
+		BaseEvent:   events.NewBaseEvent(EventInstanceCreated),
+		TenantScope: TenantScope{TenantID: instance.TenantID},
+		InstanceID:  instance.ID,
+		Name:        instance.Name,
+		Provider:    instance.Provider,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *InstanceCreatedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// InstanceDeletedEvent é publicado quando uma instância é removida
+type InstanceDeletedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID `json:"instance_id"`
+	Name       string    `json:"name"`
+	Provider   string    `json:"provider"`
+}
+
+// NewInstanceDeletedEvent cria o evento de instância removida
+func NewInstanceDeletedEvent(instance *Instance) *InstanceDeletedEvent {
+	return &InstanceDeletedEvent{
+		BaseEvent:   events.NewBaseEvent(EventInstanceDeleted),
+		TenantScope: TenantScope{TenantID: instance.TenantID},
+		InstanceID:  instance.ID,
+		Name:        instance.Name,
+		Provider:    instance.Provider,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *InstanceDeletedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// ProfileField identifica o dado do perfil alterado
+type ProfileField string
+
+const (
+	ProfileFieldName    ProfileField = "name"
+	ProfileFieldPicture ProfileField = "picture"
+)
+
+// ProfileUpdatedEvent é publicado quando o nome ou a foto do perfil da instância muda
+type ProfileUpdatedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID    `json:"instance_id"`
+	Field      ProfileField `json:"field"`
+	Value      string       `json:"value"` // novo nome ou URL da nova foto
+}
+
+// NewProfileUpdatedEvent cria o evento de perfil atualizado
+func NewProfileUpdatedEvent(instance *Instance, field ProfileField, value string) *ProfileUpdatedEvent {
+	return &ProfileUpdatedEvent{
+		BaseEvent:   events.NewBaseEvent(EventProfileUpdated),
+		TenantScope: TenantScope{TenantID: instance.TenantID},
+		InstanceID:  instance.ID,
+		Field:       field,
+		Value:       value,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *ProfileUpdatedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// MessageQueuedEvent é publicado quando uma mensagem é gravada, antes do envio ao provider
+type MessageQueuedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID `json:"instance_id"`
+	Message    *Message  `json:"message"`
+}
+
+// NewMessageQueuedEvent cria o evento de mensagem na fila de envio
+func NewMessageQueuedEvent(instanceID uuid.UUID, message *Message) *MessageQueuedEvent {
+	return &MessageQueuedEvent{
+		BaseEvent:   events.NewBaseEvent(EventMessageQueued),
+		TenantScope: TenantScope{TenantID: message.TenantID},
+		InstanceID:  instanceID,
+		Message:     message,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *MessageQueuedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// GetPhone retorna o telefone do contato do evento
+func (e *MessageQueuedEvent) GetPhone() string {
+	return e.Message.Phone
+}
+
+// MessageSentEvent é publicado quando o provider aceita a mensagem
+type MessageSentEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID     `json:"instance_id"`
+	MessageID  uuid.UUID     `json:"message_id"`
+	Phone      string        `json:"phone"`
+	Status     MessageStatus `json:"status"`
+	ProviderID *string       `json:"provider_id,omitempty"`
+}
+
+// NewMessageSentEvent cria o evento de mensagem enviada
+func NewMessageSentEvent(instanceID uuid.UUID, message *Message, response *SendMessageResponse) *MessageSentEvent {
+	return &MessageSentEvent{
+		BaseEvent:   events.NewBaseEvent(EventMessageSent),
+		TenantScope: TenantScope{TenantID: message.TenantID},
+		InstanceID:  instanceID,
+		MessageID:   message.ID,
+		Phone:       message.Phone,
+		Status:      response.Status,
+		ProviderID:  response.ProviderID,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *MessageSentEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// GetPhone retorna o telefone do contato do evento
+func (e *MessageSentEvent) GetPhone() string {
+	return e.Phone
+}
+
+// MessageFailedEvent é publicado quando o envio da mensagem falha
+type MessageFailedEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID `json:"instance_id"`
+	MessageID  uuid.UUID `json:"message_id"`
+	Phone      string    `json:"phone"`
+	Error      string    `json:"error"`
+}
+
+// NewMessageFailedEvent cria o evento de falha no envio da mensagem
+func NewMessageFailedEvent(instanceID uuid.UUID, message *Message, errorMsg string) *MessageFailedEvent {
+	return &MessageFailedEvent{
+		BaseEvent:   events.NewBaseEvent(EventMessageFailed),
+		TenantScope: TenantScope{TenantID: message.TenantID},
+		InstanceID:  instanceID,
+		MessageID:   message.ID,
+		Phone:       message.Phone,
+		Error:       errorMsg,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *MessageFailedEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// GetPhone retorna o telefone do contato do evento
+func (e *MessageFailedEvent) GetPhone() string {
+	return e.Phone
+}
+
+// ContactOptedOutEvent é publicado quando um contato pede para não receber
+// mais mensagens, enviando uma das palavras de opt-out
+type ContactOptedOutEvent struct {
+	*events.BaseEvent
+	TenantScope
+	InstanceID uuid.UUID `json:"instance_id"`
+	Phone      string    `json:"phone"`
+	Keyword    string    `json:"keyword"`
+	Global     bool      `json:"global"` // o opt-out vale para todas as instâncias
+	MessageID  uuid.UUID `json:"message_id"`
+	OptOutID   uuid.UUID `json:"opt_out_id"`
+}
+
+// NewContactOptedOutEvent cria o evento de opt-out do contato
+func NewContactOptedOutEvent(instanceID uuid.UUID, optOut *OptOut, messageID uuid.UUID) *ContactOptedOutEvent {
+	return &ContactOptedOutEvent{
+		BaseEvent:   events.NewBaseEvent(EventContactOptedOut),
+		TenantScope: TenantScope{TenantID: optOut.TenantID},
+		InstanceID:  instanceID,
+		Phone:       optOut.Phone,
+		Keyword:     optOut.Keyword,
+		Global:      optOut.InstanceID == nil,
+		MessageID:   messageID,
+		OptOutID:    optOut.ID,
+	}
+}
+
+// GetInstanceID retorna a instância do evento
+func (e *ContactOptedOutEvent) GetInstanceID() uuid.UUID {
+	return e.InstanceID
+}
+
+// GetPhone retorna o telefone do contato do evento
+func (e *ContactOptedOutEvent) GetPhone() string {
+	return e.Phone
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportFormat representa o formato do arquivo de exportação de mensagens
+type ExportFormat string
+
+const (
+	ExportCSV   ExportFormat = "csv"
+	ExportJSONL ExportFormat = "jsonl" // uma mensagem JSON por linha
+)
+
+// IsValid indica se o formato é suportado
+func (f ExportFormat) IsValid() bool {
+	return f == ExportCSV || f == ExportJSONL
+}
+
+// ContentType retorna o tipo MIME do arquivo
+func (f ExportFormat) ContentType() string {
+	if f == ExportJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ExportStatus representa a etapa de uma exportação
+type ExportStatus string
+
+const (
+	ExportPending   ExportStatus = "pending"
+	ExportRunning   ExportStatus = "running"
+	ExportCompleted ExportStatus = "completed"
+	ExportFailed    ExportStatus = "failed"
+	ExportExpired   ExportStatus = "expired" // o arquivo gerado foi removido
+)
+
+// ExportFilter seleciona as mensagens exportadas
+type ExportFilter struct {
+	InstanceID string        `json:"instance_id,omitempty"`
+	Phone      string        `json:"phone,omitempty"`
+	Status     MessageStatus `json:"status,omitempty"`
+	From       *time.Time    `json:"from,omitempty"` // inclusivo
+	To         *time.Time    `json:"to,omitempty"`   // exclusivo
+}
+
+// MessageFilter converte o filtro da exportação para o filtro da busca de mensagens
+func (f ExportFilter) MessageFilter() MessageFilter {
+	return MessageFilter{
+		InstanceID: f.InstanceID,
+		Phone:      f.Phone,
+		Status:     f.Status,
+		From:       f.From,
+		To:         f.To,
+	}
+}
+
+// ExportJob representa a exportação de mensagens processada em background.
+// O arquivo gerado fica disponível para download até ExpiresAt
+type ExportJob struct {
+	ID          uuid.UUID    `json:"id"`
+	TenantID    uuid.UUID    `json:"tenant_id"`
+	Format      ExportFormat `json:"format"`
+	Filter      ExportFilter `json:"filter"`
+	Status      ExportStatus `json:"status"`
+	Rows        int64        `json:"rows"`
+	SizeBytes   int64        `json:"size_bytes"`
+	FileName    string       `json:"-"`
+	Error       *string      `json:"error,omitempty"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+	StartedAt   *time.Time   `json:"started_at,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// CreateExportRequest representa uma requisição para exportar mensagens
+type CreateExportRequest struct {
+	Format     ExportFormat  `json:"format" binding:"required"`
+	InstanceID string        `json:"instance_id,omitempty"`
+	Phone      string        `json:"phone,omitempty"`
+	Status     MessageStatus `json:"status,omitempty"`
+	From       *time.Time    `json:"from,omitempty"`
+	To         *time.Time    `json:"to,omitempty"`
+}
+
+// ExportJobRepository define a interface para persistência das exportações
+type ExportJobRepository interface {
+	Save(ctx context.Context, job *ExportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ExportJob, error)
+	// List lista as exportações do tenant da mais recente para a mais antiga
+	List(ctx context.Context, limit int, before *Cursor) ([]*ExportJob, error)
+	// ClaimNext reserva a exportação pendente mais antiga, marcando-a como em
+	// andamento. Exportações em andamento iniciadas antes de staleBefore são
+	// retomadas (ex: réplica encerrada no meio do processamento). Retorna nil
+	// quando não há exportações a processar
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*ExportJob, error)
+	// Update grava o resultado do processamento
+	Update(ctx context.Context, job *ExportJob) error
+	// ListExpired lista as exportações concluídas cujo arquivo venceu
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*ExportJob, error)
+	// ListCompleted lista as exportações concluídas do tenant que podem conter
+	// mensagens do telefone: as filtradas por ele e as sem filtro de telefone
+	ListCompleted(ctx context.Context, phone string) ([]*ExportJob, error)
+}
+
+// ExportStorage guarda os arquivos gerados pelas exportações
+type ExportStorage interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	Remove(name string) error
+}
@@ -1,6 +1,11 @@
 package domain
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,16 +24,83 @@ const (
 // Instance representa uma instância do WhatsApp
 type Instance struct {
 	ID         uuid.UUID      `json:"id"`
+	TenantID   uuid.UUID      `json:"tenant_id"`
 	Name       string         `json:"name"`
 	Phone      *string        `json:"phone,omitempty"`
 	Status     InstanceStatus `json:"status"`
 	Provider   string         `json:"provider"`
 	InstanceID string         `json:"instance_id"` // ID da instância no provedor (ex: Z-API)
-	Token      string         `json:"token"`       // Token de autenticação
+	Token      string         `json:"token"`       // Token de autenticação (mascarado na serialização)
 	Config     map[string]any `json:"config,omitempty"`
 	Error      *string        `json:"error,omitempty"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
+	// DisconnectedAt marca quando a instância deixou de estar conectada; nulo enquanto conectada
+	DisconnectedAt  *time.Time `json:"disconnected_at,omitempty"`
+	StatusCheckedAt *time.Time `json:"status_checked_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ApplyStatus atualiza o status da instância a partir das informações do
+// provider, controlando o início das desconexões. Retorna o status anterior e,
+// quando a instância volta a se conectar, por quanto tempo ficou desconectada
+func (i *Instance) ApplyStatus(info *InstanceInfo, checkedAt time.Time) (previous InstanceStatus, downtime time.Duration) {
+	previous = i.Status
+
+	i.Status = info.Status
+	i.Error = info.Error
+	if info.Phone != nil {
+		i.Phone = info.Phone
+	}
+	i.StatusCheckedAt = &checkedAt
+
+	switch {
+	case info.Status == InstanceConnected && i.DisconnectedAt != nil:
+		downtime = checkedAt.Sub(*i.DisconnectedAt)
+		i.DisconnectedAt = nil
+	case info.Status != InstanceConnected && i.DisconnectedAt == nil && previous == InstanceConnected:
+		i.DisconnectedAt = &checkedAt
+	}
+
+	return previous, downtime
+}
+
+// CallbackToken autentica as notificações do provider para a instância. É
+// derivado do token da instância, que só a API e o provider conhecem, e muda
+// junto com ele
+func (i *Instance) CallbackToken() string {
+	mac := hmac.New(sha256.New, []byte(i.Token))
+	mac.Write([]byte("callback:" + i.ID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidCallbackToken compara o token de uma notificação em tempo constante
+func (i *Instance) ValidCallbackToken(token string) bool {
+	return hmac.Equal([]byte(token), []byte(i.CallbackToken()))
+}
+
+// Callback retorna o endereço, relativo a /api/v1, a configurar no provider
+// para receber as notificações da instância
+func (i *Instance) Callback() *InstanceCallback {
+	token := i.CallbackToken()
+	return &InstanceCallback{
+		Path:  fmt.Sprintf("/whatsapp/callbacks/%s/%s?token=%s", i.Provider, i.ID, token),
+		Token: token,
+	}
+}
+
+// InstanceCallback é o endereço das notificações do provider para a instância
+type InstanceCallback struct {
+	Path  string `json:"path"`
+	Token string `json:"token"`
+}
+
+// MarshalJSON serializa a instância sem expor o token nem os segredos da configuração
+func (i Instance) MarshalJSON() ([]byte, error) {
+	type instanceAlias Instance
+	alias := instanceAlias(i)
+	alias.Token = MaskSecret(i.Token)
+	alias.Config = MaskConfigSecrets(i.Config)
+	return json.Marshal(alias)
 }
 
 // CreateInstanceRequest representa uma requisição para criar instância
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/your-org/boilerplate-go/internal/errors"
+)
+
+// UpdateInstanceConfigRequest representa um patch na configuração da instância.
+// Chaves com valor null são removidas; as demais são criadas ou substituídas
+type UpdateInstanceConfigRequest map[string]any
+
+// ValidateInstanceConfig valida a configuração de uma instância contra as chaves
+// conhecidas pelo provider
+func ValidateInstanceConfig(config map[string]any, fields []ProviderConfigField) error {
+	known := make(map[string]ProviderConfigField, len(fields))
+	for _, field := range fields {
+		known[field.Key] = field
+	}
+
+	var problems []string
+	for key, value := range config {
+		field, ok := known[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown key %q", key))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if err := validateConfigValue(field, value); err != nil {
+			problems = append(problems, fmt.Sprintf("key %q: %v", key, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return apperrors.NewValidationError("invalid instance config: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// MergeInstanceConfig aplica um patch sobre a configuração atual, retornando um novo mapa
+func MergeInstanceConfig(current map[string]any, patch UpdateInstanceConfigRequest) map[string]any {
+	merged := make(map[string]any, len(current)+len(patch))
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// validateConfigValue verifica se o valor é compatível com o tipo da chave
+func validateConfigValue(field ProviderConfigField, value any) error {
+	switch field.Type {
+	case ConfigFieldString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected string")
+		}
+	case ConfigFieldNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("expected number")
+		}
+	case ConfigFieldBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected bool")
+		}
+	case ConfigFieldDuration:
+		switch v := value.(type) {
+		case float64:
+			if v < 0 {
+				return fmt.Errorf("duration must not be negative")
+			}
+		case string:
+			if _, err := time.ParseDuration(v); err != nil {
+				return fmt.Errorf("expected duration such as \"10s\"")
+			}
+		default:
+			return fmt.Errorf("expected duration")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoutingPolicy define como um grupo escolhe a instância que envia a mensagem
+type RoutingPolicy string
+
+const (
+	// RoutingPriority usa sempre a instância saudável de maior prioridade
+	RoutingPriority RoutingPolicy = "priority"
+	// RoutingRoundRobin alterna entre as instâncias saudáveis a cada envio
+	RoutingRoundRobin RoutingPolicy = "round_robin"
+	// RoutingSticky mantém cada destinatário na mesma instância enquanto ela estiver saudável
+	RoutingSticky RoutingPolicy = "sticky"
+)
+
+// InstanceGroupMember representa uma instância participante de um grupo
+type InstanceGroupMember struct {
+	InstanceID uuid.UUID `json:"instance_id" binding:"required"`
+	Priority   int       `json:"priority"` // menor valor = maior prioridade
+}
+
+// InstanceGroup representa um remetente lógico formado por várias instâncias,
+// possivelmente de provedores diferentes
+type InstanceGroup struct {
+	ID        uuid.UUID             `json:"id"`
+	TenantID  uuid.UUID             `json:"tenant_id"`
+	Name      string                `json:"name"`
+	Policy    RoutingPolicy         `json:"policy"`
+	Members   []InstanceGroupMember `json:"members"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// InstanceGroupRequest representa uma requisição para criar ou substituir um grupo
+type InstanceGroupRequest struct {
+	Name    string                `json:"name" binding:"required"`
+	Policy  RoutingPolicy         `json:"policy" binding:"required,oneof=priority round_robin sticky"`
+	Members []InstanceGroupMember `json:"members" binding:"required,min=1,dive"`
+}
+
+// InstanceGroupRepository define a interface para persistência de grupos de instâncias
+type InstanceGroupRepository interface {
+	Save(ctx context.Context, group *InstanceGroup) error
+	GetByID(ctx context.Context, id uuid.UUID) (*InstanceGroup, error)
+	GetAll(ctx context.Context) ([]*InstanceGroup, error)
+	Update(ctx context.Context, group *InstanceGroup) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// RemoveInstance remove a instância de todos os grupos dos quais participa
+	RemoveInstance(ctx context.Context, instanceID uuid.UUID) error
+}
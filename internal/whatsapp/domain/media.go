@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Media representa um arquivo enviado para a API e guardado no armazenamento
+// de mídia. O ID pode ser usado no envio de mensagens no lugar de uma URL pública
+type Media struct {
+	ID         uuid.UUID   `json:"id"`
+	TenantID   uuid.UUID   `json:"tenant_id"`
+	Type       MessageType `json:"type"`
+	FileName   string      `json:"file_name"`
+	MimeType   string      `json:"mime_type"`
+	SizeBytes  int64       `json:"size_bytes"`
+	SHA256     string      `json:"sha256"`
+	StorageKey string      `json:"-"`
+	// MessageID é a mensagem recebida de onde a mídia foi baixada. Sem a
+	// mensagem (removida ou com a mídia apagada pela retenção), o arquivo é descartado
+	MessageID *uuid.UUID `json:"message_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// MediaUpload representa um arquivo recebido para ser guardado
+type MediaUpload struct {
+	Type     MessageType
+	FileName string
+	MimeType string // informado pelo cliente; conferido com o conteúdo quando possível
+	Content  io.Reader
+}
+
+// mediaMimeTypes lista os tipos MIME aceitos pelo WhatsApp em cada tipo de mensagem
+var mediaMimeTypes = map[MessageType][]string{
+	ImageMessage: {"image/jpeg", "image/png"},
+	VideoMessage: {"video/mp4", "video/3gpp"},
+	AudioMessage: {"audio/aac", "audio/amr", "audio/mpeg", "audio/mp4", "audio/ogg"},
+	DocumentMessage: {
+		"application/pdf",
+		"text/plain",
+		"application/msword",
+		"application/vnd.ms-excel",
+		"application/vnd.ms-powerpoint",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	},
+}
+
+// mediaExtensions define a extensão de cada tipo MIME aceito, usada quando o
+// nome do arquivo não tem uma
+var mediaExtensions = map[string]string{
+	"image/jpeg":                    ".jpg",
+	"image/png":                     ".png",
+	"video/mp4":                     ".mp4",
+	"video/3gpp":                    ".3gp",
+	"audio/aac":                     ".aac",
+	"audio/amr":                     ".amr",
+	"audio/mpeg":                    ".mp3",
+	"audio/mp4":                     ".m4a",
+	"audio/ogg":                     ".ogg",
+	"application/pdf":               ".pdf",
+	"text/plain":                    ".txt",
+	"application/msword":            ".doc",
+	"application/vnd.ms-excel":      ".xls",
+	"application/vnd.ms-powerpoint": ".ppt",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   ".docx",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         ".xlsx",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": ".pptx",
+}
+
+// MediaExtension retorna a extensão do tipo MIME, com o ponto, ou vazio
+func MediaExtension(mimeType string) string {
+	return mediaExtensions[mimeType]
+}
+
+// IsMedia indica se o tipo de mensagem carrega um arquivo
+func (t MessageType) IsMedia() bool {
+	_, ok := mediaMimeTypes[t]
+	return ok
+}
+
+// MediaMimeTypes retorna os tipos MIME aceitos no tipo de mensagem
+func MediaMimeTypes(messageType MessageType) []string {
+	return mediaMimeTypes[messageType]
+}
+
+// inboundMimeTypes lista os formatos recebidos de contatos que não enviamos,
+// além dos aceitos no envio (ex: figurinhas em WebP e áudios em Opus)
+var inboundMimeTypes = []string{
+	"image/webp",
+	"image/gif",
+	"audio/opus",
+	"audio/wav",
+	"video/quicktime",
+	"text/csv",
+	"application/zip",
+	"application/rtf",
+	"application/vnd.oasis.opendocument.text",
+	"application/vnd.oasis.opendocument.spreadsheet",
+	"application/vnd.oasis.opendocument.presentation",
+}
+
+// InboundMimeType retorna o tipo MIME com que a mídia recebida é guardada.
+// Tipos fora da lista (ex: HTML e SVG, que executam scripts no navegador) são
+// guardados como application/octet-stream
+func InboundMimeType(mimeType string) string {
+	for _, allowed := range mediaMimeTypes {
+		for _, candidate := range allowed {
+			if candidate == mimeType {
+				return mimeType
+			}
+		}
+	}
+	for _, candidate := range inboundMimeTypes {
+		if candidate == mimeType {
+			return mimeType
+		}
+	}
+	return "application/octet-stream"
+}
+
+// MediaLimits define o tamanho máximo, em bytes, de cada tipo de mídia
+type MediaLimits map[MessageType]int64
+
+// Validate verifica se o tipo MIME e o tamanho são aceitos no tipo de mensagem
+func (l MediaLimits) Validate(messageType MessageType, mimeType string, size int64) error {
+	allowed, ok := mediaMimeTypes[messageType]
+	if !ok {
+		return fmt.Errorf("message type %s does not carry media", messageType)
+	}
+
+	accepted := false
+	for _, candidate := range allowed {
+		if candidate == mimeType {
+			accepted = true
+			break
+		}
+	}
+	if !accepted {
+		return fmt.Errorf("mime type %s is not accepted for %s messages", mimeType, messageType)
+	}
+
+	if limit := l[messageType]; limit > 0 && size > limit {
+		return fmt.Errorf("%s files are limited to %d bytes", messageType, limit)
+	}
+	return nil
+}
+
+// MediaRepository define a interface para persistência dos metadados da mídia
+type MediaRepository interface {
+	Save(ctx context.Context, media *Media) error
+	// GetByID obtém a mídia do tenant do contexto ou, sem tenant, de qualquer tenant
+	GetByID(ctx context.Context, id uuid.UUID) (*Media, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListOrphaned lista as mídias recebidas cuja mensagem foi removida ou, se
+	// criadas antes de before, cuja mensagem não aponta mais para elas
+	ListOrphaned(ctx context.Context, before time.Time, limit int) ([]*Media, error)
+}
+
+// MediaDownload é o download da mídia de uma mensagem recebida. O provider
+// entrega a mídia por URLs temporárias; o download é feito em background, com
+// novas tentativas em caso de falha
+type MediaDownload struct {
+	ID            uuid.UUID           `json:"id"`
+	TenantID      uuid.UUID           `json:"tenant_id"`
+	MessageID     uuid.UUID           `json:"message_id"`
+	Type          MessageType         `json:"type"`
+	URL           string              `json:"url"`
+	FileName      string              `json:"file_name,omitempty"` // informado pelo provider
+	MimeType      string              `json:"mime_type,omitempty"` // informado pelo provider
+	Status        MediaDownloadStatus `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	NextAttemptAt *time.Time          `json:"next_attempt_at,omitempty"`
+	LastError     *string             `json:"last_error,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// MediaDownloadRepository define a interface para persistência dos downloads
+type MediaDownloadRepository interface {
+	Save(ctx context.Context, download *MediaDownload) error
+	// ClaimDue reserva até limit downloads pendentes vencidos, adiando a próxima
+	// tentativa por lease para que outra réplica não os baixe ao mesmo tempo
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*MediaDownload, error)
+	// RecordAttempt grava o resultado de uma tentativa
+	RecordAttempt(ctx context.Context, download *MediaDownload) error
+}
+
+// MediaStorage guarda o conteúdo dos arquivos de mídia. A implementação local
+// usa um diretório; outras (ex: S3) podem ser ligadas sem alterar o serviço
+type MediaStorage interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	Remove(name string) error
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,36 +27,143 @@ const (
 	StatusDelivered MessageStatus = "delivered"
 	StatusRead      MessageStatus = "read"
 	StatusFailed    MessageStatus = "failed"
+	StatusReceived  MessageStatus = "received" // mensagem recebida do contato
+)
+
+// PreviousStatuses lista os status a partir dos quais uma mensagem enviada pode
+// avançar para este. Notificações fora de ordem (ex: entregue depois de lida)
+// não fazem o status retroceder
+func (s MessageStatus) PreviousStatuses() []MessageStatus {
+	switch s {
+	case StatusSent:
+		return []MessageStatus{StatusPending}
+	case StatusDelivered, StatusFailed:
+		return []MessageStatus{StatusPending, StatusSent}
+	case StatusRead:
+		return []MessageStatus{StatusPending, StatusSent, StatusDelivered}
+	default:
+		return nil
+	}
+}
+
+// MessageDirection indica se a mensagem foi enviada ou recebida pela instância
+type MessageDirection string
+
+const (
+	DirectionOutbound MessageDirection = "outbound"
+	DirectionInbound  MessageDirection = "inbound"
 )
 
 // Message representa uma mensagem do WhatsApp
 type Message struct {
-	ID         uuid.UUID     `json:"id"`
-	InstanceID string        `json:"instance_id"`
-	Phone      string        `json:"phone"`
-	Type       MessageType   `json:"type"`
-	Content    string        `json:"content"`
-	MediaURL   *string       `json:"media_url,omitempty"`
-	Status     MessageStatus `json:"status"`
-	ProviderID *string       `json:"provider_id,omitempty"`
-	Error      *string       `json:"error,omitempty"`
-	CreatedAt  time.Time     `json:"created_at"`
-	UpdatedAt  time.Time     `json:"updated_at"`
+	ID         uuid.UUID        `json:"id"`
+	TenantID   uuid.UUID        `json:"tenant_id"`
+	InstanceID string           `json:"instance_id"`
+	Provider   string           `json:"provider,omitempty"`
+	Phone      string           `json:"phone"`
+	Direction  MessageDirection `json:"direction"`
+	Type       MessageType      `json:"type"`
+	Content    string           `json:"content"`
+	MediaURL   *string          `json:"media_url,omitempty"`
+	MediaID    *uuid.UUID       `json:"media_id,omitempty"` // mídia enviada para a API ou mídia recebida já guardada
+	Media      *MessageMedia    `json:"media,omitempty"`    // download da mídia recebida
+	Status     MessageStatus    `json:"status"`
+	ProviderID *string          `json:"provider_id,omitempty"`
+	Error      *string          `json:"error,omitempty"`
+	// Momento em que a mensagem atingiu cada etapa do ciclo de envio
+	SentAt      *time.Time `json:"sent_at,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	FailedAt    *time.Time `json:"failed_at,omitempty"`
+	// RedactedAt indica que o conteúdo e a mídia foram apagados pela política de retenção
+	RedactedAt *time.Time `json:"redacted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// MediaDownloadStatus representa a situação do download da mídia recebida
+type MediaDownloadStatus string
+
+const (
+	MediaDownloadPending MediaDownloadStatus = "pending"
+	MediaDownloadStored  MediaDownloadStatus = "stored"
+	MediaDownloadFailed  MediaDownloadStatus = "failed" // tentativas esgotadas
+)
+
+// MessageMedia descreve a cópia da mídia recebida guardada pela API. O
+// conteúdo fica em GET /whatsapp/messages/{id}/media
+type MessageMedia struct {
+	Status    MediaDownloadStatus `json:"status"`
+	FileName  string              `json:"file_name,omitempty"`
+	MimeType  string              `json:"mime_type,omitempty"`
+	SizeBytes int64               `json:"size_bytes,omitempty"`
+	SHA256    string              `json:"sha256,omitempty"`
 }
 
-// SendMessageRequest representa uma requisição para enviar mensagem
+// SendMessageRequest representa uma requisição para enviar mensagem.
+// Deve informar instance_id ou group_id, nunca os dois
 type SendMessageRequest struct {
-	InstanceID string      `json:"instance_id" binding:"required"`
+	InstanceID string      `json:"instance_id,omitempty"`
+	GroupID    string      `json:"group_id,omitempty"` // grupo de instâncias com failover
 	Phone      string      `json:"phone" binding:"required"`
 	Type       MessageType `json:"type" binding:"required"`
 	Content    string      `json:"content" binding:"required"`
 	MediaURL   *string     `json:"media_url,omitempty"`
+	// MediaID envia um arquivo enviado antes para POST /whatsapp/media, no lugar de media_url
+	MediaID *uuid.UUID `json:"media_id,omitempty"`
+	// FileName é o nome do documento exibido ao contato; com media_id, o nome do arquivo enviado
+	FileName string `json:"file_name,omitempty"`
+	// Transactional envia mesmo para quem está na lista de opt-out. Use apenas
+	// em mensagens esperadas pelo contato, como códigos de acesso e confirmações
+	Transactional bool `json:"transactional,omitempty"`
+}
+
+// ValidateMedia verifica se a mídia da requisição combina com o tipo da
+// mensagem: tipos de mídia exigem media_url ou media_id, e texto não aceita media_id
+func (r *SendMessageRequest) ValidateMedia() error {
+	hasURL := r.MediaURL != nil && *r.MediaURL != ""
+
+	if !r.Type.IsMedia() {
+		if r.MediaID != nil {
+			return fmt.Errorf("%s messages do not carry media", r.Type)
+		}
+		return nil
+	}
+
+	switch {
+	case hasURL && r.MediaID != nil:
+		return fmt.Errorf("inform either media_url or media_id, not both")
+	case !hasURL && r.MediaID == nil:
+		return fmt.Errorf("%s messages require media_url or media_id", r.Type)
+	}
+	return nil
 }
 
 // SendMessageResponse representa a resposta de envio de mensagem
 type SendMessageResponse struct {
 	ID         uuid.UUID     `json:"id"`
+	InstanceID string        `json:"instance_id,omitempty"` // instância que efetivamente enviou
 	Status     MessageStatus `json:"status"`
 	ProviderID *string       `json:"provider_id,omitempty"`
 	Error      *string       `json:"error,omitempty"`
 }
+
+// MessageFilter representa os filtros da busca de mensagens. Campos vazios não filtram
+type MessageFilter struct {
+	InstanceID string
+	Phone      string
+	Status     MessageStatus
+	Type       MessageType
+	Direction  MessageDirection
+	From       *time.Time // inclusivo
+	To         *time.Time // exclusivo
+	Query      string     // busca textual no conteúdo
+	ExcludeID  uuid.UUID  // ignora a mensagem com este ID, quando informado
+}
+
+// MessageSearchResult representa uma página da busca de mensagens
+type MessageSearchResult struct {
+	Messages   []*Message
+	NextCursor string
+	Total      *int64 // preenchido apenas quando solicitado
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrCodeRecipientOptedOut identifica o envio recusado porque o destinatário pediu para não receber mensagens
+const ErrCodeRecipientOptedOut = "RECIPIENT_OPTED_OUT"
+
+// OptOutSource indica como o telefone entrou na lista de opt-out
+type OptOutSource string
+
+const (
+	OptOutKeyword OptOutSource = "keyword" // o contato enviou uma palavra de opt-out
+	OptOutAPI     OptOutSource = "api"
+	OptOutImport  OptOutSource = "import" // importação de CSV
+)
+
+// OptOut representa um telefone que não deve receber mensagens da instância
+// ou, sem instância, de nenhuma instância
+type OptOut struct {
+	ID         uuid.UUID    `json:"id"`
+	TenantID   uuid.UUID    `json:"tenant_id"`
+	InstanceID *uuid.UUID   `json:"instance_id,omitempty"` // nil = todas as instâncias
+	Phone      string       `json:"phone"`
+	Source     OptOutSource `json:"source"`
+	Keyword    string       `json:"keyword,omitempty"` // palavra enviada pelo contato
+	Note       string       `json:"note,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// CreateOptOutRequest representa uma requisição para incluir um telefone na lista
+type CreateOptOutRequest struct {
+	Phone      string     `json:"phone" binding:"required"`
+	InstanceID *uuid.UUID `json:"instance_id,omitempty"`
+	Note       string     `json:"note,omitempty"`
+}
+
+// OptOutFilter representa os filtros da listagem. Sem instância, lista apenas
+// as entradas que valem para todas as instâncias
+type OptOutFilter struct {
+	InstanceID *uuid.UUID
+	Phone      string
+}
+
+// OptOutImportError descreve uma linha do CSV que não pôde ser importada
+type OptOutImportError struct {
+	Line  int    `json:"line"`
+	Value string `json:"value"`
+	Error string `json:"error"`
+}
+
+// OptOutImportResult resume a importação de um CSV
+type OptOutImportResult struct {
+	Imported int                 `json:"imported"`
+	Skipped  int                 `json:"skipped"` // telefones que já estavam na lista
+	Invalid  []OptOutImportError `json:"invalid"`
+}
+
+// OptOutRepository define a interface para persistência da lista de opt-out
+type OptOutRepository interface {
+	// Save inclui o telefone na lista. Retorna false quando ele já estava na
+	// lista do mesmo escopo
+	Save(ctx context.Context, optOut *OptOut) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*OptOut, error)
+	// Find busca uma entrada do telefone que valha para todas as instâncias do
+	// tenant ou para uma das instâncias informadas. Retorna nil quando não há
+	Find(ctx context.Context, tenantID uuid.UUID, phone string, instanceIDs []uuid.UUID) (*OptOut, error)
+	// List lista as entradas da mais recente para a mais antiga
+	List(ctx context.Context, filter OptOutFilter, limit int, before *Cursor) ([]*OptOut, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
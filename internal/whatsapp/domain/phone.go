@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizePhone normaliza um telefone para o formato usado pelos provedores:
+// apenas dígitos, sem "+", espaços, parênteses ou hífens. Sufixos como
+// "@c.us" são descartados
+func NormalizePhone(phone string) string {
+	if at := strings.Index(phone, "@"); at >= 0 {
+		phone = phone[:at]
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(phone))
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
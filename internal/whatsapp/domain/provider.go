@@ -2,8 +2,21 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrCircuitOpen indica que o provider recusou a chamada porque o circuit
+// breaker está aberto após falhas consecutivas
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// InstanceBreakerName retorna o nome do circuit breaker de uma instância do provider
+func InstanceBreakerName(providerName, instanceID string) string {
+	return providerName + "/" + instanceID
+}
+
 // ProviderConfig representa uma configuração genérica para qualquer provider
 type ProviderConfig map[string]interface{}
 
@@ -23,6 +36,41 @@ const (
 	FeatureProfilePicture ProviderFeature = "profile_picture"
 )
 
+// MessageFeature retorna a funcionalidade que o provider precisa ter para
+// enviar mensagens do tipo informado
+func MessageFeature(messageType MessageType) ProviderFeature {
+	switch messageType {
+	case ImageMessage:
+		return FeatureImageMessages
+	case VideoMessage:
+		return FeatureVideoMessages
+	case AudioMessage:
+		return FeatureAudioMessages
+	case DocumentMessage:
+		return FeatureFileMessages
+	default:
+		return FeatureTextMessages
+	}
+}
+
+// ConfigFieldType representa o tipo de valor aceito por uma chave de configuração
+type ConfigFieldType string
+
+const (
+	ConfigFieldString   ConfigFieldType = "string"
+	ConfigFieldNumber   ConfigFieldType = "number"
+	ConfigFieldBool     ConfigFieldType = "bool"
+	ConfigFieldDuration ConfigFieldType = "duration" // string no formato "10s" ou número de segundos
+)
+
+// ProviderConfigField descreve uma chave de configuração aceita por um provider
+type ProviderConfigField struct {
+	Key         string          `json:"key"`
+	Type        ConfigFieldType `json:"type"`
+	Secret      bool            `json:"secret"`
+	Description string          `json:"description,omitempty"`
+}
+
 // WhatsAppProviderExtended estende a interface WhatsAppProvider com funcionalidades adicionais
 type WhatsAppProviderExtended interface {
 	WhatsAppProvider
@@ -35,6 +83,9 @@ type WhatsAppProviderExtended interface {
 
 	// GetSupportedFeatures retorna as funcionalidades suportadas pelo provider
 	GetSupportedFeatures() []ProviderFeature
+
+	// GetConfigFields retorna as chaves de configuração por instância aceitas pelo provider
+	GetConfigFields() []ProviderConfigField
 }
 
 // ProviderFactory define a interface para criação de providers
@@ -69,3 +120,38 @@ type ProviderRegistry interface {
 	// List lista os nomes de todos os providers registrados
 	List() []string
 }
+
+// ProviderHealthStatus representa a saúde de um provider
+type ProviderHealthStatus string
+
+const (
+	ProviderHealthy   ProviderHealthStatus = "healthy"
+	ProviderDegraded  ProviderHealthStatus = "degraded" // responde, mas o circuit breaker não está fechado
+	ProviderUnhealthy ProviderHealthStatus = "unhealthy"
+)
+
+// ProviderHealth representa o resultado da verificação de saúde de um provider
+type ProviderHealth struct {
+	Provider     string               `json:"provider"`
+	Status       ProviderHealthStatus `json:"status"`
+	LatencyMs    int64                `json:"latency_ms"`
+	Error        *string              `json:"error,omitempty"`      // erro da verificação atual
+	LastError    *string              `json:"last_error,omitempty"` // último erro observado, em verificações ou chamadas reais
+	LastErrorAt  *time.Time           `json:"last_error_at,omitempty"`
+	CircuitState string               `json:"circuit_state"`
+	CheckedAt    time.Time            `json:"checked_at"`
+}
+
+// ProviderDefaults mapeia o tipo de provider para a configuração global usada
+// quando a instância não define um valor próprio
+type ProviderDefaults map[string]ProviderConfig
+
+// InstanceProviderResolver resolve o cliente de provider a ser usado por uma instância
+type InstanceProviderResolver interface {
+	// Resolve retorna o provider configurado para a instância, reaproveitando o cliente em cache
+	// enquanto a configuração efetiva da instância não mudar
+	Resolve(instance *Instance) (WhatsAppProvider, error)
+
+	// Invalidate descarta o cliente em cache de uma instância
+	Invalidate(instanceID uuid.UUID)
+}
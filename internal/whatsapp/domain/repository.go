@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -33,12 +34,43 @@ type WhatsAppProvider interface {
 	UpdateProfilePicture(ctx context.Context, instance *Instance, request UpdateProfilePictureRequest) (*UpdateProfileResponse, error)
 }
 
-// MessageRepository define a interface para persistência de mensagens
+// MessageRepository define a interface para persistência de mensagens.
+// Save também atualiza a conversa da mensagem
 type MessageRepository interface {
 	Save(ctx context.Context, message *Message) error
+	// SaveInbound grava a mensagem recebida, ignorando reentregas com o mesmo
+	// ID do provider na instância. Retorna se a mensagem foi gravada
+	SaveInbound(ctx context.Context, message *Message) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Message, error)
 	GetByInstanceID(ctx context.Context, instanceID string, limit, offset int) ([]*Message, error)
+	GetByProviderID(ctx context.Context, instanceID, providerID string) (*Message, error)
+	// Search lista as mensagens que atendem ao filtro, da mais recente para a mais antiga
+	Search(ctx context.Context, filter MessageFilter, limit int, before *Cursor) ([]*Message, error)
+	Count(ctx context.Context, filter MessageFilter) (int64, error)
+	// GetThread lista as mensagens trocadas com o telefone, da mais recente para a mais antiga
+	GetThread(ctx context.Context, instanceID, phone string, limit int, before *Cursor) ([]*Message, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status MessageStatus, providerID *string, errorMsg *string) error
+	// Reassign transfere a mensagem pendente para outra instância, usada quando o
+	// envio por um grupo passa para a próxima instância
+	Reassign(ctx context.Context, id uuid.UUID, instanceID, provider string) error
+	// AdvanceStatus avança o status notificado pelo provider, ignorando
+	// notificações que fariam o status retroceder. Retorna se a mensagem mudou
+	AdvanceStatus(ctx context.Context, id uuid.UUID, status MessageStatus, at time.Time) (bool, error)
+	// UpdateMedia grava a situação da mídia recebida guardada pela API e a
+	// mídia correspondente, quando já baixada
+	UpdateMedia(ctx context.Context, id uuid.UUID, mediaID *uuid.UUID, media *MessageMedia) error
+	// AggregateStats agrupa as estatísticas por instância, provider, tipo e período
+	AggregateStats(ctx context.Context, query MessageAnalyticsQuery) ([]*MessageStatsRow, error)
+	// SummarizeStats calcula as estatísticas de todo o intervalo consultado
+	SummarizeStats(ctx context.Context, query MessageAnalyticsQuery) (*MessageStats, error)
+	TopFailureReasons(ctx context.Context, query MessageAnalyticsQuery, limit int) ([]*FailureReason, error)
+	// CountRetention conta as mensagens alcançadas pela política de retenção
+	CountRetention(ctx context.Context, target RetentionTarget) (int64, error)
+	// RedactContent apaga o conteúdo e a mídia de até limit mensagens do alvo,
+	// mantendo os metadados. Retorna quantas foram alteradas
+	RedactContent(ctx context.Context, target RetentionTarget, limit int) (int64, error)
+	// DeleteMessages remove até limit mensagens do alvo. Retorna quantas foram removidas
+	DeleteMessages(ctx context.Context, target RetentionTarget, limit int) (int64, error)
 }
 
 // InstanceRepository define a interface para persistência de instâncias
@@ -49,5 +81,8 @@ type InstanceRepository interface {
 	GetByInstanceID(ctx context.Context, instanceID string) (*Instance, error)
 	GetAll(ctx context.Context) ([]*Instance, error)
 	Update(ctx context.Context, instance *Instance) error
+	// UpdateStatus grava apenas os campos de status, sem sobrescrever alterações
+	// concorrentes na configuração da instância
+	UpdateStatus(ctx context.Context, instance *Instance) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
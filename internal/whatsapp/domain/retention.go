@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionPolicy define por quanto tempo as mensagens de um tenant ou de uma
+// instância são guardadas. Depois de ContentDays o conteúdo e a mídia são
+// apagados, mantendo os metadados (telefone, status, datas); depois de
+// MetadataDays a mensagem é removida. Zero mantém para sempre. A política de
+// uma instância prevalece sobre a do tenant
+type RetentionPolicy struct {
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	InstanceID   *uuid.UUID `json:"instance_id,omitempty"` // nil = instâncias do tenant sem política própria
+	ContentDays  int        `json:"content_days"`
+	MetadataDays int        `json:"metadata_days"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Validate verifica os prazos da política
+func (p *RetentionPolicy) Validate() error {
+	if p.ContentDays < 0 || p.MetadataDays < 0 {
+		return fmt.Errorf("retention days cannot be negative")
+	}
+	if p.ContentDays == 0 && p.MetadataDays == 0 {
+		return fmt.Errorf("content_days or metadata_days is required")
+	}
+	if p.ContentDays > 0 && p.MetadataDays > 0 && p.MetadataDays < p.ContentDays {
+		return fmt.Errorf("metadata_days must not be shorter than content_days")
+	}
+	return nil
+}
+
+// Cutoffs retorna as datas antes das quais o conteúdo é apagado e as mensagens
+// removidas; nil quando o prazo correspondente não é aplicado
+func (p *RetentionPolicy) Cutoffs(now time.Time) (contentBefore, deleteBefore *time.Time) {
+	if p.ContentDays > 0 {
+		cutoff := now.AddDate(0, 0, -p.ContentDays)
+		contentBefore = &cutoff
+	}
+	if p.MetadataDays > 0 {
+		cutoff := now.AddDate(0, 0, -p.MetadataDays)
+		deleteBefore = &cutoff
+	}
+	return contentBefore, deleteBefore
+}
+
+// RetentionPolicyRequest representa a criação ou alteração da política do
+// tenant ou, com instance_id, de uma instância
+type RetentionPolicyRequest struct {
+	InstanceID   *uuid.UUID `json:"instance_id,omitempty"`
+	ContentDays  int        `json:"content_days"`
+	MetadataDays int        `json:"metadata_days"`
+}
+
+// RetentionTarget seleciona as mensagens alcançadas por uma política
+type RetentionTarget struct {
+	TenantID uuid.UUID
+	// InstanceIDs restringe às instâncias informadas; vazio alcança todo o tenant
+	InstanceIDs []string
+	// ExcludeInstanceIDs ignora as instâncias com política própria
+	ExcludeInstanceIDs []string
+	Before             time.Time  // mensagens criadas antes desta data
+	NotBefore          *time.Time // ignora mensagens anteriores a esta data
+	WithContent        bool       // apenas mensagens cujo conteúdo ainda não foi apagado
+}
+
+// RetentionReport resume o que uma política removeu ou, na simulação, removeria
+type RetentionReport struct {
+	PolicyID        *uuid.UUID `json:"policy_id,omitempty"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	InstanceID      *uuid.UUID `json:"instance_id,omitempty"`
+	ContentBefore   *time.Time `json:"content_before,omitempty"`
+	DeleteBefore    *time.Time `json:"delete_before,omitempty"`
+	ContentRedacted int64      `json:"content_redacted"`
+	MessagesDeleted int64      `json:"messages_deleted"`
+	DryRun          bool       `json:"dry_run"`
+}
+
+// RetentionPolicyRepository define a interface para persistência das políticas de retenção
+type RetentionPolicyRepository interface {
+	// Save cria ou substitui a política do escopo (tenant ou instância)
+	Save(ctx context.Context, policy *RetentionPolicy) error
+	GetByID(ctx context.Context, id uuid.UUID) (*RetentionPolicy, error)
+	// List lista as políticas do tenant do contexto ou, sem tenant, de todos
+	List(ctx context.Context) ([]*RetentionPolicy, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
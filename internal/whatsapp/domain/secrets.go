@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+)
+
+// secretKeyMarkers identifica chaves de configuração que guardam segredos
+var secretKeyMarkers = []string{"token", "secret", "password", "api_key", "apikey"}
+
+// IsSecretConfigKey indica se a chave de configuração guarda um segredo,
+// que deve ser criptografado em repouso e mascarado nas respostas da API
+func IsSecretConfigKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskSecret mascara um segredo mantendo apenas os últimos 4 caracteres
+func MaskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+// MaskConfigSecrets retorna uma cópia da configuração com os valores secretos mascarados
+func MaskConfigSecrets(config map[string]any) map[string]any {
+	if config == nil {
+		return nil
+	}
+
+	masked := make(map[string]any, len(config))
+	for key, value := range config {
+		if text, ok := value.(string); ok && IsSecretConfigKey(key) {
+			masked[key] = MaskSecret(text)
+			continue
+		}
+		masked[key] = value
+	}
+	return masked
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// WebhookEventKind identifica o tipo de notificação enviada pelo provider
+type WebhookEventKind string
+
+const (
+	WebhookMessageReceived WebhookEventKind = "message_received"
+	WebhookMessageStatus   WebhookEventKind = "message_status"
+	WebhookIgnored         WebhookEventKind = "ignored" // notificações que não tratamos
+)
+
+// InboundMessage representa uma mensagem recebida pela instância
+type InboundMessage struct {
+	ProviderID string
+	Phone      string
+	FromMe     bool // enviada pelo próprio número, fora da API (ex: pelo celular)
+	Type       MessageType
+	Content    string
+	MediaURL   *string
+	FileName   string // nome do documento, quando informado pelo provider
+	MimeType   string // tipo da mídia, quando informado pelo provider
+	Timestamp  time.Time
+}
+
+// MessageStatusUpdate representa a confirmação de envio, entrega ou leitura
+// de mensagens enviadas pela instância
+type MessageStatusUpdate struct {
+	ProviderIDs []string
+	Status      MessageStatus
+	Timestamp   time.Time
+}
+
+// WebhookEvent é a notificação do provider já convertida para o domínio
+type WebhookEvent struct {
+	Kind               WebhookEventKind
+	ProviderInstanceID string // ID da instância no provider, usado para validar a origem
+	Message            *InboundMessage
+	StatusUpdate       *MessageStatusUpdate
+}
+
+// WebhookParser é implementado pelos providers que recebem notificações via webhook
+type WebhookParser interface {
+	ParseWebhook(payload []byte) (*WebhookEvent, error)
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookAllEvents assina todos os eventos disponíveis
+const WebhookAllEvents = "*"
+
+// WebhookEventTypes lista os eventos que podem ser assinados por webhooks
+var WebhookEventTypes = []string{
+	EventMessageQueued,
+	EventMessageSent,
+	EventMessageFailed,
+	EventMessageReceived,
+	EventMessageStatusChanged,
+	EventInstanceCreated,
+	EventInstanceDeleted,
+	EventInstanceStatusChanged,
+	EventProfileUpdated,
+	EventContactOptedOut,
+}
+
+// IsWebhookEventType indica se o evento pode ser assinado
+func IsWebhookEventType(name string) bool {
+	if name == WebhookAllEvents {
+		return true
+	}
+	for _, eventType := range WebhookEventTypes {
+		if eventType == name {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSubscription representa uma aplicação cliente notificada sobre os
+// eventos de uma instância ou, sem instância, de todas elas
+type WebhookSubscription struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	InstanceID  *uuid.UUID `json:"instance_id,omitempty"` // nil = assinatura global
+	URL         string     `json:"url"`
+	Events      []string   `json:"events"`
+	Secret      string     `json:"-"`
+	Description string     `json:"description,omitempty"`
+	Active      bool       `json:"active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Matches indica se a assinatura deve receber o evento da instância
+func (s *WebhookSubscription) Matches(eventName string, instanceID uuid.UUID) bool {
+	if !s.Active {
+		return false
+	}
+	if s.InstanceID != nil && *s.InstanceID != instanceID {
+		return false
+	}
+	for _, name := range s.Events {
+		if name == WebhookAllEvents || name == eventName {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWebhookSubscriptionRequest representa uma requisição para criar uma
+// assinatura. Sem secret, um segredo aleatório é gerado e retornado uma única vez
+type CreateWebhookSubscriptionRequest struct {
+	InstanceID  *uuid.UUID `json:"instance_id,omitempty"`
+	URL         string     `json:"url" binding:"required,url"`
+	Events      []string   `json:"events" binding:"required,min=1"`
+	Secret      string     `json:"secret,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Active      *bool      `json:"active,omitempty"` // padrão: true
+}
+
+// UpdateWebhookSubscriptionRequest aplica um patch em uma assinatura.
+// Campos ausentes são mantidos
+type UpdateWebhookSubscriptionRequest struct {
+	URL         *string  `json:"url,omitempty" binding:"omitempty,url"`
+	Events      []string `json:"events,omitempty"`
+	Secret      *string  `json:"secret,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// WebhookSubscriptionResponse inclui o segredo da assinatura, exposto apenas na criação
+type WebhookSubscriptionResponse struct {
+	*WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryStatus representa o estado de uma entrega de webhook
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending" // aguardando a primeira tentativa ou uma nova tentativa
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed" // tentativas esgotadas
+)
+
+// WebhookDelivery representa o envio de um evento para uma assinatura e o
+// resultado da última tentativa
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	EventID        string                `json:"event_id"`
+	EventName      string                `json:"event_name"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	ResponseCode   *int                  `json:"response_code,omitempty"`
+	LatencyMs      *int64                `json:"latency_ms,omitempty"`
+	LastError      *string               `json:"last_error,omitempty"`
+	// RedeliveryOf identifica a entrega original quando esta foi reenviada manualmente
+	RedeliveryOf *uuid.UUID `json:"redelivery_of,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// WebhookSubscriptionRepository define a interface para persistência de assinaturas de webhook
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, subscription *WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+	// List lista as assinaturas da instância ou, com instanceID nil, todas as do tenant
+	List(ctx context.Context, instanceID *uuid.UUID) ([]*WebhookSubscription, error)
+	// ListActive lista as assinaturas ativas da instância e as globais do tenant
+	ListActive(ctx context.Context, tenantID, instanceID uuid.UUID) ([]*WebhookSubscription, error)
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookDeliveryRepository define a interface para persistência de entregas de webhook
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery *WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+	// ListBySubscription lista as entregas da assinatura, da mais recente para a mais antiga
+	ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, status WebhookDeliveryStatus, limit int, before *Cursor) ([]*WebhookDelivery, error)
+	// ClaimDue reserva as entregas pendentes cuja tentativa venceu, adiando a
+	// próxima tentativa por lease para que outra réplica não as envie ao mesmo tempo
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+	// RecordAttempt grava o resultado de uma tentativa de entrega
+	RecordAttempt(ctx context.Context, delivery *WebhookDelivery) error
+}
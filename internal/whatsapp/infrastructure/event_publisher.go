@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// EventBusPublisher implementa EventPublisher sobre o ChannelEventBus, entregando
+// o evento tanto aos handlers registrados por tópico quanto aos assinantes de canal
+type EventBusPublisher struct {
+	bus *events.ChannelEventBus
+}
+
+// NewEventBusPublisher cria um novo publicador de eventos
+func NewEventBusPublisher(bus *events.ChannelEventBus) *EventBusPublisher {
+	return &EventBusPublisher{bus: bus}
+}
+
+// Publish publica o evento sem bloquear quem o gerou
+func (p *EventBusPublisher) Publish(ctx context.Context, event events.Event) {
+	p.bus.PublishAsync(event.GetName(), event)
+	// Os assinantes de canal não dependem do ciclo de vida da requisição
+	p.bus.PublishEventAsync(context.WithoutCancel(ctx), event)
+}
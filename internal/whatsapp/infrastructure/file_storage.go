@@ -0,0 +1,53 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileStorage guarda arquivos em um diretório local. Atende às exportações e à
+// mídia; com várias réplicas o diretório deve ser compartilhado entre elas
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage cria o armazenamento, criando o diretório se necessário
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+// Create cria o arquivo, substituindo um arquivo anterior com o mesmo nome
+func (s *FileStorage) Create(name string) (io.WriteCloser, error) {
+	file, err := os.OpenFile(s.path(name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	return file, nil
+}
+
+// Open abre o arquivo para leitura
+func (s *FileStorage) Open(name string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
+
+// Remove apaga o arquivo; arquivos inexistentes são ignorados
+func (s *FileStorage) Remove(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+	return nil
+}
+
+// path monta o caminho do arquivo, descartando diretórios do nome
+func (s *FileStorage) path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
@@ -0,0 +1,265 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormAutomationRule representa a entidade AutomationRule para GORM
+type GormAutomationRule struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	InstanceID uuid.UUID `gorm:"type:uuid;not null;index:idx_whatsapp_automation_rules_instance,priority:1"`
+	Name       string    `gorm:"type:varchar(255);not null"`
+	Priority   int       `gorm:"not null;default:0;index:idx_whatsapp_automation_rules_instance,priority:2"`
+	Active     bool      `gorm:"not null;default:true"`
+	Trigger    string    `gorm:"type:jsonb;not null"`
+	Action     string    `gorm:"type:jsonb;not null"`
+	CreatedAt  int64     `gorm:"autoCreateTime"`
+	UpdatedAt  int64     `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormAutomationRule) TableName() string {
+	return "whatsapp_automation_rules"
+}
+
+// toDomain converte GormAutomationRule para domain.AutomationRule
+func (g *GormAutomationRule) toDomain() (*domain.AutomationRule, error) {
+	rule := &domain.AutomationRule{
+		ID:         g.ID,
+		TenantID:   g.TenantID,
+		InstanceID: g.InstanceID,
+		Name:       g.Name,
+		Priority:   g.Priority,
+		Active:     g.Active,
+		CreatedAt:  timeFromUnix(g.CreatedAt),
+		UpdatedAt:  timeFromUnix(g.UpdatedAt),
+	}
+	if err := json.Unmarshal([]byte(g.Trigger), &rule.Trigger); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule trigger: %w", err)
+	}
+	if err := json.Unmarshal([]byte(g.Action), &rule.Action); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule action: %w", err)
+	}
+	return rule, nil
+}
+
+// fromDomain converte domain.AutomationRule para GormAutomationRule
+func (g *GormAutomationRule) fromDomain(rule *domain.AutomationRule) error {
+	trigger, err := json.Marshal(rule.Trigger)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule trigger: %w", err)
+	}
+	action, err := json.Marshal(rule.Action)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule action: %w", err)
+	}
+
+	g.ID = rule.ID
+	g.TenantID = rule.TenantID
+	g.InstanceID = rule.InstanceID
+	g.Name = rule.Name
+	g.Priority = rule.Priority
+	g.Active = rule.Active
+	g.Trigger = string(trigger)
+	g.Action = string(action)
+	g.CreatedAt = timeToUnix(rule.CreatedAt)
+	g.UpdatedAt = timeToUnix(rule.UpdatedAt)
+	return nil
+}
+
+// GormRuleExecution representa a entidade RuleExecution para GORM
+type GormRuleExecution struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RuleID         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RuleName       string     `gorm:"type:varchar(255);not null"`
+	InstanceID     uuid.UUID  `gorm:"type:uuid;not null;index:idx_whatsapp_rule_executions_instance,priority:1"`
+	Phone          string     `gorm:"type:varchar(20);not null"`
+	MessageID      uuid.UUID  `gorm:"type:uuid;not null"`
+	ReplyMessageID *uuid.UUID `gorm:"type:uuid"`
+	Actions        string     `gorm:"type:text;not null"` // ações separadas por vírgula
+	Error          *string    `gorm:"type:text"`
+	CreatedAt      int64      `gorm:"autoCreateTime;index:idx_whatsapp_rule_executions_instance,priority:2"`
+}
+
+// TableName define o nome da tabela
+func (GormRuleExecution) TableName() string {
+	return "whatsapp_rule_executions"
+}
+
+// toDomain converte GormRuleExecution para domain.RuleExecution
+func (g *GormRuleExecution) toDomain() *domain.RuleExecution {
+	actions := []string{}
+	if g.Actions != "" {
+		actions = strings.Split(g.Actions, ",")
+	}
+
+	return &domain.RuleExecution{
+		ID:             g.ID,
+		RuleID:         g.RuleID,
+		RuleName:       g.RuleName,
+		InstanceID:     g.InstanceID,
+		Phone:          g.Phone,
+		MessageID:      g.MessageID,
+		ReplyMessageID: g.ReplyMessageID,
+		Actions:        actions,
+		Error:          g.Error,
+		CreatedAt:      timeFromUnix(g.CreatedAt),
+	}
+}
+
+// GormAutomationRuleRepository implementa AutomationRuleRepository usando GORM
+type GormAutomationRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAutomationRuleRepository cria um novo repositório de regras de automação
+func NewGormAutomationRuleRepository(db *gorm.DB) *GormAutomationRuleRepository {
+	return &GormAutomationRuleRepository{db: db}
+}
+
+// Save salva uma regra
+func (r *GormAutomationRuleRepository) Save(ctx context.Context, rule *domain.AutomationRule) error {
+	var gormRule GormAutomationRule
+	if err := gormRule.fromDomain(rule); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormRule).Error; err != nil {
+		return fmt.Errorf("failed to save automation rule: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtém uma regra por ID
+func (r *GormAutomationRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.AutomationRule, error) {
+	var gormRule GormAutomationRule
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormRule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("automation rule not found")
+		}
+		return nil, fmt.Errorf("failed to get automation rule: %w", err)
+	}
+
+	return gormRule.toDomain()
+}
+
+// ListByInstance lista as regras da instância por prioridade
+func (r *GormAutomationRuleRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID, activeOnly bool) ([]*domain.AutomationRule, error) {
+	var gormRules []GormAutomationRule
+
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("instance_id = ?", instanceID)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	if err := query.Order("priority").Order("created_at").Find(&gormRules).Error; err != nil {
+		return nil, fmt.Errorf("failed to list automation rules: %w", err)
+	}
+
+	rules := make([]*domain.AutomationRule, len(gormRules))
+	for i := range gormRules {
+		rule, err := gormRules[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+
+	return rules, nil
+}
+
+// Update atualiza uma regra
+func (r *GormAutomationRuleRepository) Update(ctx context.Context, rule *domain.AutomationRule) error {
+	var gormRule GormAutomationRule
+	if err := gormRule.fromDomain(rule); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Save(&gormRule).Error; err != nil {
+		return fmt.Errorf("failed to update automation rule: %w", err)
+	}
+	return nil
+}
+
+// Delete remove uma regra. O histórico de execuções é mantido
+func (r *GormAutomationRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormAutomationRule{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete automation rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("automation rule not found")
+	}
+	return nil
+}
+
+// GormRuleExecutionRepository implementa RuleExecutionRepository usando GORM
+type GormRuleExecutionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRuleExecutionRepository cria um novo repositório de execuções de regras
+func NewGormRuleExecutionRepository(db *gorm.DB) *GormRuleExecutionRepository {
+	return &GormRuleExecutionRepository{db: db}
+}
+
+// Save salva uma execução
+func (r *GormRuleExecutionRepository) Save(ctx context.Context, execution *domain.RuleExecution) error {
+	gormExecution := GormRuleExecution{
+		ID:             execution.ID,
+		RuleID:         execution.RuleID,
+		RuleName:       execution.RuleName,
+		InstanceID:     execution.InstanceID,
+		Phone:          execution.Phone,
+		MessageID:      execution.MessageID,
+		ReplyMessageID: execution.ReplyMessageID,
+		Actions:        strings.Join(execution.Actions, ","),
+		Error:          execution.Error,
+		CreatedAt:      timeToUnix(execution.CreatedAt),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormExecution).Error; err != nil {
+		return fmt.Errorf("failed to save rule execution: %w", err)
+	}
+	return nil
+}
+
+// ListByInstance lista as execuções da instância, da mais recente para a mais antiga
+func (r *GormRuleExecutionRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID, ruleID *uuid.UUID, limit int, before *domain.Cursor) ([]*domain.RuleExecution, error) {
+	var gormExecutions []GormRuleExecution
+
+	query := r.db.WithContext(ctx).Where("instance_id = ?", instanceID)
+	if ruleID != nil {
+		query = query.Where("rule_id = ?", *ruleID)
+	}
+	if before != nil {
+		createdAt := timeToUnix(before.Time)
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormExecutions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule executions: %w", err)
+	}
+
+	executions := make([]*domain.RuleExecution, len(gormExecutions))
+	for i, gormExecution := range gormExecutions {
+		executions[i] = gormExecution.toDomain()
+	}
+
+	return executions, nil
+}
@@ -0,0 +1,237 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormConversation representa a entidade Conversation para GORM
+type GormConversation struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID           uuid.UUID `gorm:"type:uuid;not null;index"`
+	InstanceID         string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_whatsapp_conversations_instance_phone,priority:1;index:idx_whatsapp_conversations_activity,priority:1"`
+	Phone              string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_whatsapp_conversations_instance_phone,priority:2"`
+	LastMessageID      uuid.UUID `gorm:"type:uuid;not null"`
+	LastMessagePreview string    `gorm:"type:text;not null"`
+	LastMessageType    string    `gorm:"type:varchar(20);not null"`
+	LastDirection      string    `gorm:"type:varchar(10);not null"`
+	LastMessageAt      int64     `gorm:"not null;index:idx_whatsapp_conversations_activity,priority:2"`
+	UnreadCount        int       `gorm:"not null;default:0"`
+	Tags               string    `gorm:"type:text;not null;default:''"` // etiquetas separadas por vírgula
+	HandoffAt          *int64    `gorm:"default:null"`
+	CreatedAt          int64     `gorm:"autoCreateTime"`
+	UpdatedAt          int64     `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormConversation) TableName() string {
+	return "whatsapp_conversations"
+}
+
+// toDomain converte GormConversation para domain.Conversation
+func (g *GormConversation) toDomain() *domain.Conversation {
+	return &domain.Conversation{
+		ID:                 g.ID,
+		TenantID:           g.TenantID,
+		InstanceID:         g.InstanceID,
+		Phone:              g.Phone,
+		LastMessageID:      g.LastMessageID,
+		LastMessagePreview: g.LastMessagePreview,
+		LastMessageType:    domain.MessageType(g.LastMessageType),
+		LastDirection:      domain.MessageDirection(g.LastDirection),
+		LastMessageAt:      timeFromUnix(g.LastMessageAt),
+		UnreadCount:        g.UnreadCount,
+		Tags:               splitTags(g.Tags),
+		HandoffAt:          timePtrFromUnix(g.HandoffAt),
+		CreatedAt:          timeFromUnix(g.CreatedAt),
+		UpdatedAt:          timeFromUnix(g.UpdatedAt),
+	}
+}
+
+// upsertConversation cria ou atualiza a conversa da mensagem dentro da
+// transação que a grava. Mensagens mais antigas que a última conhecida (ex:
+// webhooks entregues fora de ordem) só incrementam o contador de não lidas
+func upsertConversation(tx *gorm.DB, message *domain.Message) error {
+	unread := 0
+	if message.Direction == domain.DirectionInbound {
+		unread = 1
+	}
+
+	now := timeToUnix(timeNow())
+	conversation := GormConversation{
+		ID:                 uuid.New(),
+		TenantID:           message.TenantID,
+		InstanceID:         message.InstanceID,
+		Phone:              message.Phone,
+		LastMessageID:      message.ID,
+		LastMessagePreview: domain.MessagePreview(message),
+		LastMessageType:    string(message.Type),
+		LastDirection:      string(message.Direction),
+		LastMessageAt:      timeToUnix(message.CreatedAt),
+		UnreadCount:        unread,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if conversation.LastDirection == "" {
+		conversation.LastDirection = string(domain.DirectionOutbound)
+	}
+
+	latest := func(column string) clause.Expr {
+		return gorm.Expr(fmt.Sprintf(
+			"CASE WHEN excluded.last_message_at >= whatsapp_conversations.last_message_at THEN excluded.%[1]s ELSE whatsapp_conversations.%[1]s END",
+			column,
+		))
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "instance_id"}, {Name: "phone"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_message_id":      latest("last_message_id"),
+			"last_message_preview": latest("last_message_preview"),
+			"last_message_type":    latest("last_message_type"),
+			"last_direction":       latest("last_direction"),
+			"last_message_at":      gorm.Expr("GREATEST(excluded.last_message_at, whatsapp_conversations.last_message_at)"),
+			"unread_count":         gorm.Expr("whatsapp_conversations.unread_count + ?", unread),
+			"updated_at":           now,
+		}),
+	}).Create(&conversation).Error
+}
+
+// clearConversationPreviews limpa a prévia das conversas cuja última mensagem
+// teve o conteúdo apagado ou foi removida pela política de retenção
+func clearConversationPreviews(tx *gorm.DB, messageIDs []uuid.UUID) error {
+	return tx.Model(&GormConversation{}).
+		Where("last_message_id IN ?", messageIDs).
+		Updates(map[string]interface{}{
+			"last_message_preview": "",
+			"updated_at":           timeToUnix(timeNow()),
+		}).Error
+}
+
+// GormConversationRepository implementa ConversationRepository usando GORM
+type GormConversationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormConversationRepository cria um novo repositório de conversas
+func NewGormConversationRepository(db *gorm.DB) *GormConversationRepository {
+	return &GormConversationRepository{db: db}
+}
+
+// ListByInstance lista as conversas da instância por última atividade
+func (r *GormConversationRepository) ListByInstance(ctx context.Context, instanceID string, limit int, after *domain.Cursor) ([]*domain.Conversation, error) {
+	var gormConversations []GormConversation
+
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("instance_id = ?", instanceID)
+	if after != nil {
+		lastMessageAt := timeToUnix(after.Time)
+		query = query.Where("(last_message_at < ? OR (last_message_at = ? AND id < ?))", lastMessageAt, lastMessageAt, after.ID)
+	}
+
+	err := query.
+		Order("last_message_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormConversations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+
+	conversations := make([]*domain.Conversation, len(gormConversations))
+	for i, gormConversation := range gormConversations {
+		conversations[i] = gormConversation.toDomain()
+	}
+
+	return conversations, nil
+}
+
+// GetByPhone obtém a conversa da instância com um telefone
+func (r *GormConversationRepository) GetByPhone(ctx context.Context, instanceID, phone string) (*domain.Conversation, error) {
+	var gormConversation GormConversation
+
+	err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Where("instance_id = ? AND phone = ?", instanceID, phone).
+		First(&gormConversation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("conversation not found")
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return gormConversation.toDomain(), nil
+}
+
+// MarkRead zera o contador de não lidas da conversa
+func (r *GormConversationRepository) MarkRead(ctx context.Context, instanceID, phone string) error {
+	result := r.db.WithContext(ctx).Model(&GormConversation{}).Scopes(tenantScope(ctx)).
+		Where("instance_id = ? AND phone = ?", instanceID, phone).
+		Updates(map[string]interface{}{
+			"unread_count": 0,
+			"updated_at":   timeToUnix(timeNow()),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark conversation as read: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("conversation not found")
+	}
+
+	return nil
+}
+
+// AddTag adiciona a etiqueta à conversa, se ainda não estiver presente
+func (r *GormConversationRepository) AddTag(ctx context.Context, instanceID, phone, tag string) error {
+	result := r.db.WithContext(ctx).Model(&GormConversation{}).Scopes(tenantScope(ctx)).
+		Where("instance_id = ? AND phone = ?", instanceID, phone).
+		Where("strpos(',' || tags || ',', ',' || ? || ',') = 0", tag).
+		Updates(map[string]interface{}{
+			"tags":       gorm.Expr("CASE WHEN tags = '' THEN ? ELSE tags || ',' || ? END", tag, tag),
+			"updated_at": timeToUnix(timeNow()),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to tag conversation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// Sem alteração: a conversa não existe ou já tem a etiqueta
+		if _, err := r.GetByPhone(ctx, instanceID, phone); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetHandoff transfere a conversa para atendimento humano ou a devolve à automação
+func (r *GormConversationRepository) SetHandoff(ctx context.Context, instanceID, phone string, at *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&GormConversation{}).Scopes(tenantScope(ctx)).
+		Where("instance_id = ? AND phone = ?", instanceID, phone).
+		Updates(map[string]interface{}{
+			"handoff_at": timePtrToUnix(at),
+			"updated_at": timeToUnix(timeNow()),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update conversation handoff: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("conversation not found")
+	}
+
+	return nil
+}
+
+// splitTags converte a coluna de etiquetas em lista
+func splitTags(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,252 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormErasureRepository implementa ErasureRepository usando GORM
+type GormErasureRepository struct {
+	db *gorm.DB
+}
+
+// NewGormErasureRepository cria um novo repositório de exclusão de dados
+func NewGormErasureRepository(db *gorm.DB) *GormErasureRepository {
+	return &GormErasureRepository{db: db}
+}
+
+// Erase remove os dados do telefone no tenant em uma única transação: ou tudo
+// é removido, ou nada
+func (r *GormErasureRepository) Erase(ctx context.Context, tenantID uuid.UUID, phone string, removeOptOuts bool) (*domain.ErasureCounts, error) {
+	counts := &domain.ErasureCounts{}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Os downloads de mídia guardam a URL do provider; os arquivos já baixados
+		// ficam sem mensagem e são descartados pelo serviço de mídia
+		messages := tx.Model(&GormMessage{}).Select("id").Where("tenant_id = ? AND phone = ?", tenantID, phone)
+		if err := tx.Where("message_id IN (?)", messages).Delete(&GormMediaDownload{}).Error; err != nil {
+			return fmt.Errorf("failed to erase media downloads: %w", err)
+		}
+
+		result := tx.Where("tenant_id = ? AND phone = ?", tenantID, phone).Delete(&GormMessage{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to erase messages: %w", result.Error)
+		}
+		counts.Messages = result.RowsAffected
+
+		result = tx.Where("tenant_id = ? AND phone = ?", tenantID, phone).Delete(&GormConversation{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to erase conversations: %w", result.Error)
+		}
+		counts.Conversations = result.RowsAffected
+
+		// O histórico de regras não guarda o tenant: as instâncias o identificam
+		instances := tx.Model(&GormInstance{}).Select("id").Where("tenant_id = ?", tenantID)
+		result = tx.Where("phone = ? AND instance_id IN (?)", phone, instances).Delete(&GormRuleExecution{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to erase rule executions: %w", result.Error)
+		}
+		counts.RuleExecutions = result.RowsAffected
+
+		// Os eventos de mensagem levam o telefone na raiz do payload ou na mensagem
+		subscriptions := tx.Model(&GormWebhookSubscription{}).Select("id").Where("tenant_id = ?", tenantID)
+		result = tx.
+			Where("subscription_id IN (?)", subscriptions).
+			Where("(payload->>'phone' = ? OR payload->'message'->>'phone' = ?)", phone, phone).
+			Delete(&GormWebhookDelivery{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to erase webhook deliveries: %w", result.Error)
+		}
+		counts.WebhookDeliveries = result.RowsAffected
+
+		optOuts := tx.Where("tenant_id = ? AND phone = ?", tenantID, phone)
+		if removeOptOuts {
+			result = optOuts.Delete(&GormOptOut{})
+			if result.Error != nil {
+				return fmt.Errorf("failed to erase opt-outs: %w", result.Error)
+			}
+			counts.OptOuts = result.RowsAffected
+		} else if err := optOuts.Model(&GormOptOut{}).Count(&counts.OptOutsKept).Error; err != nil {
+			return fmt.Errorf("failed to count opt-outs: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GormErasureReceipt representa a entidade ErasureReceipt para GORM
+type GormErasureReceipt struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Version   int       `gorm:"not null;default:1"` // recibos anteriores à versão são da versão 1
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_whatsapp_erasure_receipts_chain,priority:1"`
+	Sequence  int64     `gorm:"not null;uniqueIndex:idx_whatsapp_erasure_receipts_chain,priority:2"`
+	PhoneHash string    `gorm:"type:varchar(64);not null"`
+	PhoneSalt string    `gorm:"type:varchar(64);not null"`
+	Removed   string    `gorm:"type:jsonb;not null"`
+	ActorType string    `gorm:"type:varchar(20);not null"`
+	ActorID   string    `gorm:"type:varchar(64);not null;default:''"`
+	PrevHash  string    `gorm:"type:varchar(64);not null;default:''"`
+	Hash      string    `gorm:"type:varchar(64);not null"`
+	CreatedAt int64     `gorm:"not null;index"`
+}
+
+// TableName define o nome da tabela
+func (GormErasureReceipt) TableName() string {
+	return "whatsapp_erasure_receipts"
+}
+
+// toDomain converte GormErasureReceipt para domain.ErasureReceipt
+func (g *GormErasureReceipt) toDomain() *domain.ErasureReceipt {
+	var removed domain.ErasureCounts
+	_ = json.Unmarshal([]byte(g.Removed), &removed)
+
+	return &domain.ErasureReceipt{
+		ID:        g.ID,
+		Version:   g.Version,
+		TenantID:  g.TenantID,
+		Sequence:  g.Sequence,
+		PhoneHash: g.PhoneHash,
+		PhoneSalt: g.PhoneSalt,
+		Removed:   removed,
+		ActorType: g.ActorType,
+		ActorID:   g.ActorID,
+		PrevHash:  g.PrevHash,
+		Hash:      g.Hash,
+		CreatedAt: timeFromUnix(g.CreatedAt),
+	}
+}
+
+// GormErasureReceiptRepository implementa ErasureReceiptRepository usando GORM
+type GormErasureReceiptRepository struct {
+	db *gorm.DB
+}
+
+// NewGormErasureReceiptRepository cria um novo repositório de recibos de exclusão
+func NewGormErasureReceiptRepository(db *gorm.DB) *GormErasureReceiptRepository {
+	return &GormErasureReceiptRepository{db: db}
+}
+
+// Save grava o recibo. O índice único de tenant e sequência impede que dois
+// recibos sejam encadeados ao mesmo anterior
+func (r *GormErasureReceiptRepository) Save(ctx context.Context, receipt *domain.ErasureReceipt) (bool, error) {
+	removed, err := json.Marshal(receipt.Removed)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode erasure counts: %w", err)
+	}
+
+	gormReceipt := GormErasureReceipt{
+		ID:        receipt.ID,
+		Version:   receipt.Version,
+		TenantID:  receipt.TenantID,
+		Sequence:  receipt.Sequence,
+		PhoneHash: receipt.PhoneHash,
+		PhoneSalt: receipt.PhoneSalt,
+		Removed:   string(removed),
+		ActorType: receipt.ActorType,
+		ActorID:   receipt.ActorID,
+		PrevHash:  receipt.PrevHash,
+		Hash:      receipt.Hash,
+		CreatedAt: timeToUnix(receipt.CreatedAt),
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&gormReceipt)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save erasure receipt: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID obtém um recibo por ID
+func (r *GormErasureReceiptRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ErasureReceipt, error) {
+	var gormReceipt GormErasureReceipt
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormReceipt).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("erasure receipt not found")
+		}
+		return nil, fmt.Errorf("failed to get erasure receipt: %w", err)
+	}
+
+	return gormReceipt.toDomain(), nil
+}
+
+// Last retorna o último recibo do tenant ou nil quando não há
+func (r *GormErasureReceiptRepository) Last(ctx context.Context, tenantID uuid.UUID) (*domain.ErasureReceipt, error) {
+	var gormReceipts []GormErasureReceipt
+
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("sequence DESC").
+		Limit(1).
+		Find(&gormReceipts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last erasure receipt: %w", err)
+	}
+	if len(gormReceipts) == 0 {
+		return nil, nil
+	}
+
+	return gormReceipts[0].toDomain(), nil
+}
+
+// List lista os recibos do tenant do mais recente para o mais antigo
+func (r *GormErasureReceiptRepository) List(ctx context.Context, limit int, before *domain.Cursor) ([]*domain.ErasureReceipt, error) {
+	var gormReceipts []GormErasureReceipt
+
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx))
+	if before != nil {
+		createdAt := timeToUnix(before.Time)
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormReceipts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list erasure receipts: %w", err)
+	}
+
+	return erasureReceiptsToDomain(gormReceipts), nil
+}
+
+// ListChain lista os recibos do tenant em ordem de sequência
+func (r *GormErasureReceiptRepository) ListChain(ctx context.Context, tenantID uuid.UUID, afterSequence int64, limit int) ([]*domain.ErasureReceipt, error) {
+	var gormReceipts []GormErasureReceipt
+
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND sequence > ?", tenantID, afterSequence).
+		Order("sequence").
+		Limit(limit).
+		Find(&gormReceipts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list erasure receipts: %w", err)
+	}
+
+	return erasureReceiptsToDomain(gormReceipts), nil
+}
+
+// erasureReceiptsToDomain converte a lista de GormErasureReceipt para domain.ErasureReceipt
+func erasureReceiptsToDomain(gormReceipts []GormErasureReceipt) []*domain.ErasureReceipt {
+	receipts := make([]*domain.ErasureReceipt, len(gormReceipts))
+	for i, gormReceipt := range gormReceipts {
+		receipts[i] = gormReceipt.toDomain()
+	}
+	return receipts
+}
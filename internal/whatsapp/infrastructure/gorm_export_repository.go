@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormExportJob representa a entidade ExportJob para GORM
+type GormExportJob struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID         uuid.UUID `gorm:"type:uuid;not null;index:idx_whatsapp_export_jobs_tenant,priority:1"`
+	Format           string    `gorm:"type:varchar(10);not null"`
+	FilterInstanceID string    `gorm:"type:varchar(255);not null;default:''"`
+	FilterPhone      string    `gorm:"type:varchar(20);not null;default:''"`
+	FilterStatus     string    `gorm:"type:varchar(20);not null;default:''"`
+	FilterFrom       *int64
+	FilterTo         *int64
+	Status           string  `gorm:"type:varchar(20);not null;default:'pending';index:idx_whatsapp_export_jobs_status,priority:1"`
+	RowCount         int64   `gorm:"not null;default:0"`
+	SizeBytes        int64   `gorm:"not null;default:0"`
+	FileName         string  `gorm:"type:varchar(255);not null;default:''"`
+	Error            *string `gorm:"type:text"`
+	ExpiresAt        *int64  `gorm:"index"`
+	StartedAt        *int64
+	CompletedAt      *int64
+	CreatedAt        int64 `gorm:"autoCreateTime;index:idx_whatsapp_export_jobs_tenant,priority:2;index:idx_whatsapp_export_jobs_status,priority:2"`
+	UpdatedAt        int64 `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormExportJob) TableName() string {
+	return "whatsapp_export_jobs"
+}
+
+// toDomain converte GormExportJob para domain.ExportJob
+func (g *GormExportJob) toDomain() *domain.ExportJob {
+	return &domain.ExportJob{
+		ID:       g.ID,
+		TenantID: g.TenantID,
+		Format:   domain.ExportFormat(g.Format),
+		Filter: domain.ExportFilter{
+			InstanceID: g.FilterInstanceID,
+			Phone:      g.FilterPhone,
+			Status:     domain.MessageStatus(g.FilterStatus),
+			From:       timePtrFromUnix(g.FilterFrom),
+			To:         timePtrFromUnix(g.FilterTo),
+		},
+		Status:      domain.ExportStatus(g.Status),
+		Rows:        g.RowCount,
+		SizeBytes:   g.SizeBytes,
+		FileName:    g.FileName,
+		Error:       g.Error,
+		ExpiresAt:   timePtrFromUnix(g.ExpiresAt),
+		StartedAt:   timePtrFromUnix(g.StartedAt),
+		CompletedAt: timePtrFromUnix(g.CompletedAt),
+		CreatedAt:   timeFromUnix(g.CreatedAt),
+		UpdatedAt:   timeFromUnix(g.UpdatedAt),
+	}
+}
+
+// GormExportJobRepository implementa ExportJobRepository usando GORM
+type GormExportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewGormExportJobRepository cria um novo repositório de exportações
+func NewGormExportJobRepository(db *gorm.DB) *GormExportJobRepository {
+	return &GormExportJobRepository{db: db}
+}
+
+// Save cria uma exportação
+func (r *GormExportJobRepository) Save(ctx context.Context, job *domain.ExportJob) error {
+	gormJob := GormExportJob{
+		ID:               job.ID,
+		TenantID:         job.TenantID,
+		Format:           string(job.Format),
+		FilterInstanceID: job.Filter.InstanceID,
+		FilterPhone:      job.Filter.Phone,
+		FilterStatus:     string(job.Filter.Status),
+		FilterFrom:       timePtrToUnix(job.Filter.From),
+		FilterTo:         timePtrToUnix(job.Filter.To),
+		Status:           string(job.Status),
+		CreatedAt:        timeToUnix(job.CreatedAt),
+		UpdatedAt:        timeToUnix(job.UpdatedAt),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormJob).Error; err != nil {
+		return fmt.Errorf("failed to save export job: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtém uma exportação por ID
+func (r *GormExportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error) {
+	var gormJob GormExportJob
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormJob).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("export job not found")
+		}
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+
+	return gormJob.toDomain(), nil
+}
+
+// List lista as exportações do tenant da mais recente para a mais antiga
+func (r *GormExportJobRepository) List(ctx context.Context, limit int, before *domain.Cursor) ([]*domain.ExportJob, error) {
+	var gormJobs []GormExportJob
+
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx))
+	if before != nil {
+		createdAt := timeToUnix(before.Time)
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormJobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export jobs: %w", err)
+	}
+
+	return exportJobsToDomain(gormJobs), nil
+}
+
+// ClaimNext reserva a exportação pendente mais antiga. SKIP LOCKED permite que
+// várias réplicas processem a fila sem gerar o mesmo arquivo duas vezes
+func (r *GormExportJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.ExportJob, error) {
+	var gormJobs []GormExportJob
+
+	now := timeToUnix(timeNow())
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE whatsapp_export_jobs SET status = ?, started_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM whatsapp_export_jobs
+			WHERE status = ? OR (status = ? AND started_at < ?)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		string(domain.ExportRunning), now, now,
+		string(domain.ExportPending), string(domain.ExportRunning), timeToUnix(staleBefore),
+	).Scan(&gormJobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim export job: %w", err)
+	}
+	if len(gormJobs) == 0 {
+		return nil, nil
+	}
+
+	return gormJobs[0].toDomain(), nil
+}
+
+// Update grava o status e o resultado do processamento
+func (r *GormExportJobRepository) Update(ctx context.Context, job *domain.ExportJob) error {
+	err := r.db.WithContext(ctx).Model(&GormExportJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":       string(job.Status),
+			"row_count":    job.Rows,
+			"size_bytes":   job.SizeBytes,
+			"file_name":    job.FileName,
+			"error":        job.Error,
+			"expires_at":   timePtrToUnix(job.ExpiresAt),
+			"completed_at": timePtrToUnix(job.CompletedAt),
+			"updated_at":   timeToUnix(timeNow()),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update export job: %w", err)
+	}
+	return nil
+}
+
+// ListExpired lista as exportações concluídas cujo arquivo venceu
+func (r *GormExportJobRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.ExportJob, error) {
+	var gormJobs []GormExportJob
+
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", string(domain.ExportCompleted), timeToUnix(now)).
+		Order("expires_at").
+		Limit(limit).
+		Find(&gormJobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired export jobs: %w", err)
+	}
+
+	return exportJobsToDomain(gormJobs), nil
+}
+
+// ListCompleted lista as exportações concluídas do tenant que podem conter
+// mensagens do telefone
+func (r *GormExportJobRepository) ListCompleted(ctx context.Context, phone string) ([]*domain.ExportJob, error) {
+	var gormJobs []GormExportJob
+
+	err := r.db.WithContext(ctx).
+		Scopes(tenantScope(ctx)).
+		Where("status = ? AND filter_phone IN ?", string(domain.ExportCompleted), []string{"", phone}).
+		Order("created_at").
+		Find(&gormJobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed export jobs: %w", err)
+	}
+
+	return exportJobsToDomain(gormJobs), nil
+}
+
+// exportJobsToDomain converte a lista de GormExportJob para domain.ExportJob
+func exportJobsToDomain(gormJobs []GormExportJob) []*domain.ExportJob {
+	jobs := make([]*domain.ExportJob, len(gormJobs))
+	for i, gormJob := range gormJobs {
+		jobs[i] = gormJob.toDomain()
+	}
+	return jobs
+}
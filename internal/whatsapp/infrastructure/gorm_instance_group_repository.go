@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormInstanceGroup representa a entidade InstanceGroup para GORM
+type GormInstanceGroup struct {
+	ID        uuid.UUID                 `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID  uuid.UUID                 `gorm:"type:uuid;not null;index"`
+	Name      string                    `gorm:"type:varchar(255);not null"`
+	Policy    string                    `gorm:"type:varchar(20);not null;default:'priority'"`
+	Members   []GormInstanceGroupMember `gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE"`
+	CreatedAt int64                     `gorm:"autoCreateTime"`
+	UpdatedAt int64                     `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormInstanceGroup) TableName() string {
+	return "whatsapp_instance_groups"
+}
+
+// GormInstanceGroupMember representa a participação de uma instância em um grupo
+type GormInstanceGroupMember struct {
+	GroupID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	InstanceID uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Priority   int       `gorm:"not null;default:0"`
+}
+
+// TableName define o nome da tabela
+func (GormInstanceGroupMember) TableName() string {
+	return "whatsapp_instance_group_members"
+}
+
+// toDomain converte GormInstanceGroup para domain.InstanceGroup
+func (g *GormInstanceGroup) toDomain() *domain.InstanceGroup {
+	members := make([]domain.InstanceGroupMember, len(g.Members))
+	for i, member := range g.Members {
+		members[i] = domain.InstanceGroupMember{
+			InstanceID: member.InstanceID,
+			Priority:   member.Priority,
+		}
+	}
+
+	return &domain.InstanceGroup{
+		ID:        g.ID,
+		TenantID:  g.TenantID,
+		Name:      g.Name,
+		Policy:    domain.RoutingPolicy(g.Policy),
+		Members:   members,
+		CreatedAt: timeFromUnix(g.CreatedAt),
+		UpdatedAt: timeFromUnix(g.UpdatedAt),
+	}
+}
+
+// fromDomain converte domain.InstanceGroup para GormInstanceGroup
+func (g *GormInstanceGroup) fromDomain(group *domain.InstanceGroup) {
+	g.ID = group.ID
+	g.TenantID = group.TenantID
+	g.Name = group.Name
+	g.Policy = string(group.Policy)
+	g.CreatedAt = timeToUnix(group.CreatedAt)
+	g.UpdatedAt = timeToUnix(group.UpdatedAt)
+
+	g.Members = make([]GormInstanceGroupMember, len(group.Members))
+	for i, member := range group.Members {
+		g.Members[i] = GormInstanceGroupMember{
+			GroupID:    group.ID,
+			InstanceID: member.InstanceID,
+			Priority:   member.Priority,
+		}
+	}
+}
+
+// GormInstanceGroupRepository implementa InstanceGroupRepository usando GORM
+type GormInstanceGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormInstanceGroupRepository cria um novo repositório de grupos de instâncias
+func NewGormInstanceGroupRepository(db *gorm.DB) *GormInstanceGroupRepository {
+	return &GormInstanceGroupRepository{db: db}
+}
+
+// Save salva um grupo com seus membros
+func (r *GormInstanceGroupRepository) Save(ctx context.Context, group *domain.InstanceGroup) error {
+	var gormGroup GormInstanceGroup
+	gormGroup.fromDomain(group)
+
+	if err := r.db.WithContext(ctx).Create(&gormGroup).Error; err != nil {
+		return fmt.Errorf("failed to save instance group: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtém um grupo por ID
+func (r *GormInstanceGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InstanceGroup, error) {
+	var gormGroup GormInstanceGroup
+
+	err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("priority ASC") }).
+		Where("id = ?", id).
+		First(&gormGroup).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("instance group not found")
+		}
+		return nil, fmt.Errorf("failed to get instance group: %w", err)
+	}
+
+	return gormGroup.toDomain(), nil
+}
+
+// GetAll obtém todos os grupos
+func (r *GormInstanceGroupRepository) GetAll(ctx context.Context) ([]*domain.InstanceGroup, error) {
+	var gormGroups []GormInstanceGroup
+
+	err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("priority ASC") }).
+		Order("name ASC").
+		Find(&gormGroups).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance groups: %w", err)
+	}
+
+	groups := make([]*domain.InstanceGroup, len(gormGroups))
+	for i := range gormGroups {
+		groups[i] = gormGroups[i].toDomain()
+	}
+
+	return groups, nil
+}
+
+// Update substitui os dados e os membros de um grupo
+func (r *GormInstanceGroupRepository) Update(ctx context.Context, group *domain.InstanceGroup) error {
+	var gormGroup GormInstanceGroup
+	gormGroup.fromDomain(group)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", group.ID).Delete(&GormInstanceGroupMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Members").Save(&gormGroup).Error; err != nil {
+			return err
+		}
+		if len(gormGroup.Members) > 0 {
+			return tx.Create(&gormGroup.Members).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update instance group: %w", err)
+	}
+
+	return nil
+}
+
+// Delete remove um grupo e seus membros
+func (r *GormInstanceGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&GormInstanceGroupMember{}).Error; err != nil {
+			return err
+		}
+		result := tx.Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormInstanceGroup{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("instance group not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete instance group: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveInstance remove a instância de todos os grupos
+func (r *GormInstanceGroupRepository) RemoveInstance(ctx context.Context, instanceID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("instance_id = ?", instanceID).Delete(&GormInstanceGroupMember{}).Error; err != nil {
+		return fmt.Errorf("failed to remove instance from groups: %w", err)
+	}
+
+	return nil
+}
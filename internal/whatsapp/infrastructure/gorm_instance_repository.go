@@ -7,22 +7,27 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/your-org/boilerplate-go/internal/encryption"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
 )
 
 // GormInstance representa a entidade Instance para GORM
 type GormInstance struct {
-	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name       string    `gorm:"type:varchar(255);not null"`
-	Phone      *string   `gorm:"type:varchar(20)"`
-	Status     string    `gorm:"type:varchar(20);not null;default:'disconnected'"`
-	Provider   string    `gorm:"type:varchar(50);not null"`
-	InstanceID string    `gorm:"type:varchar(255);not null"`
-	Token      string    `gorm:"type:varchar(255);not null"`
-	Config     string    `gorm:"type:jsonb"`
-	Error      *string   `gorm:"type:text"`
-	CreatedAt  int64     `gorm:"autoCreateTime"`
-	UpdatedAt  int64     `gorm:"autoUpdateTime"`
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name            string    `gorm:"type:varchar(255);not null"`
+	Phone           *string   `gorm:"type:varchar(20)"`
+	Status          string    `gorm:"type:varchar(20);not null;default:'disconnected'"`
+	Provider        string    `gorm:"type:varchar(50);not null"`
+	InstanceID      string    `gorm:"type:varchar(255);not null"`
+	Token           string    `gorm:"type:text;not null"`     // criptografado quando há chave configurada
+	TokenHash       string    `gorm:"type:varchar(64);index"` // SHA-256 do token em texto puro, usado nas buscas
+	Config          JSONMap
+	Error           *string `gorm:"type:text"`
+	DisconnectedAt  *int64
+	StatusCheckedAt *int64
+	CreatedAt       int64 `gorm:"autoCreateTime"`
+	UpdatedAt       int64 `gorm:"autoUpdateTime"`
 }
 
 // TableName define o nome da tabela
@@ -32,56 +37,183 @@ func (GormInstance) TableName() string {
 
 // toDomain converte GormInstance para domain.Instance
 func (g *GormInstance) toDomain() *domain.Instance {
-	var config map[string]any
-	if g.Config != "" {
-		// Aqui você pode deserializar o JSON se necessário
-		config = make(map[string]any)
-	}
-
 	return &domain.Instance{
-		ID:         g.ID,
-		Name:       g.Name,
-		Phone:      g.Phone,
-		Status:     domain.InstanceStatus(g.Status),
-		Provider:   g.Provider,
-		InstanceID: g.InstanceID,
-		Token:      g.Token,
-		Config:     config,
-		Error:      g.Error,
-		CreatedAt:  timeFromUnix(g.CreatedAt),
-		UpdatedAt:  timeFromUnix(g.UpdatedAt),
+		ID:              g.ID,
+		TenantID:        g.TenantID,
+		Name:            g.Name,
+		Phone:           g.Phone,
+		Status:          domain.InstanceStatus(g.Status),
+		Provider:        g.Provider,
+		InstanceID:      g.InstanceID,
+		Token:           g.Token,
+		Config:          g.Config,
+		Error:           g.Error,
+		DisconnectedAt:  timePtrFromUnix(g.DisconnectedAt),
+		StatusCheckedAt: timePtrFromUnix(g.StatusCheckedAt),
+		CreatedAt:       timeFromUnix(g.CreatedAt),
+		UpdatedAt:       timeFromUnix(g.UpdatedAt),
 	}
 }
 
 // fromDomain converte domain.Instance para GormInstance
 func (g *GormInstance) fromDomain(instance *domain.Instance) {
 	g.ID = instance.ID
+	g.TenantID = instance.TenantID
 	g.Name = instance.Name
 	g.Phone = instance.Phone
 	g.Status = string(instance.Status)
 	g.Provider = instance.Provider
 	g.InstanceID = instance.InstanceID
 	g.Token = instance.Token
+	g.Config = JSONMap(instance.Config)
 	g.Error = instance.Error
+	g.DisconnectedAt = timePtrToUnix(instance.DisconnectedAt)
+	g.StatusCheckedAt = timePtrToUnix(instance.StatusCheckedAt)
 	g.CreatedAt = timeToUnix(instance.CreatedAt)
 	g.UpdatedAt = timeToUnix(instance.UpdatedAt)
+}
 
-	// Serializar config para JSON se necessário
-	g.Config = "{}"
-} // GormInstanceRepository implementa InstanceRepository usando GORM
+// GormInstanceRepository implementa InstanceRepository usando GORM. O token e os
+// segredos da configuração são criptografados ao gravar e descriptografados ao ler
 type GormInstanceRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher *encryption.Cipher
 }
 
 // NewGormInstanceRepository cria um novo repositório de instâncias
-func NewGormInstanceRepository(db *gorm.DB) *GormInstanceRepository {
-	return &GormInstanceRepository{db: db}
+func NewGormInstanceRepository(db *gorm.DB, cipher *encryption.Cipher) *GormInstanceRepository {
+	return &GormInstanceRepository{db: db, cipher: cipher}
+}
+
+// sealSecrets criptografa o token e os segredos da configuração antes de gravar
+func (r *GormInstanceRepository) sealSecrets(g *GormInstance) error {
+	token, err := r.cipher.Decrypt(g.Token)
+	if err != nil {
+		return fmt.Errorf("failed to read instance token: %w", err)
+	}
+	g.TokenHash = encryption.Hash(token)
+
+	if g.Token, err = r.cipher.Encrypt(token); err != nil {
+		return fmt.Errorf("failed to encrypt instance token: %w", err)
+	}
+
+	config, err := transformConfigSecrets(g.Config, r.cipher.Encrypt)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt instance config: %w", err)
+	}
+	g.Config = config
+
+	return nil
+}
+
+// openSecrets descriptografa o token e os segredos da configuração após a leitura
+func (r *GormInstanceRepository) openSecrets(g *GormInstance) error {
+	token, err := r.cipher.Decrypt(g.Token)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt instance token: %w", err)
+	}
+	g.Token = token
+
+	config, err := transformConfigSecrets(g.Config, r.cipher.Decrypt)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt instance config: %w", err)
+	}
+	g.Config = config
+
+	return nil
+}
+
+// needsReEncrypt indica se a linha guarda segredos em texto puro, cifrados
+// com uma chave antiga ou sem o hash de busca do token
+func (r *GormInstanceRepository) needsReEncrypt(g *GormInstance) bool {
+	if g.TokenHash == "" || r.cipher.NeedsRotation(g.Token) {
+		return true
+	}
+	for key, value := range g.Config {
+		if text, ok := value.(string); ok && domain.IsSecretConfigKey(key) && r.cipher.NeedsRotation(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// transformConfigSecrets aplica a transformação aos valores secretos da
+// configuração, retornando uma cópia para não alterar o mapa do domínio
+func transformConfigSecrets(config JSONMap, transform func(string) (string, error)) (JSONMap, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	result := make(JSONMap, len(config))
+	for key, value := range config {
+		text, ok := value.(string)
+		if !ok || !domain.IsSecretConfigKey(key) {
+			result[key] = value
+			continue
+		}
+
+		transformed, err := transform(text)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key, err)
+		}
+		result[key] = transformed
+	}
+	return result, nil
+}
+
+// toDomain descriptografa a linha e converte para domain.Instance
+func (r *GormInstanceRepository) toDomain(g *GormInstance) (*domain.Instance, error) {
+	if err := r.openSecrets(g); err != nil {
+		return nil, err
+	}
+	return g.toDomain(), nil
+}
+
+// ReEncrypt regrava com a chave ativa os segredos ainda em texto puro ou
+// cifrados com chaves antigas, retornando quantas instâncias foram atualizadas
+func (r *GormInstanceRepository) ReEncrypt(ctx context.Context) (int, error) {
+	var gormInstances []GormInstance
+	if err := r.db.WithContext(ctx).Find(&gormInstances).Error; err != nil {
+		return 0, fmt.Errorf("failed to get instances: %w", err)
+	}
+
+	updated := 0
+	for i := range gormInstances {
+		gormInstance := &gormInstances[i]
+		if !r.needsReEncrypt(gormInstance) {
+			continue
+		}
+
+		if err := r.openSecrets(gormInstance); err != nil {
+			return updated, fmt.Errorf("instance %s: %w", gormInstance.ID, err)
+		}
+		if err := r.sealSecrets(gormInstance); err != nil {
+			return updated, fmt.Errorf("instance %s: %w", gormInstance.ID, err)
+		}
+
+		err := r.db.WithContext(ctx).Model(&GormInstance{}).
+			Where("id = ?", gormInstance.ID).
+			Updates(map[string]interface{}{
+				"token":      gormInstance.Token,
+				"token_hash": gormInstance.TokenHash,
+				"config":     gormInstance.Config,
+			}).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to re-encrypt instance %s: %w", gormInstance.ID, err)
+		}
+		updated++
+	}
+
+	return updated, nil
 }
 
 // Save salva uma instância
 func (r *GormInstanceRepository) Save(ctx context.Context, instance *domain.Instance) error {
 	var gormInstance GormInstance
 	gormInstance.fromDomain(instance)
+	if err := r.sealSecrets(&gormInstance); err != nil {
+		return err
+	}
 
 	if err := r.db.WithContext(ctx).Create(&gormInstance).Error; err != nil {
 		return fmt.Errorf("failed to save instance: %w", err)
@@ -94,56 +226,63 @@ func (r *GormInstanceRepository) Save(ctx context.Context, instance *domain.Inst
 func (r *GormInstanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Instance, error) {
 	var gormInstance GormInstance
 
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormInstance).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormInstance).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("instance not found")
 		}
 		return nil, fmt.Errorf("failed to get instance: %w", err)
 	}
 
-	return gormInstance.toDomain(), nil
+	return r.toDomain(&gormInstance)
 }
 
 // GetByToken obtém uma instância por token
 func (r *GormInstanceRepository) GetByToken(ctx context.Context, token string) (*domain.Instance, error) {
 	var gormInstance GormInstance
 
-	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&gormInstance).Error; err != nil {
+	// Linhas anteriores à criptografia ainda não têm hash e guardam o token em texto puro
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Where("token_hash = ? OR ((token_hash IS NULL OR token_hash = '') AND token = ?)", encryption.Hash(token), token)
+
+	if err := query.First(&gormInstance).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("instance not found")
 		}
 		return nil, fmt.Errorf("failed to get instance: %w", err)
 	}
 
-	return gormInstance.toDomain(), nil
+	return r.toDomain(&gormInstance)
 }
 
 // GetByInstanceID obtém uma instância por instance_id
 func (r *GormInstanceRepository) GetByInstanceID(ctx context.Context, instanceID string) (*domain.Instance, error) {
 	var gormInstance GormInstance
 
-	fmt.Printf("DEBUG: GetByInstanceID called with instanceID: %s\n", instanceID)
-	if err := r.db.WithContext(ctx).Where("instance_id = ?", instanceID).First(&gormInstance).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("instance_id = ?", instanceID).First(&gormInstance).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("instance not found")
 		}
 		return nil, fmt.Errorf("failed to get instance: %w", err)
 	}
 
-	return gormInstance.toDomain(), nil
+	return r.toDomain(&gormInstance)
 }
 
 // GetAll obtém todas as instâncias
 func (r *GormInstanceRepository) GetAll(ctx context.Context) ([]*domain.Instance, error) {
 	var gormInstances []GormInstance
 
-	if err := r.db.WithContext(ctx).Find(&gormInstances).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Find(&gormInstances).Error; err != nil {
 		return nil, fmt.Errorf("failed to get instances: %w", err)
 	}
 
 	instances := make([]*domain.Instance, len(gormInstances))
-	for i, gormInstance := range gormInstances {
-		instances[i] = gormInstance.toDomain()
+	for i := range gormInstances {
+		instance, err := r.toDomain(&gormInstances[i])
+		if err != nil {
+			return nil, err
+		}
+		instances[i] = instance
 	}
 
 	return instances, nil
@@ -153,6 +292,9 @@ func (r *GormInstanceRepository) GetAll(ctx context.Context) ([]*domain.Instance
 func (r *GormInstanceRepository) Update(ctx context.Context, instance *domain.Instance) error {
 	var gormInstance GormInstance
 	gormInstance.fromDomain(instance)
+	if err := r.sealSecrets(&gormInstance); err != nil {
+		return err
+	}
 
 	if err := r.db.WithContext(ctx).Save(&gormInstance).Error; err != nil {
 		return fmt.Errorf("failed to update instance: %w", err)
@@ -161,9 +303,32 @@ func (r *GormInstanceRepository) Update(ctx context.Context, instance *domain.In
 	return nil
 }
 
+// UpdateStatus atualiza apenas os campos de status da instância
+func (r *GormInstanceRepository) UpdateStatus(ctx context.Context, instance *domain.Instance) error {
+	// Map em vez de struct para que campos nulos (ex: error, disconnected_at) sejam limpos
+	updates := map[string]interface{}{
+		"status":            string(instance.Status),
+		"phone":             instance.Phone,
+		"error":             instance.Error,
+		"disconnected_at":   timePtrToUnix(instance.DisconnectedAt),
+		"status_checked_at": timePtrToUnix(instance.StatusCheckedAt),
+		"updated_at":        timeToUnix(timeNow()),
+	}
+
+	result := r.db.WithContext(ctx).Model(&GormInstance{}).Scopes(tenantScope(ctx)).Where("id = ?", instance.ID).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update instance status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("instance not found")
+	}
+
+	return nil
+}
+
 // Delete remove uma instância
 func (r *GormInstanceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&GormInstance{}).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormInstance{}).Error; err != nil {
 		return fmt.Errorf("failed to delete instance: %w", err)
 	}
 
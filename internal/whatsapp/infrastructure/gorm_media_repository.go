@@ -0,0 +1,248 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormMedia representa a entidade Media para GORM
+type GormMedia struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Type       string     `gorm:"type:varchar(20);not null"`
+	FileName   string     `gorm:"type:varchar(255);not null"`
+	MimeType   string     `gorm:"type:varchar(255);not null"`
+	SizeBytes  int64      `gorm:"not null"`
+	SHA256     string     `gorm:"column:sha256;type:varchar(64);not null"`
+	StorageKey string     `gorm:"type:varchar(255);not null"`
+	MessageID  *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt  int64      `gorm:"not null"`
+}
+
+// TableName define o nome da tabela
+func (GormMedia) TableName() string {
+	return "whatsapp_media"
+}
+
+// toDomain converte GormMedia para domain.Media
+func (g *GormMedia) toDomain() *domain.Media {
+	return &domain.Media{
+		ID:         g.ID,
+		TenantID:   g.TenantID,
+		Type:       domain.MessageType(g.Type),
+		FileName:   g.FileName,
+		MimeType:   g.MimeType,
+		SizeBytes:  g.SizeBytes,
+		SHA256:     g.SHA256,
+		StorageKey: g.StorageKey,
+		MessageID:  g.MessageID,
+		CreatedAt:  timeFromUnix(g.CreatedAt),
+	}
+}
+
+// GormMediaRepository implementa MediaRepository usando GORM
+type GormMediaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMediaRepository cria um novo repositório de mídia
+func NewGormMediaRepository(db *gorm.DB) *GormMediaRepository {
+	return &GormMediaRepository{db: db}
+}
+
+// Save grava os metadados de uma nova mídia
+func (r *GormMediaRepository) Save(ctx context.Context, media *domain.Media) error {
+	gormMedia := GormMedia{
+		ID:         media.ID,
+		TenantID:   media.TenantID,
+		Type:       string(media.Type),
+		FileName:   media.FileName,
+		MimeType:   media.MimeType,
+		SizeBytes:  media.SizeBytes,
+		SHA256:     media.SHA256,
+		StorageKey: media.StorageKey,
+		MessageID:  media.MessageID,
+		CreatedAt:  timeToUnix(media.CreatedAt),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormMedia).Error; err != nil {
+		return fmt.Errorf("failed to save media: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtém uma mídia por ID
+func (r *GormMediaRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Media, error) {
+	var gormMedia GormMedia
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormMedia).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("media not found")
+		}
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	return gormMedia.toDomain(), nil
+}
+
+// Delete remove os metadados de uma mídia
+func (r *GormMediaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormMedia{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete media: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("media not found")
+	}
+	return nil
+}
+
+// ListOrphaned lista as mídias recebidas cuja mensagem foi removida ou, para as
+// criadas antes de before, não aponta mais para elas (ex: mídia apagada pela retenção)
+func (r *GormMediaRepository) ListOrphaned(ctx context.Context, before time.Time, limit int) ([]*domain.Media, error) {
+	var gormMedia []GormMedia
+
+	err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Where("message_id IS NOT NULL").
+		Where("(NOT EXISTS (SELECT 1 FROM whatsapp_messages m WHERE m.id = whatsapp_media.message_id)"+
+			" OR (created_at < ? AND NOT EXISTS (SELECT 1 FROM whatsapp_messages m WHERE m.id = whatsapp_media.message_id AND m.media_id = whatsapp_media.id)))",
+			timeToUnix(before)).
+		Order("created_at").
+		Limit(limit).
+		Find(&gormMedia).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orphaned media: %w", err)
+	}
+
+	media := make([]*domain.Media, len(gormMedia))
+	for i := range gormMedia {
+		media[i] = gormMedia[i].toDomain()
+	}
+	return media, nil
+}
+
+// GormMediaDownload representa a entidade MediaDownload para GORM
+type GormMediaDownload struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	MessageID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type          string    `gorm:"type:varchar(20);not null"`
+	URL           string    `gorm:"type:text;not null"`
+	FileName      string    `gorm:"type:varchar(255);not null;default:''"`
+	MimeType      string    `gorm:"type:varchar(255);not null;default:''"`
+	Status        string    `gorm:"type:varchar(20);not null;default:'pending';index:idx_whatsapp_media_downloads_due,priority:1"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt *int64    `gorm:"index:idx_whatsapp_media_downloads_due,priority:2"`
+	LastError     *string   `gorm:"type:text"`
+	CreatedAt     int64     `gorm:"autoCreateTime"`
+	UpdatedAt     int64     `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormMediaDownload) TableName() string {
+	return "whatsapp_media_downloads"
+}
+
+// toDomain converte GormMediaDownload para domain.MediaDownload
+func (g *GormMediaDownload) toDomain() *domain.MediaDownload {
+	return &domain.MediaDownload{
+		ID:            g.ID,
+		TenantID:      g.TenantID,
+		MessageID:     g.MessageID,
+		Type:          domain.MessageType(g.Type),
+		URL:           g.URL,
+		FileName:      g.FileName,
+		MimeType:      g.MimeType,
+		Status:        domain.MediaDownloadStatus(g.Status),
+		Attempts:      g.Attempts,
+		NextAttemptAt: timePtrFromUnix(g.NextAttemptAt),
+		LastError:     g.LastError,
+		CreatedAt:     timeFromUnix(g.CreatedAt),
+		UpdatedAt:     timeFromUnix(g.UpdatedAt),
+	}
+}
+
+// GormMediaDownloadRepository implementa MediaDownloadRepository usando GORM
+type GormMediaDownloadRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMediaDownloadRepository cria um novo repositório de downloads de mídia
+func NewGormMediaDownloadRepository(db *gorm.DB) *GormMediaDownloadRepository {
+	return &GormMediaDownloadRepository{db: db}
+}
+
+// Save grava um novo download
+func (r *GormMediaDownloadRepository) Save(ctx context.Context, download *domain.MediaDownload) error {
+	gormDownload := GormMediaDownload{
+		ID:            download.ID,
+		TenantID:      download.TenantID,
+		MessageID:     download.MessageID,
+		Type:          string(download.Type),
+		URL:           download.URL,
+		FileName:      download.FileName,
+		MimeType:      download.MimeType,
+		Status:        string(download.Status),
+		Attempts:      download.Attempts,
+		NextAttemptAt: timePtrToUnix(download.NextAttemptAt),
+		LastError:     download.LastError,
+		CreatedAt:     timeToUnix(download.CreatedAt),
+		UpdatedAt:     timeToUnix(download.UpdatedAt),
+	}
+
+	if err := r.db.WithContext(ctx).Create(&gormDownload).Error; err != nil {
+		return fmt.Errorf("failed to save media download: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue reserva os downloads vencidos adiando a próxima tentativa. SKIP
+// LOCKED permite que várias réplicas busquem downloads ao mesmo tempo
+func (r *GormMediaDownloadRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.MediaDownload, error) {
+	var gormDownloads []GormMediaDownload
+
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE whatsapp_media_downloads SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM whatsapp_media_downloads
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		timeToUnix(now.Add(lease)), string(domain.MediaDownloadPending), timeToUnix(now), limit,
+	).Scan(&gormDownloads).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim media downloads: %w", err)
+	}
+
+	downloads := make([]*domain.MediaDownload, len(gormDownloads))
+	for i := range gormDownloads {
+		downloads[i] = gormDownloads[i].toDomain()
+	}
+	return downloads, nil
+}
+
+// RecordAttempt grava o resultado de uma tentativa de download
+func (r *GormMediaDownloadRepository) RecordAttempt(ctx context.Context, download *domain.MediaDownload) error {
+	err := r.db.WithContext(ctx).Model(&GormMediaDownload{}).
+		Where("id = ?", download.ID).
+		Updates(map[string]interface{}{
+			"status":          string(download.Status),
+			"attempts":        download.Attempts,
+			"next_attempt_at": timePtrToUnix(download.NextAttemptAt),
+			"last_error":      download.LastError,
+			"updated_at":      timeToUnix(timeNow()),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record media download attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// messageStatsColumns calcula os contadores e tempos médios de MessageStats.
+// Mensagens anteriores ao registro das etapas não têm delivered_at/read_at,
+// por isso entrega e leitura também consideram o status
+const messageStatsColumns = `
+	COUNT(*) FILTER (WHERE direction = 'inbound') AS received,
+	COUNT(*) FILTER (WHERE direction = 'outbound' AND (sent_at IS NOT NULL OR status IN ('sent', 'delivered', 'read'))) AS sent,
+	COUNT(*) FILTER (WHERE direction = 'outbound' AND (delivered_at IS NOT NULL OR status IN ('delivered', 'read'))) AS delivered,
+	COUNT(*) FILTER (WHERE direction = 'outbound' AND (read_at IS NOT NULL OR status = 'read')) AS read,
+	COUNT(*) FILTER (WHERE direction = 'outbound' AND status = 'failed') AS failed,
+	AVG(delivered_at - COALESCE(sent_at, created_at)) FILTER (WHERE direction = 'outbound' AND delivered_at IS NOT NULL) AS avg_delivery_seconds,
+	AVG(read_at - COALESCE(sent_at, created_at)) FILTER (WHERE direction = 'outbound' AND read_at IS NOT NULL) AS avg_read_seconds`
+
+// gormMessageStats recebe o resultado das consultas de estatísticas
+type gormMessageStats struct {
+	Bucket             time.Time
+	InstanceID         string
+	Provider           string
+	Type               string
+	Received           int64
+	Sent               int64
+	Delivered          int64
+	Read               int64
+	Failed             int64
+	AvgDeliverySeconds sql.NullFloat64
+	AvgReadSeconds     sql.NullFloat64
+}
+
+// toDomain converte o resultado para domain.MessageStats
+func (g *gormMessageStats) toDomain() domain.MessageStats {
+	stats := domain.MessageStats{
+		Received:  g.Received,
+		Sent:      g.Sent,
+		Delivered: g.Delivered,
+		Read:      g.Read,
+		Failed:    g.Failed,
+	}
+	if g.AvgDeliverySeconds.Valid {
+		stats.AvgDeliverySeconds = &g.AvgDeliverySeconds.Float64
+	}
+	if g.AvgReadSeconds.Valid {
+		stats.AvgReadSeconds = &g.AvgReadSeconds.Float64
+	}
+	stats.ComputeRates()
+	return stats
+}
+
+// AggregateStats agrupa as estatísticas por período, instância, provider e tipo.
+// Os períodos são calculados em UTC
+func (r *GormMessageRepository) AggregateStats(ctx context.Context, query domain.MessageAnalyticsQuery) ([]*domain.MessageStatsRow, error) {
+	if query.Bucket.Duration() == 0 {
+		return nil, fmt.Errorf("invalid analytics bucket: %s", query.Bucket)
+	}
+
+	// O período vem de uma lista fechada, então pode ser interpolado com segurança
+	bucket := fmt.Sprintf("date_trunc('%s', to_timestamp(created_at) AT TIME ZONE 'UTC')", query.Bucket)
+
+	var results []gormMessageStats
+	err := r.analyticsQuery(ctx, query).
+		Select(bucket + " AS bucket, instance_id, provider, type," + messageStatsColumns).
+		Group(bucket).
+		Group("instance_id").
+		Group("provider").
+		Group("type").
+		Order("bucket").
+		Order("instance_id").
+		Order("type").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate message stats: %w", err)
+	}
+
+	rows := make([]*domain.MessageStatsRow, len(results))
+	for i, result := range results {
+		rows[i] = &domain.MessageStatsRow{
+			Bucket:       result.Bucket.UTC(),
+			InstanceID:   result.InstanceID,
+			Provider:     result.Provider,
+			Type:         domain.MessageType(result.Type),
+			MessageStats: result.toDomain(),
+		}
+	}
+
+	return rows, nil
+}
+
+// SummarizeStats calcula as estatísticas de todo o intervalo consultado
+func (r *GormMessageRepository) SummarizeStats(ctx context.Context, query domain.MessageAnalyticsQuery) (*domain.MessageStats, error) {
+	var result gormMessageStats
+	err := r.analyticsQuery(ctx, query).
+		Select(messageStatsColumns).
+		Scan(&result).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize message stats: %w", err)
+	}
+
+	stats := result.toDomain()
+	return &stats, nil
+}
+
+// TopFailureReasons lista os erros mais frequentes das mensagens que falharam
+func (r *GormMessageRepository) TopFailureReasons(ctx context.Context, query domain.MessageAnalyticsQuery, limit int) ([]*domain.FailureReason, error) {
+	var reasons []*domain.FailureReason
+	err := r.analyticsQuery(ctx, query).
+		Select("COALESCE(error, '') AS reason, COUNT(*) AS count").
+		Where("direction = ? AND status = ?", string(domain.DirectionOutbound), string(domain.StatusFailed)).
+		Group("reason").
+		Order("count DESC").
+		Order("reason").
+		Limit(limit).
+		Scan(&reasons).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top failure reasons: %w", err)
+	}
+
+	return reasons, nil
+}
+
+// analyticsQuery aplica o intervalo e os filtros da consulta de estatísticas
+func (r *GormMessageRepository) analyticsQuery(ctx context.Context, query domain.MessageAnalyticsQuery) *gorm.DB {
+	db := r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)).
+		Where("created_at >= ? AND created_at < ?", timeToUnix(query.From), timeToUnix(query.To))
+
+	if query.InstanceID != "" {
+		db = db.Where("instance_id = ?", query.InstanceID)
+	}
+	if query.Provider != "" {
+		db = db.Where("provider = ?", query.Provider)
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", string(query.Type))
+	}
+	return db
+}
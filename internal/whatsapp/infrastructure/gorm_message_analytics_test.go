@@ -0,0 +1,100 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+)
+
+var statsColumns = []string{"received", "sent", "delivered", "read", "failed", "avg_delivery_seconds", "avg_read_seconds"}
+
+func TestGormMessageRepository_AggregateStats(t *testing.T) {
+	db, stub := newStubDB(t)
+	repo := infrastructure.NewGormMessageRepository(db)
+
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	query := domain.MessageAnalyticsQuery{From: from, To: from.AddDate(0, 0, 14), Bucket: domain.BucketWeek, Provider: "z-api"}
+
+	stub.Return(append([]string{"bucket", "instance_id", "provider", "type"}, statsColumns...),
+		[]driver.Value{from, "instance-1", "z-api", "text", int64(3), int64(4), int64(2), int64(1), int64(1), 12.5, nil},
+		// Sem mensagens enviadas as taxas ficam zeradas
+		[]driver.Value{from.AddDate(0, 0, 7), "instance-1", "z-api", "image", int64(2), int64(0), int64(0), int64(0), int64(0), nil, nil},
+	)
+
+	rows, err := repo.AggregateStats(context.Background(), query)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	first := rows[0]
+	assert.Equal(t, from, first.Bucket)
+	assert.Equal(t, domain.TextMessage, first.Type)
+	assert.Equal(t, int64(3), first.Received)
+	assert.Equal(t, 0.5, first.DeliveryRate)
+	assert.Equal(t, 0.5, first.ReadRate)
+	require.NotNil(t, first.AvgDeliverySeconds)
+	assert.Equal(t, 12.5, *first.AvgDeliverySeconds)
+	assert.Nil(t, first.AvgReadSeconds)
+
+	second := rows[1]
+	assert.Equal(t, domain.ImageMessage, second.Type)
+	assert.Zero(t, second.DeliveryRate)
+	assert.Zero(t, second.ReadRate)
+	assert.Nil(t, second.AvgDeliverySeconds)
+
+	queries := stub.Queries()
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].SQL, "date_trunc('week', to_timestamp(created_at) AT TIME ZONE 'UTC') AS bucket")
+	assert.Contains(t, queries[0].SQL, "WHERE (created_at >= $1 AND created_at < $2) AND provider = $3")
+	assert.Contains(t, queries[0].SQL, `GROUP BY date_trunc('week', to_timestamp(created_at) AT TIME ZONE 'UTC'),"instance_id","provider","type"`)
+	assert.Equal(t, []interface{}{from.Unix(), query.To.Unix(), "z-api"}, queries[0].Args)
+}
+
+func TestGormMessageRepository_AggregateStatsRejectsUnknownBucket(t *testing.T) {
+	db, stub := newStubDB(t)
+	repo := infrastructure.NewGormMessageRepository(db)
+
+	// O período é interpolado na consulta, então valores fora da lista não chegam ao banco
+	_, err := repo.AggregateStats(context.Background(), domain.MessageAnalyticsQuery{Bucket: "day') --"})
+	assert.Error(t, err)
+	assert.Empty(t, stub.Queries())
+}
+
+func TestGormMessageRepository_SummarizeStats(t *testing.T) {
+	db, stub := newStubDB(t)
+	repo := infrastructure.NewGormMessageRepository(db)
+
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	query := domain.MessageAnalyticsQuery{InstanceID: "instance-1", From: from, To: from.AddDate(0, 0, 1), Bucket: domain.BucketDay}
+
+	stub.Return(statsColumns, []driver.Value{int64(5), int64(10), int64(8), int64(2), int64(2), 3.0, 40.0})
+	stub.Return([]string{"reason", "count"},
+		[]driver.Value{"invalid phone", int64(2)},
+		[]driver.Value{"", int64(1)},
+	)
+
+	totals, err := repo.SummarizeStats(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), totals.Sent)
+	assert.Equal(t, 0.8, totals.DeliveryRate)
+	assert.Equal(t, 0.25, totals.ReadRate)
+	require.NotNil(t, totals.AvgReadSeconds)
+	assert.Equal(t, 40.0, *totals.AvgReadSeconds)
+
+	failures, err := repo.TopFailureReasons(context.Background(), query, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.FailureReason{{Reason: "invalid phone", Count: 2}, {Reason: "", Count: 1}}, failures)
+
+	queries := stub.Queries()
+	require.Len(t, queries, 2)
+	assert.NotContains(t, queries[0].SQL, "GROUP BY")
+	assert.Contains(t, queries[1].SQL, "direction = $4 AND status = $5")
+	assert.Contains(t, queries[1].SQL, "ORDER BY count DESC,reason LIMIT $6")
+	assert.Equal(t, []interface{}{from.Unix(), query.To.Unix(), "instance-1", "outbound", "failed", int64(10)}, queries[1].Args)
+}
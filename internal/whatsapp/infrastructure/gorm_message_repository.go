@@ -3,26 +3,45 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
 )
 
 // GormMessage representa a entidade Message para GORM
 type GormMessage struct {
-	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	InstanceID string    `gorm:"type:varchar(255);not null;index"`
-	Phone      string    `gorm:"type:varchar(20);not null"`
-	Type       string    `gorm:"type:varchar(20);not null"`
-	Content    string    `gorm:"type:text;not null"`
-	MediaURL   *string   `gorm:"type:text"`
-	Status     string    `gorm:"type:varchar(20);not null;default:'pending'"`
-	ProviderID *string   `gorm:"type:varchar(255)"`
-	Error      *string   `gorm:"type:text"`
-	CreatedAt  int64     `gorm:"autoCreateTime"`
-	UpdatedAt  int64     `gorm:"autoUpdateTime"`
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	InstanceID  string     `gorm:"type:varchar(255);not null;index;index:idx_whatsapp_messages_thread,priority:1;index:idx_whatsapp_messages_instance_created,priority:1;uniqueIndex:idx_whatsapp_messages_instance_provider,priority:1"`
+	Provider    string     `gorm:"type:varchar(50);not null;default:''"`
+	Phone       string     `gorm:"type:varchar(20);not null;index:idx_whatsapp_messages_thread,priority:2"`
+	Direction   string     `gorm:"type:varchar(10);not null;default:'outbound'"`
+	Type        string     `gorm:"type:varchar(20);not null"`
+	Content     string     `gorm:"type:text;not null"`
+	MediaURL    *string    `gorm:"type:text"`
+	MediaID     *uuid.UUID `gorm:"type:uuid"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'pending'"`
+	ProviderID  *string    `gorm:"type:varchar(255);uniqueIndex:idx_whatsapp_messages_instance_provider,priority:2"`
+	Error       *string    `gorm:"type:text"`
+	SentAt      *int64
+	DeliveredAt *int64
+	ReadAt      *int64
+	FailedAt    *int64
+	RedactedAt  *int64
+	CreatedAt   int64 `gorm:"autoCreateTime;index:idx_whatsapp_messages_thread,priority:3;index:idx_whatsapp_messages_instance_created,priority:2;index:idx_whatsapp_messages_created"`
+	UpdatedAt   int64 `gorm:"autoUpdateTime"`
+
+	// Cópia da mídia recebida guardada pela API
+	MediaStatus    *string `gorm:"type:varchar(20)"`
+	MediaFileName  *string `gorm:"type:varchar(255)"`
+	MediaMimeType  *string `gorm:"type:varchar(255)"`
+	MediaSizeBytes *int64
+	MediaSHA256    *string `gorm:"column:media_sha256;type:varchar(64)"`
 }
 
 // TableName define o nome da tabela
@@ -33,31 +52,72 @@ func (GormMessage) TableName() string {
 // toDomain converte GormMessage para domain.Message
 func (g *GormMessage) toDomain() *domain.Message {
 	return &domain.Message{
-		ID:         g.ID,
-		InstanceID: g.InstanceID,
-		Phone:      g.Phone,
-		Type:       domain.MessageType(g.Type),
-		Content:    g.Content,
-		MediaURL:   g.MediaURL,
-		Status:     domain.MessageStatus(g.Status),
-		ProviderID: g.ProviderID,
-		Error:      g.Error,
-		CreatedAt:  timeFromUnix(g.CreatedAt),
-		UpdatedAt:  timeFromUnix(g.UpdatedAt),
+		ID:          g.ID,
+		TenantID:    g.TenantID,
+		InstanceID:  g.InstanceID,
+		Provider:    g.Provider,
+		Phone:       g.Phone,
+		Direction:   domain.MessageDirection(g.Direction),
+		Type:        domain.MessageType(g.Type),
+		Content:     g.Content,
+		MediaURL:    g.MediaURL,
+		MediaID:     g.MediaID,
+		Status:      domain.MessageStatus(g.Status),
+		ProviderID:  g.ProviderID,
+		Error:       g.Error,
+		SentAt:      timePtrFromUnix(g.SentAt),
+		DeliveredAt: timePtrFromUnix(g.DeliveredAt),
+		ReadAt:      timePtrFromUnix(g.ReadAt),
+		FailedAt:    timePtrFromUnix(g.FailedAt),
+		RedactedAt:  timePtrFromUnix(g.RedactedAt),
+		CreatedAt:   timeFromUnix(g.CreatedAt),
+		UpdatedAt:   timeFromUnix(g.UpdatedAt),
+		Media:       g.mediaToDomain(),
+	}
+}
+
+// mediaToDomain monta a situação da mídia recebida, quando há download
+func (g *GormMessage) mediaToDomain() *domain.MessageMedia {
+	if g.MediaStatus == nil {
+		return nil
+	}
+
+	media := &domain.MessageMedia{Status: domain.MediaDownloadStatus(*g.MediaStatus)}
+	if g.MediaFileName != nil {
+		media.FileName = *g.MediaFileName
+	}
+	if g.MediaMimeType != nil {
+		media.MimeType = *g.MediaMimeType
+	}
+	if g.MediaSizeBytes != nil {
+		media.SizeBytes = *g.MediaSizeBytes
+	}
+	if g.MediaSHA256 != nil {
+		media.SHA256 = *g.MediaSHA256
 	}
+	return media
 }
 
 // fromDomain converte domain.Message para GormMessage
 func (g *GormMessage) fromDomain(message *domain.Message) {
 	g.ID = message.ID
+	g.TenantID = message.TenantID
 	g.InstanceID = message.InstanceID
+	g.Provider = message.Provider
 	g.Phone = message.Phone
+	g.Direction = string(message.Direction)
 	g.Type = string(message.Type)
 	g.Content = message.Content
 	g.MediaURL = message.MediaURL
+	g.MediaID = message.MediaID
 	g.Status = string(message.Status)
 	g.ProviderID = message.ProviderID
 	g.Error = message.Error
+	g.SentAt = timePtrToUnix(message.SentAt)
+	g.DeliveredAt = timePtrToUnix(message.DeliveredAt)
+	g.ReadAt = timePtrToUnix(message.ReadAt)
+	g.FailedAt = timePtrToUnix(message.FailedAt)
+	g.RedactedAt = timePtrToUnix(message.RedactedAt)
 	g.CreatedAt = timeToUnix(message.CreatedAt)
 	g.UpdatedAt = timeToUnix(message.UpdatedAt)
 }
@@ -76,19 +136,61 @@ func NewGormMessageRepository(db *gorm.DB) *GormMessageRepository {
 func (r *GormMessageRepository) Save(ctx context.Context, message *domain.Message) error {
 	var gormMessage GormMessage
 	gormMessage.fromDomain(message)
-
-	if err := r.db.WithContext(ctx).Create(&gormMessage).Error; err != nil {
-		return fmt.Errorf("failed to save message: %w", err)
+	if gormMessage.Direction == "" {
+		gormMessage.Direction = string(domain.DirectionOutbound)
 	}
 
-	return nil
+	// A mensagem e a conversa são gravadas juntas para a lista de chats
+	// nunca divergir do histórico
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&gormMessage).Error; err != nil {
+			return fmt.Errorf("failed to save message: %w", err)
+		}
+
+		if err := upsertConversation(tx, message); err != nil {
+			return fmt.Errorf("failed to update conversation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SaveInbound grava a mensagem recebida. O índice único de instância e ID do
+// provider descarta reentregas concorrentes do mesmo webhook
+func (r *GormMessageRepository) SaveInbound(ctx context.Context, message *domain.Message) (bool, error) {
+	var gormMessage GormMessage
+	gormMessage.fromDomain(message)
+
+	saved := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "instance_id"}, {Name: "provider_id"}},
+			DoNothing: true,
+		}).Create(&gormMessage)
+		if result.Error != nil {
+			return fmt.Errorf("failed to save message: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		saved = true
+
+		if err := upsertConversation(tx, message); err != nil {
+			return fmt.Errorf("failed to update conversation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return saved, nil
 }
 
 // GetByID obtém uma mensagem por ID
 func (r *GormMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Message, error) {
 	var gormMessage GormMessage
 
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormMessage).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormMessage).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("message not found")
 		}
@@ -102,8 +204,9 @@ func (r *GormMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 func (r *GormMessageRepository) GetByInstanceID(ctx context.Context, instanceID string, limit, offset int) ([]*domain.Message, error) {
 	var gormMessages []GormMessage
 
-	query := r.db.WithContext(ctx).Where("instance_id = ?", instanceID).
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("instance_id = ?", instanceID).
 		Order("created_at DESC").
+		Order("id DESC").
 		Limit(limit).
 		Offset(offset)
 
@@ -119,12 +222,113 @@ func (r *GormMessageRepository) GetByInstanceID(ctx context.Context, instanceID
 	return messages, nil
 }
 
+// GetByProviderID obtém uma mensagem da instância pelo ID atribuído pelo provider
+func (r *GormMessageRepository) GetByProviderID(ctx context.Context, instanceID, providerID string) (*domain.Message, error) {
+	var gormMessage GormMessage
+
+	err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Where("instance_id = ? AND provider_id = ?", instanceID, providerID).
+		First(&gormMessage).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return gormMessage.toDomain(), nil
+}
+
+// GetThread obtém as mensagens trocadas com um telefone, enviadas e recebidas,
+// da mais recente para a mais antiga, a partir do cursor informado
+func (r *GormMessageRepository) GetThread(ctx context.Context, instanceID, phone string, limit int, before *domain.Cursor) ([]*domain.Message, error) {
+	return r.Search(ctx, domain.MessageFilter{InstanceID: instanceID, Phone: phone}, limit, before)
+}
+
+// Search lista as mensagens que atendem ao filtro usando paginação por
+// cursor sobre (created_at, id), estável mesmo com novas mensagens chegando
+func (r *GormMessageRepository) Search(ctx context.Context, filter domain.MessageFilter, limit int, before *domain.Cursor) ([]*domain.Message, error) {
+	var gormMessages []GormMessage
+
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)), filter)
+	if before != nil {
+		createdAt := timeToUnix(before.Time)
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormMessages).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	messages := make([]*domain.Message, len(gormMessages))
+	for i, gormMessage := range gormMessages {
+		messages[i] = gormMessage.toDomain()
+	}
+
+	return messages, nil
+}
+
+// Count conta as mensagens que atendem ao filtro
+func (r *GormMessageRepository) Count(ctx context.Context, filter domain.MessageFilter) (int64, error) {
+	var total int64
+
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	return total, nil
+}
+
+// applyFilter aplica os filtros informados à consulta. A busca textual usa o
+// índice full-text no Postgres e LIKE nos demais bancos
+func (r *GormMessageRepository) applyFilter(query *gorm.DB, filter domain.MessageFilter) *gorm.DB {
+	if filter.InstanceID != "" {
+		query = query.Where("instance_id = ?", filter.InstanceID)
+	}
+	if filter.Phone != "" {
+		query = query.Where("phone = ?", filter.Phone)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", string(filter.Type))
+	}
+	if filter.Direction != "" {
+		query = query.Where("direction = ?", string(filter.Direction))
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", timeToUnix(*filter.From))
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", timeToUnix(*filter.To))
+	}
+	if filter.ExcludeID != uuid.Nil {
+		query = query.Where("id <> ?", filter.ExcludeID)
+	}
+	if filter.Query != "" {
+		if r.db.Dialector.Name() == "postgres" {
+			// A expressão precisa ser idêntica à do índice idx_whatsapp_messages_content_fts
+			query = query.Where("to_tsvector('simple', content) @@ plainto_tsquery('simple', ?)", filter.Query)
+		} else {
+			query = query.Where(`LOWER(content) LIKE ? ESCAPE '\'`, likePattern(strings.ToLower(filter.Query)))
+		}
+	}
+	return query
+}
+
 // UpdateStatus atualiza o status de uma mensagem
 func (r *GormMessageRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.MessageStatus, providerID *string, errorMsg *string) error {
-	updates := map[string]interface{}{
-		"status":     string(status),
-		"updated_at": timeToUnix(timeNow()),
-	}
+	now := timeToUnix(timeNow())
+	updates := statusTimestamps(status, now)
+	updates["status"] = string(status)
+	updates["updated_at"] = now
 
 	if providerID != nil {
 		updates["provider_id"] = *providerID
@@ -134,9 +338,223 @@ func (r *GormMessageRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 		updates["error"] = *errorMsg
 	}
 
-	if err := r.db.WithContext(ctx).Model(&GormMessage{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)).Where("id = ?", id).Updates(updates).Error; err != nil {
 		return fmt.Errorf("failed to update message status: %w", err)
 	}
 
 	return nil
 }
+
+// Reassign transfere a mensagem para outra instância
+func (r *GormMessageRepository) Reassign(ctx context.Context, id uuid.UUID, instanceID, provider string) error {
+	updates := map[string]any{
+		"instance_id": instanceID,
+		"provider":    provider,
+		"updated_at":  timeToUnix(timeNow()),
+	}
+
+	if err := r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to reassign message: %w", err)
+	}
+
+	return nil
+}
+
+// AdvanceStatus avança o status notificado pelo provider. A condição sobre o
+// status atual torna a atualização segura para notificações concorrentes
+func (r *GormMessageRepository) AdvanceStatus(ctx context.Context, id uuid.UUID, status domain.MessageStatus, at time.Time) (bool, error) {
+	previous := status.PreviousStatuses()
+	if len(previous) == 0 {
+		return false, nil
+	}
+
+	updates := statusTimestamps(status, timeToUnix(at))
+	updates["status"] = string(status)
+	updates["updated_at"] = timeToUnix(timeNow())
+
+	result := r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)).
+		Where("id = ? AND status IN ?", id, previous).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to advance message status: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateMedia grava a situação da mídia recebida. Falhas e downloads pendentes
+// não têm arquivo: os dados do arquivo são limpos
+func (r *GormMessageRepository) UpdateMedia(ctx context.Context, id uuid.UUID, mediaID *uuid.UUID, media *domain.MessageMedia) error {
+	updates := map[string]interface{}{
+		"media_id":         mediaID,
+		"media_status":     string(media.Status),
+		"media_file_name":  nilIfEmpty(media.FileName),
+		"media_mime_type":  nilIfEmpty(media.MimeType),
+		"media_size_bytes": nil,
+		"media_sha256":     nilIfEmpty(media.SHA256),
+		"updated_at":       timeToUnix(timeNow()),
+	}
+	if mediaID != nil {
+		updates["media_size_bytes"] = media.SizeBytes
+	}
+
+	result := r.db.WithContext(ctx).Model(&GormMessage{}).Scopes(tenantScope(ctx)).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update message media: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("message not found")
+	}
+	return nil
+}
+
+// statusTimestamps preenche o momento de cada etapa implícita no status,
+// preservando o primeiro registro (ex: lida antes da confirmação de entrega
+// também registra a entrega)
+func statusTimestamps(status domain.MessageStatus, at int64) map[string]interface{} {
+	var columns []string
+	switch status {
+	case domain.StatusSent:
+		columns = []string{"sent_at"}
+	case domain.StatusDelivered:
+		columns = []string{"sent_at", "delivered_at"}
+	case domain.StatusRead:
+		columns = []string{"sent_at", "delivered_at", "read_at"}
+	case domain.StatusFailed:
+		columns = []string{"failed_at"}
+	}
+
+	updates := make(map[string]interface{}, len(columns)+2)
+	for _, column := range columns {
+		updates[column] = gorm.Expr("COALESCE("+column+", ?)", at)
+	}
+	return updates
+}
+
+// CountRetention conta as mensagens alcançadas pela política de retenção
+func (r *GormMessageRepository) CountRetention(ctx context.Context, target domain.RetentionTarget) (int64, error) {
+	var total int64
+
+	query := applyRetentionTarget(r.db.WithContext(ctx).Model(&GormMessage{}), target)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count retention messages: %w", err)
+	}
+
+	return total, nil
+}
+
+// RedactContent apaga o conteúdo e a mídia de um lote de mensagens. Cada lote
+// é uma transação curta; SKIP LOCKED evita esperar por linhas em uso pelo envio
+// e pelas notificações de status. A prévia das conversas cuja última mensagem
+// foi apagada também é limpa
+func (r *GormMessageRepository) RedactContent(ctx context.Context, target domain.RetentionTarget, limit int) (int64, error) {
+	target.WithContent = true
+
+	var redacted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids, err := r.claimRetentionBatch(tx, target, limit)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		now := timeToUnix(timeNow())
+		// O arquivo da mídia recebida sem a mensagem apontando para ele é descartado pelo MediaFetcher
+		result := tx.Model(&GormMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"content":          "",
+			"media_url":        nil,
+			"media_id":         nil,
+			"media_status":     nil,
+			"media_file_name":  nil,
+			"media_mime_type":  nil,
+			"media_size_bytes": nil,
+			"media_sha256":     nil,
+			"redacted_at":      now,
+			"updated_at":       now,
+		}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"content":   "",
+			"media_url": nil,
+			"media_id":  nil,
+			// O arquivo da mídia recebida é descartado pelo MediaFetcher
+			"media_status":     nil,
+			"media_file_name":  nil,
+			"media_mime_type":  nil,
+			"media_size_bytes": nil,
+			"media_sha256":     nil,
+			"redacted_at":      now,
+			"updated_at":       now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		redacted = result.RowsAffected
+
+		return clearConversationPreviews(tx, ids)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to redact messages: %w", err)
+	}
+
+	return redacted, nil
+}
+
+// DeleteMessages remove um lote de mensagens em uma transação curta, limpando
+// a prévia das conversas cuja última mensagem foi removida
+func (r *GormMessageRepository) DeleteMessages(ctx context.Context, target domain.RetentionTarget, limit int) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids, err := r.claimRetentionBatch(tx, target, limit)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		result := tx.Where("id IN ?", ids).Delete(&GormMessage{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+
+		return clearConversationPreviews(tx, ids)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete messages: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// claimRetentionBatch bloqueia as próximas mensagens do alvo, das mais antigas
+// para as mais recentes
+func (r *GormMessageRepository) claimRetentionBatch(tx *gorm.DB, target domain.RetentionTarget, limit int) ([]uuid.UUID, error) {
+	query := applyRetentionTarget(tx.Model(&GormMessage{}), target).
+		Order("created_at").
+		Limit(limit)
+	if r.db.Dialector.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	}
+
+	var ids []uuid.UUID
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// applyRetentionTarget restringe a consulta às mensagens alcançadas pela política
+func applyRetentionTarget(query *gorm.DB, target domain.RetentionTarget) *gorm.DB {
+	query = query.Where("tenant_id = ? AND created_at < ?", target.TenantID, timeToUnix(target.Before))
+	if len(target.InstanceIDs) > 0 {
+		query = query.Where("instance_id IN ?", target.InstanceIDs)
+	}
+	if len(target.ExcludeInstanceIDs) > 0 {
+		query = query.Where("instance_id NOT IN ?", target.ExcludeInstanceIDs)
+	}
+	if target.NotBefore != nil {
+		query = query.Where("created_at >= ?", timeToUnix(*target.NotBefore))
+	}
+	if target.WithContent {
+		query = query.Where("redacted_at IS NULL")
+	}
+	return query
+}
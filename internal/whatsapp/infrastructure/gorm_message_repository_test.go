@@ -0,0 +1,187 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+)
+
+// stubQuery é uma consulta recebida pelo banco de teste
+type stubQuery struct {
+	SQL  string
+	Args []interface{}
+}
+
+// stubDB registra as consultas recebidas e responde cada uma com o próximo
+// resultado configurado. As consultas dos repositórios usam recursos do
+// Postgres, então os testes verificam o SQL gerado e a leitura do resultado
+type stubDB struct {
+	mu      sync.Mutex
+	queries []stubQuery
+	results []*stubRows
+}
+
+// newStubDB cria uma conexão do GORM com o dialeto do Postgres sobre o banco de teste
+func newStubDB(t *testing.T) (*gorm.DB, *stubDB) {
+	stub := &stubDB{}
+	sqlDB := sql.OpenDB(stub)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+	return db, stub
+}
+
+// Return configura o resultado da próxima consulta
+func (s *stubDB) Return(columns []string, rows ...[]driver.Value) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, &stubRows{columns: columns, rows: rows})
+}
+
+// Queries retorna as consultas recebidas, na ordem
+func (s *stubDB) Queries() []stubQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]stubQuery(nil), s.queries...)
+}
+
+func (s *stubDB) Connect(context.Context) (driver.Conn, error) { return stubConn{s}, nil }
+func (s *stubDB) Driver() driver.Driver                        { return nil }
+
+// query registra a consulta e retorna o próximo resultado, vazio se não houver
+func (s *stubDB) query(query string, args []driver.NamedValue) *stubRows {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	s.queries = append(s.queries, stubQuery{SQL: query, Args: values})
+
+	if len(s.results) == 0 {
+		return &stubRows{}
+	}
+	rows := s.results[0]
+	s.results = s.results[1:]
+	return rows
+}
+
+// stubConn encaminha as consultas ao stubDB
+type stubConn struct{ db *stubDB }
+
+func (c stubConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c stubConn) Close() error                        { return nil }
+func (c stubConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c stubConn) Commit() error                       { return nil }
+func (c stubConn) Rollback() error                     { return nil }
+
+func (c stubConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.db.query(query, args), nil
+}
+
+func (c stubConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.query(query, args)
+	return driver.RowsAffected(0), nil
+}
+
+// stubRows é o resultado configurado de uma consulta
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string { return r.columns }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestGormMessageRepository_SearchPaginatesByCursor(t *testing.T) {
+	db, stub := newStubDB(t)
+	repo := infrastructure.NewGormMessageRepository(db)
+	tenantID := uuid.New()
+	ctx := tenantDomain.NewContext(context.Background(), tenantID)
+
+	createdAt := time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC)
+	newer, older := uuid.New(), uuid.New()
+	stub.Return([]string{"id", "tenant_id", "instance_id", "phone", "content", "created_at"},
+		[]driver.Value{newer.String(), tenantID.String(), "instance-1", "5511999999999", "segunda", createdAt.Unix()},
+		[]driver.Value{older.String(), tenantID.String(), "instance-1", "5511999999999", "primeira", createdAt.Unix()},
+	)
+
+	messages, err := repo.Search(ctx, domain.MessageFilter{Phone: "5511999999999"}, 3, nil)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, newer, messages[0].ID)
+	assert.Equal(t, createdAt, messages[0].CreatedAt.UTC())
+
+	// A próxima página continua do cursor. Mensagens criadas no mesmo segundo
+	// são desempatadas pelo id, então nenhuma é repetida ou pulada
+	cursor, err := domain.DecodeCursor(domain.Cursor{Time: messages[1].CreatedAt, ID: messages[1].ID}.Encode())
+	require.NoError(t, err)
+	_, err = repo.Search(ctx, domain.MessageFilter{Phone: "5511999999999"}, 3, cursor)
+	require.NoError(t, err)
+
+	queries := stub.Queries()
+	require.Len(t, queries, 2)
+
+	first := queries[0]
+	assert.Contains(t, first.SQL, `phone = $1 AND "whatsapp_messages"."tenant_id" = $2`)
+	assert.NotContains(t, first.SQL, "created_at <")
+	assert.Contains(t, first.SQL, "ORDER BY created_at DESC,id DESC LIMIT $3")
+	assert.Equal(t, []interface{}{"5511999999999", tenantID.String(), int64(3)}, first.Args)
+
+	next := queries[1]
+	assert.Contains(t, next.SQL, "(created_at < $2 OR (created_at = $3 AND id < $4))")
+	assert.Contains(t, next.SQL, `"whatsapp_messages"."tenant_id" = $5 ORDER BY created_at DESC,id DESC`)
+	assert.Equal(t, []interface{}{"5511999999999", createdAt.Unix(), createdAt.Unix(), older.String(), tenantID.String(), int64(3)}, next.Args)
+}
+
+// likeDialector se apresenta como outro banco para que a busca textual use LIKE
+type likeDialector struct{ gorm.Dialector }
+
+func (likeDialector) Name() string { return "sqlite" }
+
+func TestGormMessageRepository_SearchEscapesLikeWildcards(t *testing.T) {
+	postgresDB, stub := newStubDB(t)
+	db, err := gorm.Open(likeDialector{postgresDB.Dialector}, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+	repo := infrastructure.NewGormMessageRepository(db)
+
+	_, err = repo.Count(context.Background(), domain.MessageFilter{Query: `100% OFF_hoje\`})
+	require.NoError(t, err)
+
+	queries := stub.Queries()
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].SQL, `LOWER(content) LIKE $1 ESCAPE '\'`)
+	assert.Equal(t, []interface{}{`%100\% off\_hoje\\%`}, queries[0].Args)
+}
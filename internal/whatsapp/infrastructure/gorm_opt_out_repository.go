@@ -0,0 +1,168 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormOptOut representa a entidade OptOut para GORM. Entradas que valem para
+// todas as instâncias são gravadas com o UUID nulo, o que permite o índice único
+type GormOptOut struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_whatsapp_opt_outs_scope_phone,priority:1"`
+	InstanceID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_whatsapp_opt_outs_scope_phone,priority:2"`
+	Phone      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_whatsapp_opt_outs_scope_phone,priority:3;index"`
+	Source     string    `gorm:"type:varchar(20);not null"`
+	Keyword    string    `gorm:"type:varchar(50)"`
+	Note       string    `gorm:"type:text"`
+	CreatedAt  int64     `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormOptOut) TableName() string {
+	return "whatsapp_opt_outs"
+}
+
+// toDomain converte GormOptOut para domain.OptOut
+func (g *GormOptOut) toDomain() *domain.OptOut {
+	var instanceID *uuid.UUID
+	if g.InstanceID != uuid.Nil {
+		id := g.InstanceID
+		instanceID = &id
+	}
+
+	return &domain.OptOut{
+		ID:         g.ID,
+		TenantID:   g.TenantID,
+		InstanceID: instanceID,
+		Phone:      g.Phone,
+		Source:     domain.OptOutSource(g.Source),
+		Keyword:    g.Keyword,
+		Note:       g.Note,
+		CreatedAt:  timeFromUnix(g.CreatedAt),
+	}
+}
+
+// instanceScope converte o escopo do domínio (nil = todas as instâncias) para
+// a coluna instance_id
+func instanceScope(instanceID *uuid.UUID) uuid.UUID {
+	if instanceID == nil {
+		return uuid.Nil
+	}
+	return *instanceID
+}
+
+// GormOptOutRepository implementa OptOutRepository usando GORM
+type GormOptOutRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOptOutRepository cria um novo repositório da lista de opt-out
+func NewGormOptOutRepository(db *gorm.DB) *GormOptOutRepository {
+	return &GormOptOutRepository{db: db}
+}
+
+// Save inclui o telefone na lista, ignorando entradas repetidas do mesmo escopo
+func (r *GormOptOutRepository) Save(ctx context.Context, optOut *domain.OptOut) (bool, error) {
+	gormOptOut := GormOptOut{
+		ID:         optOut.ID,
+		TenantID:   optOut.TenantID,
+		InstanceID: instanceScope(optOut.InstanceID),
+		Phone:      optOut.Phone,
+		Source:     string(optOut.Source),
+		Keyword:    optOut.Keyword,
+		Note:       optOut.Note,
+		CreatedAt:  timeToUnix(optOut.CreatedAt),
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&gormOptOut)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save opt-out: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID obtém uma entrada por ID
+func (r *GormOptOutRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.OptOut, error) {
+	var gormOptOut GormOptOut
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormOptOut).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("opt-out not found")
+		}
+		return nil, fmt.Errorf("failed to get opt-out: %w", err)
+	}
+
+	return gormOptOut.toDomain(), nil
+}
+
+// Find busca uma entrada do telefone global do tenant ou de uma das instâncias
+func (r *GormOptOutRepository) Find(ctx context.Context, tenantID uuid.UUID, phone string, instanceIDs []uuid.UUID) (*domain.OptOut, error) {
+	var gormOptOuts []GormOptOut
+
+	scopes := append([]uuid.UUID{uuid.Nil}, instanceIDs...)
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND phone = ? AND instance_id IN ?", tenantID, phone, scopes).
+		Order("created_at").
+		Limit(1).
+		Find(&gormOptOuts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find opt-out: %w", err)
+	}
+	if len(gormOptOuts) == 0 {
+		return nil, nil
+	}
+
+	return gormOptOuts[0].toDomain(), nil
+}
+
+// List lista as entradas do escopo da mais recente para a mais antiga
+func (r *GormOptOutRepository) List(ctx context.Context, filter domain.OptOutFilter, limit int, before *domain.Cursor) ([]*domain.OptOut, error) {
+	var gormOptOuts []GormOptOut
+
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("instance_id = ?", instanceScope(filter.InstanceID))
+	if filter.Phone != "" {
+		query = query.Where("phone = ?", filter.Phone)
+	}
+	if before != nil {
+		createdAt := timeToUnix(before.Time)
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormOptOuts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list opt-outs: %w", err)
+	}
+
+	optOuts := make([]*domain.OptOut, len(gormOptOuts))
+	for i, gormOptOut := range gormOptOuts {
+		optOuts[i] = gormOptOut.toDomain()
+	}
+
+	return optOuts, nil
+}
+
+// Delete remove uma entrada, voltando a permitir o envio
+func (r *GormOptOutRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormOptOut{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete opt-out: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("opt-out not found")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormRetentionPolicy representa a entidade RetentionPolicy para GORM. A
+// política do tenant é gravada com o UUID nulo, o que permite o índice único
+type GormRetentionPolicy struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_whatsapp_retention_policies_scope,priority:1"`
+	InstanceID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_whatsapp_retention_policies_scope,priority:2"`
+	ContentDays  int       `gorm:"not null;default:0"`
+	MetadataDays int       `gorm:"not null;default:0"`
+	CreatedAt    int64     `gorm:"autoCreateTime"`
+	UpdatedAt    int64     `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormRetentionPolicy) TableName() string {
+	return "whatsapp_retention_policies"
+}
+
+// toDomain converte GormRetentionPolicy para domain.RetentionPolicy
+func (g *GormRetentionPolicy) toDomain() *domain.RetentionPolicy {
+	var instanceID *uuid.UUID
+	if g.InstanceID != uuid.Nil {
+		id := g.InstanceID
+		instanceID = &id
+	}
+
+	return &domain.RetentionPolicy{
+		ID:           g.ID,
+		TenantID:     g.TenantID,
+		InstanceID:   instanceID,
+		ContentDays:  g.ContentDays,
+		MetadataDays: g.MetadataDays,
+		CreatedAt:    timeFromUnix(g.CreatedAt),
+		UpdatedAt:    timeFromUnix(g.UpdatedAt),
+	}
+}
+
+// GormRetentionPolicyRepository implementa RetentionPolicyRepository usando GORM
+type GormRetentionPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRetentionPolicyRepository cria um novo repositório de políticas de retenção
+func NewGormRetentionPolicyRepository(db *gorm.DB) *GormRetentionPolicyRepository {
+	return &GormRetentionPolicyRepository{db: db}
+}
+
+// Save cria a política do escopo ou substitui os prazos da existente,
+// preenchendo o ID e a data de criação da política gravada
+func (r *GormRetentionPolicyRepository) Save(ctx context.Context, policy *domain.RetentionPolicy) error {
+	gormPolicy := GormRetentionPolicy{
+		ID:           policy.ID,
+		TenantID:     policy.TenantID,
+		InstanceID:   instanceScope(policy.InstanceID),
+		ContentDays:  policy.ContentDays,
+		MetadataDays: policy.MetadataDays,
+		CreatedAt:    timeToUnix(policy.CreatedAt),
+		UpdatedAt:    timeToUnix(policy.UpdatedAt),
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "instance_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"content_days", "metadata_days", "updated_at"}),
+		}).Create(&gormPolicy).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("tenant_id = ? AND instance_id = ?", gormPolicy.TenantID, gormPolicy.InstanceID).
+			First(&gormPolicy).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save retention policy: %w", err)
+	}
+
+	*policy = *gormPolicy.toDomain()
+	return nil
+}
+
+// GetByID obtém uma política por ID
+func (r *GormRetentionPolicyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RetentionPolicy, error) {
+	var gormPolicy GormRetentionPolicy
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormPolicy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("retention policy not found")
+		}
+		return nil, fmt.Errorf("failed to get retention policy: %w", err)
+	}
+
+	return gormPolicy.toDomain(), nil
+}
+
+// List lista as políticas do tenant do contexto ou, para o worker, de todos
+func (r *GormRetentionPolicyRepository) List(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	var gormPolicies []GormRetentionPolicy
+
+	err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).
+		Order("tenant_id").
+		Order("created_at").
+		Find(&gormPolicies).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention policies: %w", err)
+	}
+
+	policies := make([]*domain.RetentionPolicy, len(gormPolicies))
+	for i, gormPolicy := range gormPolicies {
+		policies[i] = gormPolicy.toDomain()
+	}
+
+	return policies, nil
+}
+
+// Delete remove uma política
+func (r *GormRetentionPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormRetentionPolicy{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete retention policy: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("retention policy not found")
+	}
+	return nil
+}
@@ -0,0 +1,407 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/your-org/boilerplate-go/internal/encryption"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GormWebhookSubscription representa a entidade WebhookSubscription para GORM
+type GormWebhookSubscription struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	InstanceID  *uuid.UUID `gorm:"type:uuid;index"`
+	URL         string     `gorm:"type:text;not null"`
+	Events      string     `gorm:"type:text;not null"` // nomes dos eventos separados por vírgula
+	Secret      string     `gorm:"type:text;not null"` // criptografado quando há chave configurada
+	Description string     `gorm:"type:text"`
+	Active      bool       `gorm:"not null;default:true"`
+	CreatedAt   int64      `gorm:"autoCreateTime"`
+	UpdatedAt   int64      `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormWebhookSubscription) TableName() string {
+	return "whatsapp_webhook_subscriptions"
+}
+
+// GormWebhookDelivery representa a entidade WebhookDelivery para GORM
+type GormWebhookDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index:idx_whatsapp_webhook_deliveries_subscription,priority:1"`
+	EventID        string    `gorm:"type:varchar(64);not null"`
+	EventName      string    `gorm:"type:varchar(100);not null"`
+	Payload        string    `gorm:"type:jsonb;not null"`
+	Status         string    `gorm:"type:varchar(20);not null;default:'pending';index:idx_whatsapp_webhook_deliveries_due,priority:1"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  *int64    `gorm:"index:idx_whatsapp_webhook_deliveries_due,priority:2"`
+	LastAttemptAt  *int64
+	ResponseCode   *int
+	LatencyMs      *int64
+	LastError      *string    `gorm:"type:text"`
+	RedeliveryOf   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      int64      `gorm:"autoCreateTime;index:idx_whatsapp_webhook_deliveries_subscription,priority:2"`
+	UpdatedAt      int64      `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela
+func (GormWebhookDelivery) TableName() string {
+	return "whatsapp_webhook_deliveries"
+}
+
+// toDomain converte GormWebhookDelivery para domain.WebhookDelivery
+func (g *GormWebhookDelivery) toDomain() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             g.ID,
+		SubscriptionID: g.SubscriptionID,
+		EventID:        g.EventID,
+		EventName:      g.EventName,
+		Payload:        json.RawMessage(g.Payload),
+		Status:         domain.WebhookDeliveryStatus(g.Status),
+		Attempts:       g.Attempts,
+		NextAttemptAt:  timePtrFromUnix(g.NextAttemptAt),
+		LastAttemptAt:  timePtrFromUnix(g.LastAttemptAt),
+		ResponseCode:   g.ResponseCode,
+		LatencyMs:      g.LatencyMs,
+		LastError:      g.LastError,
+		RedeliveryOf:   g.RedeliveryOf,
+		CreatedAt:      timeFromUnix(g.CreatedAt),
+		UpdatedAt:      timeFromUnix(g.UpdatedAt),
+	}
+}
+
+// fromDomain converte domain.WebhookDelivery para GormWebhookDelivery
+func (g *GormWebhookDelivery) fromDomain(delivery *domain.WebhookDelivery) {
+	g.ID = delivery.ID
+	g.SubscriptionID = delivery.SubscriptionID
+	g.EventID = delivery.EventID
+	g.EventName = delivery.EventName
+	g.Payload = string(delivery.Payload)
+	g.Status = string(delivery.Status)
+	g.Attempts = delivery.Attempts
+	g.NextAttemptAt = timePtrToUnix(delivery.NextAttemptAt)
+	g.LastAttemptAt = timePtrToUnix(delivery.LastAttemptAt)
+	g.ResponseCode = delivery.ResponseCode
+	g.LatencyMs = delivery.LatencyMs
+	g.LastError = delivery.LastError
+	g.RedeliveryOf = delivery.RedeliveryOf
+	g.CreatedAt = timeToUnix(delivery.CreatedAt)
+	g.UpdatedAt = timeToUnix(delivery.UpdatedAt)
+}
+
+// GormWebhookSubscriptionRepository implementa WebhookSubscriptionRepository usando GORM
+type GormWebhookSubscriptionRepository struct {
+	db     *gorm.DB
+	cipher *encryption.Cipher
+}
+
+// NewGormWebhookSubscriptionRepository cria um novo repositório de assinaturas de webhook
+func NewGormWebhookSubscriptionRepository(db *gorm.DB, cipher *encryption.Cipher) *GormWebhookSubscriptionRepository {
+	return &GormWebhookSubscriptionRepository{db: db, cipher: cipher}
+}
+
+// toGorm converte a assinatura para GORM, criptografando o segredo
+func (r *GormWebhookSubscriptionRepository) toGorm(subscription *domain.WebhookSubscription) (*GormWebhookSubscription, error) {
+	secret, err := r.cipher.Encrypt(subscription.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	return &GormWebhookSubscription{
+		ID:          subscription.ID,
+		TenantID:    subscription.TenantID,
+		InstanceID:  subscription.InstanceID,
+		URL:         subscription.URL,
+		Events:      strings.Join(subscription.Events, ","),
+		Secret:      secret,
+		Description: subscription.Description,
+		Active:      subscription.Active,
+		CreatedAt:   timeToUnix(subscription.CreatedAt),
+		UpdatedAt:   timeToUnix(subscription.UpdatedAt),
+	}, nil
+}
+
+// toDomain converte a assinatura para o domínio, descriptografando o segredo
+func (r *GormWebhookSubscriptionRepository) toDomain(g *GormWebhookSubscription) (*domain.WebhookSubscription, error) {
+	secret, err := r.cipher.Decrypt(g.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	var events []string
+	if g.Events != "" {
+		events = strings.Split(g.Events, ",")
+	}
+
+	return &domain.WebhookSubscription{
+		ID:          g.ID,
+		TenantID:    g.TenantID,
+		InstanceID:  g.InstanceID,
+		URL:         g.URL,
+		Events:      events,
+		Secret:      secret,
+		Description: g.Description,
+		Active:      g.Active,
+		CreatedAt:   timeFromUnix(g.CreatedAt),
+		UpdatedAt:   timeFromUnix(g.UpdatedAt),
+	}, nil
+}
+
+// toDomainList converte uma lista de assinaturas para o domínio
+func (r *GormWebhookSubscriptionRepository) toDomainList(gormSubscriptions []GormWebhookSubscription) ([]*domain.WebhookSubscription, error) {
+	subscriptions := make([]*domain.WebhookSubscription, len(gormSubscriptions))
+	for i := range gormSubscriptions {
+		subscription, err := r.toDomain(&gormSubscriptions[i])
+		if err != nil {
+			return nil, err
+		}
+		subscriptions[i] = subscription
+	}
+	return subscriptions, nil
+}
+
+// Save salva uma assinatura
+func (r *GormWebhookSubscriptionRepository) Save(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	gormSubscription, err := r.toGorm(subscription)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(gormSubscription).Error; err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtém uma assinatura por ID
+func (r *GormWebhookSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var gormSubscription GormWebhookSubscription
+
+	if err := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&gormSubscription).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook subscription not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return r.toDomain(&gormSubscription)
+}
+
+// ReEncrypt regrava com a chave ativa os segredos ainda em texto puro ou
+// cifrados com chaves antigas, retornando quantas assinaturas foram atualizadas
+func (r *GormWebhookSubscriptionRepository) ReEncrypt(ctx context.Context) (int, error) {
+	var gormSubscriptions []GormWebhookSubscription
+	if err := r.db.WithContext(ctx).Find(&gormSubscriptions).Error; err != nil {
+		return 0, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	updated := 0
+	for i := range gormSubscriptions {
+		gormSubscription := &gormSubscriptions[i]
+		if !r.cipher.NeedsRotation(gormSubscription.Secret) {
+			continue
+		}
+
+		secret, err := r.cipher.Decrypt(gormSubscription.Secret)
+		if err != nil {
+			return updated, fmt.Errorf("webhook subscription %s: %w", gormSubscription.ID, err)
+		}
+		sealed, err := r.cipher.Encrypt(secret)
+		if err != nil {
+			return updated, fmt.Errorf("webhook subscription %s: %w", gormSubscription.ID, err)
+		}
+
+		err = r.db.WithContext(ctx).Model(&GormWebhookSubscription{}).
+			Where("id = ?", gormSubscription.ID).
+			Update("secret", sealed).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to re-encrypt webhook subscription %s: %w", gormSubscription.ID, err)
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// List lista as assinaturas da instância ou, com instanceID nil, todas elas
+func (r *GormWebhookSubscriptionRepository) List(ctx context.Context, instanceID *uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	var gormSubscriptions []GormWebhookSubscription
+
+	query := r.db.WithContext(ctx).Scopes(tenantScope(ctx)).Order("created_at")
+	if instanceID != nil {
+		query = query.Where("instance_id = ?", *instanceID)
+	}
+
+	if err := query.Find(&gormSubscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return r.toDomainList(gormSubscriptions)
+}
+
+// ListActive lista as assinaturas ativas da instância e as globais do tenant
+func (r *GormWebhookSubscriptionRepository) ListActive(ctx context.Context, tenantID, instanceID uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	var gormSubscriptions []GormWebhookSubscription
+
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND active = ? AND (instance_id IS NULL OR instance_id = ?)", tenantID, true, instanceID).
+		Find(&gormSubscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active webhook subscriptions: %w", err)
+	}
+
+	return r.toDomainList(gormSubscriptions)
+}
+
+// Update atualiza uma assinatura
+func (r *GormWebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	gormSubscription, err := r.toGorm(subscription)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Save(gormSubscription).Error; err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// Delete remove uma assinatura e o histórico de entregas
+func (r *GormWebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&GormWebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+
+		result := tx.Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&GormWebhookSubscription{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("webhook subscription not found")
+		}
+		return nil
+	})
+}
+
+// GormWebhookDeliveryRepository implementa WebhookDeliveryRepository usando GORM
+type GormWebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookDeliveryRepository cria um novo repositório de entregas de webhook
+func NewGormWebhookDeliveryRepository(db *gorm.DB) *GormWebhookDeliveryRepository {
+	return &GormWebhookDeliveryRepository{db: db}
+}
+
+// Save salva uma entrega
+func (r *GormWebhookDeliveryRepository) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	var gormDelivery GormWebhookDelivery
+	gormDelivery.fromDomain(delivery)
+
+	if err := r.db.WithContext(ctx).Create(&gormDelivery).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtém uma entrega por ID
+func (r *GormWebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var gormDelivery GormWebhookDelivery
+
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&gormDelivery).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook delivery not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return gormDelivery.toDomain(), nil
+}
+
+// ListBySubscription lista as entregas da assinatura, da mais recente para a mais antiga
+func (r *GormWebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, status domain.WebhookDeliveryStatus, limit int, before *domain.Cursor) ([]*domain.WebhookDelivery, error) {
+	var gormDeliveries []GormWebhookDelivery
+
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	if before != nil {
+		createdAt := timeToUnix(before.Time)
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", createdAt, createdAt, before.ID)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&gormDeliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(gormDeliveries))
+	for i, gormDelivery := range gormDeliveries {
+		deliveries[i] = gormDelivery.toDomain()
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDue reserva as entregas pendentes vencidas. SKIP LOCKED permite que
+// várias réplicas processem a fila sem enviar a mesma entrega duas vezes
+func (r *GormWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	var gormDeliveries []GormWebhookDelivery
+
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE whatsapp_webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM whatsapp_webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		timeToUnix(now.Add(lease)), string(domain.DeliveryPending), timeToUnix(now), limit,
+	).Scan(&gormDeliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(gormDeliveries))
+	for i, gormDelivery := range gormDeliveries {
+		deliveries[i] = gormDelivery.toDomain()
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt grava o resultado de uma tentativa de entrega
+func (r *GormWebhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	err := r.db.WithContext(ctx).Model(&GormWebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          string(delivery.Status),
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": timePtrToUnix(delivery.NextAttemptAt),
+			"last_attempt_at": timePtrToUnix(delivery.LastAttemptAt),
+			"response_code":   delivery.ResponseCode,
+			"latency_ms":      delivery.LatencyMs,
+			"last_error":      delivery.LastError,
+			"updated_at":      timeToUnix(timeNow()),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/config"
+	"github.com/your-org/boilerplate-go/internal/encryption"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+)
+
+func TestGormWebhookSubscriptionRepository_ReEncrypt(t *testing.T) {
+	oldKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	newKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32)))
+	previous, err := encryption.NewCipher(config.EncryptionConfig{ActiveKeyID: "k1", Keys: map[string]string{"k1": oldKey}})
+	require.NoError(t, err)
+	cipher, err := encryption.NewCipher(config.EncryptionConfig{ActiveKeyID: "k2", Keys: map[string]string{"k1": oldKey, "k2": newKey}})
+	require.NoError(t, err)
+
+	rotated, err := previous.Encrypt("old-key-secret")
+	require.NoError(t, err)
+	current, err := cipher.Encrypt("current-secret")
+	require.NoError(t, err)
+
+	db, stub := newStubDB(t)
+	repo := infrastructure.NewGormWebhookSubscriptionRepository(db, cipher)
+
+	plaintextID, rotatedID := uuid.New(), uuid.New()
+	stub.Return([]string{"id", "secret"},
+		[]driver.Value{plaintextID.String(), "plaintext-secret"},
+		[]driver.Value{rotatedID.String(), rotated},
+		// Segredos já cifrados com a chave ativa não são regravados
+		[]driver.Value{uuid.NewString(), current},
+	)
+
+	updated, err := repo.ReEncrypt(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	queries := stub.Queries()
+	require.Len(t, queries, 3)
+	// A primeira consulta lê as assinaturas; as seguintes regravam os segredos
+	for i, want := range []struct {
+		id     uuid.UUID
+		secret string
+	}{{plaintextID, "plaintext-secret"}, {rotatedID, "old-key-secret"}} {
+		query := queries[i+1]
+		assert.Contains(t, query.SQL, `UPDATE "whatsapp_webhook_subscriptions" SET "secret"=$1`)
+		require.Len(t, query.Args, 3)
+		assert.Equal(t, want.id.String(), query.Args[2])
+
+		sealed := query.Args[0].(string)
+		assert.False(t, cipher.NeedsRotation(sealed))
+		secret, err := cipher.Decrypt(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, want.secret, secret)
+	}
+}
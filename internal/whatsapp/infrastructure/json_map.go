@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// JSONMap persiste um mapa como documento JSON. No Postgres a coluna é jsonb;
+// nos demais bancos (ex: SQLite) o documento é armazenado como texto
+type JSONMap map[string]any
+
+// Value serializa o mapa para gravação no banco
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json map: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan desserializa o valor lido do banco
+func (m *JSONMap) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type %T for json map", value)
+	}
+
+	if len(data) == 0 {
+		*m = nil
+		return nil
+	}
+
+	result := make(map[string]any)
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal json map: %w", err)
+	}
+	*m = result
+	return nil
+}
+
+// GormDataType define o tipo genérico usado pelo GORM
+func (JSONMap) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType define o tipo da coluna de acordo com o banco em uso
+func (JSONMap) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "JSONB"
+	case "mysql":
+		return "JSON"
+	default:
+		return "TEXT"
+	}
+}
@@ -0,0 +1,56 @@
+package infrastructure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+)
+
+func TestJSONMap_ValueAndScan(t *testing.T) {
+	config := infrastructure.JSONMap{
+		"base_url": "https://api.z-api.io",
+		"timeout":  "10s",
+		"retries":  3.0,
+		"headers":  map[string]any{"x-env": "prod"},
+	}
+
+	value, err := config.Value()
+	require.NoError(t, err)
+
+	// Dependendo do driver, o documento é lido como texto ou como bytes
+	for _, stored := range []any{value, []byte(value.(string))} {
+		var scanned infrastructure.JSONMap
+		require.NoError(t, scanned.Scan(stored))
+		assert.Equal(t, config, scanned)
+	}
+
+	// Mapas nulos são gravados como documento vazio
+	value, err = infrastructure.JSONMap(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", value)
+
+	_, err = infrastructure.JSONMap{"invalid": func() {}}.Value()
+	assert.Error(t, err)
+}
+
+func TestJSONMap_ScanEmptyAndInvalidValues(t *testing.T) {
+	for _, empty := range []any{nil, "", []byte{}} {
+		scanned := infrastructure.JSONMap{"stale": true}
+		require.NoError(t, scanned.Scan(empty))
+		assert.Nil(t, scanned)
+	}
+
+	var scanned infrastructure.JSONMap
+	assert.Error(t, scanned.Scan("not json"))
+	assert.Error(t, scanned.Scan(`["a", "b"]`))
+	assert.Error(t, scanned.Scan(42))
+}
+
+func TestJSONMap_GormDBDataType(t *testing.T) {
+	db, _ := newStubDB(t)
+	assert.Equal(t, "JSONB", infrastructure.JSONMap{}.GormDBDataType(db, nil))
+	assert.Equal(t, "json", infrastructure.JSONMap{}.GormDataType())
+}
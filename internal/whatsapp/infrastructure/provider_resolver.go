@@ -0,0 +1,154 @@
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+)
+
+// cachedProvider guarda um cliente criado para uma instância junto com a
+// impressão digital da configuração usada para criá-lo
+type cachedProvider struct {
+	provider    domain.WhatsAppProvider
+	fingerprint string
+}
+
+// CachedProviderResolver implementa InstanceProviderResolver criando um cliente
+// por instância através da ProviderFactory e mantendo-o em cache
+type CachedProviderResolver struct {
+	factory  domain.ProviderFactory
+	registry domain.ProviderRegistry
+	defaults domain.ProviderDefaults
+	breakers *circuitbreaker.Registry
+	logger   zerolog.Logger
+	cache    map[uuid.UUID]cachedProvider
+	mu       sync.Mutex
+}
+
+// NewCachedProviderResolver cria um novo resolver de providers por instância
+func NewCachedProviderResolver(
+	factory domain.ProviderFactory,
+	registry domain.ProviderRegistry,
+	defaults domain.ProviderDefaults,
+	breakers *circuitbreaker.Registry,
+	logger zerolog.Logger,
+) *CachedProviderResolver {
+	return &CachedProviderResolver{
+		factory:  factory,
+		registry: registry,
+		defaults: defaults,
+		breakers: breakers,
+		logger:   logger.With().Str("component", "provider_resolver").Logger(),
+		cache:    make(map[uuid.UUID]cachedProvider),
+	}
+}
+
+// Resolve retorna o provider da instância, recriando o cliente quando a configuração muda
+func (r *CachedProviderResolver) Resolve(instance *domain.Instance) (domain.WhatsAppProvider, error) {
+	if !r.isFactorySupported(instance.Provider) {
+		// Tipos sem creator registrado continuam usando o provider compartilhado
+		provider, exists := r.registry.Get(instance.Provider)
+		if !exists {
+			return nil, fmt.Errorf("provider %s not found", instance.Provider)
+		}
+		return provider, nil
+	}
+
+	config := r.effectiveConfig(instance)
+	fingerprint, err := configFingerprint(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for instance %s: %w", instance.ID, err)
+	}
+
+	// Instâncias ainda não persistidas (ex: durante a criação) não entram no cache
+	if instance.ID == uuid.Nil {
+		return r.create(instance.Provider, config)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.cache[instance.ID]; ok && cached.fingerprint == fingerprint {
+		return cached.provider, nil
+	}
+
+	provider, err := r.create(instance.Provider, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, replaced := r.cache[instance.ID]; replaced {
+		r.logger.Info().
+			Str("instance_id", instance.ID.String()).
+			Str("provider", instance.Provider).
+			Msg("Instance config changed, provider client rebuilt")
+	}
+
+	r.cache[instance.ID] = cachedProvider{provider: provider, fingerprint: fingerprint}
+	return provider, nil
+}
+
+// Invalidate descarta o cliente em cache de uma instância
+func (r *CachedProviderResolver) Invalidate(instanceID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.cache, instanceID)
+}
+
+// create cria o cliente através da factory, injetando as dependências de runtime
+func (r *CachedProviderResolver) create(providerType string, config domain.ProviderConfig) (domain.WhatsAppProvider, error) {
+	runtimeConfig := make(domain.ProviderConfig, len(config)+2)
+	for key, value := range config {
+		runtimeConfig[key] = value
+	}
+	runtimeConfig["logger"] = r.logger
+	runtimeConfig["circuit_breakers"] = r.breakers
+
+	return r.factory.CreateProvider(providerType, runtimeConfig)
+}
+
+// effectiveConfig combina a configuração global do provider com a da instância,
+// dando precedência aos valores definidos na instância
+func (r *CachedProviderResolver) effectiveConfig(instance *domain.Instance) domain.ProviderConfig {
+	config := make(domain.ProviderConfig)
+	for key, value := range r.defaults[instance.Provider] {
+		config[key] = value
+	}
+	for key, value := range instance.Config {
+		if value == nil {
+			continue
+		}
+		config[key] = value
+	}
+	return config
+}
+
+// isFactorySupported verifica se a factory sabe criar o tipo de provider
+func (r *CachedProviderResolver) isFactorySupported(providerType string) bool {
+	for _, supported := range r.factory.GetSupportedProviders() {
+		if supported == providerType {
+			return true
+		}
+	}
+	return false
+}
+
+// configFingerprint gera uma impressão digital estável da configuração
+func configFingerprint(config domain.ProviderConfig) (string, error) {
+	// json.Marshal ordena as chaves de mapas, garantindo um resultado determinístico
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,134 @@
+package infrastructure_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure/providers"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+)
+
+// countingFactory registra na factory um creator de Z-API que guarda as
+// configurações recebidas, uma por cliente criado
+func countingFactory(t *testing.T) (*infrastructure.DefaultProviderFactory, *[]domain.ProviderConfig) {
+	var created []domain.ProviderConfig
+	factory := infrastructure.NewDefaultProviderFactory(zerolog.Nop())
+	require.NoError(t, factory.RegisterProvider("z-api", func(config domain.ProviderConfig) (domain.WhatsAppProvider, error) {
+		created = append(created, config)
+		return providers.NewZAPIProvider(zerolog.Nop()), nil
+	}))
+	return factory, &created
+}
+
+func TestCachedProviderResolver_ReusesClientForUnchangedConfig(t *testing.T) {
+	factory, created := countingFactory(t)
+	breakers := circuitbreaker.NewRegistry(circuitbreaker.DefaultSettings(), nil)
+	defaults := domain.ProviderDefaults{"z-api": {"base_url": "https://api.z-api.io", "timeout": "10s"}}
+	resolver := infrastructure.NewCachedProviderResolver(factory, infrastructure.NewDefaultProviderRegistry(zerolog.Nop()), defaults, breakers, zerolog.Nop())
+
+	instance := &domain.Instance{ID: uuid.New(), Provider: "z-api", Config: map[string]any{"timeout": "5s", "client_token": nil}}
+
+	first, err := resolver.Resolve(instance)
+	require.NoError(t, err)
+	// Uma cópia com a mesma configuração continua usando o cliente em cache
+	second, err := resolver.Resolve(&domain.Instance{ID: instance.ID, Provider: "z-api", Config: map[string]any{"timeout": "5s"}})
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	require.Len(t, *created, 1)
+
+	// A configuração da instância tem precedência sobre a global e as dependências de runtime são injetadas
+	config := (*created)[0]
+	assert.Equal(t, "https://api.z-api.io", config["base_url"])
+	assert.Equal(t, "5s", config["timeout"])
+	assert.NotContains(t, config, "client_token")
+	assert.Same(t, breakers, config["circuit_breakers"])
+	assert.Contains(t, config, "logger")
+}
+
+func TestCachedProviderResolver_RebuildsClientWhenConfigChanges(t *testing.T) {
+	factory, created := countingFactory(t)
+	resolver := infrastructure.NewCachedProviderResolver(factory, infrastructure.NewDefaultProviderRegistry(zerolog.Nop()), nil, nil, zerolog.Nop())
+	instance := &domain.Instance{ID: uuid.New(), Provider: "z-api", Config: map[string]any{"timeout": "10s"}}
+
+	original, err := resolver.Resolve(instance)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		config map[string]any
+	}{
+		{name: "config change", config: map[string]any{"timeout": "30s"}},
+		{name: "token change", config: map[string]any{"timeout": "30s", "client_token": "rotated"}},
+	}
+
+	previous := original
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance.Config = tt.config
+
+			rebuilt, err := resolver.Resolve(instance)
+			require.NoError(t, err)
+			assert.NotSame(t, previous, rebuilt)
+			assert.Equal(t, tt.config["client_token"], (*created)[len(*created)-1]["client_token"])
+
+			// O novo cliente passa a ser o cliente em cache
+			cached, err := resolver.Resolve(instance)
+			require.NoError(t, err)
+			assert.Same(t, rebuilt, cached)
+			previous = rebuilt
+		})
+	}
+
+	assert.Len(t, *created, 3)
+}
+
+func TestCachedProviderResolver_Invalidate(t *testing.T) {
+	factory, created := countingFactory(t)
+	resolver := infrastructure.NewCachedProviderResolver(factory, infrastructure.NewDefaultProviderRegistry(zerolog.Nop()), nil, nil, zerolog.Nop())
+	instance := &domain.Instance{ID: uuid.New(), Provider: "z-api"}
+	other := &domain.Instance{ID: uuid.New(), Provider: "z-api"}
+
+	first, err := resolver.Resolve(instance)
+	require.NoError(t, err)
+	otherClient, err := resolver.Resolve(other)
+	require.NoError(t, err)
+
+	resolver.Invalidate(instance.ID)
+
+	rebuilt, err := resolver.Resolve(instance)
+	require.NoError(t, err)
+	assert.NotSame(t, first, rebuilt)
+
+	// Apenas a instância invalidada perde o cliente em cache
+	cached, err := resolver.Resolve(other)
+	require.NoError(t, err)
+	assert.Same(t, otherClient, cached)
+	assert.Len(t, *created, 3)
+
+	// Invalidar uma instância sem cliente em cache não tem efeito
+	resolver.Invalidate(uuid.New())
+}
+
+func TestCachedProviderResolver_FallsBackToRegistry(t *testing.T) {
+	shared := providers.NewZAPIProvider(zerolog.Nop())
+	registry := infrastructure.NewDefaultProviderRegistry(zerolog.Nop())
+	require.NoError(t, registry.Register(shared))
+	// A factory não conhece a Z-API, então o resolver não cria clientes por instância
+	resolver := infrastructure.NewCachedProviderResolver(infrastructure.NewDefaultProviderFactory(zerolog.Nop()), registry, nil, nil, zerolog.Nop())
+
+	for _, config := range []map[string]any{nil, {"timeout": "5s"}} {
+		provider, err := resolver.Resolve(&domain.Instance{ID: uuid.New(), Provider: "z-api", Config: config})
+		require.NoError(t, err)
+		assert.Same(t, shared, provider)
+	}
+
+	_, err := resolver.Resolve(&domain.Instance{ID: uuid.New(), Provider: "unknown"})
+	assert.EqualError(t, err, "provider unknown not found")
+}
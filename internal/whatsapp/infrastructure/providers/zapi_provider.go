@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
 )
 
 // ZAPIProvider implementa a interface WhatsAppProvider para a Z-API
@@ -20,6 +24,7 @@ type ZAPIProvider struct {
 	baseURL     string
 	clientToken string
 	httpClient  *http.Client
+	breakers    *circuitbreaker.Registry
 	logger      zerolog.Logger
 }
 
@@ -37,6 +42,9 @@ type ZAPISendMessageRequest struct {
 	Image        string `json:"image,omitempty"`
 	Video        string `json:"video,omitempty"`
 	Audio        string `json:"audio,omitempty"`
+	Document     string `json:"document,omitempty"`
+	FileName     string `json:"fileName,omitempty"` // Nome exibido do documento
+	Caption      string `json:"caption,omitempty"`  // Legenda do documento
 }
 
 // ZAPISendMessageResponse representa a resposta de envio da Z-API
@@ -85,7 +93,8 @@ func NewZAPIProvider(logger zerolog.Logger) *ZAPIProvider {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		logger: logger.With().Str("provider", "z-api").Logger(),
+		breakers: circuitbreaker.NewRegistry(circuitbreaker.DefaultSettings(), nil),
+		logger:   logger.With().Str("provider", "z-api").Logger(),
 	}
 }
 
@@ -107,7 +116,8 @@ func NewZAPIProviderWithConfig(config ZAPIConfig, logger zerolog.Logger) *ZAPIPr
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		logger: logger.With().Str("provider", "z-api").Logger(),
+		breakers: circuitbreaker.NewRegistry(circuitbreaker.DefaultSettings(), nil),
+		logger:   logger.With().Str("provider", "z-api").Logger(),
 	}
 }
 
@@ -135,6 +145,10 @@ func (z *ZAPIProvider) SendMessage(ctx context.Context, instance *domain.Instanc
 		zapiRequest.Message = request.Content // Legenda
 	case domain.AudioMessage:
 		zapiRequest.Audio = *request.MediaURL
+	case domain.DocumentMessage:
+		zapiRequest.Document = *request.MediaURL
+		zapiRequest.FileName = request.FileName
+		zapiRequest.Caption = request.Content
 	default:
 		return nil, fmt.Errorf("message type %s not supported by Z-API", request.Type)
 	}
@@ -151,13 +165,18 @@ func (z *ZAPIProvider) SendMessage(ctx context.Context, instance *domain.Instanc
 	case domain.AudioMessage:
 		endpoint = "send-audio"
 	case domain.DocumentMessage:
-		endpoint = "send-document"
+		// A Z-API recebe a extensão do documento no caminho: send-document/pdf
+		extension := documentExtension(request.FileName, *request.MediaURL)
+		if extension == "" {
+			return nil, fmt.Errorf("document extension is required: inform file_name with an extension")
+		}
+		endpoint = "send-document/" + extension
 	}
 
 	url := fmt.Sprintf("%s/%s/token/%s/%s", z.baseURL, instance.InstanceID, instance.Token, endpoint)
 
 	// Faz a requisição
-	response, err := z.makeRequest(ctx, "POST", url, zapiRequest)
+	response, err := z.makeRequest(ctx, instance.InstanceID, "POST", url, zapiRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -191,21 +210,31 @@ func (z *ZAPIProvider) SendMessage(ctx context.Context, instance *domain.Instanc
 	}, nil
 }
 
+// documentExtension obtém a extensão do documento pelo nome do arquivo ou, sem
+// ele, pelo caminho da URL
+func documentExtension(fileName, mediaURL string) string {
+	extension := path.Ext(fileName)
+	if extension == "" && !strings.HasPrefix(mediaURL, "data:") {
+		if parsed, err := url.Parse(mediaURL); err == nil {
+			extension = path.Ext(parsed.Path)
+		}
+	}
+	return strings.ToLower(strings.TrimPrefix(extension, "."))
+}
+
 // GetInstanceStatus obtém o status de uma instância Z-API
 func (z *ZAPIProvider) GetInstanceStatus(ctx context.Context, instance *domain.Instance) (*domain.InstanceInfo, error) {
 	url := fmt.Sprintf("%s/%s/token/%s/status", z.baseURL, instance.InstanceID, instance.Token)
 
 	z.logger.Info().
-		Str("url", url).
 		Str("instance_id", instance.InstanceID).
-		Str("token", instance.Token).
 		Msg("Getting instance status from Z-API")
 
-	response, err := z.makeRequest(ctx, "GET", url, nil)
+	response, err := z.makeRequest(ctx, instance.InstanceID, "GET", url, nil)
 	if err != nil {
 		z.logger.Error().
 			Err(err).
-			Str("url", url).
+			Str("url", redactURLToken(url)).
 			Msg("Failed to get instance status from Z-API")
 		return nil, err
 	}
@@ -325,8 +354,13 @@ func (z *ZAPIProvider) ValidateToken(ctx context.Context, token string) error {
 	return nil
 }
 
-// makeRequest faz uma requisição HTTP para a Z-API
-func (z *ZAPIProvider) makeRequest(ctx context.Context, method, url string, body interface{}) ([]byte, error) {
+// makeRequest faz uma requisição HTTP para a Z-API passando pelos circuit
+// breakers do provider e da instância. Falhas de transporte e respostas 5xx/429
+// indicam indisponibilidade da Z-API e contam para o breaker do provider;
+// respostas 401, 403 e 404 são problemas da instância (token inválido,
+// instância inexistente) e contam apenas para o breaker dela. Os demais erros
+// 4xx vêm da própria requisição e não contam para nenhum breaker
+func (z *ZAPIProvider) makeRequest(ctx context.Context, instanceID, method, url string, body interface{}) ([]byte, error) {
 	var reqBody io.Reader
 
 	if body != nil {
@@ -346,17 +380,35 @@ func (z *ZAPIProvider) makeRequest(ctx context.Context, method, url string, body
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Client-Token", z.clientToken)
 
+	providerCall, err := z.breakers.Get(z.GetName()).Allow()
+	if err != nil {
+		return nil, fmt.Errorf("%w: Z-API is failing, requests are paused", domain.ErrCircuitOpen)
+	}
+
+	instanceCall, err := z.breakers.Get(domain.InstanceBreakerName(z.GetName(), instanceID)).Allow()
+	if err != nil {
+		providerCall.Ignore()
+		return nil, fmt.Errorf("%w: instance %s is failing, requests are paused", domain.ErrCircuitOpen, instanceID)
+	}
+
 	z.logger.Debug().
 		Str("method", method).
-		Str("url", url).
-		Str("client_token", z.clientToken).
+		Str("url", redactURLToken(url)).
 		Msg("Making request to Z-API")
 
 	resp, err := z.httpClient.Do(req)
 	if err != nil {
+		// Cancelamento pelo chamador não diz nada sobre a saúde da Z-API
+		if ctx.Err() != nil {
+			providerCall.Ignore()
+		} else {
+			providerCall.Failure(err)
+		}
+		instanceCall.Ignore()
+
 		z.logger.Error().
 			Err(err).
-			Str("url", url).
+			Str("url", redactURLToken(url)).
 			Msg("Failed to make HTTP request to Z-API")
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -364,27 +416,55 @@ func (z *ZAPIProvider) makeRequest(ctx context.Context, method, url string, body
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		providerCall.Failure(err)
+		instanceCall.Ignore()
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	z.logger.Debug().
 		Int("status_code", resp.StatusCode).
 		Str("response_body", string(responseBody)).
-		Str("url", url).
+		Str("url", redactURLToken(url)).
 		Msg("Z-API response received")
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		statusErr := fmt.Errorf("Z-API returned error status %d: %s", resp.StatusCode, string(responseBody))
+		switch {
+		case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+			providerCall.Failure(statusErr)
+			instanceCall.Ignore()
+		case isInstanceFailureStatus(resp.StatusCode):
+			providerCall.Success()
+			instanceCall.Failure(statusErr)
+		default:
+			providerCall.Success()
+			instanceCall.Ignore()
+		}
+
 		z.logger.Error().
 			Int("status_code", resp.StatusCode).
 			Str("response_body", string(responseBody)).
-			Str("url", url).
+			Str("url", redactURLToken(url)).
 			Msg("Z-API returned error status")
-		return nil, fmt.Errorf("Z-API returned error status %d: %s", resp.StatusCode, string(responseBody))
+		return nil, statusErr
 	}
 
+	providerCall.Success()
+	instanceCall.Success()
 	return responseBody, nil
 }
 
+// isInstanceFailureStatus indica se o status aponta um problema da instância,
+// como token inválido ou instância inexistente
+func isInstanceFailureStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
 // Configure configura o provider com os parâmetros específicos
 func (z *ZAPIProvider) Configure(config domain.ProviderConfig) error {
 	if baseURL, ok := config["base_url"].(string); ok && baseURL != "" {
@@ -395,8 +475,30 @@ func (z *ZAPIProvider) Configure(config domain.ProviderConfig) error {
 		z.clientToken = clientToken
 	}
 
-	if timeout, ok := config["timeout"].(time.Duration); ok && timeout > 0 {
-		z.httpClient.Timeout = timeout
+	// Registro compartilhado, para que todos os clientes da Z-API vejam os mesmos breakers
+	if breakers, ok := config["circuit_breakers"].(*circuitbreaker.Registry); ok && breakers != nil {
+		z.breakers = breakers
+	}
+
+	// O timeout pode chegar como time.Duration (configuração em código), string
+	// no formato de duração ("10s") ou número de segundos (configuração em JSON)
+	switch timeout := config["timeout"].(type) {
+	case time.Duration:
+		if timeout > 0 {
+			z.httpClient.Timeout = timeout
+		}
+	case string:
+		parsed, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", timeout, err)
+		}
+		if parsed > 0 {
+			z.httpClient.Timeout = parsed
+		}
+	case float64:
+		if timeout > 0 {
+			z.httpClient.Timeout = time.Duration(timeout * float64(time.Second))
+		}
 	}
 
 	z.logger.Info().Msg("Z-API provider configured successfully")
@@ -441,6 +543,15 @@ func (z *ZAPIProvider) GetSupportedFeatures() []domain.ProviderFeature {
 	}
 }
 
+// GetConfigFields retorna as chaves de configuração por instância aceitas pela Z-API
+func (z *ZAPIProvider) GetConfigFields() []domain.ProviderConfigField {
+	return []domain.ProviderConfigField{
+		{Key: "base_url", Type: domain.ConfigFieldString, Description: "Z-API base URL"},
+		{Key: "client_token", Type: domain.ConfigFieldString, Secret: true, Description: "Z-API account security token"},
+		{Key: "timeout", Type: domain.ConfigFieldDuration, Description: "HTTP timeout for Z-API calls"},
+	}
+}
+
 // UpdateProfileName atualiza o nome do perfil da instância
 func (z *ZAPIProvider) UpdateProfileName(ctx context.Context, instance *domain.Instance, request domain.UpdateProfileNameRequest) (*domain.UpdateProfileResponse, error) {
 	zapiRequest := ZAPIUpdateProfileNameRequest{
@@ -450,16 +561,16 @@ func (z *ZAPIProvider) UpdateProfileName(ctx context.Context, instance *domain.I
 	url := fmt.Sprintf("%s/%s/token/%s/profile-name", z.baseURL, instance.InstanceID, instance.Token)
 
 	z.logger.Info().
-		Str("url", url).
+		Str("url", redactURLToken(url)).
 		Str("instance_id", instance.InstanceID).
 		Str("name", request.Name).
 		Msg("Updating profile name via Z-API")
 
-	response, err := z.makeRequest(ctx, "PUT", url, zapiRequest)
+	response, err := z.makeRequest(ctx, instance.InstanceID, "PUT", url, zapiRequest)
 	if err != nil {
 		z.logger.Error().
 			Err(err).
-			Str("url", url).
+			Str("url", redactURLToken(url)).
 			Msg("Failed to update profile name via Z-API")
 
 		errorMsg := err.Error()
@@ -513,16 +624,16 @@ func (z *ZAPIProvider) UpdateProfilePicture(ctx context.Context, instance *domai
 	url := fmt.Sprintf("%s/%s/token/%s/profile-picture", z.baseURL, instance.InstanceID, instance.Token)
 
 	z.logger.Info().
-		Str("url", url).
+		Str("url", redactURLToken(url)).
 		Str("instance_id", instance.InstanceID).
 		Str("picture_url", request.PictureURL).
 		Msg("Updating profile picture via Z-API")
 
-	response, err := z.makeRequest(ctx, "PUT", url, zapiRequest)
+	response, err := z.makeRequest(ctx, instance.InstanceID, "PUT", url, zapiRequest)
 	if err != nil {
 		z.logger.Error().
 			Err(err).
-			Str("url", url).
+			Str("url", redactURLToken(url)).
 			Msg("Failed to update profile picture via Z-API")
 
 		errorMsg := err.Error()
@@ -566,3 +677,20 @@ func (z *ZAPIProvider) UpdateProfilePicture(ctx context.Context, instance *domai
 		Success: true,
 	}, nil
 }
+
+// redactURLToken mascara o token da instância presente no path das URLs da Z-API
+// (.../instances/{id}/token/{token}/...) para que não apareça nos logs
+func redactURLToken(url string) string {
+	const marker = "/token/"
+	start := strings.Index(url, marker)
+	if start < 0 {
+		return url
+	}
+	start += len(marker)
+
+	end := strings.Index(url[start:], "/")
+	if end < 0 {
+		return url[:start] + "****"
+	}
+	return url[:start] + "****" + url[start+end:]
+}
@@ -0,0 +1,52 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure/providers"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+)
+
+func TestZAPIProvider_ClientErrorsCountOnlyInstanceFailures(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		wantState  circuitbreaker.State
+	}{
+		{statusCode: http.StatusBadRequest, wantState: circuitbreaker.StateClosed},
+		{statusCode: http.StatusUnprocessableEntity, wantState: circuitbreaker.StateClosed},
+		{statusCode: http.StatusUnauthorized, wantState: circuitbreaker.StateOpen},
+		{statusCode: http.StatusForbidden, wantState: circuitbreaker.StateOpen},
+		{statusCode: http.StatusNotFound, wantState: circuitbreaker.StateOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			breakers := circuitbreaker.NewRegistry(circuitbreaker.Settings{FailureThreshold: 2}, nil)
+			provider := providers.NewZAPIProviderWithConfig(providers.ZAPIConfig{BaseURL: server.URL}, zerolog.Nop())
+			require.NoError(t, provider.Configure(domain.ProviderConfig{"circuit_breakers": breakers}))
+
+			instance := &domain.Instance{InstanceID: "instance-1", Token: "token"}
+			for i := 0; i < 3; i++ {
+				_, err := provider.GetInstanceStatus(context.Background(), instance)
+				require.Error(t, err)
+			}
+
+			assert.Equal(t, tt.wantState, breakers.Get(domain.InstanceBreakerName("z-api", "instance-1")).State())
+			// Erros 4xx nunca indicam indisponibilidade da Z-API
+			assert.Equal(t, circuitbreaker.StateClosed, breakers.Get("z-api").State())
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// ZAPIWebhookPayload representa as notificações enviadas pela Z-API. Os campos
+// de conteúdo variam com o tipo da mensagem recebida
+type ZAPIWebhookPayload struct {
+	Type       string   `json:"type"`
+	InstanceID string   `json:"instanceId"`
+	MessageID  string   `json:"messageId"`
+	Phone      string   `json:"phone"`
+	FromMe     bool     `json:"fromMe"`
+	Moment     int64    `json:"momment"` // milissegundos; a grafia é a da Z-API
+	Status     string   `json:"status"`  // apenas em MessageStatusCallback
+	IDs        []string `json:"ids"`     // apenas em MessageStatusCallback
+	Text       *struct {
+		Message string `json:"message"`
+	} `json:"text,omitempty"`
+	Image *struct {
+		ImageURL string `json:"imageUrl"`
+		Caption  string `json:"caption"`
+		MimeType string `json:"mimeType"`
+	} `json:"image,omitempty"`
+	Video *struct {
+		VideoURL string `json:"videoUrl"`
+		Caption  string `json:"caption"`
+		MimeType string `json:"mimeType"`
+	} `json:"video,omitempty"`
+	Audio *struct {
+		AudioURL string `json:"audioUrl"`
+		MimeType string `json:"mimeType"`
+	} `json:"audio,omitempty"`
+	Document *struct {
+		DocumentURL string `json:"documentUrl"`
+		FileName    string `json:"fileName"`
+		Caption     string `json:"caption"`
+		MimeType    string `json:"mimeType"`
+	} `json:"document,omitempty"`
+}
+
+// ParseWebhook converte a notificação da Z-API para o domínio
+func (z *ZAPIProvider) ParseWebhook(payload []byte) (*domain.WebhookEvent, error) {
+	var notification ZAPIWebhookPayload
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, fmt.Errorf("invalid Z-API webhook payload: %w", err)
+	}
+
+	event := &domain.WebhookEvent{
+		Kind:               domain.WebhookIgnored,
+		ProviderInstanceID: notification.InstanceID,
+	}
+
+	timestamp := time.Now()
+	if notification.Moment > 0 {
+		timestamp = time.UnixMilli(notification.Moment)
+	}
+
+	switch notification.Type {
+	case "ReceivedCallback":
+	case "MessageStatusCallback":
+		return parseZAPIStatus(event, notification, timestamp), nil
+	default:
+		return event, nil
+	}
+
+	message := &domain.InboundMessage{
+		ProviderID: notification.MessageID,
+		Phone:      notification.Phone,
+		FromMe:     notification.FromMe,
+		Timestamp:  timestamp,
+	}
+
+	switch {
+	case notification.Text != nil:
+		message.Type = domain.TextMessage
+		message.Content = notification.Text.Message
+	case notification.Image != nil:
+		message.Type = domain.ImageMessage
+		message.Content = notification.Image.Caption
+		message.MediaURL = &notification.Image.ImageURL
+		message.MimeType = notification.Image.MimeType
+	case notification.Video != nil:
+		message.Type = domain.VideoMessage
+		message.Content = notification.Video.Caption
+		message.MediaURL = &notification.Video.VideoURL
+		message.MimeType = notification.Video.MimeType
+	case notification.Audio != nil:
+		message.Type = domain.AudioMessage
+		message.MediaURL = &notification.Audio.AudioURL
+		message.MimeType = notification.Audio.MimeType
+	case notification.Document != nil:
+		message.Type = domain.DocumentMessage
+		message.Content = notification.Document.Caption
+		if message.Content == "" {
+			message.Content = notification.Document.FileName
+		}
+		message.MediaURL = &notification.Document.DocumentURL
+		message.FileName = notification.Document.FileName
+		message.MimeType = notification.Document.MimeType
+	default:
+		// Reações, figurinhas, localização etc. ainda não são armazenadas
+		return event, nil
+	}
+
+	event.Kind = domain.WebhookMessageReceived
+	event.Message = message
+	return event, nil
+}
+
+// zapiStatuses mapeia os status de MessageStatusCallback. READ_BY_ME indica a
+// leitura de uma mensagem recebida e não altera mensagens enviadas
+var zapiStatuses = map[string]domain.MessageStatus{
+	"SENT":     domain.StatusSent,
+	"RECEIVED": domain.StatusDelivered,
+	"READ":     domain.StatusRead,
+	"PLAYED":   domain.StatusRead, // áudio reproduzido
+}
+
+// parseZAPIStatus converte a confirmação de envio, entrega ou leitura
+func parseZAPIStatus(event *domain.WebhookEvent, notification ZAPIWebhookPayload, timestamp time.Time) *domain.WebhookEvent {
+	status, ok := zapiStatuses[notification.Status]
+	if !ok || len(notification.IDs) == 0 {
+		return event
+	}
+
+	event.Kind = domain.WebhookMessageStatus
+	event.StatusUpdate = &domain.MessageStatusUpdate{
+		ProviderIDs: notification.IDs,
+		Status:      status,
+		Timestamp:   timestamp,
+	}
+	return event
+}
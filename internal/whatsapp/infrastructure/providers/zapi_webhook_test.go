@@ -0,0 +1,116 @@
+package providers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure/providers"
+)
+
+func TestZAPIProvider_ParseWebhookReceivedMessages(t *testing.T) {
+	provider := providers.NewZAPIProvider(zerolog.Nop())
+	imageURL := "https://files.z-api.io/photo.jpg"
+	documentURL := "https://files.z-api.io/contrato.pdf"
+
+	tests := []struct {
+		name    string
+		payload string
+		want    *domain.InboundMessage
+	}{
+		{
+			name:    "text",
+			payload: `{"type":"ReceivedCallback","instanceId":"3C01","messageId":"M1","phone":"5511999999999","momment":1700000000000,"text":{"message":"Olá"}}`,
+			want: &domain.InboundMessage{
+				ProviderID: "M1",
+				Phone:      "5511999999999",
+				Type:       domain.TextMessage,
+				Content:    "Olá",
+				Timestamp:  time.UnixMilli(1700000000000),
+			},
+		},
+		{
+			name:    "image sent from the phone",
+			payload: `{"type":"ReceivedCallback","instanceId":"3C01","messageId":"M2","phone":"5511999999999","fromMe":true,"momment":1700000000000,"image":{"imageUrl":"https://files.z-api.io/photo.jpg","caption":"foto","mimeType":"image/jpeg"}}`,
+			want: &domain.InboundMessage{
+				ProviderID: "M2",
+				Phone:      "5511999999999",
+				FromMe:     true,
+				Type:       domain.ImageMessage,
+				Content:    "foto",
+				MediaURL:   &imageURL,
+				MimeType:   "image/jpeg",
+				Timestamp:  time.UnixMilli(1700000000000),
+			},
+		},
+		{
+			name:    "document without caption",
+			payload: `{"type":"ReceivedCallback","instanceId":"3C01","messageId":"M3","phone":"5511999999999","momment":1700000000000,"document":{"documentUrl":"https://files.z-api.io/contrato.pdf","fileName":"contrato.pdf","mimeType":"application/pdf"}}`,
+			want: &domain.InboundMessage{
+				ProviderID: "M3",
+				Phone:      "5511999999999",
+				Type:       domain.DocumentMessage,
+				Content:    "contrato.pdf",
+				MediaURL:   &documentURL,
+				FileName:   "contrato.pdf",
+				MimeType:   "application/pdf",
+				Timestamp:  time.UnixMilli(1700000000000),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := provider.ParseWebhook([]byte(tt.payload))
+			require.NoError(t, err)
+
+			assert.Equal(t, domain.WebhookMessageReceived, event.Kind)
+			assert.Equal(t, "3C01", event.ProviderInstanceID)
+			assert.Equal(t, tt.want, event.Message)
+		})
+	}
+}
+
+func TestZAPIProvider_ParseWebhookStatus(t *testing.T) {
+	provider := providers.NewZAPIProvider(zerolog.Nop())
+
+	event, err := provider.ParseWebhook([]byte(`{"type":"MessageStatusCallback","instanceId":"3C01","status":"RECEIVED","ids":["M1","M2"],"momment":1700000000000}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.WebhookMessageStatus, event.Kind)
+	assert.Equal(t, &domain.MessageStatusUpdate{
+		ProviderIDs: []string{"M1", "M2"},
+		Status:      domain.StatusDelivered,
+		Timestamp:   time.UnixMilli(1700000000000),
+	}, event.StatusUpdate)
+}
+
+func TestZAPIProvider_ParseWebhookIgnoredEvents(t *testing.T) {
+	provider := providers.NewZAPIProvider(zerolog.Nop())
+
+	payloads := map[string]string{
+		"presence":           `{"type":"PresenceChatCallback","instanceId":"3C01"}`,
+		"unsupported type":   `{"type":"ReceivedCallback","instanceId":"3C01","messageId":"M1","sticker":{}}`,
+		"read by me":         `{"type":"MessageStatusCallback","instanceId":"3C01","status":"READ_BY_ME","ids":["M1"]}`,
+		"status without ids": `{"type":"MessageStatusCallback","instanceId":"3C01","status":"READ"}`,
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			event, err := provider.ParseWebhook([]byte(payload))
+			require.NoError(t, err)
+
+			assert.Equal(t, domain.WebhookIgnored, event.Kind)
+			assert.Equal(t, "3C01", event.ProviderInstanceID)
+			assert.Nil(t, event.Message)
+			assert.Nil(t, event.StatusUpdate)
+		})
+	}
+
+	_, err := provider.ParseWebhook([]byte(`{"type":`))
+	assert.Error(t, err)
+}
@@ -1,9 +1,41 @@
 package infrastructure
 
 import (
+	"context"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
 )
 
+// tenantScope restringe a consulta ao tenant do contexto, de modo que recursos
+// de outro tenant não são encontrados. Contextos sem tenant (workers em segundo
+// plano e notificações dos provedores) não são restringidos
+func tenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID, ok := tenantDomain.FromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"},
+			Value:  tenantID,
+		})
+	}
+}
+
+// likeEscaper escapa o caractere de escape e os curingas do LIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likePattern monta o padrão LIKE que encontra o texto em qualquer posição,
+// comparando os curingas literalmente. Deve ser usado com ESCAPE '\'
+func likePattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
 // timeToUnix converte time.Time para timestamp Unix
 func timeToUnix(t time.Time) int64 {
 	return t.Unix()
@@ -14,7 +46,33 @@ func timeFromUnix(unix int64) time.Time {
 	return time.Unix(unix, 0)
 }
 
+// timePtrToUnix converte um time.Time opcional para timestamp Unix
+func timePtrToUnix(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
+
+// timePtrFromUnix converte um timestamp Unix opcional para time.Time
+func timePtrFromUnix(unix *int64) *time.Time {
+	if unix == nil {
+		return nil
+	}
+	t := time.Unix(*unix, 0)
+	return &t
+}
+
 // timeNow retorna o timestamp atual
 func timeNow() time.Time {
 	return time.Now()
 }
+
+// nilIfEmpty grava strings vazias como NULL em colunas opcionais
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
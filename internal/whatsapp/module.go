@@ -1,15 +1,21 @@
 package whatsapp
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/rs/zerolog"
 	"go.uber.org/fx"
 
+	auditDomain "github.com/your-org/boilerplate-go/internal/audit/domain"
 	"github.com/your-org/boilerplate-go/internal/config"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/infrastructure/providers"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/presentation"
+	"github.com/your-org/boilerplate-go/pkg/circuitbreaker"
+	"github.com/your-org/boilerplate-go/pkg/events"
 )
 
 // Module configura as dependências do módulo WhatsApp
@@ -21,12 +27,92 @@ var Module = fx.Module("whatsapp",
 			fx.As(new(domain.MessageRepository)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormConversationRepository,
+			fx.As(new(domain.ConversationRepository)),
+		),
+	),
 	fx.Provide(
 		fx.Annotate(
 			infrastructure.NewGormInstanceRepository,
 			fx.As(new(domain.InstanceRepository)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormInstanceGroupRepository,
+			fx.As(new(domain.InstanceGroupRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormWebhookSubscriptionRepository,
+			fx.As(new(domain.WebhookSubscriptionRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormWebhookDeliveryRepository,
+			fx.As(new(domain.WebhookDeliveryRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormAutomationRuleRepository,
+			fx.As(new(domain.AutomationRuleRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormRuleExecutionRepository,
+			fx.As(new(domain.RuleExecutionRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormOptOutRepository,
+			fx.As(new(domain.OptOutRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormRetentionPolicyRepository,
+			fx.As(new(domain.RetentionPolicyRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormExportJobRepository,
+			fx.As(new(domain.ExportJobRepository)),
+		),
+	),
+	fx.Provide(newExportStorage),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormErasureRepository,
+			fx.As(new(domain.ErasureRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormErasureReceiptRepository,
+			fx.As(new(domain.ErasureReceiptRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormMediaRepository,
+			fx.As(new(domain.MediaRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewGormMediaDownloadRepository,
+			fx.As(new(domain.MediaDownloadRepository)),
+		),
+	),
+	fx.Provide(newMediaStorage),
 
 	// Provider Factory e Registry
 	fx.Provide(
@@ -42,11 +128,42 @@ var Module = fx.Module("whatsapp",
 		),
 	),
 
+	fx.Provide(newProviderDefaults),
+	fx.Provide(newCircuitBreakerRegistry),
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewCachedProviderResolver,
+			fx.As(new(domain.InstanceProviderResolver)),
+		),
+	),
+
 	// Providers individuais
 	fx.Provide(newZAPIProviderWithConfig),
 
+	// Eventos
+	fx.Provide(
+		fx.Annotate(
+			infrastructure.NewEventBusPublisher,
+			fx.As(new(domain.EventPublisher)),
+		),
+	),
+
 	// Serviços
+	fx.Provide(newOptOutService),
+	fx.Provide(newMediaService),
+	fx.Provide(newMediaFetcher),
 	fx.Provide(application.NewWhatsAppService),
+	fx.Provide(newStatusMonitor),
+	fx.Provide(application.NewWebhookService),
+	fx.Provide(newWebhookDispatcher),
+	fx.Provide(newEventStream),
+	fx.Provide(application.NewAutomationService),
+	fx.Provide(newRuleEngine),
+	fx.Provide(newRetentionService),
+	fx.Provide(newRetentionWorker),
+	fx.Provide(newExportService),
+	fx.Provide(newExportWorker),
+	fx.Provide(newErasureService),
 
 	// Controllers
 	fx.Provide(presentation.NewWhatsAppController),
@@ -54,6 +171,13 @@ var Module = fx.Module("whatsapp",
 	// Configuração dos provedores
 	fx.Invoke(registerProviders),
 	fx.Invoke(setupProviderFactory),
+	fx.Invoke(startStatusMonitor),
+	fx.Invoke(startWebhookDispatcher),
+	fx.Invoke(startMediaFetcher),
+	fx.Invoke(startEventStream),
+	fx.Invoke(subscribeRuleEngine),
+	fx.Invoke(startRetentionWorker),
+	fx.Invoke(startExportWorker),
 )
 
 // registerProviders registra todos os provedores no serviço
@@ -80,11 +204,335 @@ func setupProviderFactory(
 }
 
 // newZAPIProviderWithConfig cria um ZAPIProvider com configuração injetada
-func newZAPIProviderWithConfig(cfg *config.Config, logger zerolog.Logger) *providers.ZAPIProvider {
+func newZAPIProviderWithConfig(cfg *config.Config, breakers *circuitbreaker.Registry, logger zerolog.Logger) (*providers.ZAPIProvider, error) {
 	zapiConfig := providers.ZAPIConfig{
 		BaseURL:     cfg.WhatsApp.ZApi.BaseURL,
 		ClientToken: cfg.WhatsApp.ZApi.ClientToken,
 	}
 
-	return providers.NewZAPIProviderWithConfig(zapiConfig, logger)
+	provider := providers.NewZAPIProviderWithConfig(zapiConfig, logger)
+	if err := provider.Configure(domain.ProviderConfig{"circuit_breakers": breakers}); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// newProviderDefaults monta a configuração global de cada tipo de provider,
+// usada quando a instância não define valores próprios
+func newProviderDefaults(cfg *config.Config) domain.ProviderDefaults {
+	return domain.ProviderDefaults{
+		"z-api": domain.ProviderConfig{
+			"base_url":     cfg.WhatsApp.ZApi.BaseURL,
+			"client_token": cfg.WhatsApp.ZApi.ClientToken,
+		},
+	}
+}
+
+// newStatusMonitor cria o monitor de status com os intervalos da configuração
+func newStatusMonitor(cfg *config.Config, service *application.WhatsAppService, logger zerolog.Logger) *application.StatusMonitor {
+	return application.NewStatusMonitor(service, application.StatusMonitorSettings{
+		Interval: cfg.WhatsApp.StatusMonitor.Interval,
+		Timeout:  cfg.WhatsApp.StatusMonitor.Timeout,
+	}, logger)
+}
+
+// startStatusMonitor liga o monitor de status ao ciclo de vida da aplicação
+func startStatusMonitor(lc fx.Lifecycle, cfg *config.Config, monitor *application.StatusMonitor) {
+	if !cfg.WhatsApp.StatusMonitor.Enabled {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			monitor.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return monitor.Stop(ctx)
+		},
+	})
+}
+
+// newWebhookDispatcher cria o despachante de webhooks com as tentativas da configuração
+func newWebhookDispatcher(
+	cfg *config.Config,
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	logger zerolog.Logger,
+) *application.WebhookDispatcher {
+	webhooks := cfg.WhatsApp.Webhooks
+	return application.NewWebhookDispatcher(subscriptions, deliveries, application.WebhookDispatcherSettings{
+		PollInterval:         webhooks.PollInterval,
+		Timeout:              webhooks.Timeout,
+		BatchSize:            webhooks.BatchSize,
+		MaxAttempts:          webhooks.MaxAttempts,
+		InitialBackoff:       webhooks.InitialBackoff,
+		MaxBackoff:           webhooks.MaxBackoff,
+		AllowPrivateNetworks: webhooks.AllowPrivateNetworks,
+	}, logger)
+}
+
+// startWebhookDispatcher assina os eventos entregues por webhook e liga o
+// envio ao ciclo de vida da aplicação
+func startWebhookDispatcher(lc fx.Lifecycle, cfg *config.Config, bus *events.ChannelEventBus, dispatcher *application.WebhookDispatcher) error {
+	if !cfg.WhatsApp.Webhooks.Enabled {
+		return nil
+	}
+
+	for _, name := range domain.WebhookEventTypes {
+		if err := bus.SubscribeAsync(name, dispatcher.HandleEvent, false); err != nil {
+			return err
+		}
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			dispatcher.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return dispatcher.Stop(ctx)
+		},
+	})
+	return nil
+}
+
+// newEventStream cria o stream de eventos em tempo real com a configuração
+func newEventStream(cfg *config.Config, bus *events.ChannelEventBus, logger zerolog.Logger) (*application.EventStream, error) {
+	stream := cfg.WhatsApp.Stream
+	overflow, err := events.ParseOverflowPolicy(stream.Overflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return application.NewEventStream(bus, application.EventStreamSettings{
+		Enabled:    stream.Enabled,
+		Heartbeat:  stream.Heartbeat,
+		BufferSize: stream.BufferSize,
+		ReplaySize: stream.ReplaySize,
+		Overflow:   overflow,
+	}, logger), nil
+}
+
+// startEventStream liga o stream de eventos ao ciclo de vida da aplicação
+func startEventStream(lc fx.Lifecycle, cfg *config.Config, stream *application.EventStream) {
+	if !cfg.WhatsApp.Stream.Enabled {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			stream.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			stream.Stop()
+			return nil
+		},
+	})
+}
+
+// newOptOutService cria o serviço de opt-out com as palavras-chave da configuração
+func newOptOutService(
+	cfg *config.Config,
+	optOuts domain.OptOutRepository,
+	instanceRepo domain.InstanceRepository,
+	publisher domain.EventPublisher,
+	logger zerolog.Logger,
+) (*application.OptOutService, error) {
+	optOut := cfg.WhatsApp.OptOut
+	var global bool
+	switch optOut.Scope {
+	case "", "instance":
+	case "global":
+		global = true
+	default:
+		return nil, fmt.Errorf("invalid opt-out scope %q: use instance or global", optOut.Scope)
+	}
+
+	return application.NewOptOutService(optOuts, instanceRepo, publisher, application.OptOutSettings{
+		Keywords: optOut.Keywords,
+		Global:   global,
+	}, logger), nil
+}
+
+// newRuleEngine cria o motor de regras, que responde pelo serviço do WhatsApp
+func newRuleEngine(
+	rules domain.AutomationRuleRepository,
+	executions domain.RuleExecutionRepository,
+	conversations domain.ConversationRepository,
+	messages domain.MessageRepository,
+	service *application.WhatsAppService,
+	logger zerolog.Logger,
+) *application.RuleEngine {
+	return application.NewRuleEngine(rules, executions, conversations, messages, service, logger)
+}
+
+// subscribeRuleEngine avalia as regras de automação a cada mensagem recebida
+func subscribeRuleEngine(bus *events.ChannelEventBus, engine *application.RuleEngine) error {
+	return bus.SubscribeAsync(domain.EventMessageReceived, engine.HandleEvent, false)
+}
+
+// newRetentionService cria o serviço de retenção com os lotes da configuração
+func newRetentionService(
+	cfg *config.Config,
+	policies domain.RetentionPolicyRepository,
+	messages domain.MessageRepository,
+	instanceRepo domain.InstanceRepository,
+	logger zerolog.Logger,
+) *application.RetentionService {
+	return application.NewRetentionService(policies, messages, instanceRepo, application.RetentionSettings{
+		BatchSize:  cfg.WhatsApp.Retention.BatchSize,
+		BatchPause: cfg.WhatsApp.Retention.BatchPause,
+	}, logger)
+}
+
+// newRetentionWorker cria o worker de retenção com o intervalo da configuração
+func newRetentionWorker(cfg *config.Config, service *application.RetentionService, logger zerolog.Logger) *application.RetentionWorker {
+	return application.NewRetentionWorker(service, cfg.WhatsApp.Retention.Interval, logger)
+}
+
+// startRetentionWorker liga o worker de retenção ao ciclo de vida da aplicação
+func startRetentionWorker(lc fx.Lifecycle, cfg *config.Config, worker *application.RetentionWorker) {
+	if !cfg.WhatsApp.Retention.Enabled {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			worker.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return worker.Stop(ctx)
+		},
+	})
+}
+
+// newExportStorage cria o armazenamento dos arquivos no diretório da configuração
+func newExportStorage(cfg *config.Config) (domain.ExportStorage, error) {
+	return infrastructure.NewFileStorage(cfg.WhatsApp.Exports.Dir)
+}
+
+// newExportService cria o serviço de exportação com a validade e o limite da configuração
+func newExportService(
+	cfg *config.Config,
+	jobs domain.ExportJobRepository,
+	messages domain.MessageRepository,
+	storage domain.ExportStorage,
+	audit auditDomain.Recorder,
+	logger zerolog.Logger,
+) *application.ExportService {
+	return application.NewExportService(jobs, messages, storage, audit, application.ExportSettings{
+		TTL:         cfg.WhatsApp.Exports.TTL,
+		StreamLimit: cfg.WhatsApp.Exports.StreamLimit,
+	}, logger)
+}
+
+// newExportWorker cria o worker de exportação com o intervalo da configuração
+func newExportWorker(cfg *config.Config, service *application.ExportService, logger zerolog.Logger) *application.ExportWorker {
+	return application.NewExportWorker(service, cfg.WhatsApp.Exports.PollInterval, logger)
+}
+
+// startExportWorker liga o worker de exportação ao ciclo de vida da aplicação
+func startExportWorker(lc fx.Lifecycle, cfg *config.Config, worker *application.ExportWorker) {
+	if !cfg.WhatsApp.Exports.Enabled {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			worker.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return worker.Stop(ctx)
+		},
+	})
+}
+
+// newMediaStorage cria o armazenamento da mídia no diretório da configuração
+func newMediaStorage(cfg *config.Config) (domain.MediaStorage, error) {
+	return infrastructure.NewFileStorage(cfg.WhatsApp.Media.Dir)
+}
+
+// newMediaService cria o serviço de mídia com os limites e a URL pública da configuração
+func newMediaService(
+	cfg *config.Config,
+	media domain.MediaRepository,
+	storage domain.MediaStorage,
+	logger zerolog.Logger,
+) *application.MediaService {
+	settings := cfg.WhatsApp.Media
+	return application.NewMediaService(media, storage, application.MediaSettings{
+		Limits: domain.MediaLimits{
+			domain.ImageMessage:    settings.MaxImageSize,
+			domain.VideoMessage:    settings.MaxVideoSize,
+			domain.AudioMessage:    settings.MaxAudioSize,
+			domain.DocumentMessage: settings.MaxDocumentSize,
+		},
+		PublicURL:  settings.PublicURL,
+		SigningKey: []byte(settings.SigningKey),
+		URLTTL:     settings.URLTTL,
+	}, logger)
+}
+
+// newMediaFetcher cria o serviço de download da mídia recebida com a configuração
+func newMediaFetcher(
+	cfg *config.Config,
+	downloads domain.MediaDownloadRepository,
+	messages domain.MessageRepository,
+	media *application.MediaService,
+	logger zerolog.Logger,
+) *application.MediaFetcher {
+	download := cfg.WhatsApp.Media.Download
+	return application.NewMediaFetcher(downloads, messages, media, application.MediaFetcherSettings{
+		PollInterval:         download.PollInterval,
+		Timeout:              download.Timeout,
+		BatchSize:            download.BatchSize,
+		MaxAttempts:          download.MaxAttempts,
+		InitialBackoff:       download.InitialBackoff,
+		MaxBackoff:           download.MaxBackoff,
+		AllowPrivateNetworks: download.AllowPrivateNetworks,
+	}, logger)
+}
+
+// startMediaFetcher assina as mensagens recebidas e liga os downloads ao ciclo
+// de vida da aplicação
+func startMediaFetcher(lc fx.Lifecycle, cfg *config.Config, bus *events.ChannelEventBus, fetcher *application.MediaFetcher) error {
+	if !cfg.WhatsApp.Media.Download.Enabled {
+		return nil
+	}
+
+	if err := bus.SubscribeAsync(domain.EventMessageReceived, fetcher.HandleEvent, false); err != nil {
+		return err
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			fetcher.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return fetcher.Stop(ctx)
+		},
+	})
+	return nil
+}
+
+// newErasureService cria o serviço de exclusão com a chave dos recibos da configuração
+func newErasureService(
+	cfg *config.Config,
+	erasures domain.ErasureRepository,
+	receipts domain.ErasureReceiptRepository,
+	exports *application.ExportService,
+	media *application.MediaService,
+	stream *application.EventStream,
+	anonymizer auditDomain.Anonymizer,
+	audit auditDomain.Recorder,
+	logger zerolog.Logger,
+) *application.ErasureService {
+	return application.NewErasureService(erasures, receipts, exports, media, stream, anonymizer, audit, application.ErasureSettings{
+		HashKey: cfg.WhatsApp.Erasure.HashKey,
+	}, logger)
 }
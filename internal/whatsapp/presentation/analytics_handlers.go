@@ -0,0 +1,45 @@
+package presentation
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GetMessageAnalytics retorna as estatísticas de envio das mensagens
+func (c *WhatsAppController) GetMessageAnalytics(ctx *gin.Context) {
+	query := domain.MessageAnalyticsQuery{
+		InstanceID: ctx.Query("instance_id"),
+		Provider:   ctx.Query("provider"),
+		Type:       domain.MessageType(ctx.Query("type")),
+		Bucket:     domain.AnalyticsBucket(ctx.Query("bucket")),
+	}
+
+	from, err := queryTime(ctx, "from")
+	if err != nil {
+		response.BadRequest(ctx, "Invalid from date", err.Error())
+		return
+	}
+	if from != nil {
+		query.From = *from
+	}
+
+	to, err := queryTime(ctx, "to")
+	if err != nil {
+		response.BadRequest(ctx, "Invalid to date", err.Error())
+		return
+	}
+	if to != nil {
+		query.To = *to
+	}
+
+	analytics, err := c.service.GetMessageAnalytics(ctx.Request.Context(), query)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to get message analytics")
+		response.FromError(ctx, err, "Failed to get message analytics")
+		return
+	}
+
+	response.Success(ctx, analytics)
+}
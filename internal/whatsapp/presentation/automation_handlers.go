@@ -0,0 +1,152 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// CreateAutomationRule cria uma regra de automação na instância
+func (c *WhatsAppController) CreateAutomationRule(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	var request domain.CreateAutomationRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	rule, err := c.automation.CreateRule(ctx.Request.Context(), id, request)
+	if err != nil {
+		c.logger.Error().Err(err).Str("instance_id", id.String()).Msg("Failed to create automation rule")
+		response.FromError(ctx, err, "Failed to create automation rule")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: rule})
+}
+
+// ListAutomationRules lista as regras da instância na ordem de avaliação
+func (c *WhatsAppController) ListAutomationRules(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	rules, err := c.automation.ListRules(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list automation rules")
+		return
+	}
+
+	response.Success(ctx, gin.H{"rules": rules})
+}
+
+// GetAutomationRule obtém uma regra da instância
+func (c *WhatsAppController) GetAutomationRule(ctx *gin.Context) {
+	id, ruleID, ok := parseRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	rule, err := c.automation.GetRule(ctx.Request.Context(), id, ruleID)
+	if err != nil {
+		response.FromError(ctx, err, "Automation rule not found")
+		return
+	}
+
+	response.Success(ctx, rule)
+}
+
+// UpdateAutomationRule aplica um patch em uma regra
+func (c *WhatsAppController) UpdateAutomationRule(ctx *gin.Context) {
+	id, ruleID, ok := parseRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	var request domain.UpdateAutomationRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	rule, err := c.automation.UpdateRule(ctx.Request.Context(), id, ruleID, request)
+	if err != nil {
+		c.logger.Error().Err(err).Str("rule_id", ruleID.String()).Msg("Failed to update automation rule")
+		response.FromError(ctx, err, "Failed to update automation rule")
+		return
+	}
+
+	response.Success(ctx, rule)
+}
+
+// DeleteAutomationRule remove uma regra
+func (c *WhatsAppController) DeleteAutomationRule(ctx *gin.Context) {
+	id, ruleID, ok := parseRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.automation.DeleteRule(ctx.Request.Context(), id, ruleID); err != nil {
+		response.FromError(ctx, err, "Failed to delete automation rule")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "Automation rule deleted successfully"})
+}
+
+// ListRuleExecutions lista as regras disparadas na instância
+func (c *WhatsAppController) ListRuleExecutions(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	var ruleID *uuid.UUID
+	if value := ctx.Query("rule_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			response.BadRequest(ctx, "Invalid rule ID", err.Error())
+			return
+		}
+		ruleID = &parsed
+	}
+
+	limit := pageLimit(ctx)
+	executions, next, err := c.automation.ListExecutions(ctx.Request.Context(), id, ruleID, limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list rule executions")
+		return
+	}
+
+	response.CursorPaginated(ctx, executions, limit, nil, next)
+}
+
+// parseRuleParams lê os IDs da instância e da regra da rota. Em caso de erro a
+// resposta já foi enviada
+func parseRuleParams(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("rule_id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid rule ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, ruleID, true
+}
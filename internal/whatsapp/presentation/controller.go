@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
+	authDomain "github.com/your-org/boilerplate-go/internal/auth/domain"
+	"github.com/your-org/boilerplate-go/internal/middleware"
 	"github.com/your-org/boilerplate-go/internal/response"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
 	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
@@ -15,15 +17,42 @@ import (
 
 // WhatsAppController manipula as requisições HTTP do WhatsApp
 type WhatsAppController struct {
-	service *application.WhatsAppService
-	logger  zerolog.Logger
+	service    *application.WhatsAppService
+	webhooks   *application.WebhookService
+	automation *application.AutomationService
+	optOuts    *application.OptOutService
+	retention  *application.RetentionService
+	exports    *application.ExportService
+	erasures   *application.ErasureService
+	media      *application.MediaService
+	stream     *application.EventStream
+	logger     zerolog.Logger
 }
 
 // NewWhatsAppController cria um novo controller
-func NewWhatsAppController(service *application.WhatsAppService, logger zerolog.Logger) *WhatsAppController {
+func NewWhatsAppController(
+	service *application.WhatsAppService,
+	webhooks *application.WebhookService,
+	automation *application.AutomationService,
+	optOuts *application.OptOutService,
+	retention *application.RetentionService,
+	exports *application.ExportService,
+	erasures *application.ErasureService,
+	media *application.MediaService,
+	stream *application.EventStream,
+	logger zerolog.Logger,
+) *WhatsAppController {
 	return &WhatsAppController{
-		service: service,
-		logger:  logger.With().Str("controller", "whatsapp").Logger(),
+		service:    service,
+		webhooks:   webhooks,
+		automation: automation,
+		optOuts:    optOuts,
+		retention:  retention,
+		exports:    exports,
+		erasures:   erasures,
+		media:      media,
+		stream:     stream,
+		logger:     logger.With().Str("controller", "whatsapp").Logger(),
 	}
 }
 
@@ -44,7 +73,7 @@ func (c *WhatsAppController) CreateInstance(ctx *gin.Context) {
 	instance, err := c.service.CreateInstance(ctx.Request.Context(), request)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Failed to create instance")
-		response.InternalServerError(ctx, "Failed to create instance", err.Error())
+		response.FromError(ctx, err, "Failed to create instance")
 		return
 	}
 
@@ -69,6 +98,24 @@ func (c *WhatsAppController) GetInstance(ctx *gin.Context) {
 	response.Success(ctx, instance)
 }
 
+// GetInstanceCallback retorna o endereço a configurar no provider para receber
+// as notificações da instância
+func (c *WhatsAppController) GetInstanceCallback(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	instance, err := c.service.GetInstance(ctx.Request.Context(), id)
+	if err != nil {
+		response.NotFound(ctx, "Instance not found", err.Error())
+		return
+	}
+
+	response.Success(ctx, instance.Callback())
+}
+
 // GetAllInstances obtém todas as instâncias
 func (c *WhatsAppController) GetAllInstances(ctx *gin.Context) {
 	instances, err := c.service.GetAllInstances(ctx.Request.Context())
@@ -81,6 +128,44 @@ func (c *WhatsAppController) GetAllInstances(ctx *gin.Context) {
 	response.Success(ctx, gin.H{"instances": instances})
 }
 
+// UpdateInstanceConfig aplica um patch na configuração de uma instância
+func (c *WhatsAppController) UpdateInstanceConfig(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	var request domain.UpdateInstanceConfigRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	instance, err := c.service.UpdateInstanceConfig(ctx.Request.Context(), id, request)
+	if err != nil {
+		c.logger.Error().Err(err).Str("instance_id", idStr).Msg("Failed to update instance config")
+		response.FromError(ctx, err, "Failed to update instance config")
+		return
+	}
+
+	response.Success(ctx, instance)
+}
+
+// GetProviderConfigFields retorna as chaves de configuração aceitas por um provider
+func (c *WhatsAppController) GetProviderConfigFields(ctx *gin.Context) {
+	name := ctx.Param("name")
+
+	fields, err := c.service.GetProviderConfigFields(name)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to get provider config fields")
+		return
+	}
+
+	response.Success(ctx, gin.H{"provider": name, "fields": fields})
+}
+
 // DeleteInstance remove uma instância
 func (c *WhatsAppController) DeleteInstance(ctx *gin.Context) {
 	idStr := ctx.Param("id")
@@ -93,7 +178,7 @@ func (c *WhatsAppController) DeleteInstance(ctx *gin.Context) {
 	err = c.service.DeleteInstance(ctx.Request.Context(), id)
 	if err != nil {
 		c.logger.Error().Err(err).Str("instance_id", idStr).Msg("Failed to delete instance")
-		response.InternalServerError(ctx, "Failed to delete instance", err.Error())
+		response.FromError(ctx, err, "Failed to delete instance")
 		return
 	}
 
@@ -111,7 +196,7 @@ func (c *WhatsAppController) SendMessage(ctx *gin.Context) {
 	result, err := c.service.SendMessage(ctx.Request.Context(), request)
 	if err != nil {
 		c.logger.Error().Err(err).Interface("request", request).Msg("Failed to send message")
-		response.InternalServerError(ctx, "Failed to send message", err.Error())
+		response.FromError(ctx, err, "Failed to send message")
 		return
 	}
 
@@ -176,7 +261,7 @@ func (c *WhatsAppController) GetInstanceStatus(ctx *gin.Context) {
 	status, err := c.service.GetInstanceStatus(ctx.Request.Context(), token)
 	if err != nil {
 		c.logger.Error().Err(err).Str("token", token).Msg("Failed to get instance status")
-		response.InternalServerError(ctx, "Failed to get instance status", err.Error())
+		response.FromError(ctx, err, "Failed to get instance status")
 		return
 	}
 
@@ -194,7 +279,7 @@ func (c *WhatsAppController) UpdateProfileName(ctx *gin.Context) {
 	result, err := c.service.UpdateProfileName(ctx.Request.Context(), request)
 	if err != nil {
 		c.logger.Error().Err(err).Interface("request", request).Msg("Failed to update profile name")
-		response.InternalServerError(ctx, "Failed to update profile name", err.Error())
+		response.FromError(ctx, err, "Failed to update profile name")
 		return
 	}
 
@@ -212,36 +297,133 @@ func (c *WhatsAppController) UpdateProfilePicture(ctx *gin.Context) {
 	result, err := c.service.UpdateProfilePicture(ctx.Request.Context(), request)
 	if err != nil {
 		c.logger.Error().Err(err).Interface("request", request).Msg("Failed to update profile picture")
-		response.InternalServerError(ctx, "Failed to update profile picture", err.Error())
+		response.FromError(ctx, err, "Failed to update profile picture")
 		return
 	}
 
 	response.Success(ctx, result)
 }
 
-// RegisterRoutes registra as rotas do controller
+// RegisterCallbackRoutes registra as notificações dos provedores (mensagens
+// recebidas) e o download da mídia pelas URLs assinadas. Ficam fora do escopo
+// de tenant: a instância da URL ou a assinatura identificam o recurso
+func (c *WhatsAppController) RegisterCallbackRoutes(router *gin.RouterGroup) {
+	router.POST("/whatsapp/callbacks/:provider/:id", c.ReceiveCallback)
+	router.GET("/whatsapp/public/media/:id/:name", c.DownloadSignedMedia)
+}
+
+// RegisterRoutes registra as rotas do controller, com escopo do tenant da
+// requisição. Cada rota exige o escopo da chave de API correspondente
 func (c *WhatsAppController) RegisterRoutes(router *gin.RouterGroup) {
+	instancesRead := middleware.RequireScope(authDomain.ScopeInstancesRead)
+	instancesManage := middleware.RequireScope(authDomain.ScopeInstancesManage)
+	messagesRead := middleware.RequireScope(authDomain.ScopeMessagesRead)
+	messagesSend := middleware.RequireScope(authDomain.ScopeMessagesSend)
+	optOutsManage := middleware.RequireScope(authDomain.ScopeOptOutsManage)
+	webhooksManage := middleware.RequireScope(authDomain.ScopeWebhooksManage)
+	erasuresManage := middleware.RequireScope(authDomain.ScopeErasuresManage)
+
 	whatsapp := router.Group("/whatsapp")
 	{
 		// Provedores
-		whatsapp.GET("/providers", c.GetProviders)
+		whatsapp.GET("/providers", instancesRead, c.GetProviders)
+		whatsapp.GET("/providers/health", instancesRead, c.GetProvidersHealth)
+		whatsapp.GET("/providers/:name/health", instancesRead, c.GetProviderHealth)
+		whatsapp.GET("/providers/:name/features", instancesRead, c.GetProviderFeatures)
+		whatsapp.GET("/providers/:name/config-fields", instancesRead, c.GetProviderConfigFields)
 
 		// Instâncias
-		whatsapp.POST("/instances", c.CreateInstance)
-		whatsapp.GET("/instances", c.GetAllInstances)
-		whatsapp.GET("/instances/:id", c.GetInstance)
-		whatsapp.DELETE("/instances/:id", c.DeleteInstance)
+		whatsapp.POST("/instances", instancesManage, c.CreateInstance)
+		whatsapp.GET("/instances", instancesRead, c.GetAllInstances)
+		whatsapp.GET("/instances/:id", instancesRead, c.GetInstance)
+		whatsapp.GET("/instances/:id/callback", instancesManage, c.GetInstanceCallback)
+		whatsapp.PATCH("/instances/:id/config", instancesManage, c.UpdateInstanceConfig)
+		whatsapp.DELETE("/instances/:id", instancesManage, c.DeleteInstance)
+
+		// Conversas
+		whatsapp.GET("/instances/:id/conversations", messagesRead, c.ListConversations)
+		whatsapp.GET("/instances/:id/conversations/:phone/messages", messagesRead, c.GetConversationThread)
+		whatsapp.POST("/instances/:id/conversations/:phone/read", messagesSend, c.MarkConversationRead)
+		whatsapp.POST("/instances/:id/conversations/:phone/handoff", messagesSend, c.StartConversationHandoff)
+		whatsapp.DELETE("/instances/:id/conversations/:phone/handoff", messagesSend, c.EndConversationHandoff)
+
+		// Regras de automação (respostas automáticas)
+		whatsapp.POST("/instances/:id/rules", instancesManage, c.CreateAutomationRule)
+		whatsapp.GET("/instances/:id/rules", instancesRead, c.ListAutomationRules)
+		whatsapp.GET("/instances/:id/rules/:rule_id", instancesRead, c.GetAutomationRule)
+		whatsapp.PATCH("/instances/:id/rules/:rule_id", instancesManage, c.UpdateAutomationRule)
+		whatsapp.DELETE("/instances/:id/rules/:rule_id", instancesManage, c.DeleteAutomationRule)
+		whatsapp.GET("/instances/:id/rule-executions", instancesRead, c.ListRuleExecutions)
 
 		// Status e mensagens por token (não UUID)
-		whatsapp.GET("/status/:token", c.GetInstanceStatus)
-		whatsapp.GET("/messages/instance/:token", c.GetMessagesByInstance)
+		whatsapp.GET("/status/:token", instancesRead, c.GetInstanceStatus)
+		whatsapp.GET("/messages/instance/:token", messagesRead, c.GetMessagesByInstance)
+
+		// Grupos de instâncias (remetentes lógicos com failover)
+		whatsapp.POST("/instance-groups", instancesManage, c.CreateInstanceGroup)
+		whatsapp.GET("/instance-groups", instancesRead, c.GetAllInstanceGroups)
+		whatsapp.GET("/instance-groups/:id", instancesRead, c.GetInstanceGroup)
+		whatsapp.PUT("/instance-groups/:id", instancesManage, c.UpdateInstanceGroup)
+		whatsapp.DELETE("/instance-groups/:id", instancesManage, c.DeleteInstanceGroup)
 
 		// Mensagens
-		whatsapp.POST("/messages", c.SendMessage)
-		whatsapp.GET("/messages/:id", c.GetMessage)
+		whatsapp.POST("/messages", messagesSend, c.SendMessage)
+		whatsapp.GET("/messages", messagesRead, c.SearchMessages)
+		whatsapp.GET("/messages/export", messagesRead, c.StreamMessageExport)
+		whatsapp.GET("/messages/:id", messagesRead, c.GetMessage)
+		whatsapp.GET("/messages/:id/media", messagesRead, c.DownloadMessageMedia)
+
+		// Mídia enviada para a API e usada nas mensagens pelo media_id
+		whatsapp.POST("/media", messagesSend, c.UploadMedia)
+		whatsapp.GET("/media/:id", messagesRead, c.GetMedia)
+		whatsapp.GET("/media/:id/content", messagesRead, c.DownloadMedia)
+		whatsapp.DELETE("/media/:id", messagesSend, c.DeleteMedia)
+
+		// Exportações do histórico de mensagens
+		whatsapp.POST("/exports", messagesRead, c.CreateExport)
+		whatsapp.GET("/exports", messagesRead, c.ListExports)
+		whatsapp.GET("/exports/:id", messagesRead, c.GetExport)
+		whatsapp.GET("/exports/:id/download", messagesRead, c.DownloadExport)
+
+		// Lista de opt-out (telefones que não recebem mensagens)
+		whatsapp.POST("/opt-outs", optOutsManage, c.CreateOptOut)
+		whatsapp.GET("/opt-outs", optOutsManage, c.ListOptOuts)
+		whatsapp.POST("/opt-outs/import", optOutsManage, c.ImportOptOuts)
+		whatsapp.DELETE("/opt-outs/:id", optOutsManage, c.DeleteOptOut)
+
+		// Políticas de retenção de mensagens
+		whatsapp.GET("/retention-policies", instancesRead, c.ListRetentionPolicies)
+		whatsapp.PUT("/retention-policies", instancesManage, c.SetRetentionPolicy)
+		whatsapp.POST("/retention-policies/dry-run", instancesRead, c.DryRunRetentionPolicy)
+		whatsapp.DELETE("/retention-policies/:id", instancesManage, c.DeleteRetentionPolicy)
+
+		// Exclusão dos dados de contatos (LGPD/GDPR) e recibos
+		whatsapp.POST("/erasures", erasuresManage, c.EraseContact)
+		whatsapp.GET("/erasures", erasuresManage, c.ListErasureReceipts)
+		whatsapp.GET("/erasures/verify", erasuresManage, c.VerifyErasureReceipts)
+		whatsapp.GET("/erasures/:id", erasuresManage, c.GetErasureReceipt)
+
+		// Webhooks para aplicações cliente
+		whatsapp.POST("/webhooks", webhooksManage, c.CreateWebhookSubscription)
+		whatsapp.GET("/webhooks", webhooksManage, c.ListWebhookSubscriptions)
+		whatsapp.GET("/webhooks/:id", webhooksManage, c.GetWebhookSubscription)
+		whatsapp.PATCH("/webhooks/:id", webhooksManage, c.UpdateWebhookSubscription)
+		whatsapp.DELETE("/webhooks/:id", webhooksManage, c.DeleteWebhookSubscription)
+		whatsapp.GET("/webhooks/:id/deliveries", webhooksManage, c.ListWebhookDeliveries)
+		whatsapp.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhooksManage, c.RedeliverWebhook)
+
+		// Eventos em tempo real
+		if c.stream.Settings().Enabled {
+			eventsRead := middleware.RequireScope(authDomain.ScopeEventsRead)
+			whatsapp.GET("/events/stream", eventsRead, c.StreamEvents)
+			whatsapp.GET("/events/ws", eventsRead, c.StreamEventsWebSocket)
+		}
+
+		// Estatísticas
+		whatsapp.GET("/analytics/messages", messagesRead, c.GetMessageAnalytics)
 
 		// Perfil
-		whatsapp.PUT("/profile/name", c.UpdateProfileName)
-		whatsapp.PUT("/profile/picture", c.UpdateProfilePicture)
+		whatsapp.PUT("/profile/name", instancesManage, c.UpdateProfileName)
+		whatsapp.PUT("/profile/picture", instancesManage, c.UpdateProfilePicture)
 	}
 }
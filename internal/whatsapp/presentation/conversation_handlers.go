@@ -0,0 +1,125 @@
+package presentation
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+)
+
+// maxCallbackBodySize limita o tamanho das notificações aceitas dos provedores
+const maxCallbackBodySize = 1 << 20
+
+// ListConversations lista as conversas de uma instância por última atividade
+func (c *WhatsAppController) ListConversations(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	limit := pageLimit(ctx)
+	conversations, next, err := c.service.ListConversations(ctx.Request.Context(), id, limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list conversations")
+		return
+	}
+
+	response.CursorPaginated(ctx, conversations, limit, nil, next)
+}
+
+// GetConversationThread obtém as mensagens trocadas com um telefone
+func (c *WhatsAppController) GetConversationThread(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	limit := pageLimit(ctx)
+	messages, next, err := c.service.GetConversationThread(ctx.Request.Context(), id, ctx.Param("phone"), limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to get conversation messages")
+		return
+	}
+
+	response.CursorPaginated(ctx, messages, limit, nil, next)
+}
+
+// MarkConversationRead zera as mensagens não lidas de uma conversa
+func (c *WhatsAppController) MarkConversationRead(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	if err := c.service.MarkConversationRead(ctx.Request.Context(), id, ctx.Param("phone")); err != nil {
+		response.FromError(ctx, err, "Failed to mark conversation as read")
+		return
+	}
+
+	response.Success(ctx, nil, "Conversation marked as read")
+}
+
+// StartConversationHandoff transfere a conversa para atendimento humano,
+// suspendendo as regras de automação
+func (c *WhatsAppController) StartConversationHandoff(ctx *gin.Context) {
+	c.setConversationHandoff(ctx, true)
+}
+
+// EndConversationHandoff devolve a conversa às regras de automação
+func (c *WhatsAppController) EndConversationHandoff(ctx *gin.Context) {
+	c.setConversationHandoff(ctx, false)
+}
+
+func (c *WhatsAppController) setConversationHandoff(ctx *gin.Context, handoff bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	conversation, err := c.service.SetConversationHandoff(ctx.Request.Context(), id, ctx.Param("phone"), handoff)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to update conversation handoff")
+		return
+	}
+
+	response.Success(ctx, conversation)
+}
+
+// ReceiveCallback recebe as notificações enviadas pelo provedor da instância,
+// autenticadas pelo token do endereço de callback
+func (c *WhatsAppController) ReceiveCallback(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxCallbackBodySize))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := c.service.ReceiveWebhook(ctx.Request.Context(), ctx.Param("provider"), id, ctx.Query("token"), payload); err != nil {
+		c.logger.Error().Err(err).Str("instance_id", id.String()).Msg("Failed to process provider callback")
+		response.FromError(ctx, err, "Failed to process callback")
+		return
+	}
+
+	response.Success(ctx, nil)
+}
+
+// pageLimit lê o parâmetro limit da query, aplicando o padrão e o máximo
+func pageLimit(ctx *gin.Context) int {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	return application.NormalizePageLimit(limit)
+}
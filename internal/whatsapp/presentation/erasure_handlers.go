@@ -0,0 +1,69 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// EraseContact apaga os dados de um telefone e retorna o recibo da exclusão
+func (c *WhatsAppController) EraseContact(ctx *gin.Context) {
+	var request domain.ErasureRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	receipt, err := c.erasures.Erase(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to erase contact data")
+		response.FromError(ctx, err, "Failed to erase contact data")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: receipt})
+}
+
+// ListErasureReceipts lista os recibos de exclusão do tenant
+func (c *WhatsAppController) ListErasureReceipts(ctx *gin.Context) {
+	limit := pageLimit(ctx)
+	receipts, next, err := c.erasures.ListReceipts(ctx.Request.Context(), limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list erasure receipts")
+		return
+	}
+
+	response.CursorPaginated(ctx, receipts, limit, nil, next)
+}
+
+// GetErasureReceipt retorna um recibo de exclusão
+func (c *WhatsAppController) GetErasureReceipt(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid receipt ID", err.Error())
+		return
+	}
+
+	receipt, err := c.erasures.GetReceipt(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to get erasure receipt")
+		return
+	}
+
+	response.Success(ctx, receipt)
+}
+
+// VerifyErasureReceipts confere a integridade da cadeia de recibos do tenant
+func (c *WhatsAppController) VerifyErasureReceipts(ctx *gin.Context) {
+	verification, err := c.erasures.VerifyReceipts(ctx.Request.Context())
+	if err != nil {
+		response.FromError(ctx, err, "Failed to verify erasure receipts")
+		return
+	}
+
+	response.Success(ctx, verification)
+}
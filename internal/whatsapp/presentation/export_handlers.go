@@ -0,0 +1,122 @@
+package presentation
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// CreateExport agenda a exportação do histórico de mensagens em background
+func (c *WhatsAppController) CreateExport(ctx *gin.Context) {
+	var request domain.CreateExportRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	job, err := c.exports.CreateJob(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create export")
+		response.FromError(ctx, err, "Failed to create export")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.SuccessResponse{Data: job})
+}
+
+// ListExports lista as exportações do tenant
+func (c *WhatsAppController) ListExports(ctx *gin.Context) {
+	limit := pageLimit(ctx)
+	jobs, next, err := c.exports.ListJobs(ctx.Request.Context(), limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list exports")
+		return
+	}
+
+	response.CursorPaginated(ctx, jobs, limit, nil, next)
+}
+
+// GetExport retorna o andamento de uma exportação
+func (c *WhatsAppController) GetExport(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid export ID", err.Error())
+		return
+	}
+
+	job, err := c.exports.GetJob(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to get export")
+		return
+	}
+
+	response.Success(ctx, job)
+}
+
+// DownloadExport envia o arquivo de uma exportação concluída
+func (c *WhatsAppController) DownloadExport(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid export ID", err.Error())
+		return
+	}
+
+	job, file, err := c.exports.OpenFile(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to download export")
+		return
+	}
+	defer file.Close()
+
+	ctx.DataFromReader(http.StatusOK, job.SizeBytes, job.Format.ContentType(), file, map[string]string{
+		"Content-Disposition": exportDisposition("messages-"+job.ID.String(), job.Format),
+	})
+}
+
+// StreamMessageExport exporta diretamente na resposta as mensagens que atendem
+// ao filtro. Filtros com muitas mensagens devem usar uma exportação em background
+func (c *WhatsAppController) StreamMessageExport(ctx *gin.Context) {
+	request := domain.CreateExportRequest{
+		Format:     domain.ExportFormat(ctx.DefaultQuery("format", string(domain.ExportCSV))),
+		InstanceID: ctx.Query("instance_id"),
+		Phone:      ctx.Query("phone"),
+		Status:     domain.MessageStatus(ctx.Query("status")),
+	}
+
+	var err error
+	if request.From, err = queryTime(ctx, "from"); err != nil {
+		response.BadRequest(ctx, "Invalid from date", err.Error())
+		return
+	}
+	if request.To, err = queryTime(ctx, "to"); err != nil {
+		response.BadRequest(ctx, "Invalid to date", err.Error())
+		return
+	}
+
+	write, err := c.exports.Stream(ctx.Request.Context(), request)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to export messages")
+		return
+	}
+
+	name := "messages-" + time.Now().UTC().Format("20060102T150405Z")
+	ctx.Header("Content-Type", request.Format.ContentType())
+	ctx.Header("Content-Disposition", exportDisposition(name, request.Format))
+	ctx.Status(http.StatusOK)
+
+	// A resposta já foi iniciada: falhas no meio da exportação só podem ser registradas
+	if err := write(ctx.Writer); err != nil {
+		c.logger.Error().Err(err).Msg("Failed to stream message export")
+	}
+}
+
+// exportDisposition monta o cabeçalho de download do arquivo
+func exportDisposition(name string, format domain.ExportFormat) string {
+	return fmt.Sprintf("attachment; filename=%q", name+"."+string(format))
+}
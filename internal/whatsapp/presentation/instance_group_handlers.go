@@ -0,0 +1,99 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// CreateInstanceGroup cria um novo grupo de instâncias
+func (c *WhatsAppController) CreateInstanceGroup(ctx *gin.Context) {
+	var request domain.InstanceGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	group, err := c.service.CreateInstanceGroup(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create instance group")
+		response.FromError(ctx, err, "Failed to create instance group")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: group})
+}
+
+// GetAllInstanceGroups obtém todos os grupos de instâncias
+func (c *WhatsAppController) GetAllInstanceGroups(ctx *gin.Context) {
+	groups, err := c.service.GetAllInstanceGroups(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to get instance groups")
+		response.FromError(ctx, err, "Failed to get instance groups")
+		return
+	}
+
+	response.Success(ctx, gin.H{"groups": groups})
+}
+
+// GetInstanceGroup obtém um grupo de instâncias por ID
+func (c *WhatsAppController) GetInstanceGroup(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid group ID", err.Error())
+		return
+	}
+
+	group, err := c.service.GetInstanceGroup(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Instance group not found")
+		return
+	}
+
+	response.Success(ctx, group)
+}
+
+// UpdateInstanceGroup substitui a configuração de um grupo de instâncias
+func (c *WhatsAppController) UpdateInstanceGroup(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid group ID", err.Error())
+		return
+	}
+
+	var request domain.InstanceGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	group, err := c.service.UpdateInstanceGroup(ctx.Request.Context(), id, request)
+	if err != nil {
+		c.logger.Error().Err(err).Str("group_id", id.String()).Msg("Failed to update instance group")
+		response.FromError(ctx, err, "Failed to update instance group")
+		return
+	}
+
+	response.Success(ctx, group)
+}
+
+// DeleteInstanceGroup remove um grupo de instâncias
+func (c *WhatsAppController) DeleteInstanceGroup(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid group ID", err.Error())
+		return
+	}
+
+	if err := c.service.DeleteInstanceGroup(ctx.Request.Context(), id); err != nil {
+		c.logger.Error().Err(err).Str("group_id", id.String()).Msg("Failed to delete instance group")
+		response.FromError(ctx, err, "Failed to delete instance group")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "Instance group deleted successfully"})
+}
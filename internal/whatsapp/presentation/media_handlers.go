@@ -0,0 +1,175 @@
+package presentation
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// UploadMedia recebe um arquivo (multipart, campos file e type) para ser
+// enviado depois em mensagens pelo media_id
+func (c *WhatsAppController) UploadMedia(ctx *gin.Context) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		response.BadRequest(ctx, "Invalid request body", "file is required")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+	defer file.Close()
+
+	media, err := c.media.Upload(ctx.Request.Context(), domain.MediaUpload{
+		Type:     domain.MessageType(ctx.PostForm("type")),
+		FileName: header.Filename,
+		MimeType: header.Header.Get("Content-Type"),
+		Content:  file,
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to upload media")
+		response.FromError(ctx, err, "Failed to upload media")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: media})
+}
+
+// GetMedia retorna os metadados de uma mídia
+func (c *WhatsAppController) GetMedia(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid media ID", err.Error())
+		return
+	}
+
+	media, err := c.media.GetMedia(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to get media")
+		return
+	}
+
+	response.Success(ctx, media)
+}
+
+// DownloadMedia envia o conteúdo de uma mídia
+func (c *WhatsAppController) DownloadMedia(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid media ID", err.Error())
+		return
+	}
+
+	media, file, err := c.media.OpenMedia(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to download media")
+		return
+	}
+	defer file.Close()
+
+	serveMedia(ctx, media, file)
+}
+
+// DownloadMessageMedia envia a mídia de uma mensagem recebida, baixada do
+// provider para o armazenamento da API
+func (c *WhatsAppController) DownloadMessageMedia(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid message ID", err.Error())
+		return
+	}
+
+	message, err := c.service.GetMessage(ctx.Request.Context(), id)
+	if err != nil {
+		response.NotFound(ctx, "Message not found", err.Error())
+		return
+	}
+	if message.MediaID == nil {
+		response.NotFound(ctx, "Media not found", "message has no stored media")
+		return
+	}
+
+	media, file, err := c.media.OpenMedia(ctx.Request.Context(), *message.MediaID)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to download media")
+		return
+	}
+	defer file.Close()
+
+	serveMedia(ctx, media, file)
+}
+
+// DownloadSignedMedia envia o conteúdo de uma mídia pela URL assinada entregue
+// aos provedores. Não exige credenciais: a assinatura autoriza o download
+func (c *WhatsAppController) DownloadSignedMedia(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid media ID", err.Error())
+		return
+	}
+
+	media, file, err := c.media.OpenSigned(ctx.Request.Context(), id, ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to download media")
+		return
+	}
+	defer file.Close()
+
+	serveMedia(ctx, media, file)
+}
+
+// DeleteMedia remove uma mídia
+func (c *WhatsAppController) DeleteMedia(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid media ID", err.Error())
+		return
+	}
+
+	if err := c.media.DeleteMedia(ctx.Request.Context(), id); err != nil {
+		response.FromError(ctx, err, "Failed to delete media")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "Media deleted successfully"})
+}
+
+// serveMedia envia o arquivo com o tipo e o nome da mídia. Apenas imagens,
+// áudios e vídeos são exibidos no navegador; os demais tipos (ex: HTML e SVG
+// recebidos de contatos) são baixados, e o sandbox impede que executem scripts
+// na origem da API
+func serveMedia(ctx *gin.Context, media *domain.Media, file io.Reader) {
+	disposition := "attachment"
+	if inlineMedia(media.MimeType) {
+		disposition = "inline"
+	}
+	if withName := mime.FormatMediaType(disposition, map[string]string{"filename": media.FileName}); withName != "" {
+		disposition = withName
+	}
+
+	ctx.DataFromReader(http.StatusOK, media.SizeBytes, media.MimeType, file, map[string]string{
+		"Content-Disposition":     disposition,
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "sandbox",
+	})
+}
+
+// inlineMedia indica se o tipo pode ser exibido no navegador. SVG é uma
+// imagem que executa scripts e por isso é sempre baixado
+func inlineMedia(mimeType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(mimeType)
+	if mediaType == "image/svg+xml" {
+		return false
+	}
+	kind, _, _ := strings.Cut(mediaType, "/")
+	return kind == "image" || kind == "audio" || kind == "video"
+}
@@ -0,0 +1,58 @@
+package presentation
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// SearchMessages busca mensagens com filtros e paginação por cursor
+func (c *WhatsAppController) SearchMessages(ctx *gin.Context) {
+	filter := domain.MessageFilter{
+		InstanceID: ctx.Query("instance_id"),
+		Phone:      ctx.Query("phone"),
+		Status:     domain.MessageStatus(ctx.Query("status")),
+		Type:       domain.MessageType(ctx.Query("type")),
+		Direction:  domain.MessageDirection(ctx.Query("direction")),
+		Query:      ctx.Query("q"),
+	}
+
+	var err error
+	if filter.From, err = queryTime(ctx, "from"); err != nil {
+		response.BadRequest(ctx, "Invalid from date", err.Error())
+		return
+	}
+	if filter.To, err = queryTime(ctx, "to"); err != nil {
+		response.BadRequest(ctx, "Invalid to date", err.Error())
+		return
+	}
+
+	includeTotal, _ := strconv.ParseBool(ctx.Query("include_total"))
+
+	limit := pageLimit(ctx)
+	result, err := c.service.SearchMessages(ctx.Request.Context(), filter, limit, ctx.Query("cursor"), includeTotal)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to search messages")
+		return
+	}
+
+	response.CursorPaginated(ctx, result.Messages, limit, result.Total, result.NextCursor)
+}
+
+// queryTime lê um parâmetro de data RFC 3339 da query; ausente resulta em nil
+func queryTime(ctx *gin.Context, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,125 @@
+package presentation
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// maxOptOutImportSize limita o tamanho do CSV importado
+const maxOptOutImportSize = 10 << 20
+
+// CreateOptOut inclui um telefone na lista de opt-out
+func (c *WhatsAppController) CreateOptOut(ctx *gin.Context) {
+	var request domain.CreateOptOutRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	optOut, err := c.optOuts.Create(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create opt-out")
+		response.FromError(ctx, err, "Failed to create opt-out")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: optOut})
+}
+
+// ListOptOuts lista as entradas de uma instância ou, sem instance_id, as que
+// valem para todas as instâncias
+func (c *WhatsAppController) ListOptOuts(ctx *gin.Context) {
+	instanceID, ok := optOutInstanceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	filter := domain.OptOutFilter{
+		InstanceID: instanceID,
+		Phone:      ctx.Query("phone"),
+	}
+
+	limit := pageLimit(ctx)
+	optOuts, next, err := c.optOuts.List(ctx.Request.Context(), filter, limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list opt-outs")
+		return
+	}
+
+	response.CursorPaginated(ctx, optOuts, limit, nil, next)
+}
+
+// DeleteOptOut remove uma entrada da lista, voltando a permitir o envio
+func (c *WhatsAppController) DeleteOptOut(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid opt-out ID", err.Error())
+		return
+	}
+
+	if err := c.optOuts.Delete(ctx.Request.Context(), id); err != nil {
+		response.FromError(ctx, err, "Failed to delete opt-out")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "Opt-out deleted successfully"})
+}
+
+// ImportOptOuts importa um CSV com um telefone por linha. O arquivo pode ser
+// enviado no corpo (text/csv) ou no campo "file" de um formulário multipart
+func (c *WhatsAppController) ImportOptOuts(ctx *gin.Context) {
+	instanceID, ok := optOutInstanceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxOptOutImportSize)
+
+	var reader io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			response.BadRequest(ctx, "Invalid file", err.Error())
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			response.BadRequest(ctx, "Invalid file", err.Error())
+			return
+		}
+		defer opened.Close()
+		reader = opened
+	}
+
+	result, err := c.optOuts.Import(ctx.Request.Context(), instanceID, reader)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to import opt-outs")
+		response.FromError(ctx, err, "Failed to import opt-outs")
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// optOutInstanceQuery lê o instance_id opcional da query. Em caso de erro a
+// resposta já foi enviada
+func optOutInstanceQuery(ctx *gin.Context) (*uuid.UUID, bool) {
+	value := ctx.Query("instance_id")
+	if value == "" {
+		return nil, true
+	}
+
+	instanceID, err := uuid.Parse(value)
+	if err != nil {
+		response.BadRequest(ctx, "Invalid instance ID", err.Error())
+		return nil, false
+	}
+	return &instanceID, true
+}
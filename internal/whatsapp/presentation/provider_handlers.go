@@ -0,0 +1,54 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// GetProvidersHealth retorna a saúde de todos os provedores
+func (c *WhatsAppController) GetProvidersHealth(ctx *gin.Context) {
+	results := c.service.GetAllProvidersHealth(ctx.Request.Context())
+
+	status := http.StatusOK
+	for _, health := range results {
+		if health.Status == domain.ProviderUnhealthy {
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	ctx.JSON(status, response.SuccessResponse{Data: gin.H{"providers": results}})
+}
+
+// GetProviderHealth retorna a saúde de um provedor
+func (c *WhatsAppController) GetProviderHealth(ctx *gin.Context) {
+	health, err := c.service.GetProviderHealth(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to check provider health")
+		return
+	}
+
+	status := http.StatusOK
+	if health.Status == domain.ProviderUnhealthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(status, response.SuccessResponse{Data: health})
+}
+
+// GetProviderFeatures retorna as funcionalidades suportadas por um provedor
+func (c *WhatsAppController) GetProviderFeatures(ctx *gin.Context) {
+	name := ctx.Param("name")
+
+	features, err := c.service.GetProviderFeatures(name)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to get provider features")
+		return
+	}
+
+	response.Success(ctx, gin.H{"provider": name, "features": features})
+}
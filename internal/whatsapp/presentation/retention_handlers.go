@@ -0,0 +1,72 @@
+package presentation
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// SetRetentionPolicy cria ou substitui a política de retenção do tenant ou da instância
+func (c *WhatsAppController) SetRetentionPolicy(ctx *gin.Context) {
+	var request domain.RetentionPolicyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	policy, err := c.retention.SetPolicy(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to save retention policy")
+		response.FromError(ctx, err, "Failed to save retention policy")
+		return
+	}
+
+	response.Success(ctx, policy)
+}
+
+// ListRetentionPolicies lista as políticas de retenção do tenant
+func (c *WhatsAppController) ListRetentionPolicies(ctx *gin.Context) {
+	policies, err := c.retention.ListPolicies(ctx.Request.Context())
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list retention policies")
+		return
+	}
+
+	response.Success(ctx, policies)
+}
+
+// DryRunRetentionPolicy informa o que a política enviada removeria, sem alterar mensagens
+func (c *WhatsAppController) DryRunRetentionPolicy(ctx *gin.Context) {
+	var request domain.RetentionPolicyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	report, err := c.retention.DryRun(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to simulate retention policy")
+		response.FromError(ctx, err, "Failed to simulate retention policy")
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// DeleteRetentionPolicy remove uma política de retenção
+func (c *WhatsAppController) DeleteRetentionPolicy(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid retention policy ID", err.Error())
+		return
+	}
+
+	if err := c.retention.DeletePolicy(ctx.Request.Context(), id); err != nil {
+		response.FromError(ctx, err, "Failed to delete retention policy")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "Retention policy deleted successfully"})
+}
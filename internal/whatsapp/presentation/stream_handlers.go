@@ -0,0 +1,234 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	tenantDomain "github.com/your-org/boilerplate-go/internal/tenant/domain"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/application"
+	"github.com/your-org/boilerplate-go/pkg/events"
+)
+
+// Mensagens de controle do stream, enviadas sem ID para não alterar o
+// Last-Event-ID do cliente
+const (
+	StreamEventHeartbeat = "heartbeat"
+	// StreamEventReset avisa que o último evento recebido não está mais
+	// guardado; o cliente deve recarregar o estado pela API
+	StreamEventReset = "stream.reset"
+	// StreamEventOverflow avisa que a conexão será encerrada porque o cliente
+	// não acompanhou os eventos
+	StreamEventOverflow = "stream.overflow"
+)
+
+// sseRetry é o intervalo de reconexão sugerido aos clientes SSE
+const sseRetry = 3 * time.Second
+
+// streamMessage é o envelope das mensagens enviadas por WebSocket
+type streamMessage struct {
+	ID    string       `json:"id,omitempty"`
+	Event string       `json:"event"`
+	Data  events.Event `json:"data,omitempty"`
+}
+
+// StreamEvents transmite os eventos em tempo real por Server-Sent Events
+func (c *WhatsAppController) StreamEvents(ctx *gin.Context) {
+	subscription, ok := c.subscribeStream(ctx)
+	if !ok {
+		return
+	}
+	defer subscription.Close()
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	w := ctx.Writer
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds()); err != nil {
+		return
+	}
+	if subscription.ReplayGap {
+		if err := writeSSE(w, "", StreamEventReset, nil); err != nil {
+			return
+		}
+	}
+	for _, event := range subscription.Replayed {
+		if err := writeSSE(w, event.GetID(), event.GetName(), event); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(c.stream.Settings().Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, open := <-subscription.Events():
+			if !open {
+				if subscription.Overflowed() {
+					_ = writeSSE(w, "", StreamEventOverflow, nil)
+					w.Flush()
+				}
+				return
+			}
+			if err := writeSSE(w, event.GetID(), event.GetName(), event); err != nil {
+				return
+			}
+		}
+		w.Flush()
+	}
+}
+
+// StreamEventsWebSocket transmite os eventos em tempo real por WebSocket. Cada
+// mensagem é um JSON com id, event e data
+func (c *WhatsAppController) StreamEventsWebSocket(ctx *gin.Context) {
+	subscription, ok := c.subscribeStream(ctx)
+	if !ok {
+		return
+	}
+	defer subscription.Close()
+
+	server := websocket.Server{
+		// A autenticação é feita por token, então a origem não é verificada
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+			c.pumpWebSocket(conn, subscription)
+		},
+	}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// pumpWebSocket envia os eventos até o cliente desconectar ou a assinatura terminar
+func (c *WhatsAppController) pumpWebSocket(conn *websocket.Conn, subscription *application.StreamSubscription) {
+	heartbeatInterval := c.stream.Settings().Heartbeat
+
+	send := func(message streamMessage) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(heartbeatInterval))
+		return websocket.JSON.Send(conn, message) == nil
+	}
+
+	// O cliente não envia mensagens; a leitura serve para detectar o fechamento
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		var discard string
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+	}()
+
+	if subscription.ReplayGap && !send(streamMessage{Event: StreamEventReset}) {
+		return
+	}
+	for _, event := range subscription.Replayed {
+		if !send(streamMessage{ID: event.GetID(), Event: event.GetName(), Data: event}) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+		case <-heartbeat.C:
+			if !send(streamMessage{Event: StreamEventHeartbeat}) {
+				return
+			}
+		case event, open := <-subscription.Events():
+			if !open {
+				if subscription.Overflowed() {
+					send(streamMessage{Event: StreamEventOverflow})
+				}
+				return
+			}
+			if !send(streamMessage{ID: event.GetID(), Event: event.GetName(), Data: event}) {
+				return
+			}
+		}
+	}
+}
+
+// subscribeStream lê o filtro e o último evento recebido e abre a assinatura.
+// Em caso de erro a resposta já foi enviada
+func (c *WhatsAppController) subscribeStream(ctx *gin.Context) (*application.StreamSubscription, bool) {
+	var filter application.StreamFilter
+	for _, value := range queryList(ctx, "instance_id") {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			response.BadRequest(ctx, "Invalid instance ID", err.Error())
+			return nil, false
+		}
+		filter.InstanceIDs = append(filter.InstanceIDs, id)
+	}
+	filter.Events = queryList(ctx, "events")
+	if tenantID, ok := tenantDomain.FromContext(ctx.Request.Context()); ok {
+		filter.TenantID = &tenantID
+	}
+
+	// EventSource envia o cabeçalho ao reconectar; clientes WebSocket usam o parâmetro
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+
+	subscription, err := c.stream.Subscribe(filter, lastEventID)
+	if err != nil {
+		response.FromError(ctx, err, "Failed to subscribe to events")
+		return nil, false
+	}
+	return subscription, true
+}
+
+// queryList aceita o parâmetro repetido ou com valores separados por vírgula
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range ctx.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// writeSSE escreve uma mensagem no formato Server-Sent Events
+func writeSSE(w io.Writer, id, name string, event events.Event) error {
+	data := []byte("{}")
+	if event != nil {
+		var err error
+		if data, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
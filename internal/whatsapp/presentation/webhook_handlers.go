@@ -0,0 +1,151 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/your-org/boilerplate-go/internal/response"
+	"github.com/your-org/boilerplate-go/internal/whatsapp/domain"
+)
+
+// CreateWebhookSubscription cria uma assinatura de webhook
+func (c *WhatsAppController) CreateWebhookSubscription(ctx *gin.Context) {
+	var request domain.CreateWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	subscription, err := c.webhooks.CreateSubscription(ctx.Request.Context(), request)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create webhook subscription")
+		response.FromError(ctx, err, "Failed to create webhook subscription")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.SuccessResponse{Data: subscription})
+}
+
+// ListWebhookSubscriptions lista as assinaturas, opcionalmente de uma instância
+func (c *WhatsAppController) ListWebhookSubscriptions(ctx *gin.Context) {
+	var instanceID *uuid.UUID
+	if value := ctx.Query("instance_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			response.BadRequest(ctx, "Invalid instance ID", err.Error())
+			return
+		}
+		instanceID = &id
+	}
+
+	subscriptions, err := c.webhooks.ListSubscriptions(ctx.Request.Context(), instanceID)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to list webhook subscriptions")
+		response.FromError(ctx, err, "Failed to list webhook subscriptions")
+		return
+	}
+
+	response.Success(ctx, gin.H{"webhooks": subscriptions})
+}
+
+// GetWebhookSubscription obtém uma assinatura por ID
+func (c *WhatsAppController) GetWebhookSubscription(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	subscription, err := c.webhooks.GetSubscription(ctx.Request.Context(), id)
+	if err != nil {
+		response.FromError(ctx, err, "Webhook subscription not found")
+		return
+	}
+
+	response.Success(ctx, subscription)
+}
+
+// UpdateWebhookSubscription aplica um patch em uma assinatura
+func (c *WhatsAppController) UpdateWebhookSubscription(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	var request domain.UpdateWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		response.BadRequest(ctx, "Invalid request body", err.Error())
+		return
+	}
+
+	subscription, err := c.webhooks.UpdateSubscription(ctx.Request.Context(), id, request)
+	if err != nil {
+		c.logger.Error().Err(err).Str("webhook_id", id.String()).Msg("Failed to update webhook subscription")
+		response.FromError(ctx, err, "Failed to update webhook subscription")
+		return
+	}
+
+	response.Success(ctx, subscription)
+}
+
+// DeleteWebhookSubscription remove uma assinatura
+func (c *WhatsAppController) DeleteWebhookSubscription(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	if err := c.webhooks.DeleteSubscription(ctx.Request.Context(), id); err != nil {
+		response.FromError(ctx, err, "Failed to delete webhook subscription")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "Webhook subscription deleted successfully"})
+}
+
+// ListWebhookDeliveries lista as entregas de uma assinatura
+func (c *WhatsAppController) ListWebhookDeliveries(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	limit := pageLimit(ctx)
+	status := domain.WebhookDeliveryStatus(ctx.Query("status"))
+	deliveries, next, err := c.webhooks.ListDeliveries(ctx.Request.Context(), id, status, limit, ctx.Query("cursor"))
+	if err != nil {
+		response.FromError(ctx, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	response.CursorPaginated(ctx, deliveries, limit, nil, next)
+}
+
+// RedeliverWebhook agenda um novo envio de uma entrega
+func (c *WhatsAppController) RedeliverWebhook(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	deliveryID, err := uuid.Parse(ctx.Param("delivery_id"))
+	if err != nil {
+		response.BadRequest(ctx, "Invalid delivery ID", err.Error())
+		return
+	}
+
+	delivery, err := c.webhooks.Redeliver(ctx.Request.Context(), id, deliveryID)
+	if err != nil {
+		c.logger.Error().Err(err).Str("delivery_id", deliveryID.String()).Msg("Failed to redeliver webhook")
+		response.FromError(ctx, err, "Failed to redeliver webhook")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.SuccessResponse{Data: delivery})
+}
@@ -0,0 +1,249 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the breaker is rejecting calls
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+type Settings struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting probe calls through
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the number of probe calls allowed while half-open;
+	// that many consecutive successes close the breaker again
+	HalfOpenMaxRequests int
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		FailureThreshold:    5,
+		OpenTimeout:         30 * time.Second,
+		HalfOpenMaxRequests: 1,
+	}
+}
+
+// StateChangeFunc is called, outside the breaker lock, on every state transition
+type StateChangeFunc func(name string, from, to State)
+
+type Snapshot struct {
+	Name                string     `json:"name"`
+	State               State      `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+}
+
+type Breaker struct {
+	name          string
+	settings      Settings
+	onStateChange StateChangeFunc
+	now           func() time.Time
+
+	mu            sync.Mutex
+	state         State
+	generation    uint64
+	failures      int
+	successes     int
+	inFlight      int
+	openedAt      time.Time
+	lastError     string
+	lastFailureAt time.Time
+}
+
+func New(name string, settings Settings, onStateChange StateChangeFunc) *Breaker {
+	defaults := DefaultSettings()
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = defaults.FailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = defaults.OpenTimeout
+	}
+	if settings.HalfOpenMaxRequests <= 0 {
+		settings.HalfOpenMaxRequests = defaults.HalfOpenMaxRequests
+	}
+
+	return &Breaker{
+		name:          name,
+		settings:      settings,
+		onStateChange: onStateChange,
+		now:           time.Now,
+		state:         StateClosed,
+	}
+}
+
+// Call is a permit returned by Allow. Exactly one of Success, Failure or
+// Ignore must be called when the protected operation finishes.
+type Call struct {
+	breaker    *Breaker
+	generation uint64
+	once       sync.Once
+}
+
+func (c *Call) Success() {
+	c.once.Do(func() { c.breaker.record(c.generation, nil, true) })
+}
+
+func (c *Call) Failure(err error) {
+	if err == nil {
+		err = errors.New("unknown failure")
+	}
+	c.once.Do(func() { c.breaker.record(c.generation, err, true) })
+}
+
+// Ignore releases the permit without counting the call as success or failure,
+// e.g. when the operation was never attempted or failed for unrelated reasons
+func (c *Call) Ignore() {
+	c.once.Do(func() { c.breaker.record(c.generation, nil, false) })
+}
+
+// Allow asks permission to run a call, failing fast with ErrOpen while the
+// breaker is open or the half-open probe quota is taken
+func (b *Breaker) Allow() (*Call, error) {
+	b.mu.Lock()
+
+	var transition func()
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		transition = b.setState(StateHalfOpen)
+	}
+
+	switch b.state {
+	case StateOpen:
+		b.mu.Unlock()
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.inFlight >= b.settings.HalfOpenMaxRequests {
+			b.mu.Unlock()
+			if transition != nil {
+				transition()
+			}
+			return nil, ErrOpen
+		}
+	}
+
+	b.inFlight++
+	call := &Call{breaker: b, generation: b.generation}
+	b.mu.Unlock()
+
+	if transition != nil {
+		transition()
+	}
+	return call, nil
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := Snapshot{
+		Name:                b.name,
+		State:               b.currentState(),
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		snapshot.OpenedAt = &openedAt
+	}
+	if !b.lastFailureAt.IsZero() {
+		lastFailureAt := b.lastFailureAt
+		snapshot.LastFailureAt = &lastFailureAt
+	}
+	return snapshot
+}
+
+// currentState reports half-open once the open timeout elapsed, even before
+// the next call performs the actual transition
+func (b *Breaker) currentState() State {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) record(generation uint64, err error, counted bool) {
+	b.mu.Lock()
+
+	// Results from calls admitted before the last transition no longer apply
+	if generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+
+	if b.inFlight > 0 {
+		b.inFlight--
+	}
+
+	if !counted {
+		b.mu.Unlock()
+		return
+	}
+
+	var transition func()
+	if err == nil {
+		b.failures = 0
+		if b.state == StateHalfOpen {
+			b.successes++
+			if b.successes >= b.settings.HalfOpenMaxRequests {
+				transition = b.setState(StateClosed)
+			}
+		}
+	} else {
+		b.failures++
+		b.lastError = err.Error()
+		b.lastFailureAt = b.now()
+		if b.state == StateHalfOpen || b.failures >= b.settings.FailureThreshold {
+			transition = b.setState(StateOpen)
+		}
+	}
+	b.mu.Unlock()
+
+	if transition != nil {
+		transition()
+	}
+}
+
+// setState must be called with the lock held; it returns the notification to
+// run after the lock is released
+func (b *Breaker) setState(state State) func() {
+	from := b.state
+	if from == state {
+		return nil
+	}
+
+	b.state = state
+	b.generation++
+	b.inFlight = 0
+	b.successes = 0
+	switch state {
+	case StateOpen:
+		b.openedAt = b.now()
+	case StateClosed:
+		b.failures = 0
+	}
+
+	if b.onStateChange == nil {
+		return nil
+	}
+	name, callback := b.name, b.onStateChange
+	return func() { callback(name, from, state) }
+}
@@ -0,0 +1,141 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func newTestBreaker(settings Settings, onStateChange StateChangeFunc) (*Breaker, *fakeClock) {
+	clock := &fakeClock{current: time.Unix(1700000000, 0)}
+	breaker := New("test", settings, onStateChange)
+	breaker.now = clock.now
+	return breaker, clock
+}
+
+func fail(t *testing.T, breaker *Breaker) {
+	t.Helper()
+	call, err := breaker.Allow()
+	if err != nil {
+		t.Fatalf("Expected call to be allowed, got %v", err)
+	}
+	call.Failure(errors.New("boom"))
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker(Settings{FailureThreshold: 3, OpenTimeout: time.Minute}, nil)
+
+	fail(t, breaker)
+	fail(t, breaker)
+	if breaker.State() != StateClosed {
+		t.Errorf("Expected closed before threshold, got %s", breaker.State())
+	}
+
+	fail(t, breaker)
+	if breaker.State() != StateOpen {
+		t.Errorf("Expected open after threshold, got %s", breaker.State())
+	}
+
+	if _, err := breaker.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("Expected ErrOpen while open, got %v", err)
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(Settings{FailureThreshold: 2, OpenTimeout: time.Minute}, nil)
+
+	fail(t, breaker)
+	call, _ := breaker.Allow()
+	call.Success()
+	fail(t, breaker)
+
+	if breaker.State() != StateClosed {
+		t.Errorf("Expected closed when failures are not consecutive, got %s", breaker.State())
+	}
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	var transitions []State
+	breaker, clock := newTestBreaker(Settings{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenMaxRequests: 1},
+		func(name string, from, to State) { transitions = append(transitions, to) })
+
+	fail(t, breaker)
+	clock.current = clock.current.Add(time.Minute)
+
+	if breaker.State() != StateHalfOpen {
+		t.Errorf("Expected half-open after timeout, got %s", breaker.State())
+	}
+
+	probe, err := breaker.Allow()
+	if err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	if _, err := breaker.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("Expected second call to be rejected while probing, got %v", err)
+	}
+
+	probe.Success()
+	if breaker.State() != StateClosed {
+		t.Errorf("Expected closed after successful probe, got %s", breaker.State())
+	}
+
+	expected := []State{StateOpen, StateHalfOpen, StateClosed}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("Expected transitions %v, got %v", expected, transitions)
+		}
+	}
+}
+
+func TestBreakerHalfOpenFailureReopens(t *testing.T) {
+	breaker, clock := newTestBreaker(Settings{FailureThreshold: 1, OpenTimeout: time.Minute}, nil)
+
+	fail(t, breaker)
+	clock.current = clock.current.Add(time.Minute)
+	fail(t, breaker)
+
+	if breaker.State() != StateOpen {
+		t.Errorf("Expected open after failed probe, got %s", breaker.State())
+	}
+}
+
+func TestBreakerIgnoreReleasesProbe(t *testing.T) {
+	breaker, clock := newTestBreaker(Settings{FailureThreshold: 1, OpenTimeout: time.Minute}, nil)
+
+	fail(t, breaker)
+	clock.current = clock.current.Add(time.Minute)
+
+	probe, _ := breaker.Allow()
+	probe.Ignore()
+
+	if _, err := breaker.Allow(); err != nil {
+		t.Errorf("Expected ignored probe to release its slot, got %v", err)
+	}
+}
+
+func TestRegistrySharesBreakers(t *testing.T) {
+	registry := NewRegistry(Settings{FailureThreshold: 1}, nil)
+
+	if registry.Get("z-api") != registry.Get("z-api") {
+		t.Error("Expected the same breaker for the same name")
+	}
+
+	fail(t, registry.Get("z-api"))
+	registry.Get("other")
+
+	snapshots := registry.Snapshots()
+	if len(snapshots) != 2 || snapshots[0].Name != "other" || snapshots[1].State != StateOpen {
+		t.Errorf("Unexpected snapshots: %+v", snapshots)
+	}
+}
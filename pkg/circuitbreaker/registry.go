@@ -0,0 +1,68 @@
+package circuitbreaker
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry creates breakers on demand and keeps one instance per name, so
+// independent clients talking to the same dependency share its breaker
+type Registry struct {
+	settings      Settings
+	onStateChange StateChangeFunc
+	breakers      map[string]*Breaker
+	mu            sync.RWMutex
+}
+
+func NewRegistry(settings Settings, onStateChange StateChangeFunc) *Registry {
+	return &Registry{
+		settings:      settings,
+		onStateChange: onStateChange,
+		breakers:      make(map[string]*Breaker),
+	}
+}
+
+func (r *Registry) Get(name string) *Breaker {
+	r.mu.RLock()
+	breaker, ok := r.breakers[name]
+	r.mu.RUnlock()
+	if ok {
+		return breaker
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if breaker, ok := r.breakers[name]; ok {
+		return breaker
+	}
+	breaker = New(name, r.settings, r.onStateChange)
+	r.breakers[name] = breaker
+	return breaker
+}
+
+// Remove drops the breaker, e.g. when the instance it protected was deleted
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.breakers, name)
+}
+
+// Snapshots returns the state of every breaker ordered by name
+func (r *Registry) Snapshots() []Snapshot {
+	r.mu.RLock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		breakers = append(breakers, breaker)
+	}
+	r.mu.RUnlock()
+
+	snapshots := make([]Snapshot, len(breakers))
+	for i, breaker := range breakers {
+		snapshots[i] = breaker.Snapshot()
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots
+}
@@ -2,15 +2,53 @@ package events
 
 import (
 	"context"
+	"fmt"
 	"sync"
 )
 
+// OverflowPolicy defines what happens to an event published to a channel
+// subscriber whose buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest discards the event being published
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered event to make room
+	OverflowDropOldest
+	// OverflowDisconnect closes the subscriber; consumers see the channel
+	// closed with Overflowed reporting true
+	OverflowDisconnect
+)
+
+// ParseOverflowPolicy converts the configuration name of a policy
+func ParseOverflowPolicy(name string) (OverflowPolicy, error) {
+	switch name {
+	case "", "drop_newest":
+		return OverflowDropNewest, nil
+	case "drop_oldest":
+		return OverflowDropOldest, nil
+	case "disconnect":
+		return OverflowDisconnect, nil
+	default:
+		return OverflowDropNewest, fmt.Errorf("unknown overflow policy: %s", name)
+	}
+}
+
+// SubscribeOptions configures a channel subscriber
+type SubscribeOptions struct {
+	BufferSize int
+	Overflow   OverflowPolicy
+}
+
 type ChannelSubscriber struct {
-	channel chan Event
-	ctx     context.Context
-	cancel  context.CancelFunc
-	mu      sync.RWMutex
-	closed  bool
+	channel    chan Event
+	ctx        context.Context
+	cancel     context.CancelFunc
+	overflow   OverflowPolicy
+	mu         sync.RWMutex
+	closed     bool
+	overflowed bool
+	dropped    uint64
 }
 
 type ChannelEventBus struct {
@@ -26,13 +64,23 @@ func NewChannelEventBus(config *EventBusConfig) *ChannelEventBus {
 	}
 }
 
+// SubscribeChannel subscribes a buffered channel to the topic. Events published
+// while the buffer is full are dropped.
 func (ceb *ChannelEventBus) SubscribeChannel(topic string, bufferSize int) *ChannelSubscriber {
+	return ceb.SubscribeChannelWithOptions(topic, SubscribeOptions{BufferSize: bufferSize})
+}
+
+// SubscribeChannelWithOptions subscribes a buffered channel to the topic with
+// the given policy for slow consumers
+func (ceb *ChannelEventBus) SubscribeChannelWithOptions(topic string, options SubscribeOptions) *ChannelSubscriber {
+	bufferSize := options.BufferSize
 	if bufferSize <= 0 {
 		bufferSize = 100
 	}
 
 	subscriber := &ChannelSubscriber{
-		channel: make(chan Event, bufferSize),
+		channel:  make(chan Event, bufferSize),
+		overflow: options.Overflow,
 	}
 	subscriber.ctx, subscriber.cancel = context.WithCancel(context.Background())
 
@@ -49,14 +97,10 @@ func (ceb *ChannelEventBus) PublishEvent(ctx context.Context, event Event) error
 	ceb.mu.RUnlock()
 
 	for _, subscriber := range subscribers {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-subscriber.ctx.Done():
-			continue
-		case subscriber.channel <- event:
-		default:
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+		subscriber.deliver(event)
 	}
 
 	return nil
@@ -67,18 +111,18 @@ func (ceb *ChannelEventBus) PublishEventAsync(ctx context.Context, event Event)
 	subscribers := ceb.subscribers[event.GetName()]
 	ceb.mu.RUnlock()
 
-	for _, subscriber := range subscribers {
-		go func(sub *ChannelSubscriber) {
-			select {
-			case <-ctx.Done():
-				return
-			case <-sub.ctx.Done():
+	if len(subscribers) == 0 {
+		return
+	}
+
+	go func() {
+		for _, subscriber := range subscribers {
+			if ctx.Err() != nil {
 				return
-			case sub.channel <- event:
-			default:
 			}
-		}(subscriber)
-	}
+			subscriber.deliver(event)
+		}
+	}()
 }
 
 func (ceb *ChannelEventBus) UnsubscribeChannel(topic string, subscriber *ChannelSubscriber) {
@@ -88,7 +132,10 @@ func (ceb *ChannelEventBus) UnsubscribeChannel(topic string, subscriber *Channel
 	if subscribers, exists := ceb.subscribers[topic]; exists {
 		for i, sub := range subscribers {
 			if sub == subscriber {
-				ceb.subscribers[topic] = append(subscribers[:i], subscribers[i+1:]...)
+				// A new slice keeps publishers iterating over the old one intact
+				remaining := make([]*ChannelSubscriber, 0, len(subscribers)-1)
+				remaining = append(remaining, subscribers[:i]...)
+				ceb.subscribers[topic] = append(remaining, subscribers[i+1:]...)
 				subscriber.Close()
 				break
 			}
@@ -96,6 +143,13 @@ func (ceb *ChannelEventBus) UnsubscribeChannel(topic string, subscriber *Channel
 	}
 }
 
+// SubscriberCount returns how many channel subscribers are registered for the topic
+func (ceb *ChannelEventBus) SubscriberCount(topic string) int {
+	ceb.mu.RLock()
+	defer ceb.mu.RUnlock()
+	return len(ceb.subscribers[topic])
+}
+
 func (ceb *ChannelEventBus) Close() error {
 	ceb.mu.Lock()
 	defer ceb.mu.Unlock()
@@ -118,6 +172,11 @@ func (cs *ChannelSubscriber) Close() {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 
+	cs.closeLocked()
+}
+
+// closeLocked closes the subscriber; the caller must hold the write lock
+func (cs *ChannelSubscriber) closeLocked() {
 	if !cs.closed {
 		cs.closed = true
 		cs.cancel()
@@ -125,6 +184,54 @@ func (cs *ChannelSubscriber) Close() {
 	}
 }
 
+// deliver hands the event to the subscriber without blocking, applying the
+// overflow policy when the buffer is full. Holding the lock keeps Close from
+// closing the channel during the send.
+func (cs *ChannelSubscriber) deliver(event Event) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.closed {
+		return
+	}
+
+	select {
+	case cs.channel <- event:
+		return
+	default:
+	}
+
+	cs.dropped++
+	switch cs.overflow {
+	case OverflowDropOldest:
+		select {
+		case <-cs.channel:
+		default:
+		}
+		select {
+		case cs.channel <- event:
+		default:
+		}
+	case OverflowDisconnect:
+		cs.overflowed = true
+		cs.closeLocked()
+	}
+}
+
+// Dropped returns how many events were discarded because the buffer was full
+func (cs *ChannelSubscriber) Dropped() uint64 {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.dropped
+}
+
+// Overflowed reports whether the subscriber was closed by OverflowDisconnect
+func (cs *ChannelSubscriber) Overflowed() bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.overflowed
+}
+
 func (cs *ChannelSubscriber) IsClosed() bool {
 	cs.mu.RLock()
 	defer cs.mu.RUnlock()
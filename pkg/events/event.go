@@ -1,6 +1,7 @@
 package events
 
 import (
+	"crypto/rand"
 	"time"
 )
 
@@ -43,8 +44,13 @@ func generateEventID() string {
 func randomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, length)
+	// Event IDs identify events across processes (e.g. webhook deliveries), so
+	// they must not repeat when several events are created in the same second
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
 	for i := range b {
-		b[i] = charset[time.Now().UnixNano()%int64(len(charset))]
+		b[i] = charset[int(b[i])%len(charset)]
 	}
 	return string(b)
 }
@@ -313,3 +313,124 @@ func TestChannelEventBusAsync(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 	subscriber.Close()
 }
+
+func publishUsers(t *testing.T, bus *ChannelEventBus, ids ...int) []Event {
+	t.Helper()
+	var published []Event
+	for _, id := range ids {
+		event := &UserCreatedEvent{BaseEvent: NewBaseEvent("user.created"), UserID: id}
+		if err := bus.PublishEvent(context.Background(), event); err != nil {
+			t.Fatalf("Error publishing event: %v", err)
+		}
+		published = append(published, event)
+	}
+	return published
+}
+
+func receivedUserIDs(subscriber *ChannelSubscriber) []int {
+	var ids []int
+	for {
+		select {
+		case event, ok := <-subscriber.Channel():
+			if !ok {
+				return ids
+			}
+			ids = append(ids, event.(*UserCreatedEvent).UserID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestChannelSubscriberOverflowPolicies(t *testing.T) {
+	bus := NewChannelEventBus(nil)
+
+	dropNewest := bus.SubscribeChannel("user.created", 2)
+	dropOldest := bus.SubscribeChannelWithOptions("user.created", SubscribeOptions{BufferSize: 2, Overflow: OverflowDropOldest})
+	disconnect := bus.SubscribeChannelWithOptions("user.created", SubscribeOptions{BufferSize: 2, Overflow: OverflowDisconnect})
+
+	publishUsers(t, bus, 1, 2, 3)
+
+	if ids := receivedUserIDs(dropNewest); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("drop newest: expected [1 2], got %v", ids)
+	}
+	if ids := receivedUserIDs(dropOldest); len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("drop oldest: expected [2 3], got %v", ids)
+	}
+	if dropNewest.Dropped() != 1 || dropOldest.Dropped() != 1 {
+		t.Errorf("Expected one dropped event per subscriber, got %d and %d", dropNewest.Dropped(), dropOldest.Dropped())
+	}
+
+	if !disconnect.Overflowed() {
+		t.Error("Expected disconnect subscriber to be overflowed")
+	}
+	receivedUserIDs(disconnect)
+	if _, ok := <-disconnect.Channel(); ok {
+		t.Error("Expected disconnect subscriber channel to be closed")
+	}
+
+	// Publishing after the subscriber was closed must not panic
+	publishUsers(t, bus, 4)
+	dropNewest.Close()
+	dropOldest.Close()
+	publishUsers(t, bus, 5)
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	cases := map[string]OverflowPolicy{
+		"":            OverflowDropNewest,
+		"drop_newest": OverflowDropNewest,
+		"drop_oldest": OverflowDropOldest,
+		"disconnect":  OverflowDisconnect,
+	}
+	for name, expected := range cases {
+		policy, err := ParseOverflowPolicy(name)
+		if err != nil || policy != expected {
+			t.Errorf("ParseOverflowPolicy(%q) = %v, %v", name, policy, err)
+		}
+	}
+	if _, err := ParseOverflowPolicy("block"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+}
+
+func TestReplayBuffer(t *testing.T) {
+	buffer := NewReplayBuffer(3)
+
+	var published []Event
+	for i := 1; i <= 4; i++ {
+		event := NewBaseEvent("user.created")
+		buffer.Add(event)
+		published = append(published, event)
+	}
+
+	missed, ok := buffer.Since(published[1].GetID())
+	if !ok || len(missed) != 2 || missed[0] != published[2] || missed[1] != published[3] {
+		t.Errorf("Expected the two events after the second one, got %v (found=%v)", missed, ok)
+	}
+
+	missed, ok = buffer.Since(published[3].GetID())
+	if !ok || len(missed) != 0 {
+		t.Errorf("Expected nothing after the latest event, got %v (found=%v)", missed, ok)
+	}
+
+	if _, ok := buffer.Since(published[0].GetID()); ok {
+		t.Error("Expected evicted event not to be found")
+	}
+
+	if removed := buffer.Remove(func(event Event) bool { return event == published[2] }); removed != 1 {
+		t.Errorf("Expected 1 removed event, got %d", removed)
+	}
+	missed, ok = buffer.Since(published[1].GetID())
+	if !ok || len(missed) != 1 || missed[0] != published[3] {
+		t.Errorf("Expected only the event after the removed one, got %v (found=%v)", missed, ok)
+	}
+
+	// The buffer keeps filling in order after a removal
+	event := NewBaseEvent("user.created")
+	buffer.Add(event)
+	missed, ok = buffer.Since(published[3].GetID())
+	if !ok || len(missed) != 1 || missed[0] != event {
+		t.Errorf("Expected the event added after the removal, got %v (found=%v)", missed, ok)
+	}
+}
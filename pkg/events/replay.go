@@ -0,0 +1,85 @@
+package events
+
+import "sync"
+
+// ReplayBuffer keeps the most recent events so that consumers reconnecting
+// with the ID of the last event they saw can catch up on what they missed
+type ReplayBuffer struct {
+	mu     sync.RWMutex
+	events []Event
+	next   int
+	full   bool
+}
+
+// NewReplayBuffer creates a buffer holding up to capacity events
+func NewReplayBuffer(capacity int) *ReplayBuffer {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &ReplayBuffer{events: make([]Event, capacity)}
+}
+
+// Add appends the event, discarding the oldest one when the buffer is full
+func (b *ReplayBuffer) Add(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events[b.next] = event
+	b.next = (b.next + 1) % len(b.events)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Since returns the events added after the one with the given ID, oldest
+// first. It reports false when the ID is no longer (or was never) buffered,
+// in which case the consumer may have missed events.
+func (b *ReplayBuffer) Since(id string) ([]Event, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ordered := b.orderedLocked()
+	for i := len(ordered) - 1; i >= 0; i-- {
+		if ordered[i].GetID() == id {
+			missed := make([]Event, len(ordered)-i-1)
+			copy(missed, ordered[i+1:])
+			return missed, true
+		}
+	}
+	return nil, false
+}
+
+// orderedLocked returns the buffered events, oldest first
+func (b *ReplayBuffer) orderedLocked() []Event {
+	if !b.full {
+		return b.events[:b.next]
+	}
+	return append(append([]Event(nil), b.events[b.next:]...), b.events[:b.next]...)
+}
+
+// Remove discards the buffered events matching the predicate, keeping the
+// order of the others, and returns how many were discarded
+func (b *ReplayBuffer) Remove(match func(Event) bool) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ordered := b.orderedLocked()
+	kept := make([]Event, 0, len(ordered))
+	for _, event := range ordered {
+		if !match(event) {
+			kept = append(kept, event)
+		}
+	}
+
+	removed := len(ordered) - len(kept)
+	if removed == 0 {
+		return 0
+	}
+
+	events := make([]Event, len(b.events))
+	copy(events, kept)
+	b.events = events
+	b.next = len(kept)
+	b.full = false
+	return removed
+}
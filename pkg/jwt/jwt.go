@@ -0,0 +1,91 @@
+// Package jwt signs and verifies compact JSON Web Tokens using HMAC-SHA256.
+// Only the HS256 algorithm is accepted, which rules out "none" and algorithm
+// confusion attacks.
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned for malformed tokens and bad signatures
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken is returned for well-signed tokens past their expiry
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// header is the only header produced and accepted
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// RegisteredClaims holds the standard claims. Embed it in the claims of a token
+type RegisteredClaims struct {
+	Subject   string `json:"sub,omitempty"`
+	ID        string `json:"jti,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// Expiry returns the expiry time, or the zero time when the token never expires
+func (c RegisteredClaims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Claims is implemented by claim types embedding RegisteredClaims
+type Claims interface {
+	Expiry() time.Time
+}
+
+// Sign encodes the claims as a token signed with the secret
+func Sign(claims Claims, secret []byte) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + sign(unsigned, secret), nil
+}
+
+// Parse verifies the signature and expiry of the token and decodes its
+// claims into the value pointed to by claims
+func Parse(token string, secret []byte, claims Claims) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return ErrInvalidToken
+	}
+
+	expected := sign(parts[0]+"."+parts[1], secret)
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return ErrInvalidToken
+	}
+
+	if expiry := claims.Expiry(); !expiry.IsZero() && !time.Now().Before(expiry) {
+		return ErrExpiredToken
+	}
+	return nil
+}
+
+// sign returns the encoded HMAC-SHA256 of the signing input
+func sign(input string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package jwt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testClaims struct {
+	RegisteredClaims
+	Role string `json:"role"`
+}
+
+func TestSignAndParse(t *testing.T) {
+	secret := []byte("secret")
+	token, err := Sign(testClaims{
+		RegisteredClaims: RegisteredClaims{Subject: "42", ExpiresAt: time.Now().Add(time.Minute).Unix()},
+		Role:             "admin",
+	}, secret)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var claims testClaims
+	if err := Parse(token, secret, &claims); err != nil {
+		t.Fatalf("Expected valid token, got %v", err)
+	}
+	if claims.Subject != "42" || claims.Role != "admin" {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+}
+
+func TestParseRejectsTamperedTokens(t *testing.T) {
+	secret := []byte("secret")
+	token, _ := Sign(testClaims{Role: "viewer"}, secret)
+
+	if err := Parse(token, []byte("other"), &testClaims{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected invalid token for wrong secret, got %v", err)
+	}
+
+	forged, _ := Sign(testClaims{Role: "admin"}, []byte("other"))
+	parts := strings.Split(token, ".")
+	forgedParts := strings.Split(forged, ".")
+	if err := Parse(parts[0]+"."+forgedParts[1]+"."+parts[2], secret, &testClaims{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected invalid token for swapped payload, got %v", err)
+	}
+
+	none := "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + parts[1] + "."
+	if err := Parse(none, secret, &testClaims{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected invalid token for alg none, got %v", err)
+	}
+}
+
+func TestParseRejectsExpiredTokens(t *testing.T) {
+	secret := []byte("secret")
+	token, _ := Sign(testClaims{RegisteredClaims: RegisteredClaims{ExpiresAt: time.Now().Add(-time.Second).Unix()}}, secret)
+
+	if err := Parse(token, secret, &testClaims{}); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("Expected expired token, got %v", err)
+	}
+}